@@ -23,6 +23,15 @@ type ResultMessage struct {
 type ActionResult struct {
 	Message *ResultMessage
 
+	// Result is the structured output of the action, like the contract of its JSON output. Actions that return a Result
+	// don't write it themselves, the command layer formats it with the output format selected for the command. With no
+	// output format, results implementing fmt.Stringer are written as text and other results are not written.
+	// Results of child actions are not written.
+	Result any
+
+	// FormatOptions are passed to the formatter with Result, like the columns of a table.
+	FormatOptions any
+
 	// TraceID is a unique identifier of the end-to-end CLI command execution, that can be used to correlate events in logs.
 	TraceID string
 }
@@ -33,6 +33,13 @@ func authActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		DefaultFormat:  output.NoneFormat,
 	})
 
+	group.Add("status", &actions.ActionDescriptorOptions{
+		Command:        newAuthStatusCmd(),
+		ActionResolver: newAuthStatusAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+	})
+
 	group.Add("logout", &actions.ActionDescriptorOptions{
 		Command:        newLogoutCmd("auth"),
 		ActionResolver: newLogoutAction,
@@ -10,6 +10,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -50,6 +52,7 @@ type loginFlags struct {
 	clientID               string
 	clientSecret           stringPtr
 	clientCertificate      string
+	certificatePassword    stringPtr
 	federatedTokenProvider string
 	scopes                 []string
 	redirectPort           int
@@ -105,6 +108,7 @@ func (p *boolPtr) Type() string {
 const (
 	cClientSecretFlagName                = "client-secret"
 	cClientCertificateFlagName           = "client-certificate"
+	cCertificatePasswordFlagName         = "client-certificate-password"
 	cFederatedCredentialProviderFlagName = "federated-credential-provider"
 )
 
@@ -129,6 +133,11 @@ func (lf *loginFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandO
 		cClientCertificateFlagName,
 		"",
 		"The path to the client certificate for the service principal to authenticate with.")
+	local.Var(
+		&lf.certificatePassword,
+		cCertificatePasswordFlagName,
+		"The password of an encrypted PFX client certificate. "+
+			"Set to the empty string to read the value from the console.")
 	local.StringVar(
 		&lf.federatedTokenProvider,
 		cFederatedCredentialProviderFlagName,
@@ -143,8 +152,8 @@ func (lf *loginFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandO
 		&lf.scopes,
 		"scope",
 		nil,
-		"The scope to acquire during login")
-	_ = local.MarkHidden("scope")
+		//nolint:lll
+		"Acquires and caches a token for the scope during login, so later commands don't wait for it. Can be specified multiple times.")
 	local.IntVar(
 		&lf.redirectPort,
 		"redirect-port",
@@ -172,7 +181,8 @@ func newLoginCmd(parent string) *cobra.Command {
 		--use-device-code.
 		
 		To log in as a service principal, pass --client-id and --tenant-id as well as one of: --client-secret, 
-		--client-certificate, or --federated-credential-provider.
+		--client-certificate, or --federated-credential-provider. The certificate can be a PEM or PFX file containing
+		the private key. Pass --client-certificate-password when the PFX file is encrypted.
 		`),
 		Annotations: map[string]string{
 			loginCmdParentAnnotation: parent,
@@ -189,6 +199,7 @@ type loginAction struct {
 	flags             *loginFlags
 	annotations       CmdAnnotations
 	commandRunner     exec.CommandRunner
+	userConfigManager config.UserConfigManager
 }
 
 // it is important to update both newAuthLoginAction and newLoginAction at the same time
@@ -203,6 +214,7 @@ func newAuthLoginAction(
 	console input.Console,
 	annotations CmdAnnotations,
 	commandRunner exec.CommandRunner,
+	userConfigManager config.UserConfigManager,
 ) actions.Action {
 	return &loginAction{
 		formatter:         formatter,
@@ -213,6 +225,7 @@ func newAuthLoginAction(
 		flags:             &flags.loginFlags,
 		annotations:       annotations,
 		commandRunner:     commandRunner,
+		userConfigManager: userConfigManager,
 	}
 }
 
@@ -228,6 +241,7 @@ func newLoginAction(
 	console input.Console,
 	annotations CmdAnnotations,
 	commandRunner exec.CommandRunner,
+	userConfigManager config.UserConfigManager,
 ) actions.Action {
 	return &loginAction{
 		formatter:         formatter,
@@ -238,15 +252,17 @@ func newLoginAction(
 		flags:             flags,
 		annotations:       annotations,
 		commandRunner:     commandRunner,
+		userConfigManager: userConfigManager,
 	}
 }
 
 const cLoginSuccessMessage = "Logged in to Azure."
 
 func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	if len(la.flags.scopes) == 0 {
-		la.flags.scopes = auth.LoginScopes
-	}
+	// The login always requests auth.LoginScopes, which the other commands need. The tokens of the scopes set with --scope
+	// are acquired once logged in, together with the configured ones.
+	requestedScopes := la.flags.scopes
+	la.flags.scopes = auth.LoginScopes
 
 	if la.annotations[loginCmdParentAnnotation] == "" {
 		fmt.Fprintln(
@@ -308,6 +324,13 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		return nil, err
 	}
 
+	prewarmScopes, err := configuredPrewarmScopes(la.userConfigManager)
+	if err != nil {
+		return nil, err
+	}
+
+	la.prewarmTokens(ctx, prewarmScopeGroups(requestedScopes, prewarmScopes))
+
 	// Rehydrate or clear the account's subscriptions cache.
 	// The caching is done here to increase responsiveness of listing subscriptions (during azd init).
 	// It also allows an implicit command for the user to refresh cached subscriptions.
@@ -363,6 +386,123 @@ func (la *loginAction) verifyLoggedIn(ctx context.Context) (*azcore.AccessToken,
 	return &token, nil
 }
 
+// prewarmScopesConfigKey is the user configuration path of the scopes whose tokens `azd auth login` acquires and caches,
+// in addition to the scopes set with --scope.
+const prewarmScopesConfigKey = "auth.prewarmScopes"
+
+// configuredPrewarmScopes returns the scopes set in the user configuration at prewarmScopesConfigKey.
+func configuredPrewarmScopes(userConfigManager config.UserConfigManager) ([]string, error) {
+	userConfig, err := userConfigManager.Load()
+	if err != nil {
+		log.Printf("skipping configured prewarm scopes, loading user config failed: %v", err)
+		return nil, nil
+	}
+
+	configured, has := userConfig.Get(prewarmScopesConfigKey)
+	if !has {
+		return nil, nil
+	}
+
+	var scopes []string
+	switch configured := configured.(type) {
+	case string:
+		scopes = strings.Split(configured, ",")
+	case []any:
+		for _, scope := range configured {
+			scopes = append(scopes, fmt.Sprint(scope))
+		}
+	default:
+		return nil, fmt.Errorf("'%s' must be a comma separated list of scopes", prewarmScopesConfigKey)
+	}
+
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+
+	return result, nil
+}
+
+// prewarmScopeGroups returns the scopes whose tokens are acquired once logged in, grouped by resource. These are the
+// requested scopes followed by the configured ones, without auth.LoginScopes, which the login already acquired.
+func prewarmScopeGroups(requested []string, configured []string) [][]string {
+	scopes := make([]string, 0, len(requested)+len(configured))
+	for _, scope := range append(slices.Clone(requested), configured...) {
+		if !slices.Contains(auth.LoginScopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return groupScopesByResource(scopes)
+}
+
+// groupScopesByResource groups scopes by the resource they belong to, like https://graph.microsoft.com for
+// https://graph.microsoft.com/User.Read, in the order each resource first appears. Duplicate scopes are removed.
+func groupScopesByResource(scopes []string) [][]string {
+	var groups [][]string
+	resources := map[string]int{}
+	seen := map[string]bool{}
+
+	for _, scope := range scopes {
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+
+		resource := scope
+		if idx := strings.LastIndex(scope, "/"); idx > 0 {
+			resource = strings.TrimRight(scope[:idx], "/")
+		}
+
+		if idx, has := resources[resource]; has {
+			groups[idx] = append(groups[idx], scope)
+			continue
+		}
+
+		resources[resource] = len(groups)
+		groups = append(groups, []string{scope})
+	}
+
+	return groups
+}
+
+// prewarmTokens acquires a token for each group of scopes, which the credential caches, so the commands that need them
+// later don't wait for a token request. Failures are only reported as warnings, since tokens are also acquired on demand.
+func (la *loginAction) prewarmTokens(ctx context.Context, scopeGroups [][]string) {
+	if len(scopeGroups) == 0 {
+		return
+	}
+
+	cred, err := la.authManager.CredentialForCurrentUser(ctx, &auth.CredentialForCurrentUserOptions{
+		TenantID: la.flags.tenantID,
+	})
+	if err != nil {
+		log.Printf("skipping token prewarming: %v", err)
+		return
+	}
+
+	for _, err := range acquireTokens(ctx, cred, scopeGroups) {
+		la.console.Message(ctx, output.WithWarningFormat("WARNING: %s", err))
+	}
+}
+
+// acquireTokens requests a token for each group of scopes from cred, and returns the errors of the failed requests.
+func acquireTokens(ctx context.Context, cred azcore.TokenCredential, scopeGroups [][]string) []error {
+	var errs []error
+	for _, scopes := range scopeGroups {
+		if _, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: scopes}); err != nil {
+			errs = append(errs, fmt.Errorf("acquiring a token for %s: %w", strings.Join(scopes, ", "), err))
+			continue
+		}
+
+		log.Printf("acquired a token for %s", strings.Join(scopes, ", "))
+	}
+
+	return errs
+}
+
 func countTrue(elms ...bool) int {
 	i := 0
 
@@ -412,6 +552,10 @@ func (la *loginAction) login(ctx context.Context) error {
 				}, ", "))
 		}
 
+		if la.flags.certificatePassword.ptr != nil && la.flags.clientCertificate == "" {
+			return fmt.Errorf("%s can only be set with %s", cCertificatePasswordFlagName, cClientCertificateFlagName)
+		}
+
 		switch {
 		case la.flags.clientSecret.ptr != nil:
 			if *la.flags.clientSecret.ptr == "" {
@@ -441,8 +585,23 @@ func (la *loginAction) login(ctx context.Context) error {
 				return fmt.Errorf("reading certificate: %w", err)
 			}
 
+			password := ""
+			if la.flags.certificatePassword.ptr != nil {
+				password = *la.flags.certificatePassword.ptr
+				if password == "" {
+					v, err := la.console.Prompt(ctx, input.ConsoleOptions{
+						Message:    "Enter the password of your client certificate",
+						IsPassword: true,
+					})
+					if err != nil {
+						return fmt.Errorf("prompting for certificate password: %w", err)
+					}
+					password = v
+				}
+			}
+
 			if _, err := la.authManager.LoginWithServicePrincipalCertificate(
-				ctx, la.flags.tenantID, la.flags.clientID, cert,
+				ctx, la.flags.tenantID, la.flags.clientID, cert, password,
 			); err != nil {
 				return fmt.Errorf("logging in: %w", err)
 			}
@@ -0,0 +1,105 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_groupScopesByResource(t *testing.T) {
+	groups := groupScopesByResource([]string{
+		azure.ManagementScope,
+		"https://graph.microsoft.com/User.Read",
+		"https://management.azure.com//.default",
+		"https://graph.microsoft.com/Mail.Read",
+		"https://devcenter.azure.com/.default",
+		"https://graph.microsoft.com/User.Read",
+	})
+
+	require.Equal(t, [][]string{
+		{azure.ManagementScope},
+		{"https://graph.microsoft.com/User.Read", "https://graph.microsoft.com/Mail.Read"},
+		{"https://devcenter.azure.com/.default"},
+	}, groups)
+}
+
+func Test_prewarmScopeGroups(t *testing.T) {
+	groups := prewarmScopeGroups(
+		[]string{azure.ManagementScope, "https://graph.microsoft.com/User.Read"},
+		[]string{"https://devcenter.azure.com/.default", "https://graph.microsoft.com/User.Read"},
+	)
+
+	// The login scopes are acquired by the login itself
+	require.Equal(t, [][]string{
+		{"https://graph.microsoft.com/User.Read"},
+		{"https://devcenter.azure.com/.default"},
+	}, groups)
+}
+
+func Test_acquireTokens(t *testing.T) {
+	// cache holds the tokens acquired by the credential, keyed by their scopes, like the MSAL cache would
+	cache := map[string]azcore.AccessToken{}
+	cred := authTokenFn(func(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+		key := strings.Join(options.Scopes, " ")
+		if strings.HasPrefix(key, "https://unknown") {
+			return azcore.AccessToken{}, errors.New("AADSTS500011: resource principal not found")
+		}
+
+		token := azcore.AccessToken{Token: "token for " + key, ExpiresOn: time.Now().Add(time.Hour)}
+		cache[key] = token
+		return token, nil
+	})
+
+	scopes := []string{
+		"https://graph.microsoft.com/.default",
+		"https://devcenter.azure.com/.default",
+		"https://unknown.contoso.com/.default",
+	}
+
+	errs := acquireTokens(context.Background(), cred, groupScopesByResource(scopes))
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "acquiring a token for https://unknown.contoso.com/.default")
+
+	require.Len(t, cache, 2)
+	require.Contains(t, cache, "https://graph.microsoft.com/.default")
+	require.Contains(t, cache, "https://devcenter.azure.com/.default")
+}
+
+func Test_configuredPrewarmScopes(t *testing.T) {
+	load := func(value any) ([]string, error) {
+		userConfig := config.NewEmptyConfig()
+		if value != nil {
+			require.NoError(t, userConfig.Set(prewarmScopesConfigKey, value))
+		}
+
+		return configuredPrewarmScopes(
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager().WithConfig(userConfig)))
+	}
+
+	scopes, err := load(nil)
+	require.NoError(t, err)
+	require.Empty(t, scopes)
+
+	scopes, err = load("https://graph.microsoft.com/.default, https://devcenter.azure.com/.default,")
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://graph.microsoft.com/.default", "https://devcenter.azure.com/.default"}, scopes)
+
+	scopes, err = load([]any{"https://graph.microsoft.com/.default"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://graph.microsoft.com/.default"}, scopes)
+
+	_, err = load(map[string]any{"scope": "https://graph.microsoft.com/.default"})
+	require.ErrorContains(t, err, "must be a comma separated list of scopes")
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newAuthStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the account signed in to azd, without logging in.",
+		Args:  cobra.NoArgs,
+	}
+}
+
+type authStatusAction struct {
+	credentialProvider CredentialProviderFn
+	formatter          output.Formatter
+	writer             io.Writer
+}
+
+func newAuthStatusAction(
+	credentialProvider CredentialProviderFn,
+	formatter output.Formatter,
+	writer io.Writer,
+	_ *internal.GlobalCommandOptions,
+) actions.Action {
+	return &authStatusAction{
+		credentialProvider: credentialProvider,
+		formatter:          formatter,
+		writer:             writer,
+	}
+}
+
+func (a *authStatusAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	res, statusErr := a.status(ctx)
+
+	if a.formatter.Kind() == output.JsonFormat {
+		if err := a.formatter.Format(res, a.writer, nil); err != nil {
+			return nil, err
+		}
+
+		// The status is written either way, the exit code tells whether the account is logged in
+		return nil, statusErr
+	}
+
+	if statusErr != nil {
+		return nil, statusErr
+	}
+
+	return nil, a.formatter.Format(res, a.writer, output.TableFormatterOptions{
+		Columns: []output.Column{
+			{
+				Heading:       "Account",
+				ValueTemplate: "{{.Account}}",
+			},
+			{
+				Heading:       "Type",
+				ValueTemplate: "{{.Type}}",
+			},
+			{
+				Heading:       "Object Id",
+				ValueTemplate: "{{.ObjectId}}",
+			},
+			{
+				Heading:       "Tenant Id",
+				ValueTemplate: "{{.TenantId}}",
+			},
+			{
+				Heading:       "Expires On",
+				ValueTemplate: `{{if .ExpiresOn}}{{.ExpiresOn.Local.Format "2006-01-02 15:04:05"}}{{end}}`,
+			},
+		},
+	})
+}
+
+// status returns the login status of the current account. Tokens are only fetched silently, so an account that needs to
+// log in again is reported as unauthenticated instead of starting an interactive login.
+func (a *authStatusAction) status(ctx context.Context) (contracts.AuthStatusResult, error) {
+	unauthenticated := contracts.AuthStatusResult{Status: contracts.LoginStatusUnauthenticated}
+
+	credential, err := a.credentialProvider(ctx, nil)
+	if err == nil {
+		var token *azcore.AccessToken
+		token, err = auth.EnsureLoggedInCredential(ctx, credential)
+		if err == nil {
+			return authStatusFromToken(*token)
+		}
+	}
+
+	var loginExpiryError *auth.ReLoginRequiredError
+	if errors.Is(err, auth.ErrNoCurrentUser) || errors.As(err, &loginExpiryError) {
+		return unauthenticated, err
+	}
+
+	return unauthenticated, fmt.Errorf("checking login status: %w", err)
+}
+
+// authStatusFromToken describes the account an access token was issued to. Tokens issued to users carry a username,
+// while tokens issued to service principals only carry the application id.
+func authStatusFromToken(token azcore.AccessToken) (contracts.AuthStatusResult, error) {
+	claims, err := auth.GetClaimsFromAccessToken(token.Token)
+	if err != nil {
+		return contracts.AuthStatusResult{}, fmt.Errorf("reading access token claims: %w", err)
+	}
+
+	res := contracts.AuthStatusResult{
+		Status:    contracts.LoginStatusSuccess,
+		Type:      contracts.AccountTypeUser,
+		Account:   claims.Username(),
+		ObjectId:  claims.Oid,
+		TenantId:  claims.Tid,
+		ExpiresOn: &token.ExpiresOn,
+	}
+
+	if res.Account == "" {
+		res.Type = contracts.AccountTypeServicePrincipal
+		res.Account = claims.AppId
+	}
+
+	return res, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+// cspell: disable
+
+const (
+	// a token with the claims oid: user-oid, tid: test-tenant and upn: user@contoso.com
+	authStatusUserToken = "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." +
+		"eyJvaWQiOiJ1c2VyLW9pZCIsInRpZCI6InRlc3QtdGVuYW50IiwidXBuIjoidXNlckBjb250b3NvLmNvbSJ9.sig"
+	// a token with the claims oid: sp-oid, tid: test-tenant and appid: app-id
+	authStatusServicePrincipalToken = "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." +
+		"eyJvaWQiOiJzcC1vaWQiLCJ0aWQiOiJ0ZXN0LXRlbmFudCIsImFwcGlkIjoiYXBwLWlkIn0.sig"
+)
+
+// cspell: enable
+
+func TestAuthStatus(t *testing.T) {
+	expiresOn := time.Unix(1669153000, 0).UTC()
+
+	tokenFn := func(token string) authTokenFn {
+		return func(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+			require.ElementsMatch(t, auth.LoginScopes, options.Scopes)
+			return azcore.AccessToken{Token: token, ExpiresOn: expiresOn}, nil
+		}
+	}
+
+	t.Run("User", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		a := newAuthStatusAction(credentialProviderForTokenFn(tokenFn(authStatusUserToken)), &output.JsonFormatter{}, buf, nil)
+
+		_, err := a.Run(context.Background())
+		require.NoError(t, err)
+
+		var res contracts.AuthStatusResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		require.Equal(t, contracts.LoginStatusSuccess, res.Status)
+		require.Equal(t, contracts.AccountTypeUser, res.Type)
+		require.Equal(t, "user@contoso.com", res.Account)
+		require.Equal(t, "user-oid", res.ObjectId)
+		require.Equal(t, "test-tenant", res.TenantId)
+		require.Equal(t, expiresOn, res.ExpiresOn.UTC())
+	})
+
+	t.Run("ServicePrincipal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		a := newAuthStatusAction(
+			credentialProviderForTokenFn(tokenFn(authStatusServicePrincipalToken)), &output.TableFormatter{}, buf, nil)
+
+		_, err := a.Run(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "app-id")
+		require.Contains(t, buf.String(), "servicePrincipal")
+		require.Contains(t, buf.String(), "sp-oid")
+	})
+
+	t.Run("NotLoggedIn", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		notLoggedIn := func(context.Context, *auth.CredentialForCurrentUserOptions) (azcore.TokenCredential, error) {
+			return nil, auth.ErrNoCurrentUser
+		}
+		a := newAuthStatusAction(notLoggedIn, &output.JsonFormatter{}, buf, nil)
+
+		_, err := a.Run(context.Background())
+		require.ErrorIs(t, err, auth.ErrNoCurrentUser)
+
+		// The status is still written, so scripts can read it from the output
+		var res contracts.AuthStatusResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		require.Equal(t, contracts.LoginStatusUnauthenticated, res.Status)
+		require.Empty(t, res.Account)
+	})
+
+	t.Run("TokenError", func(t *testing.T) {
+		failing := authTokenFn(func(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+			return azcore.AccessToken{}, errors.New("token endpoint unavailable")
+		})
+		a := newAuthStatusAction(credentialProviderForTokenFn(failing), &output.TableFormatter{}, &bytes.Buffer{}, nil)
+
+		_, err := a.Run(context.Background())
+		require.ErrorContains(t, err, "checking login status: token endpoint unavailable")
+	})
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"slices"
 	"strconv"
@@ -104,6 +105,10 @@ func (cb *CobraBuilder) configureActionResolver(cmd *cobra.Command, descriptor *
 		ctx := cmd.Context()
 		ctx = tools.WithInstalledCheckCache(ctx)
 
+		// Long running actions, like provisioning, observe the cancellation to clean up when the user presses Ctrl+C
+		ctx, stopInterruptHandling := withInterruptCancellation(ctx, cmd.ErrOrStderr())
+		defer stopInterruptHandling()
+
 		// Registers the following to enable injection into actions that require them
 		ioc.RegisterInstance(cb.container, cb.runner)
 		ioc.RegisterInstance(cb.container, middleware.MiddlewareContext(cb.runner))
@@ -141,6 +146,13 @@ func (cb *CobraBuilder) configureActionResolver(cmd *cobra.Command, descriptor *
 		// Run the middleware chain with action
 		log.Printf("Resolved action '%s'\n", actionName)
 		actionResult, err := cb.runner.RunAction(ctx, runOptions, action)
+		if err == nil && actionResult != nil && actionResult.Result != nil {
+			if invokeErr := cb.container.Invoke(func(formatter output.Formatter, writer io.Writer) {
+				err = writeActionResult(actionResult, formatter, writer)
+			}); invokeErr != nil {
+				return invokeErr
+			}
+		}
 
 		// At this point, we know that there might be an error, so we can silence cobra from showing it after us.
 		cmd.SilenceErrors = true
@@ -159,6 +171,11 @@ func (cb *CobraBuilder) configureActionResolver(cmd *cobra.Command, descriptor *
 				}
 			}
 
+			// The console discards all output when `--output none` is set, but errors must still be reported
+			if err != nil && output.IsOutputSuppressed(cmd) {
+				console.SetWriter(cmd.ErrOrStderr())
+			}
+
 			if displayResult != nil {
 				console.MessageUxItem(ctx, displayResult)
 			}
@@ -413,3 +430,21 @@ func createActionName(cmd *cobra.Command) string {
 
 	return strings.ToLower(actionName)
 }
+
+// writeActionResult writes the structured result of an action in the output format of the command.
+func writeActionResult(actionResult *actions.ActionResult, formatter output.Formatter, writer io.Writer) error {
+	if formatter.Kind() == output.NoneFormat {
+		if text, ok := actionResult.Result.(fmt.Stringer); ok {
+			_, err := fmt.Fprintln(writer, text.String())
+			return err
+		}
+
+		return nil
+	}
+
+	if err := formatter.Format(actionResult.Result, writer, actionResult.FormatOptions); err != nil {
+		return fmt.Errorf("formatting result: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/cmd/middleware"
 	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -286,6 +288,46 @@ func Test_RunDocsAndHelpFlow(t *testing.T) {
 	require.Equal(t, "", calledUrl)
 }
 
+func Test_writeActionResult(t *testing.T) {
+	result := contracts.VersionResult{}
+	result.Azd.Version = "1.0.0"
+	result.Azd.Commit = "abc"
+
+	t.Run("Json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := writeActionResult(&actions.ActionResult{Result: result}, &output.JsonFormatter{}, buf)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"azd": {"version": "1.0.0", "commit": "abc"}}`, buf.String())
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := writeActionResult(&actions.ActionResult{
+			Result: []contracts.VersionResult{result},
+			FormatOptions: output.TableFormatterOptions{
+				Columns: []output.Column{{Heading: "VERSION", ValueTemplate: "{{.Azd.Version}}"}},
+			},
+		}, &output.TableFormatter{}, buf)
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "VERSION")
+		require.Contains(t, buf.String(), "1.0.0")
+	})
+
+	t.Run("NoneWritesText", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := writeActionResult(&actions.ActionResult{Result: result}, &output.NoneFormatter{}, buf)
+		require.NoError(t, err)
+		require.Equal(t, "azd version 1.0.0 (commit abc)\n", buf.String())
+	})
+
+	t.Run("NoneWithoutText", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := writeActionResult(&actions.ActionResult{Result: []string{"a"}}, &output.NoneFormatter{}, buf)
+		require.NoError(t, err)
+		require.Empty(t, buf.String())
+	})
+}
+
 func setup(container *ioc.NestedContainer) {
 	registerCommonDependencies(container)
 	globalOptions := &internal.GlobalCommandOptions{
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
@@ -13,10 +17,15 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 var userConfigPath string
@@ -33,7 +42,7 @@ func configActions(root *actions.ActionDescriptor, rootOptions *internal.GlobalC
 
 		The configuration directory can be overridden by specifying a path in the AZD_CONFIG_DIR environment variable`)
 	} else if err != nil {
-		userConfigPath = output.WithBackticks(filepath.Join("$AZURE_CONFIG_DIR", "config.json"))
+		userConfigPath = output.WithBackticks(filepath.Join("$AZD_CONFIG_DIR", "config.json"))
 	} else {
 		userConfigPath = output.WithBackticks(filepath.Join(userConfigDir, "config.json"))
 	}
@@ -104,24 +113,51 @@ func configActions(root *actions.ActionDescriptor, rootOptions *internal.GlobalC
 		Command: &cobra.Command{
 			Use:   "get <path>",
 			Short: "Gets a configuration.",
-			Long:  `Gets a configuration in ` + userConfigPath + `.`,
-			Args:  cobra.ExactArgs(1),
+			Long: `Gets a configuration in ` + userConfigPath + `. A path to a section, like ` +
+				output.WithBackticks("defaults") + `, gets the whole section. A wildcard in the last segment of the ` +
+				`path, like ` + output.WithBackticks("defaults.*") + ` or ` + output.WithBackticks("alpha.dep*") +
+				`, gets the matching keys of the section, and nothing when no key matches. ` +
+				`With ` + output.WithBackticks("--output table") + `, the values are listed by key.`,
+			Args: cobra.ExactArgs(1),
 		},
 		ActionResolver: newConfigGetAction,
-		OutputFormats:  []output.Format{output.JsonFormat},
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
 		DefaultFormat:  output.JsonFormat,
 	})
 
+	group.Add("keys", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Short: "Lists the configuration keys recognized by azd.",
+			Long: `Lists the configuration keys recognized by azd, with a short description of each. ` +
+				`Segments like ` + output.WithBackticks("<name>") + ` are placeholders for any value.`,
+			Args: cobra.NoArgs,
+		},
+		ActionResolver: newConfigKeysAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+	})
+
 	group.Add("set", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
 			Use:   "set <path> <value>",
 			Short: "Sets a configuration.",
-			Long:  `Sets a configuration in ` + userConfigPath + `.`,
-			Args:  cobra.ExactArgs(2),
+			Long: `Sets a configuration in ` + userConfigPath + `.` + "\n\n" +
+				`Use ` + output.WithBackticks("-") + ` as the value, or ` + output.WithBackticks("--value-stdin") +
+				`, to read the value from stdin. Values read from stdin that are valid JSON are stored as JSON.` + "\n\n" +
+				`Use ` + output.WithBackticks("--type") + ` to store the value as a string, bool, int or JSON, ` +
+				`regardless of its content.` + "\n\n" +
+				`Use ` + output.WithBackticks("--project") + ` to set the configuration in the ` +
+				azdcontext.ProjectFileName + ` of the current project instead, for keys that can be set at project scope.`,
+			Args: cobra.RangeArgs(1, 2),
 			Example: `$ azd config set defaults.subscription <yourSubscriptionID>
-$ azd config set defaults.location eastus`,
+$ azd config set defaults.location eastus
+$ cat settings.json | azd config set my.settings -
+$ azd config set my.id 0123 --type string
+$ azd config set my.enabled true --type bool
+$ azd config set state.remote.backend AzureBlobStorage --project`,
 		},
 		ActionResolver: newConfigSetAction,
+		FlagsResolver:  newConfigSetFlags,
 	})
 
 	group.Add("unset", &actions.ActionDescriptorOptions{
@@ -225,54 +261,384 @@ func (a *configGetAction) Run(ctx context.Context) (*actions.ActionResult, error
 	}
 
 	key := a.args[0]
-	value, ok := azdConfig.Get(key)
-
-	if !ok {
-		return nil, fmt.Errorf("no value stored at path '%s'", key)
+	var value any
+	if strings.Contains(key, "*") {
+		value, err = matchConfigValues(azdConfig, key)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var ok bool
+		value, ok = azdConfig.Get(key)
+		if !ok {
+			return nil, fmt.Errorf("no value stored at path '%s'", key)
+		}
 	}
 
-	if a.formatter.Kind() == output.JsonFormat {
+	switch a.formatter.Kind() {
+	case output.JsonFormat:
 		err := a.formatter.Format(value, a.writer, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failing formatting config values: %w", err)
 		}
+	case output.TableFormat:
+		// A wildcard matches keys of the section at the path without its last segment
+		prefix := key
+		if strings.Contains(key, "*") {
+			prefix = key[:max(strings.LastIndex(key, "."), 0)]
+		}
+
+		err := a.formatter.Format(configLeafValues(prefix, value), a.writer, output.TableFormatterOptions{
+			Columns: []output.Column{
+				{
+					Heading:       "Key",
+					ValueTemplate: "{{.Key}}",
+				},
+				{
+					Heading:       "Value",
+					ValueTemplate: "{{.Value}}",
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failing formatting config values: %w", err)
+		}
 	}
 
 	return nil, nil
 }
 
+// matchConfigValues returns the keys, and their values, of the configuration section that match the wildcard in the last
+// segment of pattern, like `defaults.*`. The result is empty when no key matches or the section doesn't exist.
+func matchConfigValues(azdConfig config.Config, pattern string) (map[string]any, error) {
+	sectionPath, keyPattern := "", pattern
+	if idx := strings.LastIndex(pattern, "."); idx >= 0 {
+		sectionPath, keyPattern = pattern[:idx], pattern[idx+1:]
+	}
+
+	if strings.Contains(sectionPath, "*") {
+		return nil, fmt.Errorf("invalid path '%s', a wildcard can only be used in the last segment", pattern)
+	}
+
+	if _, err := path.Match(keyPattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid path '%s': %w", pattern, err)
+	}
+
+	section := azdConfig.Raw()
+	if sectionPath != "" {
+		value, _ := azdConfig.Get(sectionPath)
+		section, _ = value.(map[string]any)
+	}
+
+	matches := map[string]any{}
+	for key, value := range section {
+		if matched, _ := path.Match(keyPattern, key); matched {
+			matches[key] = value
+		}
+	}
+
+	return matches, nil
+}
+
+// configValue is a value of the configuration and its dotted path
+type configValue struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// configLeafValues returns the values of the configuration under the path prefix, by dotted path and sorted by path.
+// Sections are expanded to the values they contain.
+func configLeafValues(prefix string, value any) []configValue {
+	section, isSection := value.(map[string]any)
+	if !isSection {
+		return []configValue{{Key: prefix, Value: value}}
+	}
+
+	values := []configValue{}
+	keys := maps.Keys(section)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		childPath := key
+		if prefix != "" {
+			childPath = prefix + "." + key
+		}
+
+		values = append(values, configLeafValues(childPath, section[key])...)
+	}
+
+	return values
+}
+
+// azd config keys
+
+type configKeysAction struct {
+	formatter output.Formatter
+	writer    io.Writer
+}
+
+func newConfigKeysAction(formatter output.Formatter, writer io.Writer) actions.Action {
+	return &configKeysAction{
+		formatter: formatter,
+		writer:    writer,
+	}
+}
+
+// Executes the `azd config keys` action
+func (a *configKeysAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	keys := config.KnownKeys()
+
+	if a.formatter.Kind() == output.TableFormat {
+		columns := []output.Column{
+			{
+				Heading:       "Key",
+				ValueTemplate: "{{.Key}}",
+			},
+			{
+				Heading:       "Description",
+				ValueTemplate: "{{.Description}}",
+			},
+		}
+
+		return nil, a.formatter.Format(keys, a.writer, output.TableFormatterOptions{
+			Columns: columns,
+		})
+	}
+
+	return nil, a.formatter.Format(keys, a.writer, nil)
+}
+
 // azd config set <path> <value>
 
+// stdinValueArg is the value argument that instructs `azd config set` to read the value from stdin
+const stdinValueArg = "-"
+
+// The types a value can be stored as with `azd config set --type`
+const (
+	configValueTypeString = "string"
+	configValueTypeBool   = "bool"
+	configValueTypeInt    = "int"
+	configValueTypeJson   = "json"
+)
+
+var configValueTypes = []string{configValueTypeString, configValueTypeBool, configValueTypeInt, configValueTypeJson}
+
+type configSetActionFlags struct {
+	valueStdin bool
+	force      bool
+	project    bool
+	valueType  string
+}
+
+func newConfigSetFlags(cmd *cobra.Command) *configSetActionFlags {
+	flags := &configSetActionFlags{}
+	cmd.Flags().BoolVar(&flags.valueStdin, "value-stdin", false, "Reads the configuration value from stdin.")
+	cmd.Flags().BoolVar(
+		&flags.force, "force", false, "Sets the configuration without checking that the key is recognized by azd.")
+	cmd.Flags().BoolVar(
+		&flags.project,
+		"project",
+		false,
+		"Sets the configuration in the "+azdcontext.ProjectFileName+" of the current project, so it applies to everyone "+
+			"working on the project. Only supported for keys that can be set at project scope.",
+	)
+	cmd.Flags().StringVar(
+		&flags.valueType,
+		"type",
+		"",
+		"Stores the value as the given type: "+strings.Join(configValueTypes, ", ")+". By default, values are stored "+
+			"as strings, and values read from stdin that are valid JSON are stored as JSON.",
+	)
+
+	return flags
+}
+
 type configSetAction struct {
-	configManager config.UserConfigManager
-	args          []string
+	configManager  config.UserConfigManager
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext]
+	console        input.Console
+	flags          *configSetActionFlags
+	args           []string
 }
 
-func newConfigSetAction(configManager config.UserConfigManager, args []string) actions.Action {
+func newConfigSetAction(
+	configManager config.UserConfigManager,
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
+	console input.Console,
+	flags *configSetActionFlags,
+	args []string,
+) actions.Action {
 	return &configSetAction{
-		configManager: configManager,
-		args:          args,
+		configManager:  configManager,
+		lazyAzdContext: lazyAzdContext,
+		console:        console,
+		flags:          flags,
+		args:           args,
 	}
 }
 
 // Executes the `azd config set <path> <value>` action
 func (a *configSetAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	path := a.args[0]
+	fromStdin := a.flags.valueStdin || (len(a.args) == 2 && a.args[1] == stdinValueArg)
+
+	if a.flags.valueStdin && len(a.args) == 2 {
+		return nil, fmt.Errorf("a value cannot be specified when using --value-stdin")
+	}
+
+	if !fromStdin && len(a.args) != 2 {
+		return nil, fmt.Errorf("a value must be specified for '%s', or use --value-stdin to read it from stdin", path)
+	}
+
+	if a.flags.valueType != "" && !slices.Contains(configValueTypes, a.flags.valueType) {
+		return nil, fmt.Errorf(
+			"invalid type '%s', the supported types are: %s", a.flags.valueType, strings.Join(configValueTypes, ", "))
+	}
+
+	if a.flags.project {
+		return nil, a.setProjectValue(ctx, path, fromStdin)
+	}
+
+	// Unknown keys are still set, since they may be read by a newer version of azd
+	if !a.flags.force && !config.IsKnownKey(path) {
+		a.console.MessageUxItem(ctx, &ux.WarningMessage{Description: unknownConfigKeyMessage(path)})
+	}
+
 	azdConfig, err := a.configManager.Load()
 	if err != nil {
 		return nil, err
 	}
 
-	path := a.args[0]
-	value := a.args[1]
-
-	err = azdConfig.Set(path, value)
+	value, err := a.value(fromStdin)
 	if err != nil {
-		return nil, fmt.Errorf("failed setting configuration value '%s' to '%s'. %w", path, value, err)
+		return nil, err
+	}
+
+	if err := azdConfig.Set(path, value); err != nil {
+		// The value is omitted from the error since values piped through stdin are frequently secrets
+		if fromStdin {
+			return nil, fmt.Errorf("failed setting configuration value '%s'. %w", path, err)
+		}
+
+		return nil, fmt.Errorf("failed setting configuration value '%s' to '%s'. %w", path, a.args[1], err)
 	}
 
 	return nil, a.configManager.Save(azdConfig)
 }
 
+// value returns the value to set, read from stdin when fromStdin is set, and stored as the type set with --type.
+func (a *configSetAction) value(fromStdin bool) (any, error) {
+	if a.flags.valueType == "" {
+		if fromStdin {
+			return readConfigValue(a.console.Handles().Stdin)
+		}
+
+		return a.args[1], nil
+	}
+
+	text := ""
+	if fromStdin {
+		var err error
+		if text, err = readConfigText(a.console.Handles().Stdin); err != nil {
+			return nil, err
+		}
+	} else {
+		text = a.args[1]
+	}
+
+	return typedConfigValue(text, a.flags.valueType)
+}
+
+// setProjectValue sets the configuration in the azure.yaml of the current project. Unlike the user configuration, only
+// keys that azd reads from the project can be set.
+func (a *configSetAction) setProjectValue(ctx context.Context, path string, fromStdin bool) error {
+	if !config.IsProjectKey(path) {
+		if config.IsKnownKey(path) {
+			return fmt.Errorf("'%s' can only be set in the user configuration, remove '--project' to set it", path)
+		}
+
+		return errors.New(unknownConfigKeyMessage(path))
+	}
+
+	azdCtx, err := a.lazyAzdContext.GetValue()
+	if err != nil {
+		return err
+	}
+
+	value, err := a.value(fromStdin)
+	if err != nil {
+		return err
+	}
+
+	return project.SetValue(ctx, azdCtx.ProjectPath(), path, value)
+}
+
+// unknownConfigKeyMessage returns the warning displayed when setting a key that azd doesn't recognize.
+func unknownConfigKeyMessage(path string) string {
+	message := fmt.Sprintf("'%s' is not a configuration key recognized by azd.", path)
+	if suggestion, ok := config.SuggestKey(path); ok {
+		message += fmt.Sprintf(" Did you mean '%s'?", suggestion)
+	}
+
+	return message + fmt.Sprintf(" Run %s to list recognized keys.", output.WithHighLightFormat("azd config keys"))
+}
+
+// readConfigValue reads a configuration value from the given reader. Values that are valid JSON are returned in their
+// structured form, any other content is returned as a string with the trailing newline removed.
+func readConfigValue(reader io.Reader) (any, error) {
+	text, err := readConfigText(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var structured any
+	if err := json.Unmarshal([]byte(text), &structured); err == nil {
+		return structured, nil
+	}
+
+	return text, nil
+}
+
+// readConfigText reads a configuration value from the given reader, with the trailing newline removed.
+func readConfigText(reader io.Reader) (string, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("reading value from stdin: %w", err)
+	}
+
+	return strings.TrimRight(string(contents), "\r\n"), nil
+}
+
+// typedConfigValue converts text to the given type, one of configValueTypes. The text is omitted from the errors, since
+// it may have been read from stdin.
+func typedConfigValue(text string, valueType string) (any, error) {
+	switch valueType {
+	case configValueTypeBool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, errors.New("the value is not a valid bool, use true or false")
+		}
+
+		return value, nil
+	case configValueTypeInt:
+		value, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, errors.New("the value is not a valid int")
+		}
+
+		return value, nil
+	case configValueTypeJson:
+		var value any
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return nil, fmt.Errorf("the value is not valid JSON: %w", err)
+		}
+
+		return value, nil
+	default:
+		return text, nil
+	}
+}
+
 // azd config unset <path>
 
 type configUnsetAction struct {
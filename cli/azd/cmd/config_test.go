@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readConfigValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{name: "PlainText", input: "eastus\n", expected: "eastus"},
+		{name: "JsonObject", input: `{"key":"value","count":2}`, expected: map[string]any{"key": "value", "count": 2.0}},
+		{name: "JsonArray", input: "[\"a\",\"b\"]\r\n", expected: []any{"a", "b"}},
+		{name: "JsonBool", input: "true\n", expected: true},
+		{name: "InvalidJson", input: "{not json", expected: "{not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := readConfigValue(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func Test_unknownConfigKeyMessage(t *testing.T) {
+	require.Contains(t, unknownConfigKeyMessage("defaults.subscriptoin"), "Did you mean 'defaults.subscription'?")
+	require.NotContains(t, unknownConfigKeyMessage("something.else.entirely"), "Did you mean")
+}
+
+func Test_configSetAction_Project(t *testing.T) {
+	newAction := func(projectDir string, args ...string) actions.Action {
+		lazyAzdContext := lazy.NewLazy(func() (*azdcontext.AzdContext, error) {
+			return azdcontext.NewAzdContextWithDirectory(projectDir), nil
+		})
+
+		return newConfigSetAction(
+			nil,
+			lazyAzdContext,
+			mocks.NewMockContext(context.Background()).Console,
+			&configSetActionFlags{project: true},
+			args,
+		)
+	}
+
+	projectDir := t.TempDir()
+	projectPath := filepath.Join(projectDir, azdcontext.ProjectFileName)
+	require.NoError(t, os.WriteFile(projectPath, []byte("# project comment\nname: test-proj\n"), osutil.PermissionFile))
+
+	_, err := newAction(projectDir, "state.remote.backend", "AzureBlobStorage").Run(context.Background())
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(projectPath)
+	require.NoError(t, err)
+	require.Equal(t, "# project comment\nname: test-proj\nstate:\n  remote:\n    backend: AzureBlobStorage\n", string(contents))
+
+	// The project default output format uses the same key as the user configuration
+	_, err = newAction(projectDir, "defaults.output", "json").Run(context.Background())
+	require.NoError(t, err)
+
+	projectConfig, err := project.Load(context.Background(), projectPath)
+	require.NoError(t, err)
+	require.Equal(t, "json", projectConfig.Defaults.Output)
+
+	_, err = newAction(projectDir, "defaults.location", "eastus2").Run(context.Background())
+	require.ErrorContains(t, err, "'defaults.location' can only be set in the user configuration")
+
+	_, err = newAction(projectDir, "platfrom.type", "devcenter").Run(context.Background())
+	require.ErrorContains(t, err, "'platfrom.type' is not a configuration key recognized by azd")
+}
+
+func Test_configSetAction_Type(t *testing.T) {
+	run := func(valueType string, value string) (any, error) {
+		userConfig := config.NewEmptyConfig()
+		action := newConfigSetAction(
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager().WithConfig(userConfig)),
+			nil,
+			mocks.NewMockContext(context.Background()).Console,
+			&configSetActionFlags{valueType: valueType},
+			[]string{"my.value", value},
+		)
+		if _, err := action.Run(context.Background()); err != nil {
+			return nil, err
+		}
+
+		stored, has := userConfig.Get("my.value")
+		require.True(t, has)
+		return stored, nil
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		value, err := run("", "0123")
+		require.NoError(t, err)
+		require.Equal(t, "0123", value)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		value, err := run(configValueTypeString, "true")
+		require.NoError(t, err)
+		require.Equal(t, "true", value)
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		value, err := run(configValueTypeBool, "true")
+		require.NoError(t, err)
+		require.Equal(t, true, value)
+
+		_, err = run(configValueTypeBool, "yes")
+		require.ErrorContains(t, err, "not a valid bool")
+	})
+
+	t.Run("Int", func(t *testing.T) {
+		value, err := run(configValueTypeInt, "0123")
+		require.NoError(t, err)
+		require.Equal(t, 123, value)
+
+		_, err = run(configValueTypeInt, "1.5")
+		require.ErrorContains(t, err, "not a valid int")
+	})
+
+	t.Run("Json", func(t *testing.T) {
+		value, err := run(configValueTypeJson, `{"enabled": true, "ids": ["0123"]}`)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"enabled": true, "ids": []any{"0123"}}, value)
+
+		_, err = run(configValueTypeJson, "{")
+		require.ErrorContains(t, err, "not valid JSON")
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		_, err := run("float", "1.5")
+		require.ErrorContains(t, err, "invalid type 'float'")
+	})
+}
+
+func Test_configGetAction(t *testing.T) {
+	userConfig := config.NewEmptyConfig()
+	require.NoError(t, userConfig.Set("defaults.location", "eastus2"))
+	require.NoError(t, userConfig.Set("defaults.subscription", "SUBSCRIPTION_ID"))
+	require.NoError(t, userConfig.Set("alpha.deployment.stacks", "on"))
+	require.NoError(t, userConfig.Set("alpha.all", "off"))
+
+	run := func(formatter output.Formatter, path string) (string, error) {
+		buf := &strings.Builder{}
+		action := newConfigGetAction(
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager().WithConfig(userConfig)),
+			formatter,
+			buf,
+			[]string{path},
+		)
+		_, err := action.Run(context.Background())
+		return buf.String(), err
+	}
+
+	t.Run("Section", func(t *testing.T) {
+		value, err := run(&output.JsonFormatter{}, "defaults")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"location": "eastus2", "subscription": "SUBSCRIPTION_ID"}`, value)
+	})
+
+	t.Run("Wildcard", func(t *testing.T) {
+		value, err := run(&output.JsonFormatter{}, "defaults.*")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"location": "eastus2", "subscription": "SUBSCRIPTION_ID"}`, value)
+
+		value, err = run(&output.JsonFormatter{}, "alpha.dep*")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"deployment": {"stacks": "on"}}`, value)
+
+		value, err = run(&output.JsonFormatter{}, "*")
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"alpha": {"all": "off", "deployment": {"stacks": "on"}},
+			"defaults": {"location": "eastus2", "subscription": "SUBSCRIPTION_ID"}
+		}`, value)
+	})
+
+	t.Run("NoMatches", func(t *testing.T) {
+		value, err := run(&output.JsonFormatter{}, "defaults.foo*")
+		require.NoError(t, err)
+		require.JSONEq(t, `{}`, value)
+
+		value, err = run(&output.JsonFormatter{}, "missing.*")
+		require.NoError(t, err)
+		require.JSONEq(t, `{}`, value)
+
+		_, err = run(&output.JsonFormatter{}, "missing")
+		require.EqualError(t, err, "no value stored at path 'missing'")
+	})
+
+	t.Run("InvalidWildcard", func(t *testing.T) {
+		_, err := run(&output.JsonFormatter{}, "*.location")
+		require.ErrorContains(t, err, "a wildcard can only be used in the last segment")
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		value, err := run(&output.TableFormatter{}, "alpha.*")
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(value), "\n")
+		require.Len(t, lines, 3)
+		require.Regexp(t, `^Key\s+Value$`, lines[0])
+		require.Regexp(t, `^alpha\.all\s+off$`, lines[1])
+		require.Regexp(t, `^alpha\.deployment\.stacks\s+on$`, lines[2])
+
+		value, err = run(&output.TableFormatter{}, "defaults.location")
+		require.NoError(t, err)
+		require.Regexp(t, `defaults\.location\s+eastus2`, value)
+	})
+}
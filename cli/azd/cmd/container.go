@@ -3,11 +3,15 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
@@ -48,6 +52,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/python"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/swa"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/terraform"
+	"github.com/fatih/color"
 	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
@@ -91,35 +96,174 @@ func resolveAction[T actions.Action](container *ioc.NestedContainer, actionName
 	return instance, nil
 }
 
-// Registers common Azd dependencies
-func registerCommonDependencies(container *ioc.NestedContainer) {
-	container.RegisterSingleton(output.GetCommandFormatter)
+// defaultOutputFormat returns the output format used when `--output` is not set, looked up in the following precedence:
+// 1. Project azure.yaml
+// 2. User configuration
+func defaultOutputFormat(
+	lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+	userConfigManager config.UserConfigManager,
+) output.Format {
+	// The project config may not be available, like when running outside of a project
+	projectConfig, _ := lazyProjectConfig.GetValue()
+	if projectConfig != nil && projectConfig.Defaults != nil && projectConfig.Defaults.Output != "" {
+		return output.Format(projectConfig.Defaults.Output)
+	}
 
-	container.RegisterSingleton(func(
-		rootOptions *internal.GlobalCommandOptions,
-		formatter output.Formatter,
-		cmd *cobra.Command) input.Console {
-		writer := cmd.OutOrStdout()
-		// When using JSON formatting, we want to ensure we always write messages from the console to stderr.
-		if formatter != nil && formatter.Kind() == output.JsonFormat {
-			writer = cmd.ErrOrStderr()
+	// Commands that repair the user configuration, like `azd config reset`, must keep working when it can't be loaded
+	userConfig, err := userConfigManager.Load()
+	if err != nil {
+		log.Printf("ignoring default output format, loading user config failed: %v", err)
+		return ""
+	}
+
+	if format, ok := userConfig.Get("defaults.output"); ok {
+		if formatString, isString := format.(string); isString {
+			return output.Format(formatString)
 		}
+	}
 
-		if os.Getenv("NO_COLOR") != "" {
-			writer = colorable.NewNonColorable(writer)
+	return ""
+}
+
+// loadProjectConfig loads the project config and applies the overrides of the environment with the specified name, or of
+// the default environment when no name is specified. Every command therefore sees the same services, whether it packages,
+// provisions or deploys them.
+func loadProjectConfig(
+	ctx context.Context,
+	azdContext *azdcontext.AzdContext,
+	environmentName string,
+) (*project.ProjectConfig, error) {
+	projectConfig, err := project.Load(ctx, azdContext.ProjectPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if environmentName == "" {
+		environmentName, err = azdContext.GetDefaultEnvironmentName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if environmentName != "" {
+		if err := projectConfig.ApplyEnvironmentOverrides(environmentName); err != nil {
+			return nil, err
+		}
+	}
+
+	return projectConfig, nil
+}
+
+// envRemoteStateConfig returns the remote state config stored in the configuration of the environment with the specified
+// name, or of the default environment when no name is specified. The configuration is read from the local copy of the
+// environment, since the remote state config is needed to reach the remote copy.
+func envRemoteStateConfig(
+	azdContext *azdcontext.AzdContext,
+	environmentName string,
+	fileConfigManager config.FileConfigManager,
+) (*state.RemoteConfig, error) {
+	if environmentName == "" {
+		var err error
+		environmentName, err = azdContext.GetDefaultEnvironmentName()
+		if err != nil || environmentName == "" {
+			return nil, err
 		}
+	}
+
+	configPath := filepath.Join(azdContext.EnvironmentRoot(environmentName), environment.ConfigFileName)
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	envConfig, err := fileConfigManager.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading config of environment '%s': %w", environmentName, err)
+	}
+
+	return remoteStateFromConfig(envConfig)
+}
+
+// remoteStateFromConfig returns the remote state config stored at `state.remote` in the specified config, if any.
+func remoteStateFromConfig(cfg config.Config) (*state.RemoteConfig, error) {
+	remoteState, ok := cfg.Get("state.remote")
+	if !ok {
+		return nil, nil
+	}
+
+	jsonBytes, err := json.Marshal(remoteState)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling remote state: %w", err)
+	}
 
-		isTerminal := cmd.OutOrStdout() == os.Stdout &&
-			cmd.InOrStdin() == os.Stdin && isatty.IsTerminal(os.Stdin.Fd()) &&
-			isatty.IsTerminal(os.Stdout.Fd())
+	var remoteStateConfig *state.RemoteConfig
+	if err := json.Unmarshal(jsonBytes, &remoteStateConfig); err != nil {
+		return nil, fmt.Errorf("unmarshalling remote state: %w", err)
+	}
 
-		return input.NewConsole(rootOptions.NoPrompt, isTerminal, writer, input.ConsoleHandles{
-			Stdin:  cmd.InOrStdin(),
-			Stdout: cmd.OutOrStdout(),
-			Stderr: cmd.ErrOrStderr(),
-		}, formatter)
+	return remoteStateConfig, nil
+}
+
+// newConsoleFromOptions creates the console of a command from the global options and the output format of the command.
+func newConsoleFromOptions(
+	rootOptions *internal.GlobalCommandOptions,
+	formatter output.Formatter,
+	cmd *cobra.Command,
+) input.Console {
+	stdout := cmd.OutOrStdout()
+	writer := stdout
+	// When using JSON formatting, we want to ensure we always write messages from the console to stderr.
+	if formatter != nil && formatter.Kind() == output.JsonFormat {
+		writer = cmd.ErrOrStderr()
+	}
+
+	// With `--output none`, nothing is written on success. Errors are still written to stderr once the command fails.
+	// Commands without a none format keep formatting their output, but the console reports errors as plain text.
+	suppressed := output.IsOutputSuppressed(cmd)
+	consoleFormatter := formatter
+	if suppressed {
+		stdout = io.Discard
+		writer = io.Discard
+		consoleFormatter = &output.NoneFormatter{}
+	}
+
+	if isColorDisabled(rootOptions) {
+		// Formatting helpers, like output.WithWarningFormat, and prompts check these before adding colors. Any other
+		// escape sequences, like the ones of spinners, are removed by the writer.
+		color.NoColor = true
+		core.DisableColor = true
+		writer = colorable.NewNonColorable(writer)
+	}
+
+	// Without output there is no one to answer prompts or watch spinners, so both are disabled.
+	isTerminal := !suppressed && cmd.OutOrStdout() == os.Stdout &&
+		cmd.InOrStdin() == os.Stdin && isatty.IsTerminal(os.Stdin.Fd()) &&
+		isatty.IsTerminal(os.Stdout.Fd())
+
+	return input.NewConsole(rootOptions.NoPrompt || suppressed, isTerminal, writer, input.ConsoleHandles{
+		Stdin:  cmd.InOrStdin(),
+		Stdout: stdout,
+		Stderr: cmd.ErrOrStderr(),
+	}, consoleFormatter)
+}
+
+// isColorDisabled returns true when console output should not have colors or styling, with `--no-color` or when the
+// NO_COLOR environment variable is set to any value. See https://no-color.org.
+func isColorDisabled(rootOptions *internal.GlobalCommandOptions) bool {
+	return rootOptions.NoColor || os.Getenv("NO_COLOR") != ""
+}
+
+// Registers common Azd dependencies
+func registerCommonDependencies(container *ioc.NestedContainer) {
+	container.RegisterSingleton(func(
+		cmd *cobra.Command,
+		lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+		userConfigManager config.UserConfigManager,
+	) (output.Formatter, error) {
+		return output.GetCommandFormatterWithDefault(cmd, defaultOutputFormat(lazyProjectConfig, userConfigManager))
 	})
 
+	container.RegisterSingleton(newConsoleFromOptions)
+
 	container.RegisterSingleton(func(console input.Console, rootOptions *internal.GlobalCommandOptions) exec.CommandRunner {
 		return exec.NewCommandRunner(
 			&exec.RunnerOptions{
@@ -144,10 +288,10 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		return mgr.CredentialForCurrentUser
 	})
 
-	container.RegisterSingleton(func(console input.Console) io.Writer {
+	container.RegisterSingleton(func(console input.Console, rootOptions *internal.GlobalCommandOptions) io.Writer {
 		writer := console.Handles().Stdout
 
-		if os.Getenv("NO_COLOR") != "" {
+		if isColorDisabled(rootOptions) {
 			writer = colorable.NewNonColorable(writer)
 		}
 
@@ -168,12 +312,12 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	})
 
 	// Azd Context
-	container.RegisterSingleton(azdcontext.NewAzdContext)
+	container.RegisterSingleton(newAzdContext)
 
 	// Lazy loads the Azd context after the azure.yaml file becomes available
-	container.RegisterSingleton(func() *lazy.Lazy[*azdcontext.AzdContext] {
+	container.RegisterSingleton(func(rootOptions *internal.GlobalCommandOptions) *lazy.Lazy[*azdcontext.AzdContext] {
 		return lazy.NewLazy(func() (*azdcontext.AzdContext, error) {
-			return azdcontext.NewAzdContext()
+			return newAzdContext(rootOptions)
 		})
 	})
 
@@ -206,15 +350,23 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 			return env, nil
 		},
 	)
-	container.RegisterSingleton(func(lazyEnvManager *lazy.Lazy[environment.Manager]) environment.EnvironmentResolver {
+	container.RegisterSingleton(func(
+		lazyEnvManager *lazy.Lazy[environment.Manager],
+		rootOptions *internal.GlobalCommandOptions,
+	) environment.EnvironmentResolver {
 		return func(ctx context.Context) (*environment.Environment, error) {
-			azdCtx, err := azdcontext.NewAzdContext()
+			azdCtx, err := newAzdContext(rootOptions)
 			if err != nil {
 				return nil, err
 			}
-			defaultEnv, err := azdCtx.GetDefaultEnvironmentName()
-			if err != nil {
-				return nil, err
+
+			// The environment selected with --environment takes precedence over the default environment
+			environmentName := rootOptions.EnvironmentName
+			if environmentName == "" {
+				environmentName, err = azdCtx.GetDefaultEnvironmentName()
+				if err != nil {
+					return nil, err
+				}
 			}
 
 			// We need to lazy load the environment manager since it depends on azd context
@@ -223,7 +375,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 				return nil, err
 			}
 
-			return envManager.Get(ctx, defaultEnv)
+			return envManager.Get(ctx, environmentName)
 		}
 	})
 
@@ -266,39 +418,38 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	container.RegisterSingleton(func(
 		lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+		lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
 		userConfigManager config.UserConfigManager,
+		fileConfigManager config.FileConfigManager,
+		cmd *cobra.Command,
 	) (*state.RemoteConfig, error) {
-		var remoteStateConfig *state.RemoteConfig
-
 		// The project config may not be available yet
 		// Ex) Within init phase of fingerprinting
 		projectConfig, _ := lazyProjectConfig.GetValue()
 
 		// Lookup remote state config in the following precedence:
 		// 1. Project azure.yaml
-		// 2. User configuration
+		// 2. Environment configuration, set with `azd env set-remote`
+		// 3. User configuration
 		if projectConfig != nil && projectConfig.State != nil && projectConfig.State.Remote != nil {
-			remoteStateConfig = projectConfig.State.Remote
-		} else {
-			userConfig, err := userConfigManager.Load()
-			if err != nil {
-				return nil, fmt.Errorf("loading user config: %w", err)
-			}
-
-			remoteState, ok := userConfig.Get("state.remote")
-			if ok {
-				jsonBytes, err := json.Marshal(remoteState)
-				if err != nil {
-					return nil, fmt.Errorf("marshalling remote state: %w", err)
-				}
+			return projectConfig.State.Remote, nil
+		}
 
-				if err := json.Unmarshal(jsonBytes, &remoteStateConfig); err != nil {
-					return nil, fmt.Errorf("unmarshalling remote state: %w", err)
-				}
+		if azdContext, err := lazyAzdContext.GetValue(); err == nil {
+			// The environment flag is either bound by the command or inherited from the root command
+			environmentName, _ := cmd.Flags().GetString(environmentNameFlag)
+			remoteStateConfig, err := envRemoteStateConfig(azdContext, environmentName, fileConfigManager)
+			if err != nil || remoteStateConfig != nil {
+				return remoteStateConfig, err
 			}
 		}
 
-		return remoteStateConfig, nil
+		userConfig, err := userConfigManager.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading user config: %w", err)
+		}
+
+		return remoteStateFromConfig(userConfig)
 	})
 
 	container.RegisterSingleton(func(
@@ -370,17 +521,14 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	// Project Config
 	container.RegisterSingleton(
-		func(ctx context.Context, azdContext *azdcontext.AzdContext) (*project.ProjectConfig, error) {
+		func(ctx context.Context, azdContext *azdcontext.AzdContext, cmd *cobra.Command) (*project.ProjectConfig, error) {
 			if azdContext == nil {
 				return nil, azdcontext.ErrNoProject
 			}
 
-			projectConfig, err := project.Load(ctx, azdContext.ProjectPath())
-			if err != nil {
-				return nil, err
-			}
-
-			return projectConfig, nil
+			// The environment flag is either bound by the command or inherited from the root command
+			environmentName, _ := cmd.Flags().GetString(environmentNameFlag)
+			return loadProjectConfig(ctx, azdContext, environmentName)
 		},
 	)
 
@@ -445,6 +593,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		})
 	})
 	container.RegisterSingleton(azapi.NewDeployments)
+	container.RegisterSingleton(azapi.NewDeploymentStacks)
 	container.RegisterSingleton(azapi.NewDeploymentOperations)
 	container.RegisterSingleton(bicep.NewBicepCli)
 	container.RegisterSingleton(docker.NewDocker)
@@ -534,14 +683,29 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		}
 	}
 
+	// The 'provision' command resolves to the init-aware provisionInitAction, the underlying provision action is
+	// registered separately so composite actions can run it directly.
+	if err := container.RegisterNamedSingleton(provisionActionName, newProvisionAction); err != nil {
+		panic(fmt.Errorf("registering provision action: %w", err))
+	}
+
 	// Required for nested actions called from composite actions like 'up'
 	registerActionInitializer[*initAction](container, "azd-init-action")
-	registerActionInitializer[*provisionAction](container, "azd-provision-action")
+	registerActionInitializer[*provisionAction](container, provisionActionName)
 	registerActionInitializer[*restoreAction](container, "azd-restore-action")
 	registerActionInitializer[*buildAction](container, "azd-build-action")
 	registerActionInitializer[*packageAction](container, "azd-package-action")
 	registerActionInitializer[*deployAction](container, "azd-deploy-action")
 
-	registerAction[*provisionAction](container, "azd-provision-action")
+	registerAction[*provisionAction](container, provisionActionName)
 	registerAction[*downAction](container, "azd-down-action")
 }
+
+// newAzdContext discovers the azd project, honoring the project file name set with --project-file.
+func newAzdContext(rootOptions *internal.GlobalCommandOptions) (*azdcontext.AzdContext, error) {
+	if rootOptions.ProjectFile == "" {
+		return azdcontext.NewAzdContext()
+	}
+
+	return azdcontext.NewAzdContextWithProjectFile(rootOptions.ProjectFile)
+}
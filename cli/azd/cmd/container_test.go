@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_defaultOutputFormat(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	userConfigManager := config.NewUserConfigManager(config.NewFileConfigManager(config.NewManager()))
+	userConfig, err := userConfigManager.Load()
+	require.NoError(t, err)
+	require.NoError(t, userConfig.Set("defaults.output", "yaml"))
+	require.NoError(t, userConfigManager.Save(userConfig))
+
+	newLazyProjectConfig := func(projectConfig *project.ProjectConfig) *lazy.Lazy[*project.ProjectConfig] {
+		return lazy.NewLazy(func() (*project.ProjectConfig, error) {
+			return projectConfig, nil
+		})
+	}
+
+	t.Run("ProjectDefault", func(t *testing.T) {
+		lazyProjectConfig := newLazyProjectConfig(&project.ProjectConfig{Defaults: &project.DefaultsConfig{Output: "json"}})
+		require.Equal(t, output.JsonFormat, defaultOutputFormat(lazyProjectConfig, userConfigManager))
+	})
+
+	t.Run("UserDefault", func(t *testing.T) {
+		lazyProjectConfig := newLazyProjectConfig(&project.ProjectConfig{})
+		require.Equal(t, output.YamlFormat, defaultOutputFormat(lazyProjectConfig, userConfigManager))
+	})
+
+	t.Run("NoProject", func(t *testing.T) {
+		lazyProjectConfig := lazy.NewLazy(func() (*project.ProjectConfig, error) {
+			return nil, errors.New("no project exists")
+		})
+		require.Equal(t, output.YamlFormat, defaultOutputFormat(lazyProjectConfig, userConfigManager))
+	})
+}
+
+func Test_loadProjectConfig(t *testing.T) {
+	const projectYaml = `
+name: test-proj
+services:
+  web:
+    project: src/web
+    language: js
+    host: containerapp
+    resourceName: web-dev
+environments:
+  prod:
+    services:
+      web:
+        resourceName: web-prod
+`
+
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	require.NoError(t, os.WriteFile(azdContext.ProjectPath(), []byte(projectYaml), osutil.PermissionFile))
+
+	t.Run("NoEnvironment", func(t *testing.T) {
+		projectConfig, err := loadProjectConfig(context.Background(), azdContext, "")
+		require.NoError(t, err)
+		require.Equal(t, project.NewExpandableString("web-dev"), projectConfig.Services["web"].ResourceName)
+	})
+
+	t.Run("SelectedEnvironment", func(t *testing.T) {
+		projectConfig, err := loadProjectConfig(context.Background(), azdContext, "prod")
+		require.NoError(t, err)
+		require.Equal(t, project.NewExpandableString("web-prod"), projectConfig.Services["web"].ResourceName)
+	})
+
+	t.Run("DefaultEnvironment", func(t *testing.T) {
+		require.NoError(t, azdContext.SetDefaultEnvironmentName("prod"))
+
+		projectConfig, err := loadProjectConfig(context.Background(), azdContext, "")
+		require.NoError(t, err)
+		require.Equal(t, project.NewExpandableString("web-prod"), projectConfig.Services["web"].ResourceName)
+
+		// The selected environment takes precedence over the default environment
+		projectConfig, err = loadProjectConfig(context.Background(), azdContext, "dev")
+		require.NoError(t, err)
+		require.Equal(t, project.NewExpandableString("web-dev"), projectConfig.Services["web"].ResourceName)
+	})
+}
+
+func Test_OutputNone(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	// Keep the experimentation middleware from reaching the assignment service
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	t.Setenv("AZD_DEBUG_EXPERIMENTATION_TAS_ENDPOINT", server.URL)
+
+	run := func(args ...string) (string, string, error) {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+
+		root := NewRootCmd(false, nil)
+		root.SetArgs(args)
+		root.SetOut(stdout)
+		root.SetErr(stderr)
+
+		err := root.ExecuteContext(context.Background())
+		return stdout.String(), stderr.String(), err
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		stdout, _, err := run("version")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "azd version")
+
+		stdout, stderr, err := run("version", "--output", "none")
+		require.NoError(t, err)
+		require.Empty(t, stdout)
+		require.Empty(t, stderr)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		stdout, stderr, err := run("config", "get", "defaults.location", "--output", "none")
+		require.Error(t, err)
+		require.Empty(t, stdout)
+		require.Contains(t, stderr, "ERROR:")
+	})
+}
+
+func Test_newConsoleFromOptions_NoColor(t *testing.T) {
+	// Colors are enabled to begin with, as on a terminal, so the test shows that disabling them takes effect
+	prevNoColor := color.NoColor
+	prevDisableColor := core.DisableColor
+	t.Cleanup(func() {
+		color.NoColor = prevNoColor
+		core.DisableColor = prevDisableColor
+	})
+
+	run := func(rootOptions *internal.GlobalCommandOptions) string {
+		color.NoColor = false
+		core.DisableColor = false
+
+		stdout := &bytes.Buffer{}
+		cmd := &cobra.Command{}
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		ctx := context.Background()
+		console := newConsoleFromOptions(rootOptions, &output.NoneFormatter{}, cmd)
+		console.Message(ctx, output.WithWarningFormat("WARNING: warning"))
+		console.Message(ctx, "\x1b[1mbold\x1b[0m")
+		console.MessageUxItem(ctx, &ux.ActionResult{SuccessMessage: "done"})
+		console.ShowSpinner(ctx, "working", input.Step)
+		console.StopSpinner(ctx, "working", input.StepDone)
+
+		return stdout.String()
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		require.Contains(t, run(&internal.GlobalCommandOptions{}), "\x1b[")
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		stdout := run(&internal.GlobalCommandOptions{NoColor: true})
+		require.NotContains(t, stdout, "\x1b[")
+		require.Contains(t, stdout, "WARNING: warning")
+		require.Contains(t, stdout, "bold")
+		require.True(t, core.DisableColor)
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		stdout := run(&internal.GlobalCommandOptions{})
+		require.NotContains(t, stdout, "\x1b[")
+		require.Contains(t, stdout, "WARNING: warning")
+	})
+}
@@ -5,9 +5,13 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -18,19 +22,35 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
 )
 
 type deployFlags struct {
 	serviceName string
 	all         bool
 	fromPackage string
+	imageTag    string
+	buildArgs   []string
+	waitHealthy bool
+	rollback    bool
+	prune       bool
+	keep        int
+	envFile     string
+	skipRestore bool
+	skipBuild   bool
+	slot        string
+	createSlot  bool
+	swap        bool
+	parallel    int
 	global      *internal.GlobalCommandOptions
 	*envFlag
 }
@@ -52,9 +72,73 @@ func (d *deployFlags) bindNonCommon(
 	)
 	//deprecate:flag hide --service
 	_ = local.MarkHidden("service")
+	local.StringVar(
+		&d.imageTag,
+		"tag",
+		"",
+		"Overrides the generated container image tag. Only supported for container services.",
+	)
+	local.StringArrayVar(
+		&d.buildArgs,
+		"build-arg",
+		nil,
+		"Sets a build argument, as KEY=VALUE, for container image builds. Can be specified multiple times.",
+	)
+	local.BoolVar(
+		&d.waitHealthy,
+		"wait-healthy",
+		false,
+		"Waits for the health endpoint of each deployed service to return a successful response.",
+	)
+	local.BoolVar(
+		&d.rollback,
+		"rollback-on-failure",
+		false,
+		"Reverts traffic to the previously active revision when the deployment of a revisioned service fails.",
+	)
+	local.BoolVar(
+		&d.prune,
+		"prune",
+		false,
+		"Deactivates the revisions created by azd beyond the most recent ones after a successful deployment.",
+	)
+	local.IntVar(
+		&d.keep,
+		"keep",
+		defaultKeepRevisions,
+		"The number of most recent revisions created by azd to keep active when '--prune' is set.",
+	)
+	local.StringVar(
+		&d.slot,
+		"slot",
+		"",
+		"Deploys to the named deployment slot instead of production. Only supported for App Service services.",
+	)
+	local.BoolVar(
+		&d.createSlot,
+		"create-slot",
+		false,
+		"Creates the deployment slot set with '--slot' when it doesn't exist.",
+	)
+	local.BoolVar(
+		&d.swap,
+		"swap",
+		false,
+		"Swaps the deployment slot set with '--slot' into production after a successful deployment.",
+	)
+	local.IntVar(
+		&d.parallel,
+		"parallel",
+		0,
+		//nolint:lll
+		"The number of services deployed at the same time. Defaults to deploy.parallelism in "+azdcontext.ProjectFileName+", or 1. Services with 'deploy: serial' are always deployed on their own.",
+	)
 	d.global = global
 }
 
+// defaultKeepRevisions is the number of revisions kept active by `azd deploy --prune` when `--keep` is not set.
+const defaultKeepRevisions = 3
+
 func (d *deployFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	d.envFlag = &envFlag{}
 	d.envFlag.Bind(local, global)
@@ -71,6 +155,26 @@ func (d *deployFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCo
 		"",
 		"Deploys the application from an existing package.",
 	)
+	local.StringVar(
+		&d.envFile,
+		"env-file",
+		"",
+		//nolint:lll
+		"Sets runtime settings of the deployed service, like app settings or container environment variables, from a dotenv file. The settings are not added to the azd environment.",
+	)
+	local.BoolVar(
+		&d.skipRestore,
+		"skip-restore",
+		false,
+		"Skips restoring the dependencies of the services, which must already be restored.",
+	)
+	local.BoolVar(
+		&d.skipBuild,
+		"skip-build",
+		false,
+		//nolint:lll
+		"Skips building the services and deploys their existing build output, found in the 'dist' directory of each service. Not supported for container services.",
+	)
 }
 
 func (d *deployFlags) setCommon(envFlag *envFlag) {
@@ -109,9 +213,28 @@ type deployAction struct {
 	writer                   io.Writer
 	console                  input.Console
 	commandRunner            exec.CommandRunner
+	httpClient               httputil.HttpClient
 	middlewareRunner         middleware.MiddlewareContext
 	packageActionInitializer actions.ActionInitializer[*packageAction]
 	alphaFeatureManager      *alpha.FeatureManager
+	// revisions serving traffic before deployment, keyed by service name, used by --rollback-on-failure
+	previousRevisions map[string]*deployRevision
+	// previousRevisionsMu guards previousRevisions, used by services deployed in parallel
+	previousRevisionsMu sync.Mutex
+}
+
+// deployRevision is the revision of a revisioned service target captured before deployment
+type deployRevision struct {
+	target         project.RevisionedServiceTarget
+	targetResource *environment.TargetResource
+	name           string
+}
+
+// deploySettings are the runtime settings applied to a service target before deployment, restored when it fails
+type deploySettings struct {
+	target         project.SettingsServiceTarget
+	targetResource *environment.TargetResource
+	changes        *project.SettingsChanges
 }
 
 func newDeployAction(
@@ -126,6 +249,7 @@ func newDeployAction(
 	accountManager account.Manager,
 	azCli azcli.AzCli,
 	commandRunner exec.CommandRunner,
+	httpClient httputil.HttpClient,
 	console input.Console,
 	formatter output.Formatter,
 	writer io.Writer,
@@ -148,15 +272,105 @@ func newDeployAction(
 		writer:                   writer,
 		console:                  console,
 		commandRunner:            commandRunner,
+		httpClient:               httpClient,
 		middlewareRunner:         middlewareRunner,
 		packageActionInitializer: packageActionInitializer,
 		alphaFeatureManager:      alphaFeatureManager,
+		previousRevisions:        map[string]*deployRevision{},
 	}
 }
 
 type DeploymentResult struct {
-	Timestamp time.Time                               `json:"timestamp"`
-	Services  map[string]*project.ServiceDeployResult `json:"services"`
+	Timestamp time.Time                           `json:"timestamp"`
+	Services  map[string]*ServiceDeploymentResult `json:"services"`
+}
+
+// ServiceDeploymentResult is the deployment result of a single service, including how long the service took to deploy.
+type ServiceDeploymentResult struct {
+	*project.ServiceDeployResult
+	Name            string  `json:"name"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	// ProductionEndpoints are the endpoints of production after the deployment slot was swapped into it with '--swap'
+	ProductionEndpoints []string `json:"productionEndpoints,omitempty"`
+}
+
+// MarshalJSON marshals the service name and duration alongside the fields of the deploy result. Without it, the
+// MarshalJSON promoted from the embedded deploy result would omit them.
+func (r *ServiceDeploymentResult) MarshalJSON() ([]byte, error) {
+	// serviceDeployResult has the fields of project.ServiceDeployResult without its methods
+	type serviceDeployResult project.ServiceDeployResult
+
+	result := struct {
+		*serviceDeployResult
+		Name                string   `json:"name"`
+		DurationSeconds     float64  `json:"durationSeconds"`
+		ProductionEndpoints []string `json:"productionEndpoints,omitempty"`
+	}{
+		serviceDeployResult: (*serviceDeployResult)(r.ServiceDeployResult),
+		Name:                r.Name,
+		DurationSeconds:     r.DurationSeconds,
+		ProductionEndpoints: r.ProductionEndpoints,
+	}
+
+	return json.Marshal(result)
+}
+
+// containerFlagsMessage returns the container-only flags that are set, for error messages.
+func containerFlagsMessage(flags *deployFlags) string {
+	switch {
+	case flags.imageTag != "" && len(flags.buildArgs) > 0:
+		return "'--tag' and '--build-arg' are"
+	case flags.imageTag != "":
+		return "'--tag' is"
+	default:
+		return "'--build-arg' is"
+	}
+}
+
+// applyContainerFlags applies the '--tag' and '--build-arg' flags to the target services. It runs before the services are
+// packaged, which is also before deploying when running azd up, and can be applied more than once to the same project.
+func applyContainerFlags(
+	projectConfig *project.ProjectConfig,
+	flags *deployFlags,
+	isTargetService func(svc *project.ServiceConfig) bool,
+) error {
+	for _, arg := range flags.buildArgs {
+		if key, _, found := strings.Cut(arg, "="); !found || key == "" {
+			return fmt.Errorf("invalid build argument '%s', expected KEY=VALUE", arg)
+		}
+	}
+
+	if flags.imageTag == "" && len(flags.buildArgs) == 0 {
+		return nil
+	}
+
+	for _, svc := range projectConfig.Services {
+		if !isTargetService(svc) {
+			continue
+		}
+
+		if !svc.Host.RequiresContainer() {
+			return fmt.Errorf(
+				"%s only supported for container services, service '%s' uses host '%s'",
+				containerFlagsMessage(flags),
+				svc.Name,
+				svc.Host,
+			)
+		}
+
+		if flags.imageTag != "" {
+			svc.Docker.ImageTag = flags.imageTag
+		}
+
+		// Build arguments from the command line are passed after the ones in azure.yaml, so they take precedence
+		for _, arg := range flags.buildArgs {
+			if !slices.Contains(svc.Docker.BuildArgs, arg) {
+				svc.Docker.BuildArgs = append(svc.Docker.BuildArgs, arg)
+			}
+		}
+	}
+
+	return nil
 }
 
 func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error) {
@@ -173,16 +387,38 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		)
 	}
 
-	targetServiceName, err := getTargetServiceName(
-		ctx,
-		da.projectManager,
-		da.projectConfig,
-		string(project.ServiceEventDeploy),
-		targetServiceName,
-		da.flags.all,
-	)
-	if err != nil {
-		return nil, err
+	// A pattern, like api-*, deploys all the services it matches. The name of a service always matches only that service.
+	var targetServiceNames []string
+	var err error
+	if isServicePattern(targetServiceName) && !da.projectConfig.HasService(targetServiceName) {
+		if da.flags.all {
+			return nil, errors.New("cannot specify both --all and <service>")
+		}
+
+		targetServiceNames, err = matchServiceNames(da.projectConfig, targetServiceName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		targetServiceName, err = getTargetServiceName(
+			ctx,
+			da.projectManager,
+			da.projectConfig,
+			string(project.ServiceEventDeploy),
+			targetServiceName,
+			da.flags.all,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if targetServiceName != "" {
+			targetServiceNames = []string{targetServiceName}
+		}
+	}
+
+	isTargetService := func(svc *project.ServiceConfig) bool {
+		return len(targetServiceNames) == 0 || slices.Contains(targetServiceNames, svc.Name)
 	}
 
 	if da.flags.all && da.flags.fromPackage != "" {
@@ -190,23 +426,96 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			"'--from-package' cannot be specified when '--all' is set. Specify a specific service by passing a <service>")
 	}
 
-	if targetServiceName == "" && da.flags.fromPackage != "" {
+	if len(targetServiceNames) == 0 && da.flags.fromPackage != "" {
 		return nil, errors.New(
 			//nolint:lll
 			"'--from-package' cannot be specified when deploying all services. Specify a specific service by passing a <service>",
 		)
 	}
 
+	if len(targetServiceNames) > 1 && da.flags.fromPackage != "" {
+		return nil, fmt.Errorf(
+			"'--from-package' requires a single service, '%s' matches the services %s",
+			targetServiceName,
+			strings.Join(targetServiceNames, ", "),
+		)
+	}
+
+	if da.flags.prune && da.flags.keep < 1 {
+		return nil, fmt.Errorf("invalid value %d for '--keep', at least one revision must be kept", da.flags.keep)
+	}
+
+	if da.flags.parallel < 0 {
+		return nil, fmt.Errorf(
+			"invalid value %d for '--parallel', at least one service must be deployed at a time", da.flags.parallel)
+	}
+
+	if da.flags.createSlot && da.flags.slot == "" {
+		return nil, errors.New("'--create-slot' requires '--slot'")
+	}
+
+	if da.flags.swap && da.flags.slot == "" {
+		return nil, errors.New("'--swap' requires '--slot'")
+	}
+
+	// Runtime settings are applied to the production slot, which would not run the deployed code
+	if da.flags.slot != "" && da.flags.envFile != "" {
+		return nil, errors.New("'--env-file' can't be used with '--slot'")
+	}
+
+	var settings map[string]string
+	if da.flags.envFile != "" {
+		if len(targetServiceNames) == 0 {
+			return nil, errors.New(
+				//nolint:lll
+				"'--env-file' cannot be specified when deploying all services. Specify a specific service by passing a <service>",
+			)
+		}
+
+		if len(targetServiceNames) > 1 {
+			return nil, fmt.Errorf(
+				"'--env-file' requires a single service, '%s' matches the services %s",
+				targetServiceName,
+				strings.Join(targetServiceNames, ", "),
+			)
+		}
+
+		settings, err = godotenv.Read(da.flags.envFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading runtime settings from '%s': %w", da.flags.envFile, err)
+		}
+	}
+
+	if err := applyContainerFlags(da.projectConfig, da.flags, isTargetService); err != nil {
+		return nil, err
+	}
+
 	if err := da.projectManager.Initialize(ctx, da.projectConfig); err != nil {
 		return nil, err
 	}
 
-	if err := da.projectManager.EnsureServiceTargetTools(ctx, da.projectConfig, func(svc *project.ServiceConfig) bool {
-		return targetServiceName == "" || svc.Name == targetServiceName
-	}); err != nil {
+	if err := da.projectManager.EnsureServiceTargetTools(ctx, da.projectConfig, isTargetService); err != nil {
 		return nil, err
 	}
 
+	if da.flags.slot != "" {
+		for _, svc := range da.projectConfig.Services {
+			if !isTargetService(svc) {
+				continue
+			}
+
+			serviceTarget, err := da.serviceManager.GetServiceTarget(ctx, svc)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := serviceTarget.(project.SlotServiceTarget); !ok {
+				return nil, fmt.Errorf(
+					"'--slot' is not supported for service '%s', host '%s' does not have deployment slots", svc.Name, svc.Host)
+			}
+		}
+	}
+
 	// Command title
 	da.console.MessageUxItem(ctx, &ux.MessageTitle{
 		Title: "Deploying services (azd deploy)",
@@ -214,96 +523,606 @@ func (da *deployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 
 	startTime := time.Now()
 
-	deployResults := map[string]*project.ServiceDeployResult{}
-
+	var services []*project.ServiceConfig
 	for _, svc := range da.projectConfig.GetServicesStable() {
-		stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
-
 		// Skip this service if both cases are true:
-		// 1. The user specified a service name
-		// 2. This service is not the one the user specified
-		if targetServiceName != "" && targetServiceName != svc.Name {
+		// 1. The user specified a service name or pattern
+		// 2. This service is not one the user specified
+		if isTargetService(svc) {
+			services = append(services, svc)
+		}
+	}
+
+	parallelism := deployParallelism(da.flags.parallel, da.projectConfig)
+	deploy := func(svc *project.ServiceConfig, parallel bool) (*ServiceDeploymentResult, error) {
+		return da.deployService(ctx, svc, settings, &deployProgress{console: da.console, parallel: parallel})
+	}
+
+	deployResults, err := deployServices(services, parallelism, deploy)
+	if err != nil {
+		return nil, err
+	}
+
+	if da.formatter.Kind() == output.JsonFormat {
+		deployResult := DeploymentResult{
+			Timestamp: time.Now(),
+			Services:  deployResults,
+		}
+
+		if fmtErr := da.formatter.Format(deployResult, da.writer, nil); fmtErr != nil {
+			return nil, fmt.Errorf("deploy result could not be displayed: %w", fmtErr)
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header:   fmt.Sprintf("Your application was deployed to Azure in %s.", ux.DurationAsText(since(startTime))),
+			FollowUp: getResourceGroupFollowUp(ctx, da.formatter, da.projectConfig, da.resourceManager, da.env, false),
+		},
+	}, nil
+}
+
+// deployParallelism returns the number of services deployed at the same time. The value of `--parallel` takes
+// precedence over deploy.parallelism in azure.yaml.
+func deployParallelism(parallelFlag int, projectConfig *project.ProjectConfig) int {
+	if parallelFlag > 0 {
+		return parallelFlag
+	}
+
+	if projectConfig.Deploy != nil && projectConfig.Deploy.Parallelism > 0 {
+		return projectConfig.Deploy.Parallelism
+	}
+
+	return 1
+}
+
+// deployServices deploys the services, up to parallelism of them at the same time. Services with the serial deploy mode
+// are deployed first, one at a time. After a service fails to deploy, the services that haven't started are skipped and
+// the errors of the services that were deploying are returned together. deploy is told whether the service may deploy at
+// the same time as other services.
+func deployServices(
+	services []*project.ServiceConfig,
+	parallelism int,
+	deploy func(svc *project.ServiceConfig, parallel bool) (*ServiceDeploymentResult, error),
+) (map[string]*ServiceDeploymentResult, error) {
+	deployResults := map[string]*ServiceDeploymentResult{}
+
+	var pool []*project.ServiceConfig
+	for _, svc := range services {
+		if parallelism > 1 && svc.Deploy != project.ServiceDeploySerial {
+			pool = append(pool, svc)
 			continue
 		}
 
-		if alphaFeatureId, isAlphaFeature := alpha.IsFeatureKey(string(svc.Host)); isAlphaFeature {
-			// alpha feature on/off detection for host is done during initialization.
-			// This is just for displaying the warning during deployment.
-			da.console.WarnForFeature(ctx, alphaFeatureId)
+		result, err := deploy(svc, false)
+		if err != nil {
+			return nil, err
 		}
 
-		da.console.ShowSpinner(ctx, stepMessage, input.Step)
-		var packageResult *project.ServicePackageResult
-		if da.flags.fromPackage != "" {
-			// --from-package set, skip packaging
-			packageResult = &project.ServicePackageResult{
-				PackagePath: da.flags.fromPackage,
-			}
-		} else {
-			//  --from-package not set, package the application
-			packageTask := da.serviceManager.Package(ctx, svc, nil, nil)
-			done := make(chan struct{})
-			go func() {
-				for packageProgress := range packageTask.Progress() {
-					progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, packageProgress.Message)
-					da.console.ShowSpinner(ctx, progressMessage, input.Step)
-				}
-				close(done)
-			}()
-
-			packageResult, err = packageTask.Await()
-			// wait for console updates to complete
-			<-done
-			// do not stop progress here as next step is to deploy
+		deployResults[svc.Name] = result
+	}
+
+	parallel := len(pool) > 1
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs []error
+	// slots holds a value for each service being deployed, and blocks while parallelism services are deploying
+	slots := make(chan struct{}, parallelism)
+
+	for _, svc := range pool {
+		slots <- struct{}{}
+
+		mu.Lock()
+		failed := len(errs) > 0
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		wg.Add(1)
+		go func(svc *project.ServiceConfig) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result, err := deploy(svc, parallel)
+
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
-				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
-				return nil, err
+				errs = append(errs, err)
+				return
 			}
-		}
 
-		deployTask := da.serviceManager.Deploy(ctx, svc, packageResult)
+			deployResults[svc.Name] = result
+		}(svc)
+	}
+
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return deployResults, nil
+}
+
+// deployService packages and deploys a single service, then runs the post deployment steps selected with the flags.
+func (da *deployAction) deployService(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	settings map[string]string,
+	progress *deployProgress,
+) (*ServiceDeploymentResult, error) {
+	stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
+
+	if alphaFeatureId, isAlphaFeature := alpha.IsFeatureKey(string(svc.Host)); isAlphaFeature {
+		// alpha feature on/off detection for host is done during initialization.
+		// This is just for displaying the warning during deployment.
+		da.console.WarnForFeature(ctx, alphaFeatureId)
+	}
+
+	serviceStartTime := time.Now()
+	var err error
+	progress.Start(ctx, stepMessage)
+	var packageResult *project.ServicePackageResult
+	if da.flags.fromPackage != "" {
+		// --from-package set, skip packaging
+		packageResult = &project.ServicePackageResult{
+			PackagePath: da.flags.fromPackage,
+		}
+	} else {
+		//  --from-package not set, package the application
+		packageTask := da.serviceManager.Package(ctx, svc, nil, &project.PackageOptions{
+			SkipRestore: da.flags.skipRestore,
+			SkipBuild:   da.flags.skipBuild,
+		})
 		done := make(chan struct{})
 		go func() {
-			for deployProgress := range deployTask.Progress() {
-				progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, deployProgress.Message)
-				da.console.ShowSpinner(ctx, progressMessage, input.Step)
+			for packageProgress := range packageTask.Progress() {
+				progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, packageProgress.Message)
+				progress.Update(ctx, progressMessage)
 			}
 			close(done)
 		}()
 
-		deployResult, err := deployTask.Await()
+		packageResult, err = packageTask.Await()
 		// wait for console updates to complete
 		<-done
-		da.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+		// do not stop progress here as next step is to deploy
 		if err != nil {
+			progress.Stop(ctx, stepMessage, input.StepFailed)
 			return nil, err
 		}
+	}
 
-		deployResults[svc.Name] = deployResult
+	if da.flags.rollback {
+		if err := da.captureRevision(ctx, svc); err != nil {
+			progress.Stop(ctx, stepMessage, input.StepFailed)
+			return nil, err
+		}
+	}
 
-		// report deploy outputs
-		da.console.MessageUxItem(ctx, deployResult)
+	// Runtime settings are applied before the code is deployed, so the new code starts with them. They are restored
+	// when the deployment fails.
+	var appliedSettings *deploySettings
+	if settings != nil {
+		appliedSettings, err = da.applySettings(ctx, svc, settings)
+		if err != nil {
+			progress.Stop(ctx, stepMessage, input.StepFailed)
+			return nil, err
+		}
 	}
 
-	if da.formatter.Kind() == output.JsonFormat {
-		deployResult := DeploymentResult{
-			Timestamp: time.Now(),
-			Services:  deployResults,
+	deployTask := da.serviceManager.Deploy(ctx, svc, packageResult, &project.DeployOptions{
+		Slot:       da.flags.slot,
+		CreateSlot: da.flags.createSlot,
+	})
+	done := make(chan struct{})
+	go func() {
+		for deployProgress := range deployTask.Progress() {
+			progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, deployProgress.Message)
+			progress.Update(ctx, progressMessage)
 		}
+		close(done)
+	}()
 
-		if fmtErr := da.formatter.Format(deployResult, da.writer, nil); fmtErr != nil {
-			return nil, fmt.Errorf("deploy result could not be displayed: %w", fmtErr)
+	deployResult, err := deployTask.Await()
+	// wait for console updates to complete
+	<-done
+	progress.Stop(ctx, stepMessage, input.GetStepResultFormat(err))
+	if errors.Is(err, azcli.ErrAzCliSlotNotFound) {
+		err = fmt.Errorf("%w. Pass '--create-slot' to create it", err)
+	}
+	if err != nil {
+		return nil, da.recoverDeploy(ctx, svc, progress, appliedSettings, err)
+	}
+
+	if deployResult.Endpoints == nil {
+		deployResult.Endpoints = []string{}
+	}
+
+	// report deploy outputs
+	da.console.MessageUxItem(ctx, deployResult)
+	if da.flags.imageTag != "" {
+		imageName := da.env.GetServiceProperty(svc.Name, "IMAGE_NAME")
+		da.console.Message(ctx, fmt.Sprintf("  - Image: %s", output.WithLinkFormat(imageName)))
+	}
+
+	if appliedSettings != nil {
+		reportSettingsChanges(ctx, da.console, appliedSettings.changes)
+	}
+
+	if da.flags.waitHealthy {
+		if err := da.waitForHealthy(ctx, svc, progress, deployResult); err != nil {
+			return nil, da.recoverDeploy(ctx, svc, progress, appliedSettings, err)
 		}
 	}
 
-	return &actions.ActionResult{
-		Message: &actions.ResultMessage{
-			Header:   fmt.Sprintf("Your application was deployed to Azure in %s.", ux.DurationAsText(since(startTime))),
-			FollowUp: getResourceGroupFollowUp(ctx, da.formatter, da.projectConfig, da.resourceManager, da.env, false),
-		},
+	// The slot is swapped after the health check, so an unhealthy deployment never reaches production
+	var productionEndpoints []string
+	if da.flags.swap {
+		productionEndpoints, err = da.swapSlot(ctx, svc, progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if da.flags.prune {
+		if err := da.pruneRevisions(ctx, svc, progress); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ServiceDeploymentResult{
+		ServiceDeployResult: deployResult,
+		Name:                svc.Name,
+		DurationSeconds:     since(serviceStartTime).Seconds(),
+		ProductionEndpoints: productionEndpoints,
+	}, nil
+}
+
+// waitForHealthy polls the health endpoint of each endpoint of the deployed service until it is healthy.
+func (da *deployAction) waitForHealthy(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	progress *deployProgress,
+	deployResult *project.ServiceDeployResult,
+) error {
+	var healthUrls []string
+	for _, endpoint := range deployResult.Endpoints {
+		healthUrl, ok, err := svc.HealthCheckUrl(endpoint)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			log.Printf("skipping health check of service %s at endpoint '%s'", svc.Name, endpoint)
+			continue
+		}
+
+		healthUrls = append(healthUrls, healthUrl)
+	}
+
+	if len(healthUrls) == 0 {
+		da.console.Message(
+			ctx,
+			output.WithWarningFormat("WARNING: Service %s has no endpoints to check, skipping health check.", svc.Name),
+		)
+		return nil
+	}
+
+	for _, healthUrl := range healthUrls {
+		stepMessage := fmt.Sprintf("Waiting for service %s to be healthy (%s)", svc.Name, healthUrl)
+		progress.Start(ctx, stepMessage)
+		statusCode, err := project.WaitForHealthy(ctx, da.httpClient, healthUrl)
+		progress.Stop(ctx, stepMessage, input.GetStepResultFormat(err))
+		if err != nil {
+			return err
+		}
+
+		da.console.Message(
+			ctx,
+			fmt.Sprintf("  - Health: %s (status code %d)", output.WithLinkFormat(healthUrl), statusCode),
+		)
+	}
+
+	return nil
+}
+
+// swapSlot swaps the deployment slot that received the deployment of the service into production, and reports the
+// endpoints of production. Production is left unchanged when the swap fails.
+func (da *deployAction) swapSlot(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	progress *deployProgress,
+) ([]string, error) {
+	serviceTarget, err := da.serviceManager.GetServiceTarget(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	slotTarget, ok := serviceTarget.(project.SlotServiceTarget)
+	if !ok {
+		return nil, fmt.Errorf("service '%s' uses host '%s' which does not support deployment slots", svc.Name, svc.Host)
+	}
+
+	targetResource, err := da.resourceManager.GetTargetResource(ctx, da.env.GetSubscriptionId(), svc)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource for service '%s': %w", svc.Name, err)
+	}
+
+	stepMessage := fmt.Sprintf("Swapping slot %s of service %s into production", da.flags.slot, svc.Name)
+	progress.Start(ctx, stepMessage)
+	err = slotTarget.SwapSlot(ctx, svc, targetResource, da.flags.slot)
+	progress.Stop(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"swapping slot '%s' of service '%s' into production failed, production was not changed: %w",
+			da.flags.slot,
+			svc.Name,
+			err,
+		)
+	}
+
+	endpoints, err := serviceTarget.Endpoints(ctx, svc, targetResource)
+	if err != nil {
+		return nil, fmt.Errorf("slot '%s' was swapped into production, fetching the production endpoints: %w", da.flags.slot, err)
+	}
+
+	da.console.Message(ctx, fmt.Sprintf("  - Swapped slot %s into production", da.flags.slot))
+	for _, endpoint := range endpoints {
+		da.console.Message(ctx, fmt.Sprintf("  - Production endpoint: %s", output.WithLinkFormat(endpoint)))
+	}
+
+	return endpoints, nil
+}
+
+// captureRevision records the revision currently serving traffic for the service so it can be restored when
+// the deployment fails. Services whose host does not deploy revisions, or that have not been deployed yet, are skipped.
+func (da *deployAction) captureRevision(ctx context.Context, svc *project.ServiceConfig) error {
+	serviceTarget, err := da.serviceManager.GetServiceTarget(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	revisionedTarget, ok := serviceTarget.(project.RevisionedServiceTarget)
+	if !ok {
+		da.console.Message(ctx, output.WithWarningFormat(
+			"WARNING: Service %s uses host '%s' which does not support rollback on failure.", svc.Name, svc.Host))
+		return nil
+	}
+
+	targetResource, err := da.resourceManager.GetTargetResource(ctx, da.env.GetSubscriptionId(), svc)
+	if err != nil {
+		log.Printf("skipping rollback for service %s, target resource not found: %v", svc.Name, err)
+		return nil
+	}
+
+	revision, err := revisionedTarget.CurrentRevision(ctx, svc, targetResource)
+	if err != nil {
+		log.Printf("skipping rollback for service %s, no current revision: %v", svc.Name, err)
+		return nil
+	}
+
+	da.previousRevisionsMu.Lock()
+	defer da.previousRevisionsMu.Unlock()
+
+	da.previousRevisions[svc.Name] = &deployRevision{
+		target:         revisionedTarget,
+		targetResource: targetResource,
+		name:           revision,
+	}
+
+	return nil
+}
+
+// recoverDeploy reverts a failed deployment of the service. The service is rolled back to the revision captured before
+// deployment, if any, which also brings back the runtime settings of that revision. The runtime settings applied before
+// the deployment are only restored when the service wasn't rolled back, since restoring them on the failed revision
+// would create another revision from it. The returned error always contains the original deployment error.
+func (da *deployAction) recoverDeploy(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	progress *deployProgress,
+	applied *deploySettings,
+	deployErr error,
+) error {
+	rolledBack, err := da.rollback(ctx, svc, progress, deployErr)
+	if rolledBack {
+		return err
+	}
+
+	return da.restoreSettings(ctx, svc, progress, applied, err)
+}
+
+// rollback reverts the service to the revision captured before deployment, if any, and reports whether it was rolled
+// back. The returned error always contains the original deployment error, along with the rollback error when the rollback
+// also fails.
+func (da *deployAction) rollback(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	progress *deployProgress,
+	deployErr error,
+) (bool, error) {
+	da.previousRevisionsMu.Lock()
+	previous, has := da.previousRevisions[svc.Name]
+	da.previousRevisionsMu.Unlock()
+	if !has {
+		return false, deployErr
+	}
+
+	stepMessage := fmt.Sprintf("Rolling back service %s to revision %s", svc.Name, previous.name)
+	progress.Start(ctx, stepMessage)
+	err := previous.target.Rollback(ctx, svc, previous.targetResource, previous.name)
+	progress.Stop(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return false, fmt.Errorf(
+			"%w\n\nrolling back service '%s' to revision '%s' also failed: %w", deployErr, svc.Name, previous.name, err)
+	}
+
+	return true, fmt.Errorf("%w\n\nservice '%s' was rolled back to revision '%s'", deployErr, svc.Name, previous.name)
+}
+
+// pruneRevisions deactivates the revisions created by azd for the service beyond the ones kept with `--keep`, and
+// reports the names of the deactivated revisions. Services whose host does not deploy revisions are skipped.
+func (da *deployAction) pruneRevisions(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	progress *deployProgress,
+) error {
+	serviceTarget, err := da.serviceManager.GetServiceTarget(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	revisionedTarget, ok := serviceTarget.(project.RevisionedServiceTarget)
+	if !ok {
+		da.console.Message(ctx, output.WithWarningFormat(
+			"WARNING: Service %s uses host '%s' which does not support pruning revisions.", svc.Name, svc.Host))
+		return nil
+	}
+
+	targetResource, err := da.resourceManager.GetTargetResource(ctx, da.env.GetSubscriptionId(), svc)
+	if err != nil {
+		return fmt.Errorf("getting target resource for service '%s': %w", svc.Name, err)
+	}
+
+	stepMessage := fmt.Sprintf("Pruning revisions of service %s", svc.Name)
+	progress.Start(ctx, stepMessage)
+	pruned, err := revisionedTarget.PruneRevisions(ctx, svc, targetResource, da.flags.keep)
+	progress.Stop(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return fmt.Errorf("pruning revisions of service '%s': %w", svc.Name, err)
+	}
+
+	if len(pruned) == 0 {
+		da.console.Message(ctx, "  - Pruned revisions: none")
+	} else {
+		da.console.Message(ctx, fmt.Sprintf("  - Pruned revisions: %s", strings.Join(pruned, ", ")))
+	}
+
+	return nil
+}
+
+// applySettings sets the runtime settings of the target resource of the service
+func (da *deployAction) applySettings(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	settings map[string]string,
+) (*deploySettings, error) {
+	serviceTarget, err := da.serviceManager.GetServiceTarget(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	settingsTarget, ok := serviceTarget.(project.SettingsServiceTarget)
+	if !ok {
+		return nil, fmt.Errorf("service '%s' uses host '%s' which does not support '--env-file'", svc.Name, svc.Host)
+	}
+
+	targetResource, err := da.resourceManager.GetTargetResource(ctx, da.env.GetSubscriptionId(), svc)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource for service '%s': %w", svc.Name, err)
+	}
+
+	changes, err := settingsTarget.ApplySettings(ctx, svc, targetResource, settings)
+	if err != nil {
+		return nil, fmt.Errorf("applying runtime settings of service '%s': %w", svc.Name, err)
+	}
+
+	return &deploySettings{
+		target:         settingsTarget,
+		targetResource: targetResource,
+		changes:        changes,
 	}, nil
 }
 
+// restoreSettings reverts the runtime settings applied before a failed deployment, if any. The returned error always
+// contains the original deployment error, along with the restore error when restoring the settings also fails.
+func (da *deployAction) restoreSettings(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	progress *deployProgress,
+	applied *deploySettings,
+	deployErr error,
+) error {
+	if applied == nil || !applied.changes.HasChanges() {
+		return deployErr
+	}
+
+	stepMessage := fmt.Sprintf("Restoring runtime settings of service %s", svc.Name)
+	progress.Start(ctx, stepMessage)
+	err := applied.target.RestoreSettings(ctx, svc, applied.targetResource, applied.changes)
+	progress.Stop(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return fmt.Errorf("%w\n\nrestoring runtime settings of service '%s' also failed: %w", deployErr, svc.Name, err)
+	}
+
+	return fmt.Errorf("%w\n\nruntime settings of service '%s' were restored", deployErr, svc.Name)
+}
+
+// deployProgress reports the steps of the deployment of a service. Steps are shown with the console spinner, unless the
+// service deploys at the same time as other services. The spinner is shared by all of them, so each step of these
+// services is reported with its own messages instead.
+type deployProgress struct {
+	console  input.Console
+	parallel bool
+}
+
+// Start reports that a step started.
+func (p *deployProgress) Start(ctx context.Context, message string) {
+	if p.parallel {
+		p.console.Message(ctx, fmt.Sprintf("  %s...", message))
+		return
+	}
+
+	p.console.ShowSpinner(ctx, message, input.Step)
+}
+
+// Update reports the progress of the running step. The progress of services deployed in parallel is only logged, so
+// their messages don't flood the console.
+func (p *deployProgress) Update(ctx context.Context, message string) {
+	if p.parallel {
+		log.Print(message)
+		return
+	}
+
+	p.console.ShowSpinner(ctx, message, input.Step)
+}
+
+// Stop reports the result of the running step.
+func (p *deployProgress) Stop(ctx context.Context, message string, format input.SpinnerUxType) {
+	if !p.parallel {
+		p.console.StopSpinner(ctx, message, format)
+		return
+	}
+
+	if format == input.StepFailed {
+		p.console.Message(ctx, output.WithErrorFormat("  (x) Failed: %s", message))
+		return
+	}
+
+	p.console.MessageUxItem(ctx, &ux.DoneMessage{Message: message})
+}
+
+// reportSettingsChanges prints the runtime settings applied by `azd deploy --env-file`
+func reportSettingsChanges(ctx context.Context, console input.Console, changes *project.SettingsChanges) {
+	groups := []struct {
+		label string
+		names []string
+	}{
+		{"added", changes.Added},
+		{"changed", changes.Changed},
+		{"unchanged", changes.Unchanged},
+	}
+
+	for _, group := range groups {
+		if len(group.names) > 0 {
+			console.Message(ctx, fmt.Sprintf("  - Settings %s: %s", group.label, strings.Join(group.names, ", ")))
+		}
+	}
+}
+
 func getCmdDeployHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription("Deploy application to Azure.", []string{
 		formatHelpNote(
@@ -311,6 +1130,18 @@ func getCmdDeployHelpDescription(*cobra.Command) string {
 				" or the service described in the project that matches the current directory."),
 		formatHelpNote(
 			fmt.Sprintf("When %s is set, only the specific service is deployed.", output.WithHighLightFormat("<service>"))),
+		formatHelpNote(
+			fmt.Sprintf(
+				"%s can also be a pattern, like %s, to deploy all the services it matches.",
+				output.WithHighLightFormat("<service>"),
+				output.WithHighLightFormat("'api-*'"))),
+		formatHelpNote(
+			fmt.Sprintf(
+				"Use %s and %s when the services are already restored and built, like in CI. Both have no effect"+
+					" with %s, which deploys the existing package without restoring, building or packaging.",
+				output.WithHighLightFormat("--skip-restore"),
+				output.WithHighLightFormat("--skip-build"),
+				output.WithHighLightFormat("--from-package"))),
 		formatHelpNote("After the deployment is complete, the endpoint is printed. To start the service, select" +
 			" the endpoint or paste it in a browser."),
 	})
@@ -318,6 +1149,9 @@ func getCmdDeployHelpDescription(*cobra.Command) string {
 
 func getCmdDeployHelpFooter(*cobra.Command) string {
 	return generateCmdHelpSamplesBlock(map[string]string{
+		"Deploy the services whose names start with 'api-' to Azure.": output.WithHighLightFormat(
+			"azd deploy 'api-*'",
+		),
 		"Deploy all services in the current project to Azure.": output.WithHighLightFormat(
 			"azd deploy --all",
 		),
@@ -330,5 +1164,17 @@ func getCmdDeployHelpFooter(*cobra.Command) string {
 		"Deploy the service named 'api' to Azure from a previously generated package.": output.WithHighLightFormat(
 			"azd deploy api --from-package <package-path>",
 		),
+		"Deploy the container service named 'api' to Azure using a specific image tag.": output.WithHighLightFormat(
+			"azd deploy api --tag <image-tag>",
+		),
+		"Deploy the service named 'api' to Azure and roll back if the deployment fails.": output.WithHighLightFormat(
+			"azd deploy api --rollback-on-failure",
+		),
+		"Deploy the service named 'api' to Azure and deactivate all but its 5 most recent revisions.": output.WithHighLightFormat(
+			"azd deploy api --prune --keep 5",
+		),
+		"Deploy the service named 'api' to Azure and set its app settings from a dotenv file.": output.WithHighLightFormat(
+			"azd deploy api --env-file <path>",
+		),
 	})
 }
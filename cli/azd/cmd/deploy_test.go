@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ServiceDeploymentResult_Json(t *testing.T) {
+	result := &ServiceDeploymentResult{
+		ServiceDeployResult: &project.ServiceDeployResult{
+			TargetResourceId: "RESOURCE_ID",
+			Kind:             project.ContainerAppTarget,
+			Endpoints:        []string{"https://api.example.com"},
+		},
+		Name:            "api",
+		DurationSeconds: 12.5,
+	}
+
+	contents, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var actual map[string]any
+	require.NoError(t, json.Unmarshal(contents, &actual))
+
+	require.Equal(t, "api", actual["name"])
+	require.Equal(t, string(project.ContainerAppTarget), actual["kind"])
+	require.Equal(t, "RESOURCE_ID", actual["targetResourceId"])
+	require.Equal(t, []any{"https://api.example.com"}, actual["endpoints"])
+	require.Equal(t, 12.5, actual["durationSeconds"])
+	require.NotContains(t, actual, "productionEndpoints")
+}
+
+func Test_ServiceDeploymentResult_Json_Swap(t *testing.T) {
+	result := &ServiceDeploymentResult{
+		ServiceDeployResult: &project.ServiceDeployResult{
+			TargetResourceId: "RESOURCE_ID/slots/staging",
+			Kind:             project.AppServiceTarget,
+			Endpoints:        []string{"https://web-staging.azurewebsites.net/"},
+			Slot:             "staging",
+		},
+		Name:                "web",
+		ProductionEndpoints: []string{"https://web.azurewebsites.net/"},
+	}
+
+	contents, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var actual map[string]any
+	require.NoError(t, json.Unmarshal(contents, &actual))
+
+	require.Equal(t, "staging", actual["slot"])
+	require.Equal(t, []any{"https://web-staging.azurewebsites.net/"}, actual["endpoints"])
+	require.Equal(t, []any{"https://web.azurewebsites.net/"}, actual["productionEndpoints"])
+}
+
+func Test_containerFlagsMessage(t *testing.T) {
+	require.Equal(t, "'--tag' is", containerFlagsMessage(&deployFlags{imageTag: "v1"}))
+	require.Equal(t, "'--build-arg' is", containerFlagsMessage(&deployFlags{buildArgs: []string{"VERSION=1"}}))
+	require.Equal(t,
+		"'--tag' and '--build-arg' are",
+		containerFlagsMessage(&deployFlags{imageTag: "v1", buildArgs: []string{"VERSION=1"}}))
+}
+
+func Test_applyContainerFlags(t *testing.T) {
+	allServices := func(*project.ServiceConfig) bool { return true }
+
+	t.Run("AppliedOnce", func(t *testing.T) {
+		web := &project.ServiceConfig{Name: "web", Host: project.ContainerAppTarget}
+		projectConfig := &project.ProjectConfig{Services: map[string]*project.ServiceConfig{"web": web}}
+		flags := &deployFlags{imageTag: "v1", buildArgs: []string{"VERSION=1"}}
+
+		// azd up applies the flags before packaging, then again when deploying
+		require.NoError(t, applyContainerFlags(projectConfig, flags, allServices))
+		require.NoError(t, applyContainerFlags(projectConfig, flags, allServices))
+		require.Equal(t, "v1", web.Docker.ImageTag)
+		require.Equal(t, []string{"VERSION=1"}, web.Docker.BuildArgs)
+	})
+
+	t.Run("InvalidBuildArg", func(t *testing.T) {
+		err := applyContainerFlags(&project.ProjectConfig{}, &deployFlags{buildArgs: []string{"=1"}}, allServices)
+		require.ErrorContains(t, err, "expected KEY=VALUE")
+	})
+
+	t.Run("NotContainerService", func(t *testing.T) {
+		api := &project.ServiceConfig{Name: "api", Host: project.AppServiceTarget}
+		projectConfig := &project.ProjectConfig{Services: map[string]*project.ServiceConfig{"api": api}}
+
+		err := applyContainerFlags(projectConfig, &deployFlags{imageTag: "v1"}, allServices)
+		require.ErrorContains(t, err, "'--tag' is only supported for container services")
+	})
+}
+
+func Test_deployParallelism(t *testing.T) {
+	withConfig := &project.ProjectConfig{Deploy: &project.DeployConfig{Parallelism: 4}}
+
+	require.Equal(t, 1, deployParallelism(0, &project.ProjectConfig{}))
+	require.Equal(t, 4, deployParallelism(0, withConfig))
+	require.Equal(t, 2, deployParallelism(2, withConfig))
+	require.Equal(t, 1, deployParallelism(1, withConfig))
+}
+
+func Test_deployServices(t *testing.T) {
+	newServices := func(names ...string) []*project.ServiceConfig {
+		services := make([]*project.ServiceConfig, len(names))
+		for i, name := range names {
+			services[i] = &project.ServiceConfig{Name: name}
+		}
+		return services
+	}
+
+	// tracker records the order services start in, and the most services deploying at the same time
+	type tracker struct {
+		mu         sync.Mutex
+		started    []string
+		running    int32
+		maxRunning int32
+		// serialOverlap is set when a serial service deploys alongside another service
+		serialOverlap bool
+		// parallel records, by service, whether the service was told it deploys in parallel
+		parallel map[string]bool
+	}
+
+	deployWith := func(
+		tr *tracker,
+		fail string,
+	) func(svc *project.ServiceConfig, parallel bool) (*ServiceDeploymentResult, error) {
+		return func(svc *project.ServiceConfig, parallel bool) (*ServiceDeploymentResult, error) {
+			running := atomic.AddInt32(&tr.running, 1)
+			defer atomic.AddInt32(&tr.running, -1)
+
+			tr.mu.Lock()
+			tr.started = append(tr.started, svc.Name)
+			if tr.parallel == nil {
+				tr.parallel = map[string]bool{}
+			}
+			tr.parallel[svc.Name] = parallel
+			if running > tr.maxRunning {
+				tr.maxRunning = running
+			}
+			if svc.Deploy == project.ServiceDeploySerial && running > 1 {
+				tr.serialOverlap = true
+			}
+			tr.mu.Unlock()
+
+			// Gives other services the time to start
+			time.Sleep(20 * time.Millisecond)
+
+			if svc.Name == fail {
+				return nil, errors.New("deploying " + svc.Name)
+			}
+
+			return &ServiceDeploymentResult{Name: svc.Name}, nil
+		}
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		tr := &tracker{}
+		results, err := deployServices(newServices("api", "web", "worker"), 1, deployWith(tr, ""))
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		require.Equal(t, []string{"api", "web", "worker"}, tr.started)
+		require.Equal(t, int32(1), tr.maxRunning)
+		require.Equal(t, map[string]bool{"api": false, "web": false, "worker": false}, tr.parallel)
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		tr := &tracker{}
+		results, err := deployServices(newServices("a", "b", "c", "d", "e"), 2, deployWith(tr, ""))
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+		require.Equal(t, int32(2), tr.maxRunning)
+		require.Equal(t, map[string]bool{"a": true, "b": true, "c": true, "d": true, "e": true}, tr.parallel)
+	})
+
+	t.Run("SerialOverride", func(t *testing.T) {
+		services := newServices("api", "db-migrate", "web", "worker")
+		services[1].Deploy = project.ServiceDeploySerial
+
+		tr := &tracker{}
+		results, err := deployServices(services, 3, deployWith(tr, ""))
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+		require.Equal(t, "db-migrate", tr.started[0])
+		require.False(t, tr.serialOverlap)
+		require.Equal(t, int32(3), tr.maxRunning)
+		require.Equal(t, map[string]bool{"api": true, "db-migrate": false, "web": true, "worker": true}, tr.parallel)
+	})
+
+	t.Run("SerialFailureSkipsPool", func(t *testing.T) {
+		services := newServices("api", "db-migrate", "web")
+		services[1].Deploy = project.ServiceDeploySerial
+
+		tr := &tracker{}
+		_, err := deployServices(services, 3, deployWith(tr, "db-migrate"))
+		require.EqualError(t, err, "deploying db-migrate")
+		require.Equal(t, []string{"db-migrate"}, tr.started)
+	})
+
+	t.Run("FailureStopsScheduling", func(t *testing.T) {
+		tr := &tracker{}
+		_, err := deployServices(newServices("a", "b", "c", "d", "e"), 2, deployWith(tr, "a"))
+		require.ErrorContains(t, err, "deploying a")
+
+		// a and b deploy together, a fails and at most one more service starts in the slot released by b
+		require.LessOrEqual(t, len(tr.started), 3)
+	})
+}
+
+func Test_deployProgress(t *testing.T) {
+	t.Run("Spinner", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		progress := &deployProgress{console: mockContext.Console}
+
+		progress.Start(*mockContext.Context, "Deploying service web")
+		progress.Update(*mockContext.Context, "Deploying service web (Uploading)")
+		progress.Stop(*mockContext.Context, "Deploying service web", input.StepDone)
+
+		require.Len(t, mockContext.Console.SpinnerOps(), 3)
+		require.Empty(t, mockContext.Console.Output())
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		web := &deployProgress{console: mockContext.Console, parallel: true}
+		api := &deployProgress{console: mockContext.Console, parallel: true}
+
+		// Each service reports its own steps, without the shared spinner
+		web.Start(*mockContext.Context, "Deploying service web")
+		api.Start(*mockContext.Context, "Deploying service api")
+		web.Update(*mockContext.Context, "Deploying service web (Uploading)")
+		api.Stop(*mockContext.Context, "Deploying service api", input.StepFailed)
+		web.Stop(*mockContext.Context, "Deploying service web", input.StepDone)
+
+		require.Empty(t, mockContext.Console.SpinnerOps())
+		output := mockContext.Console.Output()
+		require.Len(t, output, 4)
+		require.Equal(t, "  Deploying service web...", output[0])
+		require.Equal(t, "  Deploying service api...", output[1])
+		require.Contains(t, output[2], "Failed: Deploying service api")
+		require.Contains(t, output[3], "Done: Deploying service web")
+	})
+}
+
+// recoveryTarget records the rollbacks and settings restores of a failed deployment.
+type recoveryTarget struct {
+	project.RevisionedServiceTarget
+	project.SettingsServiceTarget
+	rollbackErr error
+	calls       []string
+}
+
+func (t *recoveryTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	targetResource *environment.TargetResource,
+	revision string,
+) error {
+	t.calls = append(t.calls, "rollback "+revision)
+	return t.rollbackErr
+}
+
+func (t *recoveryTarget) RestoreSettings(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	targetResource *environment.TargetResource,
+	changes *project.SettingsChanges,
+) error {
+	t.calls = append(t.calls, "restore settings")
+	return nil
+}
+
+func Test_deployAction_recoverDeploy(t *testing.T) {
+	deployErr := errors.New("deployment failed")
+	svc := &project.ServiceConfig{Name: "api"}
+
+	recoverDeploy := func(target *recoveryTarget, hasRevision bool) error {
+		mockContext := mocks.NewMockContext(context.Background())
+		da := &deployAction{previousRevisions: map[string]*deployRevision{}}
+		if hasRevision {
+			da.previousRevisions[svc.Name] = &deployRevision{target: target, name: "api--rev1"}
+		}
+
+		applied := &deploySettings{target: target, changes: &project.SettingsChanges{Added: []string{"LOG_LEVEL"}}}
+		return da.recoverDeploy(
+			*mockContext.Context, svc, &deployProgress{console: mockContext.Console}, applied, deployErr)
+	}
+
+	t.Run("RolledBack", func(t *testing.T) {
+		// The previous revision brings back its settings, restoring them would create another revision
+		target := &recoveryTarget{}
+		err := recoverDeploy(target, true)
+		require.ErrorIs(t, err, deployErr)
+		require.ErrorContains(t, err, "service 'api' was rolled back to revision 'api--rev1'")
+		require.Equal(t, []string{"rollback api--rev1"}, target.calls)
+	})
+
+	t.Run("RollbackFailed", func(t *testing.T) {
+		target := &recoveryTarget{rollbackErr: errors.New("revision not found")}
+		err := recoverDeploy(target, true)
+		require.ErrorIs(t, err, deployErr)
+		require.ErrorContains(t, err, "rolling back service 'api' to revision 'api--rev1' also failed")
+		require.ErrorContains(t, err, "runtime settings of service 'api' were restored")
+		require.Equal(t, []string{"rollback api--rev1", "restore settings"}, target.calls)
+	})
+
+	t.Run("NoRevision", func(t *testing.T) {
+		target := &recoveryTarget{}
+		err := recoverDeploy(target, false)
+		require.ErrorIs(t, err, deployErr)
+		require.ErrorContains(t, err, "runtime settings of service 'api' were restored")
+		require.Equal(t, []string{"restore settings"}, target.calls)
+	})
+}
@@ -6,13 +6,24 @@
 package cmd
 
 import (
+	"fmt"
 	"net/http"
+	"os"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/benbjohnson/clock"
 )
 
 func createHttpClient() *http.Client {
-	return &http.Client{}
+	transport, err := httputil.NewTransport()
+	if err != nil {
+		// The console isn't available yet, the warning is written directly to stderr
+		fmt.Fprintln(os.Stderr, output.WithWarningFormat("WARNING: %v, using the default HTTP transport.", err))
+		return &http.Client{}
+	}
+
+	return &http.Client{Transport: transport}
 }
 
 func createClock() clock.Clock {
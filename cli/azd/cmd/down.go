@@ -21,6 +21,7 @@ import (
 type downFlags struct {
 	forceDelete bool
 	purgeDelete bool
+	useStack    bool
 	global      *internal.GlobalCommandOptions
 	envFlag
 }
@@ -34,6 +35,11 @@ func (i *downFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		//nolint:lll
 		"Does not require confirmation before it permanently deletes resources that are soft-deleted by default (for example, key vaults).",
 	)
+	local.BoolVar(
+		&i.useStack,
+		"use-stack",
+		false,
+		"Deletes the Azure deployment stack of the infrastructure, together with the resources it manages (bicep only).")
 	i.envFlag.Bind(local, global)
 	i.global = global
 }
@@ -86,6 +92,10 @@ func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 
 	startTime := time.Now()
 
+	if a.flags.useStack {
+		a.projectConfig.Infra.DeploymentStack = true
+	}
+
 	if err := a.provisionManager.Initialize(ctx, a.projectConfig.Path, a.projectConfig.Infra); err != nil {
 		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
 	}
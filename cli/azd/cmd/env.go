@@ -5,21 +5,37 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/state"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
@@ -53,10 +69,27 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvNewAction,
 	})
 
+	group.Add("delete", &actions.ActionDescriptorOptions{
+		Command:        newEnvDeleteCmd(),
+		FlagsResolver:  newEnvDeleteFlags,
+		ActionResolver: newEnvDeleteAction,
+	})
+
+	group.Add("rename", &actions.ActionDescriptorOptions{
+		Command:        newEnvRenameCmd(),
+		ActionResolver: newEnvRenameAction,
+	})
+
+	group.Add("set-remote", &actions.ActionDescriptorOptions{
+		Command:        newEnvSetRemoteCmd(),
+		FlagsResolver:  newEnvSetRemoteFlags,
+		ActionResolver: newEnvSetRemoteAction,
+	})
+
 	group.Add("list", &actions.ActionDescriptorOptions{
 		Command:        newEnvListCmd(),
 		ActionResolver: newEnvListAction,
-		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat, output.YamlFormat},
 		DefaultFormat:  output.TableFormat,
 	})
 
@@ -72,8 +105,21 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		Command:        newEnvGetValuesCmd(),
 		FlagsResolver:  newEnvGetValuesFlags,
 		ActionResolver: newEnvGetValuesAction,
-		OutputFormats:  []output.Format{output.JsonFormat, output.EnvVarsFormat},
-		DefaultFormat:  output.EnvVarsFormat,
+		OutputFormats: []output.Format{
+			output.JsonFormat,
+			output.EnvVarsFormat,
+			output.EnvExportFormat,
+			output.YamlFormat,
+		},
+		DefaultFormat: output.EnvVarsFormat,
+	})
+
+	group.Add("get-value", &actions.ActionDescriptorOptions{
+		Command:        newEnvGetValueCmd(),
+		FlagsResolver:  newEnvGetValueFlags,
+		ActionResolver: newEnvGetValueAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
 	})
 
 	return group
@@ -88,18 +134,47 @@ func newEnvSetFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *
 
 func newEnvSetCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "set <key> <value>",
+		Use:   "set <key> [<value>]",
 		Short: "Manage your environment settings.",
-		Args:  cobra.ExactArgs(2),
+		Args:  cobra.RangeArgs(1, 2),
 	}
 }
 
 type envSetFlags struct {
+	append     string
+	list       []string
+	force      bool
+	resolveNow bool
 	envFlag
 	global *internal.GlobalCommandOptions
 }
 
 func (f *envSetFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(
+		&f.append,
+		"append",
+		"",
+		"Appends the value to the list stored at the key, as a JSON array.",
+	)
+	local.StringSliceVar(
+		&f.list,
+		"list",
+		nil,
+		"Sets the key to a list of comma separated values, stored as a JSON array.",
+	)
+	local.BoolVar(
+		&f.force,
+		"force",
+		false,
+		"Replaces an existing value that isn't a list when used with '--append' or '--list'.",
+	)
+	local.BoolVar(
+		&f.resolveNow,
+		"resolve-now",
+		false,
+		"Stores the value with its ${KEY} references to other environment values resolved, "+
+			"instead of resolving them when the values are read.",
+	)
 	f.envFlag.Bind(local, global)
 	f.global = global
 }
@@ -132,7 +207,47 @@ func newEnvSetAction(
 }
 
 func (e *envSetAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	e.env.DotenvSet(e.args[0], e.args[1])
+	key := e.args[0]
+	isList := e.flags.append != "" || len(e.flags.list) > 0
+
+	switch {
+	case e.flags.append != "" && len(e.flags.list) > 0:
+		return nil, errors.New("'--append' and '--list' can't be used together")
+	case isList && len(e.args) != 1:
+		return nil, errors.New("a value can't be passed with '--append' or '--list'")
+	case !isList && len(e.args) != 2:
+		return nil, fmt.Errorf("missing the value to set for '%s'", key)
+	case isList && e.flags.resolveNow:
+		return nil, errors.New("'--resolve-now' can't be used with '--append' or '--list'")
+	}
+
+	if !isList {
+		value := e.args[1]
+		switch {
+		case e.flags.resolveNow:
+			resolved, err := e.env.ExpandValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("resolving value of '%s': %w", key, err)
+			}
+
+			e.env.DotenvSet(key, resolved)
+		case environment.HasReferences(value):
+			if err := e.env.DotenvSetReferences(key, value); err != nil {
+				return nil, fmt.Errorf("setting value of '%s': %w", key, err)
+			}
+		default:
+			e.env.DotenvSet(key, value)
+		}
+	} else {
+		items, err := e.listItems(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := e.env.DotenvSetList(key, items); err != nil {
+			return nil, fmt.Errorf("setting value of '%s': %w", key, err)
+		}
+	}
 
 	if err := e.envManager.Save(ctx, e.env); err != nil {
 		return nil, fmt.Errorf("saving environment: %w", err)
@@ -141,6 +256,47 @@ func (e *envSetAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	return nil, nil
 }
 
+// listItems returns the items of the list to store at key for '--append' or '--list'. An existing value that isn't a
+// list is only replaced with '--force'.
+func (e *envSetAction) listItems(key string) ([]any, error) {
+	existing, has := e.env.LookupEnv(key)
+
+	items, isList := parseListValue(existing)
+	if has && existing != "" && !isList {
+		if !e.flags.force {
+			return nil, fmt.Errorf("the value of '%s' isn't a list, use '--force' to replace it with a list", key)
+		}
+
+		items = nil
+	}
+
+	if len(e.flags.list) > 0 {
+		items = []any{}
+		for _, item := range e.flags.list {
+			items = append(items, item)
+		}
+	} else {
+		items = append(items, e.flags.append)
+	}
+
+	return items, nil
+}
+
+// parseListValue returns the items of a value that holds a JSON array, as stored by `azd env set --append` and
+// `azd env set --list`, and by provisioning for array outputs.
+func parseListValue(value string) ([]any, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(value), "[") {
+		return nil, false
+	}
+
+	var items []any
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return nil, false
+	}
+
+	return items, true
+}
+
 func newEnvSelectCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "select <environment>",
@@ -182,6 +338,414 @@ func (e *envSelectAction) Run(ctx context.Context) (*actions.ActionResult, error
 	return nil, nil
 }
 
+type envDeleteFlags struct {
+	force     bool
+	localOnly bool
+	global    *internal.GlobalCommandOptions
+}
+
+func (f *envDeleteFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(
+		&f.force,
+		"force",
+		false,
+		"Deletes the environment without confirmation, even when it is the default environment.",
+	)
+	local.BoolVar(&f.localOnly, "local-only", false, "Deletes only the local environment and keeps any remote state.")
+
+	f.global = global
+}
+
+func newEnvDeleteFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envDeleteFlags {
+	flags := &envDeleteFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <environment>",
+		Short: "Delete an environment.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+type envDeleteAction struct {
+	envManager environment.Manager
+	console    input.Console
+	flags      *envDeleteFlags
+	args       []string
+}
+
+func newEnvDeleteAction(
+	envManager environment.Manager,
+	console input.Console,
+	flags *envDeleteFlags,
+	args []string,
+) actions.Action {
+	return &envDeleteAction{
+		envManager: envManager,
+		console:    console,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (e *envDeleteAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := e.args[0]
+
+	envs, err := e.envManager.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing environments: %w", err)
+	}
+
+	idx := slices.IndexFunc(envs, func(env *environment.Description) bool {
+		return env.Name == name
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("environment '%s' does not exist", name)
+	}
+
+	env := envs[idx]
+	if env.IsDefault && !e.flags.force {
+		return nil, fmt.Errorf(
+			"environment '%s' is the default environment. Select another environment with "+
+				"\"azd env select <environment>\" or use --force to delete it",
+			name,
+		)
+	}
+
+	includeRemote := env.HasRemote && !e.flags.localOnly
+	if includeRemote && !e.flags.force {
+		confirm, err := e.console.Confirm(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf(
+				"Environment '%s' also has remote state. Do you want to delete the remote environment too?", name),
+			DefaultValue: false,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting to delete remote environment: %w", err)
+		}
+
+		includeRemote = confirm
+	}
+
+	if !env.HasLocal && !includeRemote {
+		return nil, fmt.Errorf("environment '%s' only exists remotely and was not deleted", name)
+	}
+
+	if err := e.envManager.Delete(ctx, name, environment.DeleteOptions{IncludeRemote: includeRemote}); err != nil {
+		return nil, fmt.Errorf("deleting environment: %w", err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Environment '%s' deleted", name),
+		},
+	}, nil
+}
+
+type envSetRemoteFlags struct {
+	backend       string
+	accountName   string
+	containerName string
+	endpoint      string
+	global        *internal.GlobalCommandOptions
+	envFlag
+}
+
+func (f *envSetRemoteFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(
+		&f.backend,
+		"backend",
+		"",
+		fmt.Sprintf("The remote state backend to use. Supported values: %s.", strings.Join(environment.ValidRemoteKinds, ", ")),
+	)
+	local.StringVar(&f.accountName, "account-name", "", "The name of the Azure Storage account (AzureBlobStorage backend).")
+	local.StringVar(
+		&f.containerName,
+		"container-name",
+		"",
+		"The name of the blob container, defaults to the project name (AzureBlobStorage backend).",
+	)
+	local.StringVar(
+		&f.endpoint,
+		"endpoint",
+		"",
+		fmt.Sprintf("The blob storage endpoint, defaults to %s (AzureBlobStorage backend).", storage.DefaultBlobEndpoint),
+	)
+
+	f.envFlag.Bind(local, global)
+	f.global = global
+}
+
+func newEnvRenameCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <environment> <new-name>",
+		Short: "Rename an environment.",
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+type envRenameAction struct {
+	envManager environment.Manager
+	azCli      azcli.AzCli
+	args       []string
+}
+
+func newEnvRenameAction(envManager environment.Manager, azCli azcli.AzCli, args []string) actions.Action {
+	return &envRenameAction{
+		envManager: envManager,
+		azCli:      azCli,
+		args:       args,
+	}
+}
+
+func (e *envRenameAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name, newName := e.args[0], e.args[1]
+
+	env, err := e.envManager.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Provisioned resources are found by their azd-env-name tag, which still has the previous name after the rename
+	provisioned, err := e.hasProvisionedResources(ctx, env)
+	if err != nil {
+		return nil, fmt.Errorf("checking for provisioned resources of environment '%s': %w", name, err)
+	}
+
+	if provisioned {
+		return nil, fmt.Errorf(
+			"environment '%s' has provisioned resources tagged with '%s=%s', which azd can't find after a rename. "+
+				"Run 'azd down' to delete them before renaming the environment",
+			name,
+			azure.TagKeyAzdEnvName,
+			name,
+		)
+	}
+
+	if err := e.envManager.Rename(ctx, name, newName); err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Renamed environment '%s' to '%s'.", name, newName),
+		},
+	}, nil
+}
+
+// hasProvisionedResources returns true when the subscription of the environment has resource groups tagged with the
+// environment name, or when the resource group of the environment has resources tagged with it.
+func (e *envRenameAction) hasProvisionedResources(ctx context.Context, env *environment.Environment) (bool, error) {
+	subscriptionId := env.GetSubscriptionId()
+	if subscriptionId == "" {
+		return false, nil
+	}
+
+	groups, err := e.azCli.ListResourceGroup(ctx, subscriptionId, &azcli.ListResourceGroupOptions{
+		TagFilter: &azcli.Filter{Key: azure.TagKeyAzdEnvName, Value: env.GetEnvName()},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if len(groups) > 0 {
+		return true, nil
+	}
+
+	resourceGroupName := env.Getenv(environment.ResourceGroupEnvVarName)
+	if resourceGroupName == "" {
+		return false, nil
+	}
+
+	tagFilter := fmt.Sprintf(
+		"tagName eq '%s' and tagValue eq '%s'",
+		azure.TagKeyAzdEnvName,
+		env.GetEnvName(),
+	)
+	resources, err := e.azCli.ListResourceGroupResources(
+		ctx, subscriptionId, resourceGroupName, &azcli.ListResourceGroupResourcesOptions{Filter: &tagFilter})
+
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return len(resources) > 0, nil
+}
+
+func newEnvSetRemoteFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envSetRemoteFlags {
+	flags := &envSetRemoteFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvSetRemoteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-remote",
+		Short: "Configure a remote state backend for an environment.",
+		Args:  cobra.NoArgs,
+	}
+}
+
+type envSetRemoteAction struct {
+	env        *environment.Environment
+	envManager environment.Manager
+	credential azcore.TokenCredential
+	httpClient httputil.HttpClient
+	userAgent  httputil.UserAgent
+	console    input.Console
+	flags      *envSetRemoteFlags
+}
+
+func newEnvSetRemoteAction(
+	env *environment.Environment,
+	envManager environment.Manager,
+	credential azcore.TokenCredential,
+	httpClient httputil.HttpClient,
+	userAgent httputil.UserAgent,
+	console input.Console,
+	flags *envSetRemoteFlags,
+) actions.Action {
+	return &envSetRemoteAction{
+		env:        env,
+		envManager: envManager,
+		credential: credential,
+		httpClient: httpClient,
+		userAgent:  userAgent,
+		console:    console,
+		flags:      flags,
+	}
+}
+
+func (e *envSetRemoteAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	backend := e.flags.backend
+	if backend == "" {
+		if e.flags.global.NoPrompt {
+			return nil, errors.New("--backend is required when running with --no-prompt")
+		}
+
+		selected, err := e.console.Select(ctx, input.ConsoleOptions{
+			Message: "Select a remote state backend",
+			Options: environment.ValidRemoteKinds,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for backend: %w", err)
+		}
+
+		backend = environment.ValidRemoteKinds[selected]
+	}
+
+	var remoteConfig *state.RemoteConfig
+	var err error
+
+	switch environment.RemoteKind(backend) {
+	case environment.RemoteKindAzureBlobStorage:
+		remoteConfig, err = e.blobStorageConfig(ctx)
+	default:
+		return nil, fmt.Errorf(
+			"the backend '%s' is not valid. Valid values are '%s'",
+			backend,
+			ux.ListAsText(environment.ValidRemoteKinds),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.env.Config.Set("state.remote", map[string]any{
+		"backend": remoteConfig.Backend,
+		"config":  remoteConfig.Config,
+	}); err != nil {
+		return nil, fmt.Errorf("setting remote state config: %w", err)
+	}
+
+	if err := e.envManager.Save(ctx, e.env); err != nil {
+		return nil, fmt.Errorf("saving environment: %w", err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Remote state of environment '%s' is now stored using the %s backend", e.env.GetEnvName(), backend),
+			FollowUp: "Projects that define their own remote state in azure.yaml keep using it. Run " +
+				output.WithHighLightFormat("azd env list") + " to view local and remote environments.",
+		},
+	}, nil
+}
+
+// blobStorageConfig builds the AzureBlobStorage remote state configuration from flags and prompts, and verifies that
+// the storage account can be reached with the current credentials.
+func (e *envSetRemoteAction) blobStorageConfig(ctx context.Context) (*state.RemoteConfig, error) {
+	accountConfig := &storage.AccountConfig{
+		AccountName:   e.flags.accountName,
+		ContainerName: e.flags.containerName,
+		Endpoint:      e.flags.endpoint,
+	}
+
+	if accountConfig.AccountName == "" {
+		if e.flags.global.NoPrompt {
+			return nil, errors.New("--account-name is required when running with --no-prompt")
+		}
+
+		accountName, err := e.console.Prompt(ctx, input.ConsoleOptions{
+			Message: "Enter the name of the Azure Storage account",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for storage account: %w", err)
+		}
+
+		accountConfig.AccountName = strings.TrimSpace(accountName)
+		if accountConfig.AccountName == "" {
+			return nil, errors.New("a storage account name is required")
+		}
+
+		// Only prompt for the optional settings when the account wasn't passed as a flag
+		containerName, err := e.console.Prompt(ctx, input.ConsoleOptions{
+			Message: "Enter the name of the blob container (leave empty to use the project name)",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for container name: %w", err)
+		}
+
+		accountConfig.ContainerName = strings.TrimSpace(containerName)
+	}
+
+	// Copy the config since creating the client fills in the default endpoint
+	clientConfig := *accountConfig
+	client, err := storage.NewBlobSdkClient(ctx, e.credential, &clientConfig, e.httpClient, e.userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	e.console.ShowSpinner(ctx, "Verifying access to the storage account", input.Step)
+	_, err = client.NewListContainersPager(nil).NextPage(ctx)
+	e.console.StopSpinner(ctx, "Verifying access to the storage account", input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to storage account '%s': %w", accountConfig.AccountName, err)
+	}
+
+	remoteConfig := map[string]any{
+		"accountName": accountConfig.AccountName,
+	}
+	if accountConfig.ContainerName != "" {
+		remoteConfig["containerName"] = accountConfig.ContainerName
+	}
+	if accountConfig.Endpoint != "" {
+		remoteConfig["endpoint"] = accountConfig.Endpoint
+	}
+
+	return &state.RemoteConfig{
+		Backend: string(environment.RemoteKindAzureBlobStorage),
+		Config:  remoteConfig,
+	}, nil
+}
+
 func newEnvListCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     "list",
@@ -254,6 +818,7 @@ func (e *envListAction) Run(ctx context.Context) (*actions.ActionResult, error)
 type envNewFlags struct {
 	subscription string
 	location     string
+	noDefault    bool
 	global       *internal.GlobalCommandOptions
 }
 
@@ -265,6 +830,12 @@ func (f *envNewFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandO
 		"Name or ID of an Azure subscription to use for the new environment",
 	)
 	local.StringVarP(&f.location, "location", "l", "", "Azure location for the new environment")
+	local.BoolVar(
+		&f.noDefault,
+		"no-default",
+		false,
+		"Creates the environment without setting it as the default environment",
+	)
 
 	f.global = global
 }
@@ -327,6 +898,10 @@ func (en *envNewAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		return nil, fmt.Errorf("creating new environment: %w", err)
 	}
 
+	if en.flags.noDefault {
+		return nil, nil
+	}
+
 	if err := en.azdCtx.SetDefaultEnvironmentName(env.GetEnvName()); err != nil {
 		return nil, fmt.Errorf("saving default environment: %w", err)
 	}
@@ -391,6 +966,7 @@ func newEnvRefreshCmd() *cobra.Command {
 }
 
 type envRefreshAction struct {
+	serviceLocator   ioc.ServiceLocator
 	provisionManager *provisioning.Manager
 	projectConfig    *project.ProjectConfig
 	projectManager   project.ProjectManager
@@ -403,10 +979,9 @@ type envRefreshAction struct {
 }
 
 func newEnvRefreshAction(
-	provisionManager *provisioning.Manager,
+	serviceLocator ioc.ServiceLocator,
 	projectConfig *project.ProjectConfig,
 	projectManager project.ProjectManager,
-	env *environment.Environment,
 	envManager environment.Manager,
 	flags *envRefreshFlags,
 	console input.Console,
@@ -414,19 +989,46 @@ func newEnvRefreshAction(
 	writer io.Writer,
 ) actions.Action {
 	return &envRefreshAction{
-		provisionManager: provisionManager,
-		projectManager:   projectManager,
-		env:              env,
-		envManager:       envManager,
-		console:          console,
-		flags:            flags,
-		formatter:        formatter,
-		projectConfig:    projectConfig,
-		writer:           writer,
+		serviceLocator: serviceLocator,
+		projectManager: projectManager,
+		envManager:     envManager,
+		console:        console,
+		flags:          flags,
+		formatter:      formatter,
+		projectConfig:  projectConfig,
+		writer:         writer,
+	}
+}
+
+// loadEnvironment resolves the environment to refresh along with the provisioning manager bound to it.
+// When an environment name is given explicitly, it must already exist. Refreshing never creates a new environment
+// or changes the default environment.
+func (ef *envRefreshAction) loadEnvironment(ctx context.Context) error {
+	if name := ef.flags.environmentName; name != "" {
+		_, err := ef.envManager.Get(ctx, name)
+		if errors.Is(err, environment.ErrNotFound) {
+			return fmt.Errorf(
+				`environment '%s' does not exist. You can create it with "azd env new %s"`,
+				name,
+				name,
+			)
+		} else if err != nil {
+			return fmt.Errorf("loading environment '%s': %w", name, err)
+		}
+	}
+
+	if err := ef.serviceLocator.Resolve(&ef.env); err != nil {
+		return err
 	}
+
+	return ef.serviceLocator.Resolve(&ef.provisionManager)
 }
 
 func (ef *envRefreshAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if err := ef.loadEnvironment(ctx); err != nil {
+		return nil, err
+	}
+
 	// Command title
 	ef.console.MessageUxItem(ctx, &ux.MessageTitle{
 		Title: fmt.Sprintf("Refreshing environment %s (azd env refresh)", ef.env.GetEnvName()),
@@ -504,45 +1106,585 @@ func newEnvGetValuesCmd() *cobra.Command {
 }
 
 type envGetValuesFlags struct {
+	expand  bool
+	service string
+	require []string
+	reveal  bool
+	diff    string
 	envFlag
 	global *internal.GlobalCommandOptions
 }
 
 func (eg *envGetValuesFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(
+		&eg.expand,
+		"expand",
+		false,
+		"Expands dotted keys, like services.api.endpoint, into nested objects. Requires '--output json'.",
+	)
+	local.StringVar(
+		&eg.service,
+		"service",
+		"",
+		"Only gets the values of the specified service, the keys that start with SERVICE_<NAME>_.",
+	)
+	local.StringSliceVar(
+		&eg.require,
+		"require",
+		nil,
+		"Fails without printing the values when any of the specified keys, like KEY1,KEY2, is missing or empty.",
+	)
+	local.BoolVar(
+		&eg.reveal,
+		"reveal",
+		false,
+		"Prints the values of keys that look like secrets, like connection strings and passwords, instead of redacting them. "+
+			"Values are always printed with '--output env-export'.",
+	)
+	local.StringVar(
+		&eg.diff,
+		"diff",
+		"",
+		"Compares the values with the values of the specified environment, and prints the keys that differ.",
+	)
 	eg.envFlag.Bind(local, global)
 	eg.global = global
 }
 
 type envGetValuesAction struct {
-	azdCtx    *azdcontext.AzdContext
-	console   input.Console
-	env       *environment.Environment
-	formatter output.Formatter
-	writer    io.Writer
-	flags     *envGetValuesFlags
+	azdCtx            *azdcontext.AzdContext
+	console           input.Console
+	env               *environment.Environment
+	envManager        environment.Manager
+	lazyProjectConfig *lazy.Lazy[*project.ProjectConfig]
+	userConfigManager config.UserConfigManager
+	formatter         output.Formatter
+	writer            io.Writer
+	flags             *envGetValuesFlags
 }
 
 func newEnvGetValuesAction(
 	azdCtx *azdcontext.AzdContext,
 	env *environment.Environment,
+	envManager environment.Manager,
+	lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+	userConfigManager config.UserConfigManager,
 	console input.Console,
 	formatter output.Formatter,
 	writer io.Writer,
 	flags *envGetValuesFlags,
 ) actions.Action {
 	return &envGetValuesAction{
-		azdCtx:    azdCtx,
-		console:   console,
+		azdCtx:            azdCtx,
+		console:           console,
+		env:               env,
+		envManager:        envManager,
+		lazyProjectConfig: lazyProjectConfig,
+		userConfigManager: userConfigManager,
+		formatter:         formatter,
+		writer:            writer,
+		flags:             flags,
+	}
+}
+
+func (eg *envGetValuesAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	values, err := eg.values(eg.env)
+	if err != nil {
+		return nil, err
+	}
+
+	if missing := missingValues(values, eg.flags.require); len(missing) > 0 {
+		return nil, fmt.Errorf("required values are missing or empty: %s", strings.Join(missing, ", "))
+	}
+
+	if eg.flags.diff != "" {
+		return nil, eg.runDiff(ctx, values)
+	}
+
+	// The output of env-export is meant to be evaluated by a shell, so its values are never redacted
+	if !eg.flags.reveal && eg.formatter.Kind() != output.EnvExportFormat {
+		patterns, err := redactPatterns(eg.userConfigManager)
+		if err != nil {
+			return nil, err
+		}
+
+		values = redactValues(values, patterns)
+	}
+
+	if !eg.flags.expand {
+		if eg.formatter.Kind() == output.JsonFormat || eg.formatter.Kind() == output.YamlFormat {
+			structured := decodeListValues(values, eg.env.ListKeys())
+			if err := eg.formatter.Format(structured, eg.writer, nil); err != nil {
+				return nil, err
+			}
+
+			return nil, nil
+		}
+
+		if err := eg.formatter.Format(values, eg.writer, nil); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	if eg.formatter.Kind() != output.JsonFormat {
+		return nil, errors.New("'--expand' can only be used with '--output json'")
+	}
+
+	expanded, collisions := expandDottedKeys(decodeListValues(values, eg.env.ListKeys()))
+	if len(collisions) > 0 {
+		fmt.Fprintln(
+			eg.console.Handles().Stderr,
+			output.WithWarningFormat(
+				"WARNING: The following keys conflict with other keys when expanded and were kept unexpanded: %s",
+				strings.Join(collisions, ", "),
+			),
+		)
+	}
+
+	if err := eg.formatter.Format(expanded, eg.writer, nil); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// values returns the resolved values of env, only the values of the service set with `--service` when it's set.
+func (eg *envGetValuesAction) values(env *environment.Environment) (map[string]string, error) {
+	values := env.Dotenv()
+	if eg.flags.service != "" {
+		projectConfig, err := eg.lazyProjectConfig.GetValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if !projectConfig.HasService(eg.flags.service) {
+			return nil, unknownServiceError(projectConfig, eg.flags.service)
+		}
+
+		values = serviceValues(values, eg.flags.service, maps.Keys(projectConfig.Services))
+	}
+
+	return values, nil
+}
+
+// runDiff prints the differences between values and the values of the environment set with `--diff`.
+func (eg *envGetValuesAction) runDiff(ctx context.Context, values map[string]string) error {
+	if eg.flags.expand {
+		return errors.New("'--expand' can't be used with '--diff'")
+	}
+
+	if eg.formatter.Kind() == output.EnvExportFormat {
+		return fmt.Errorf("'--diff' can't be used with '--output %s'", output.EnvExportFormat)
+	}
+
+	otherEnv, err := eg.envManager.Get(ctx, eg.flags.diff)
+	if errors.Is(err, environment.ErrNotFound) {
+		return fmt.Errorf(
+			`environment '%s' does not exist. You can create it with "azd env new %s"`, eg.flags.diff, eg.flags.diff)
+	} else if err != nil {
+		return fmt.Errorf("loading environment '%s': %w", eg.flags.diff, err)
+	}
+
+	otherValues, err := eg.values(otherEnv)
+	if err != nil {
+		return fmt.Errorf("environment '%s': %w", eg.flags.diff, err)
+	}
+
+	diff := diffValues(values, otherValues)
+	diff.Environment = eg.env.GetEnvName()
+	diff.OtherEnvironment = otherEnv.GetEnvName()
+
+	if !eg.flags.reveal {
+		patterns, err := redactPatterns(eg.userConfigManager)
+		if err != nil {
+			return err
+		}
+
+		diff.redact(patterns)
+	}
+
+	if eg.formatter.Kind() == output.JsonFormat || eg.formatter.Kind() == output.YamlFormat {
+		return eg.formatter.Format(diff, eg.writer, nil)
+	}
+
+	return diff.write(eg.writer)
+}
+
+// envValuesDiff is the difference between the values of two environments, printed by `azd env get-values --diff`.
+type envValuesDiff struct {
+	Environment            string                    `json:"environment"            yaml:"environment"`
+	OtherEnvironment       string                    `json:"otherEnvironment"       yaml:"otherEnvironment"`
+	OnlyInEnvironment      map[string]string         `json:"onlyInEnvironment"      yaml:"onlyInEnvironment"`
+	OnlyInOtherEnvironment map[string]string         `json:"onlyInOtherEnvironment" yaml:"onlyInOtherEnvironment"`
+	Changed                map[string]envValueChange `json:"changed"                yaml:"changed"`
+}
+
+// envValueChange is a key whose value differs between two environments.
+type envValueChange struct {
+	Value      string `json:"value"      yaml:"value"`
+	OtherValue string `json:"otherValue" yaml:"otherValue"`
+}
+
+// diffValues returns the keys that are only set in values, only set in otherValues, or set to different values in both.
+// AZURE_ENV_NAME, which always differs between environments, isn't compared.
+func diffValues(values map[string]string, otherValues map[string]string) envValuesDiff {
+	diff := envValuesDiff{
+		OnlyInEnvironment:      map[string]string{},
+		OnlyInOtherEnvironment: map[string]string{},
+		Changed:                map[string]envValueChange{},
+	}
+
+	for key, value := range values {
+		if key == environment.EnvNameEnvVarName {
+			continue
+		}
+
+		otherValue, has := otherValues[key]
+		if !has {
+			diff.OnlyInEnvironment[key] = value
+		} else if value != otherValue {
+			diff.Changed[key] = envValueChange{Value: value, OtherValue: otherValue}
+		}
+	}
+
+	for key, otherValue := range otherValues {
+		if _, has := values[key]; !has && key != environment.EnvNameEnvVarName {
+			diff.OnlyInOtherEnvironment[key] = otherValue
+		}
+	}
+
+	return diff
+}
+
+// redact replaces the values of the keys matching any of the patterns, like redactValues. Changed values are compared
+// before they're redacted, so a changed secret is still reported.
+func (d *envValuesDiff) redact(patterns []*regexp.Regexp) {
+	d.OnlyInEnvironment = redactValues(d.OnlyInEnvironment, patterns)
+	d.OnlyInOtherEnvironment = redactValues(d.OnlyInOtherEnvironment, patterns)
+
+	for key, change := range d.Changed {
+		d.Changed[key] = envValueChange{
+			Value:      redactValues(map[string]string{key: change.Value}, patterns)[key],
+			OtherValue: redactValues(map[string]string{key: change.OtherValue}, patterns)[key],
+		}
+	}
+}
+
+// write prints the differences as text, grouped by kind and sorted by key.
+func (d *envValuesDiff) write(writer io.Writer) error {
+	var sb strings.Builder
+	if len(d.OnlyInEnvironment) == 0 && len(d.OnlyInOtherEnvironment) == 0 && len(d.Changed) == 0 {
+		fmt.Fprintf(&sb, "No differences between '%s' and '%s'.\n", d.Environment, d.OtherEnvironment)
+	}
+
+	writeOnlyIn := func(name string, values map[string]string) {
+		if len(values) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&sb, "Only in '%s':\n", name)
+		keys := maps.Keys(values)
+		slices.Sort(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&sb, "  %s=%q\n", key, values[key])
+		}
+	}
+
+	writeOnlyIn(d.Environment, d.OnlyInEnvironment)
+	writeOnlyIn(d.OtherEnvironment, d.OnlyInOtherEnvironment)
+
+	if len(d.Changed) > 0 {
+		sb.WriteString("Changed:\n")
+		keys := maps.Keys(d.Changed)
+		slices.Sort(keys)
+		for _, key := range keys {
+			change := d.Changed[key]
+			fmt.Fprintf(
+				&sb, "  %s: %q in '%s', %q in '%s'\n", key, change.Value, d.Environment, change.OtherValue, d.OtherEnvironment)
+		}
+	}
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+// decodeListValues returns values for structured output, where the values of the keys in lists, which
+// `azd env set --append` and `azd env set --list` store as a JSON array, are decoded to the array. Other values are kept
+// as they are, even when they look like a JSON array.
+func decodeListValues(values map[string]string, lists []string) map[string]any {
+	structured := make(map[string]any, len(values))
+	for key, value := range values {
+		structured[key] = value
+	}
+
+	for _, key := range lists {
+		value, has := values[key]
+		if !has {
+			continue
+		}
+
+		if items, isList := parseListValue(value); isList {
+			structured[key] = items
+		}
+	}
+
+	return structured
+}
+
+// redactedValue replaces the values redacted by `azd env get-values`.
+const redactedValue = "<redacted>"
+
+// redactPatternsConfigKey is the user configuration path of the patterns matching the keys redacted by
+// `azd env get-values`, which replace defaultRedactPatterns.
+const redactPatternsConfigKey = "env.redactPatterns"
+
+// defaultRedactPatterns match the keys of values that are commonly secrets, like connection strings, keys and passwords.
+var defaultRedactPatterns = []string{
+	"CONNECTION_?STRING",
+	"PASSWORD",
+	"SECRET",
+	"TOKEN",
+	"(^|_)KEY$",
+}
+
+// redactPatterns returns the patterns matching the keys whose values are redacted, from the user configuration when set
+// and otherwise the default patterns. Patterns are regular expressions matched against keys, ignoring case. The
+// configuration holds either a comma separated string, as set by `azd config set`, or a list.
+func redactPatterns(userConfigManager config.UserConfigManager) ([]*regexp.Regexp, error) {
+	patterns := defaultRedactPatterns
+
+	// Values are redacted with the default patterns when the user configuration can't be loaded
+	if userConfig, err := userConfigManager.Load(); err != nil {
+		log.Printf("using default redact patterns, loading user config failed: %v", err)
+	} else if configured, has := userConfig.Get(redactPatternsConfigKey); has {
+		patterns = nil
+		switch configured := configured.(type) {
+		case string:
+			patterns = strings.Split(configured, ",")
+		case []any:
+			for _, pattern := range configured {
+				patterns = append(patterns, fmt.Sprint(pattern))
+			}
+		default:
+			return nil, fmt.Errorf("'%s' must be a comma separated list of patterns", redactPatternsConfigKey)
+		}
+	}
+
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s' in '%s': %w", pattern, redactPatternsConfigKey, err)
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// redactValues returns a copy of values where the non-empty values of the keys matching any of the patterns are
+// replaced by redactedValue. Empty values are kept, so it stays visible which values are missing.
+func redactValues(values map[string]string, patterns []*regexp.Regexp) map[string]string {
+	redacted := make(map[string]string, len(values))
+	for key, value := range values {
+		redacted[key] = value
+		if value == "" {
+			continue
+		}
+
+		for _, pattern := range patterns {
+			if pattern.MatchString(key) {
+				redacted[key] = redactedValue
+				break
+			}
+		}
+	}
+
+	return redacted
+}
+
+// missingValues returns the required keys that have no value, or an empty value, sorted.
+func missingValues(values map[string]string, required []string) []string {
+	var missing []string
+	for _, key := range required {
+		key = strings.TrimSpace(key)
+		if key != "" && values[key] == "" && !slices.Contains(missing, key) {
+			missing = append(missing, key)
+		}
+	}
+
+	slices.Sort(missing)
+	return missing
+}
+
+// serviceValues returns the values of the service with the given name, which azd and templates store under keys that
+// start with SERVICE_<NAME>_, where <NAME> is the upper case service name with dashes replaced by underscores. Keys that
+// also match the longer prefix of another service, like SERVICE_API_GATEWAY_URL for the services api and api-gateway,
+// belong to that service.
+func serviceValues(values map[string]string, serviceName string, serviceNames []string) map[string]string {
+	servicePrefix := func(name string) string {
+		return fmt.Sprintf("SERVICE_%s_", strings.ReplaceAll(strings.ToUpper(name), "-", "_"))
+	}
+
+	prefix := servicePrefix(serviceName)
+	otherPrefixes := []string{}
+	for _, name := range serviceNames {
+		if other := servicePrefix(name); len(other) > len(prefix) && strings.HasPrefix(other, prefix) {
+			otherPrefixes = append(otherPrefixes, other)
+		}
+	}
+
+	filtered := map[string]string{}
+	for key, value := range values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if slices.ContainsFunc(otherPrefixes, func(other string) bool { return strings.HasPrefix(key, other) }) {
+			continue
+		}
+
+		filtered[key] = value
+	}
+
+	return filtered
+}
+
+// expandDottedKeys turns dotted keys, like services.api.endpoint, into a tree of nested objects. Keys without dots, and
+// keys with empty segments, stay top-level. Keys are expanded in sorted order, so when a key conflicts with the path of
+// a key expanded before it, for example a.b.c after a.b, the result is deterministic: the later key is kept top-level
+// under its full, unexpanded name and is returned in the list of collisions.
+func expandDottedKeys(values map[string]any) (map[string]any, []string) {
+	keys := maps.Keys(values)
+	slices.Sort(keys)
+
+	expanded := map[string]any{}
+	collisions := []string{}
+
+	for _, key := range keys {
+		segments := strings.Split(key, ".")
+		if len(segments) == 1 || slices.Contains(segments, "") {
+			expanded[key] = values[key]
+			continue
+		}
+
+		if !setExpandedValue(expanded, segments, values[key]) {
+			collisions = append(collisions, key)
+			expanded[key] = values[key]
+		}
+	}
+
+	return expanded, collisions
+}
+
+// setExpandedValue sets value at the path given by segments, creating the intermediate objects. It returns false, without
+// changing the tree, when the path passes through a value or ends at an existing entry.
+func setExpandedValue(tree map[string]any, segments []string, value any) bool {
+	// Check the path before creating any objects, so a conflicting key leaves the tree unchanged.
+	node := tree
+	for _, segment := range segments {
+		existing, has := node[segment]
+		if !has {
+			break
+		}
+
+		child, isObject := existing.(map[string]any)
+		if !isObject {
+			return false
+		}
+
+		node = child
+	}
+
+	node = tree
+	for _, segment := range segments[:len(segments)-1] {
+		child, has := node[segment].(map[string]any)
+		if !has {
+			child = map[string]any{}
+			node[segment] = child
+		}
+
+		node = child
+	}
+
+	last := segments[len(segments)-1]
+	if _, has := node[last]; has {
+		return false
+	}
+
+	node[last] = value
+	return true
+}
+
+func newEnvGetValueFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envGetValueFlags {
+	flags := &envGetValueFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvGetValueCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-value <keyName>",
+		Short: "Get a specific environment value.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+// envGetValueFlags only binds the --environment flag, which is read when resolving the environment of the action.
+type envGetValueFlags struct {
+	envFlag
+}
+
+func (eg *envGetValueFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	eg.envFlag.Bind(local, global)
+}
+
+type envGetValueAction struct {
+	env       *environment.Environment
+	formatter output.Formatter
+	writer    io.Writer
+	args      []string
+}
+
+func newEnvGetValueAction(
+	env *environment.Environment,
+	formatter output.Formatter,
+	writer io.Writer,
+	args []string,
+) actions.Action {
+	return &envGetValueAction{
 		env:       env,
 		formatter: formatter,
 		writer:    writer,
-		flags:     flags,
+		args:      args,
 	}
 }
 
-func (eg *envGetValuesAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	err := eg.formatter.Format(eg.env.Dotenv(), eg.writer, nil)
-	if err != nil {
+func (eg *envGetValueAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	keyName := eg.args[0]
+
+	// Only values from the .env file are considered, unlike LookupEnv which falls back to the process environment
+	value, has := eg.env.Dotenv()[keyName]
+	if !has {
+		return nil, fmt.Errorf("key '%s' not found in the environment values of '%s'", keyName, eg.env.GetEnvName())
+	}
+
+	// Plain values are written without quoting, so they can be consumed directly by shell scripts
+	if eg.formatter.Kind() == output.NoneFormat {
+		_, err := fmt.Fprintln(eg.writer, value)
+		return nil, err
+	}
+
+	if err := eg.formatter.Format(value, eg.writer, nil); err != nil {
 		return nil, err
 	}
 
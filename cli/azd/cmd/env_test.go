@@ -0,0 +1,854 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockarmresources"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockconfig"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EnvNewAction(t *testing.T) {
+	newEnvManager := func() *mockenv.MockEnvManager {
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Create", mock.Anything, mock.Anything).Return(environment.New("env2"), nil)
+
+		return envManager
+	}
+
+	t.Run("SetsDefault", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		require.NoError(t, azdCtx.SetDefaultEnvironmentName("env1"))
+
+		action := newEnvNewAction(azdCtx, newEnvManager(), &envNewFlags{}, []string{"env2"}, mockContext.Console)
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+
+		defaultEnvName, err := azdCtx.GetDefaultEnvironmentName()
+		require.NoError(t, err)
+		require.Equal(t, "env2", defaultEnvName)
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		require.NoError(t, azdCtx.SetDefaultEnvironmentName("env1"))
+
+		envManager := newEnvManager()
+		flags := &envNewFlags{noDefault: true}
+		action := newEnvNewAction(azdCtx, envManager, flags, []string{"env2"}, mockContext.Console)
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+
+		envManager.AssertCalled(t, "Create", mock.Anything, environment.Spec{Name: "env2"})
+
+		defaultEnvName, err := azdCtx.GetDefaultEnvironmentName()
+		require.NoError(t, err)
+		require.Equal(t, "env1", defaultEnvName)
+	})
+}
+
+func Test_EnvSetAction_Lists(t *testing.T) {
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	run := func(env *environment.Environment, flags *envSetFlags, args ...string) error {
+		mockContext := mocks.NewMockContext(context.Background())
+		action := newEnvSetAction(nil, env, envManager, mockContext.Console, flags, args)
+		_, err := action.Run(*mockContext.Context)
+		return err
+	}
+
+	t.Run("Append", func(t *testing.T) {
+		env := environment.New("test")
+		require.NoError(t, run(env, &envSetFlags{append: "https://contoso.com"}, "ALLOWED_ORIGINS"))
+		require.NoError(t, run(env, &envSetFlags{append: "http://localhost:3000"}, "ALLOWED_ORIGINS"))
+		require.Equal(t, `["https://contoso.com","http://localhost:3000"]`, env.Getenv("ALLOWED_ORIGINS"))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"ALLOWED_ORIGINS": `["https://contoso.com"]`})
+		require.NoError(t, run(env, &envSetFlags{list: []string{"a", "b", "c"}}, "ALLOWED_ORIGINS"))
+		require.Equal(t, `["a","b","c"]`, env.Getenv("ALLOWED_ORIGINS"))
+	})
+
+	t.Run("ExistingScalar", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"ALLOWED_ORIGINS": "https://contoso.com"})
+		err := run(env, &envSetFlags{append: "http://localhost:3000"}, "ALLOWED_ORIGINS")
+		require.EqualError(t, err, "the value of 'ALLOWED_ORIGINS' isn't a list, use '--force' to replace it with a list")
+		require.Equal(t, "https://contoso.com", env.Getenv("ALLOWED_ORIGINS"))
+
+		require.NoError(t, run(env, &envSetFlags{append: "http://localhost:3000", force: true}, "ALLOWED_ORIGINS"))
+		require.Equal(t, `["http://localhost:3000"]`, env.Getenv("ALLOWED_ORIGINS"))
+	})
+
+	t.Run("InvalidArgs", func(t *testing.T) {
+		env := environment.New("test")
+		require.Error(t, run(env, &envSetFlags{append: "a", list: []string{"b"}}, "KEY"))
+		require.Error(t, run(env, &envSetFlags{append: "a"}, "KEY", "value"))
+		require.Error(t, run(env, &envSetFlags{}, "KEY"))
+	})
+
+	t.Run("GetValuesRoundTrip", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{
+			"AZURE_LOCATION": "eastus2",
+			"SCOPES":         `["read"]`,
+		})
+		require.NoError(t, run(env, &envSetFlags{list: []string{"a", "b"}}, "ALLOWED_ORIGINS"))
+
+		buf := &strings.Builder{}
+		action := newEnvGetValuesAction(
+			nil,
+			env,
+			nil,
+			nil,
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager()),
+			mocks.NewMockContext(context.Background()).Console,
+			&output.JsonFormatter{},
+			buf,
+			&envGetValuesFlags{},
+		)
+		_, err := action.Run(context.Background())
+		require.NoError(t, err)
+
+		// Only the values stored as lists are decoded, other values that look like a JSON array stay strings
+		require.JSONEq(t,
+			`{"AZURE_ENV_NAME": "test", "AZURE_LOCATION": "eastus2", "ALLOWED_ORIGINS": ["a", "b"], "SCOPES": "[\"read\"]"}`,
+			buf.String(),
+		)
+	})
+}
+
+func Test_EnvSetRemoteAction(t *testing.T) {
+	newAction := func(
+		mockContext *mocks.MockContext,
+		env *environment.Environment,
+		envManager environment.Manager,
+	) actions.Action {
+		flags := &envSetRemoteFlags{
+			backend:       string(environment.RemoteKindAzureBlobStorage),
+			accountName:   "myaccount",
+			containerName: "mycontainer",
+			global:        &internal.GlobalCommandOptions{NoPrompt: true},
+		}
+
+		return newEnvSetRemoteAction(
+			env,
+			envManager,
+			mockContext.Credentials,
+			mockContext.HttpClient,
+			httputil.UserAgent("azdev"),
+			mockContext.Console,
+			flags,
+		)
+	}
+
+	listContainers := func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			request.URL.Host == "myaccount.blob.core.windows.net" &&
+			request.URL.Query().Get("comp") == "list"
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		env := environment.New("test")
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, env).Return(nil)
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.HttpClient.When(listContainers).RespondFn(func(request *http.Request) (*http.Response, error) {
+			response, err := mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+			response.Header.Set("Content-Type", "application/xml")
+			response.Body = io.NopCloser(strings.NewReader(
+				`<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Containers /></EnumerationResults>`))
+			return response, err
+		})
+
+		_, err := newAction(mockContext, env, envManager).Run(*mockContext.Context)
+		require.NoError(t, err)
+		envManager.AssertCalled(t, "Save", mock.Anything, env)
+
+		backend, _ := env.Config.Get("state.remote.backend")
+		require.Equal(t, string(environment.RemoteKindAzureBlobStorage), backend)
+		accountName, _ := env.Config.Get("state.remote.config.accountName")
+		require.Equal(t, "myaccount", accountName)
+		containerName, _ := env.Config.Get("state.remote.config.containerName")
+		require.Equal(t, "mycontainer", containerName)
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		env := environment.New("test")
+		envManager := &mockenv.MockEnvManager{}
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.HttpClient.When(listContainers).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateEmptyHttpResponse(request, http.StatusForbidden)
+		})
+
+		_, err := newAction(mockContext, env, envManager).Run(*mockContext.Context)
+		require.ErrorContains(t, err, "connecting to storage account 'myaccount'")
+		envManager.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+
+		_, has := env.Config.Get("state.remote")
+		require.False(t, has)
+	})
+}
+
+func Test_envRemoteStateConfig(t *testing.T) {
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+
+	envConfig := config.NewEmptyConfig()
+	require.NoError(t, envConfig.Set("state.remote", map[string]any{
+		"backend": "AzureBlobStorage",
+		"config":  map[string]any{"accountName": "myaccount"},
+	}))
+	require.NoError(t, fileConfigManager.Save(
+		envConfig, filepath.Join(azdContext.EnvironmentRoot("dev"), environment.ConfigFileName)))
+
+	t.Run("Environment", func(t *testing.T) {
+		remoteConfig, err := envRemoteStateConfig(azdContext, "dev", fileConfigManager)
+		require.NoError(t, err)
+		require.Equal(t, "AzureBlobStorage", remoteConfig.Backend)
+		require.Equal(t, "myaccount", remoteConfig.Config["accountName"])
+	})
+
+	t.Run("DefaultEnvironment", func(t *testing.T) {
+		require.NoError(t, azdContext.SetDefaultEnvironmentName("dev"))
+
+		remoteConfig, err := envRemoteStateConfig(azdContext, "", fileConfigManager)
+		require.NoError(t, err)
+		require.Equal(t, "AzureBlobStorage", remoteConfig.Backend)
+	})
+
+	t.Run("NotSet", func(t *testing.T) {
+		remoteConfig, err := envRemoteStateConfig(azdContext, "prod", fileConfigManager)
+		require.NoError(t, err)
+		require.Nil(t, remoteConfig)
+	})
+}
+
+func Test_EnvGetValueAction(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"AZURE_LOCATION": "westus2",
+		"MESSAGE":        "hello \"world\"",
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		buf := &strings.Builder{}
+		action := newEnvGetValueAction(env, &output.NoneFormatter{}, buf, []string{"MESSAGE"})
+		_, err := action.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "hello \"world\"\n", buf.String())
+	})
+
+	t.Run("Json", func(t *testing.T) {
+		buf := &strings.Builder{}
+		action := newEnvGetValueAction(env, &output.JsonFormatter{}, buf, []string{"MESSAGE"})
+		_, err := action.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "\"hello \\\"world\\\"\"\n", buf.String())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		buf := &strings.Builder{}
+		action := newEnvGetValueAction(env, &output.NoneFormatter{}, buf, []string{"MISSING"})
+		_, err := action.Run(context.Background())
+		require.ErrorContains(t, err, "key 'MISSING' not found")
+		require.Empty(t, buf.String())
+	})
+}
+
+func Test_expandDottedKeys(t *testing.T) {
+	expanded, collisions := expandDottedKeys(map[string]any{
+		"AZURE_LOCATION":            "eastus2",
+		"services.api.endpoint":     "https://api.contoso.com",
+		"services.api.name":         "api",
+		"services.web.endpoint":     "https://web.contoso.com",
+		"services.web.endpoint.url": "https://collision.contoso.com",
+		"services.web":              "collision",
+		"trailing.":                 "kept",
+	})
+
+	require.Equal(t, map[string]any{
+		"AZURE_LOCATION": "eastus2",
+		"services": map[string]any{
+			"api": map[string]any{
+				"endpoint": "https://api.contoso.com",
+				"name":     "api",
+			},
+			"web": "collision",
+		},
+		"services.web.endpoint":     "https://web.contoso.com",
+		"services.web.endpoint.url": "https://collision.contoso.com",
+		"trailing.":                 "kept",
+	}, expanded)
+	require.Equal(t, []string{"services.web.endpoint", "services.web.endpoint.url"}, collisions)
+}
+
+func Test_EnvGetValuesAction_Require(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"AZURE_LOCATION":           "eastus2",
+		"SERVICE_API_ENDPOINT_URL": "https://api.contoso.com",
+		"SERVICE_WEB_ENDPOINT_URL": "",
+	})
+
+	run := func(required ...string) (string, error) {
+		buf := &strings.Builder{}
+		action := newEnvGetValuesAction(
+			nil,
+			env,
+			nil,
+			nil,
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager()),
+			mocks.NewMockContext(context.Background()).Console,
+			&output.JsonFormatter{},
+			buf,
+			&envGetValuesFlags{require: required},
+		)
+		_, err := action.Run(context.Background())
+		return buf.String(), err
+	}
+
+	t.Run("Present", func(t *testing.T) {
+		result, err := run("AZURE_LOCATION", "SERVICE_API_ENDPOINT_URL")
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"AZURE_ENV_NAME": "test",
+			"AZURE_LOCATION": "eastus2",
+			"SERVICE_API_ENDPOINT_URL": "https://api.contoso.com",
+			"SERVICE_WEB_ENDPOINT_URL": ""
+		}`, result)
+	})
+
+	t.Run("MissingOrEmpty", func(t *testing.T) {
+		result, err := run("SERVICE_WEB_ENDPOINT_URL", "AZURE_LOCATION", "AZURE_SUBSCRIPTION_ID")
+		require.EqualError(t, err, "required values are missing or empty: AZURE_SUBSCRIPTION_ID, SERVICE_WEB_ENDPOINT_URL")
+
+		// Nothing is printed when a required value is missing
+		require.Empty(t, result)
+	})
+}
+
+func Test_EnvGetValuesAction_Service(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"AZURE_LOCATION":                "eastus2",
+		"SERVICE_API_ENDPOINT_URL":      "https://api.contoso.com",
+		"SERVICE_API_IMAGE_NAME":        "api:latest",
+		"SERVICE_API_GATEWAY_ENDPOINTS": "https://gateway.contoso.com",
+	})
+
+	lazyProjectConfig := lazy.NewLazy(func() (*project.ProjectConfig, error) {
+		return &project.ProjectConfig{
+			Services: map[string]*project.ServiceConfig{
+				"api":         {Name: "api"},
+				"api-gateway": {Name: "api-gateway"},
+				"web":         {Name: "web"},
+			},
+		}, nil
+	})
+
+	run := func(service string) (string, error) {
+		buf := &strings.Builder{}
+		action := newEnvGetValuesAction(
+			nil,
+			env,
+			nil,
+			lazyProjectConfig,
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager()),
+			mocks.NewMockContext(context.Background()).Console,
+			&output.JsonFormatter{},
+			buf,
+			&envGetValuesFlags{service: service},
+		)
+		_, err := action.Run(context.Background())
+		return buf.String(), err
+	}
+
+	t.Run("Service", func(t *testing.T) {
+		result, err := run("api")
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"SERVICE_API_ENDPOINT_URL": "https://api.contoso.com",
+			"SERVICE_API_IMAGE_NAME": "api:latest"
+		}`, result)
+	})
+
+	t.Run("ServiceWithDashes", func(t *testing.T) {
+		result, err := run("api-gateway")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"SERVICE_API_GATEWAY_ENDPOINTS": "https://gateway.contoso.com"}`, result)
+	})
+
+	t.Run("NoValues", func(t *testing.T) {
+		result, err := run("web")
+		require.NoError(t, err)
+		require.JSONEq(t, `{}`, result)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		_, err := run("worker")
+		require.EqualError(t, err, "service name 'worker' doesn't exist, valid service names are: api, api-gateway, web")
+	})
+}
+
+func Test_EnvGetValuesAction_Redact(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"AZURE_LOCATION":                  "eastus2",
+		"AZURE_STORAGE_CONNECTION_STRING": "DefaultEndpointsProtocol=https;AccountKey=secret",
+		"AZURE_STORAGE_ACCOUNT_KEY":       "secret",
+		"DATABASE_PASSWORD":               "secret",
+		"API_CLIENT_SECRET":               "",
+		"SERVICE_API_ENDPOINT_URL":        "https://api.contoso.com",
+	})
+
+	run := func(userConfig config.Config, flags *envGetValuesFlags) (map[string]string, error) {
+		buf := &strings.Builder{}
+		action := newEnvGetValuesAction(
+			nil,
+			env,
+			nil,
+			nil,
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager().WithConfig(userConfig)),
+			mocks.NewMockContext(context.Background()).Console,
+			&output.JsonFormatter{},
+			buf,
+			flags,
+		)
+		if _, err := action.Run(context.Background()); err != nil {
+			return nil, err
+		}
+
+		values := map[string]string{}
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &values))
+		return values, nil
+	}
+
+	t.Run("DefaultPatterns", func(t *testing.T) {
+		values, err := run(config.NewEmptyConfig(), &envGetValuesFlags{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"AZURE_ENV_NAME":                  "test",
+			"AZURE_LOCATION":                  "eastus2",
+			"AZURE_STORAGE_CONNECTION_STRING": redactedValue,
+			"AZURE_STORAGE_ACCOUNT_KEY":       redactedValue,
+			"DATABASE_PASSWORD":               redactedValue,
+			"API_CLIENT_SECRET":               "",
+			"SERVICE_API_ENDPOINT_URL":        "https://api.contoso.com",
+		}, values)
+	})
+
+	t.Run("Reveal", func(t *testing.T) {
+		values, err := run(config.NewEmptyConfig(), &envGetValuesFlags{reveal: true})
+		require.NoError(t, err)
+		require.Equal(t, "secret", values["DATABASE_PASSWORD"])
+		require.Equal(t, "DefaultEndpointsProtocol=https;AccountKey=secret", values["AZURE_STORAGE_CONNECTION_STRING"])
+	})
+
+	t.Run("ConfiguredPatterns", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(redactPatternsConfigKey, "password, ^SERVICE_.*_URL$"))
+
+		values, err := run(userConfig, &envGetValuesFlags{})
+		require.NoError(t, err)
+		require.Equal(t, redactedValue, values["DATABASE_PASSWORD"])
+		require.Equal(t, redactedValue, values["SERVICE_API_ENDPOINT_URL"])
+		require.Equal(t, "secret", values["AZURE_STORAGE_ACCOUNT_KEY"])
+	})
+
+	t.Run("ConfiguredList", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(redactPatternsConfigKey, []any{"LOCATION"}))
+
+		values, err := run(userConfig, &envGetValuesFlags{})
+		require.NoError(t, err)
+		require.Equal(t, redactedValue, values["AZURE_LOCATION"])
+		require.Equal(t, "secret", values["DATABASE_PASSWORD"])
+	})
+
+	t.Run("InvalidPattern", func(t *testing.T) {
+		userConfig := config.NewEmptyConfig()
+		require.NoError(t, userConfig.Set(redactPatternsConfigKey, "KEY("))
+
+		_, err := run(userConfig, &envGetValuesFlags{})
+		require.ErrorContains(t, err, redactPatternsConfigKey)
+	})
+}
+
+func Test_EnvSetAction_References(t *testing.T) {
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	set := func(env *environment.Environment, flags *envSetFlags, args ...string) error {
+		mockContext := mocks.NewMockContext(context.Background())
+		action := newEnvSetAction(nil, env, envManager, mockContext.Console, flags, args)
+		_, err := action.Run(*mockContext.Context)
+		return err
+	}
+
+	getValues := func(env *environment.Environment) (map[string]string, error) {
+		buf := &strings.Builder{}
+		action := newEnvGetValuesAction(
+			nil,
+			env,
+			nil,
+			nil,
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager()),
+			mocks.NewMockContext(context.Background()).Console,
+			&output.JsonFormatter{},
+			buf,
+			&envGetValuesFlags{},
+		)
+		if _, err := action.Run(context.Background()); err != nil {
+			return nil, err
+		}
+
+		values := map[string]string{}
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &values))
+		return values, nil
+	}
+
+	t.Run("ResolvedOnRead", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"WEB_HOSTNAME": "web.azurewebsites.net"})
+		require.NoError(t, set(env, &envSetFlags{}, "URL", "https://${WEB_HOSTNAME}/api"))
+		require.Equal(t, "https://web.azurewebsites.net/api", env.Getenv("URL"))
+
+		values, err := getValues(env)
+		require.NoError(t, err)
+		require.Equal(t, "https://web.azurewebsites.net/api", values["URL"])
+
+		// Later changes to the referenced value are picked up
+		env.DotenvSet("WEB_HOSTNAME", "contoso.com")
+		values, err = getValues(env)
+		require.NoError(t, err)
+		require.Equal(t, "https://contoso.com/api", values["URL"])
+
+		buf := &strings.Builder{}
+		action := newEnvGetValueAction(env, &output.NoneFormatter{}, buf, []string{"URL"})
+		_, err = action.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "https://contoso.com/api\n", buf.String())
+	})
+
+	t.Run("ResolveNow", func(t *testing.T) {
+		env := environment.NewWithValues("test", map[string]string{"WEB_HOSTNAME": "web.azurewebsites.net"})
+		require.NoError(t, set(env, &envSetFlags{resolveNow: true}, "URL", "https://${WEB_HOSTNAME}/api"))
+		require.Equal(t, "https://web.azurewebsites.net/api", env.Getenv("URL"))
+
+		err := set(env, &envSetFlags{resolveNow: true}, "OTHER", "${MISSING}")
+		require.ErrorContains(t, err, "'MISSING', which is not set")
+		require.Empty(t, env.Getenv("OTHER"))
+
+		require.Error(t, set(env, &envSetFlags{resolveNow: true, append: "a"}, "LIST"))
+	})
+
+	t.Run("UndefinedOnSet", func(t *testing.T) {
+		env := environment.NewWithValues("test", nil)
+		err := set(env, &envSetFlags{}, "URL", "https://${WEB_HOSTNAME}/api")
+		require.ErrorContains(t, err, "'URL' references 'WEB_HOSTNAME', which is not set")
+	})
+
+	t.Run("PlainValuesOnRead", func(t *testing.T) {
+		// Values not stored by `azd env set` are written as they are, even when they look like references
+		env := environment.NewWithValues("test", map[string]string{
+			"SCRIPT": "echo ${HOME}",
+			"ESCAPE": "$${NAME}",
+		})
+		values, err := getValues(env)
+		require.NoError(t, err)
+		require.Equal(t, "echo ${HOME}", values["SCRIPT"])
+		require.Equal(t, "$${NAME}", values["ESCAPE"])
+
+		buf := &strings.Builder{}
+		action := newEnvGetValueAction(env, &output.NoneFormatter{}, buf, []string{"SCRIPT"})
+		_, err = action.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "echo ${HOME}\n", buf.String())
+	})
+}
+
+func Test_EnvGetValuesAction_EnvExport(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"AZURE_LOCATION":    "eastus2",
+		"DATABASE_PASSWORD": "secret",
+	})
+	require.NoError(t, env.DotenvSetReferences("GREETING", "it's ${AZURE_LOCATION}"))
+
+	buf := &strings.Builder{}
+	action := newEnvGetValuesAction(
+		nil,
+		env,
+		nil,
+		nil,
+		config.NewUserConfigManager(mockconfig.NewMockConfigManager()),
+		mocks.NewMockContext(context.Background()).Console,
+		&output.EnvExportFormatter{},
+		buf,
+		&envGetValuesFlags{},
+	)
+	_, err := action.Run(context.Background())
+	require.NoError(t, err)
+
+	// Values that look like secrets are exported without --reveal, since the output is evaluated by a shell
+	require.Equal(t,
+		"export AZURE_ENV_NAME='test'\nexport AZURE_LOCATION='eastus2'\nexport DATABASE_PASSWORD='secret'\n"+
+			"export GREETING='it'\\''s eastus2'\n",
+		buf.String(),
+	)
+}
+
+func Test_EnvGetValuesAction_Diff(t *testing.T) {
+	staging := environment.NewWithValues("staging", map[string]string{
+		"AZURE_LOCATION":    "eastus2",
+		"API_URL":           "https://staging.contoso.com",
+		"DATABASE_PASSWORD": "staging-secret",
+		"STAGING_ONLY":      "yes",
+	})
+	prod := environment.NewWithValues("prod", map[string]string{
+		"AZURE_LOCATION":    "eastus2",
+		"API_URL":           "https://contoso.com",
+		"DATABASE_PASSWORD": "prod-secret",
+		"PROD_ONLY":         "yes",
+	})
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Get", mock.Anything, "prod").Return(prod, nil)
+	envManager.On("Get", mock.Anything, "missing").Return((*environment.Environment)(nil), environment.ErrNotFound)
+
+	run := func(formatter output.Formatter, flags *envGetValuesFlags) (string, error) {
+		buf := &strings.Builder{}
+		action := newEnvGetValuesAction(
+			nil,
+			staging,
+			envManager,
+			nil,
+			config.NewUserConfigManager(mockconfig.NewMockConfigManager()),
+			mocks.NewMockContext(context.Background()).Console,
+			formatter,
+			buf,
+			flags,
+		)
+		_, err := action.Run(context.Background())
+		return buf.String(), err
+	}
+
+	t.Run("Json", func(t *testing.T) {
+		out, err := run(&output.JsonFormatter{}, &envGetValuesFlags{diff: "prod"})
+		require.NoError(t, err)
+
+		var diff envValuesDiff
+		require.NoError(t, json.Unmarshal([]byte(out), &diff))
+		require.Equal(t, envValuesDiff{
+			Environment:            "staging",
+			OtherEnvironment:       "prod",
+			OnlyInEnvironment:      map[string]string{"STAGING_ONLY": "yes"},
+			OnlyInOtherEnvironment: map[string]string{"PROD_ONLY": "yes"},
+			Changed: map[string]envValueChange{
+				"API_URL":           {Value: "https://staging.contoso.com", OtherValue: "https://contoso.com"},
+				"DATABASE_PASSWORD": {Value: redactedValue, OtherValue: redactedValue},
+			},
+		}, diff)
+	})
+
+	t.Run("Reveal", func(t *testing.T) {
+		out, err := run(&output.JsonFormatter{}, &envGetValuesFlags{diff: "prod", reveal: true})
+		require.NoError(t, err)
+
+		var diff envValuesDiff
+		require.NoError(t, json.Unmarshal([]byte(out), &diff))
+		require.Equal(t,
+			envValueChange{Value: "staging-secret", OtherValue: "prod-secret"}, diff.Changed["DATABASE_PASSWORD"])
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		out, err := run(&output.EnvVarsFormatter{}, &envGetValuesFlags{diff: "prod"})
+		require.NoError(t, err)
+		require.Equal(t,
+			"Only in 'staging':\n"+
+				"  STAGING_ONLY=\"yes\"\n"+
+				"Only in 'prod':\n"+
+				"  PROD_ONLY=\"yes\"\n"+
+				"Changed:\n"+
+				"  API_URL: \"https://staging.contoso.com\" in 'staging', \"https://contoso.com\" in 'prod'\n"+
+				"  DATABASE_PASSWORD: \"<redacted>\" in 'staging', \"<redacted>\" in 'prod'\n",
+			out,
+		)
+	})
+
+	t.Run("NoDifferences", func(t *testing.T) {
+		require.Equal(t, envValuesDiff{
+			OnlyInEnvironment:      map[string]string{},
+			OnlyInOtherEnvironment: map[string]string{},
+			Changed:                map[string]envValueChange{},
+		}, diffValues(
+			map[string]string{"AZURE_ENV_NAME": "staging", "KEY": "value"},
+			map[string]string{"AZURE_ENV_NAME": "prod", "KEY": "value"},
+		))
+	})
+
+	t.Run("MissingEnvironment", func(t *testing.T) {
+		_, err := run(&output.JsonFormatter{}, &envGetValuesFlags{diff: "missing"})
+		require.ErrorContains(t, err, "environment 'missing' does not exist")
+	})
+}
+
+func Test_EnvRenameAction(t *testing.T) {
+	newAction := func(
+		mockContext *mocks.MockContext, values map[string]string) (*envRenameAction, *mockenv.MockEnvManager) {
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Get", mock.Anything, "env1").Return(environment.NewWithValues("env1", values), nil)
+		envManager.On("Rename", mock.Anything, "env1", "env2").Return(nil)
+
+		action := newEnvRenameAction(
+			envManager, mockazcli.NewAzCliFromMockContext(mockContext), []string{"env1", "env2"})
+		return action.(*envRenameAction), envManager
+	}
+
+	t.Run("NotProvisioned", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		action, envManager := newAction(mockContext, nil)
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		envManager.AssertCalled(t, "Rename", mock.Anything, "env1", "env2")
+	})
+
+	t.Run("NoTaggedResources", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockarmresources.AddResourceGroupListMock(mockContext.HttpClient, "SUBSCRIPTION_ID", nil)
+		mockarmresources.AddAzResourceListMock(mockContext.HttpClient, convert.RefOf("rg-env1"), nil)
+		action, envManager := newAction(mockContext, map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+			environment.ResourceGroupEnvVarName:  "rg-env1",
+		})
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		envManager.AssertCalled(t, "Rename", mock.Anything, "env1", "env2")
+	})
+
+	t.Run("TaggedResourceGroup", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockarmresources.AddResourceGroupListMock(mockContext.HttpClient, "SUBSCRIPTION_ID", []*armresources.ResourceGroup{
+			{
+				ID:       convert.RefOf("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-env1"),
+				Name:     convert.RefOf("rg-env1"),
+				Type:     convert.RefOf("Microsoft.Resources/resourceGroups"),
+				Location: convert.RefOf("eastus2"),
+			},
+		})
+		action, envManager := newAction(mockContext, map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		})
+
+		_, err := action.Run(*mockContext.Context)
+		require.ErrorContains(t, err, "has provisioned resources tagged with 'azd-env-name=env1'")
+		envManager.AssertNotCalled(t, "Rename", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("TaggedResources", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockarmresources.AddResourceGroupListMock(mockContext.HttpClient, "SUBSCRIPTION_ID", nil)
+		mockarmresources.AddAzResourceListMock(
+			mockContext.HttpClient,
+			convert.RefOf("rg-shared"),
+			[]*armresources.GenericResourceExpanded{
+				{
+					ID:       convert.RefOf("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-shared/providers/Microsoft.Web/sites/web"),
+					Name:     convert.RefOf("web"),
+					Type:     convert.RefOf("Microsoft.Web/sites"),
+					Location: convert.RefOf("eastus2"),
+					Tags:     map[string]*string{azure.TagKeyAzdEnvName: convert.RefOf("env1")},
+				},
+			},
+		)
+		action, envManager := newAction(mockContext, map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+			environment.ResourceGroupEnvVarName:  "rg-shared",
+		})
+
+		_, err := action.Run(*mockContext.Context)
+		require.ErrorContains(t, err, "Run 'azd down'")
+		envManager.AssertNotCalled(t, "Rename", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_EnvRefreshAction_MissingEnvironment(t *testing.T) {
+	newAction := func(mockContext *mocks.MockContext, envManager environment.Manager, envName string) *envRefreshAction {
+		action := newEnvRefreshAction(
+			mockContext.Container,
+			&project.ProjectConfig{},
+			nil,
+			envManager,
+			&envRefreshFlags{envFlag: envFlag{environmentName: envName}},
+			mockContext.Console,
+			&output.JsonFormatter{},
+			io.Discard,
+		)
+
+		return action.(*envRefreshAction)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Get", mock.Anything, "missing").Return((*environment.Environment)(nil), environment.ErrNotFound)
+
+		action := newAction(mockContext, envManager, "missing")
+		_, err := action.Run(*mockContext.Context)
+		require.EqualError(
+			t, err, `environment 'missing' does not exist. You can create it with "azd env new missing"`)
+
+		// The environment isn't created
+		require.Nil(t, action.env)
+		envManager.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("LoadError", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Get", mock.Anything, "dev").Return((*environment.Environment)(nil), errors.New("access denied"))
+
+		_, err := newAction(mockContext, envManager, "dev").Run(*mockContext.Context)
+		require.EqualError(t, err, "loading environment 'dev': access denied")
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := environment.New("dev")
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Get", mock.Anything, "dev").Return(env, nil)
+		ioc.RegisterInstance(mockContext.Container, env)
+		ioc.RegisterInstance(mockContext.Container, &provisioning.Manager{})
+
+		action := newAction(mockContext, envManager, "dev")
+		require.NoError(t, action.loadEnvironment(*mockContext.Context))
+		require.Same(t, env, action.env)
+		require.NotNil(t, action.provisionManager)
+	})
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
@@ -16,6 +17,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 func hooksActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
@@ -118,6 +121,10 @@ func (hra *hooksRunAction) Run(ctx context.Context) (*actions.ActionResult, erro
 		return nil, fmt.Errorf("service name '%s' doesn't exist", hra.flags.service)
 	}
 
+	if err := validateHookName(hra.projectConfig, hra.flags.service, hookName); err != nil {
+		return nil, err
+	}
+
 	// Project level hooks
 	if err := hra.processHooks(
 		ctx,
@@ -155,6 +162,42 @@ func (hra *hooksRunAction) Run(ctx context.Context) (*actions.ActionResult, erro
 	}, nil
 }
 
+// validateHookName returns an error listing the available hooks when hookName isn't defined for the project, or for any
+// service that the hooks run for.
+func validateHookName(projectConfig *project.ProjectConfig, serviceName string, hookName string) error {
+	hookNames := map[string]struct{}{}
+	for name := range projectConfig.Hooks {
+		hookNames[name] = struct{}{}
+	}
+
+	for _, service := range projectConfig.Services {
+		if serviceName != "" && service.Name != serviceName {
+			continue
+		}
+
+		for name := range service.Hooks {
+			hookNames[name] = struct{}{}
+		}
+	}
+
+	if _, has := hookNames[hookName]; has {
+		return nil
+	}
+
+	if len(hookNames) == 0 {
+		return fmt.Errorf("hook '%s' is not defined, the project doesn't define any hooks", hookName)
+	}
+
+	available := maps.Keys(hookNames)
+	slices.Sort(available)
+
+	return fmt.Errorf(
+		"hook '%s' is not defined, available hooks are: %s",
+		hookName,
+		strings.Join(available, ", "),
+	)
+}
+
 func (hra *hooksRunAction) processHooks(
 	ctx context.Context,
 	cwd string,
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateHookName(t *testing.T) {
+	projectConfig := &project.ProjectConfig{
+		Hooks: map[string]*ext.HookConfig{
+			"preprovision": {},
+		},
+		Services: map[string]*project.ServiceConfig{
+			"api": {
+				Name:  "api",
+				Hooks: map[string]*ext.HookConfig{"predeploy": {}},
+			},
+			"web": {
+				Name:  "web",
+				Hooks: map[string]*ext.HookConfig{"postdeploy": {}},
+			},
+		},
+	}
+
+	require.NoError(t, validateHookName(projectConfig, "", "preprovision"))
+	require.NoError(t, validateHookName(projectConfig, "", "postdeploy"))
+	require.NoError(t, validateHookName(projectConfig, "api", "predeploy"))
+
+	err := validateHookName(projectConfig, "", "prepackage")
+	require.EqualError(t, err, "hook 'prepackage' is not defined, available hooks are: postdeploy, predeploy, preprovision")
+
+	err = validateHookName(projectConfig, "api", "postdeploy")
+	require.EqualError(t, err, "hook 'postdeploy' is not defined, available hooks are: predeploy, preprovision")
+
+	err = validateHookName(&project.ProjectConfig{}, "", "preprovision")
+	require.EqualError(t, err, "hook 'preprovision' is not defined, the project doesn't define any hooks")
+}
@@ -3,9 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/spf13/cobra"
@@ -13,11 +15,25 @@ import (
 
 type infraCreateFlags struct {
 	provisionFlags
+	parametersFile string
+	resourceGroup  string
 }
 
 func newInfraCreateFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *infraCreateFlags {
 	flags := &infraCreateFlags{}
 	flags.Bind(cmd.Flags(), global)
+	cmd.Flags().StringVar(
+		&flags.parametersFile,
+		"parameters",
+		"",
+		"Path to an ARM parameters file whose values override the parameters derived from the environment (bicep only).",
+	)
+	cmd.Flags().StringVar(
+		&flags.resourceGroup,
+		"resource-group",
+		"",
+		"Name of an existing resource group to deploy a resource group scoped template into (bicep only).",
+	)
 
 	return flags
 }
@@ -32,6 +48,7 @@ func newInfraCreateCmd() *cobra.Command {
 
 type infraCreateAction struct {
 	infraCreate *provisionAction
+	flags       *infraCreateFlags
 	console     input.Console
 }
 
@@ -45,6 +62,7 @@ func newInfraCreateAction(
 
 	return &infraCreateAction{
 		infraCreate: provision,
+		flags:       createFlags,
 		console:     console,
 	}
 }
@@ -57,5 +75,32 @@ func (a *infraCreateAction) Run(ctx context.Context) (*actions.ActionResult, err
 	fmt.Fprintln(
 		a.console.Handles().Stderr,
 		"Next time use `azd provision`")
+
+	// Only the bicep provider reads the resource group and the parameters file
+	provider := a.infraCreate.projectConfig.Infra.Provider
+	isBicep := provider == provisioning.Bicep || provider == ""
+
+	if a.flags.resourceGroup != "" {
+		// The bicep provider checks that the resource group exists, and that the template deploys to a resource group
+		if !isBicep {
+			return nil, fmt.Errorf("'--resource-group' is not supported by the %s provider", provider)
+		}
+
+		a.infraCreate.projectConfig.Infra.ResourceGroup = a.flags.resourceGroup
+	}
+
+	if a.flags.parametersFile != "" {
+		if !isBicep {
+			return nil, fmt.Errorf("'--parameters' is not supported by the %s provider", provider)
+		}
+
+		parametersFile, err := filepath.Abs(a.flags.parametersFile)
+		if err != nil {
+			return nil, fmt.Errorf("resolving parameters file path: %w", err)
+		}
+
+		a.infraCreate.projectConfig.Infra.ParametersFile = parametersFile
+	}
+
 	return a.infraCreate.Run(ctx)
 }
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InfraCreateAction_ResourceGroupNotSupported(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	action := &infraCreateAction{
+		infraCreate: &provisionAction{
+			projectConfig: &project.ProjectConfig{
+				Infra: provisioning.Options{Provider: provisioning.Terraform},
+			},
+		},
+		flags:   &infraCreateFlags{resourceGroup: "rg-existing"},
+		console: mockContext.Console,
+	}
+
+	_, err := action.Run(*mockContext.Context)
+	require.ErrorContains(t, err, "'--resource-group' is not supported by the terraform provider")
+	require.Empty(t, action.infraCreate.projectConfig.Infra.ResourceGroup)
+}
+
+func Test_InfraCreateAction_ParametersNotSupported(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	action := &infraCreateAction{
+		infraCreate: &provisionAction{
+			projectConfig: &project.ProjectConfig{
+				Infra: provisioning.Options{Provider: provisioning.Terraform},
+			},
+		},
+		flags:   &infraCreateFlags{parametersFile: "main.parameters.json"},
+		console: mockContext.Console,
+	}
+
+	_, err := action.Run(*mockContext.Context)
+	require.ErrorContains(t, err, "'--parameters' is not supported by the terraform provider")
+	require.Empty(t, action.infraCreate.projectConfig.Infra.ParametersFile)
+}
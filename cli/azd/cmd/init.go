@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -16,14 +17,19 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal/repository"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/bicep"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"github.com/azure/azure-dev/cli/azd/pkg/templates"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
@@ -31,6 +37,8 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 func newInitFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *initFlags {
@@ -52,6 +60,9 @@ type initFlags struct {
 	templateBranch string
 	subscription   string
 	location       string
+	force          bool
+	minimal        bool
+	parameters     []string
 	global         *internal.GlobalCommandOptions
 	envFlag
 }
@@ -79,6 +90,26 @@ func (i *initFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		"Name or ID of an Azure subscription to use for the new environment",
 	)
 	local.StringVarP(&i.location, "location", "l", "", "Azure location for the new environment")
+	local.BoolVar(
+		&i.force,
+		"force",
+		false,
+		//nolint:lll
+		"Initializes the template in a non-empty directory without prompting. Existing files are kept, and azure.yaml is merged with the template.",
+	)
+	local.BoolVar(
+		&i.minimal,
+		"minimal",
+		false,
+		"Creates only azure.yaml, without any services, and the .azure directory, without using a template.",
+	)
+	local.StringArrayVar(
+		&i.parameters,
+		"set",
+		nil,
+		//nolint:lll
+		"Sets an infrastructure parameter of the new environment, as KEY=VALUE, so provisioning doesn't prompt for it. Can be specified multiple times.",
+	)
 	i.envFlag.Bind(local, global)
 
 	i.global = global
@@ -94,6 +125,8 @@ type initAction struct {
 	repoInitializer *repository.Initializer
 	templateManager *templates.TemplateManager
 	featuresManager *alpha.FeatureManager
+	accountManager  account.Manager
+	azCli           azcli.AzCli
 }
 
 func newInitAction(
@@ -105,7 +138,9 @@ func newInitAction(
 	flags *initFlags,
 	repoInitializer *repository.Initializer,
 	templateManager *templates.TemplateManager,
-	featuresManager *alpha.FeatureManager) actions.Action {
+	featuresManager *alpha.FeatureManager,
+	accountManager account.Manager,
+	azCli azcli.AzCli) actions.Action {
 	return &initAction{
 		lazyAzdCtx:      lazyAzdCtx,
 		lazyEnvManager:  lazyEnvManager,
@@ -116,6 +151,8 @@ func newInitAction(
 		repoInitializer: repoInitializer,
 		templateManager: templateManager,
 		featuresManager: featuresManager,
+		accountManager:  accountManager,
+		azCli:           azCli,
 	}
 }
 
@@ -137,6 +174,15 @@ func (i *initAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 				"Using branch argument (-b or --branch) requires a template argument (--template or -t) to be specified.")
 	}
 
+	if i.flags.minimal && i.flags.templatePath != "" {
+		return nil, errors.New("'--minimal' cannot be used with a template argument (--template or -t)")
+	}
+
+	// The values are validated against the template once it is initialized, the syntax is checked before cloning it
+	if _, err := parseParameterFlags(i.flags.parameters); err != nil {
+		return nil, err
+	}
+
 	// ensure that git is available
 	if err := tools.EnsureInstalled(ctx, []tools.ExternalTool{i.gitCli}...); err != nil {
 		return nil, err
@@ -157,12 +203,16 @@ func (i *initAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	}
 
 	var initTypeSelect initType
+	if i.flags.minimal {
+		initTypeSelect = initMinimal
+	}
+
 	if i.flags.templatePath != "" {
 		// an explicit --template passed, always initialize from app template
 		initTypeSelect = initAppTemplate
 	}
 
-	if i.flags.templatePath == "" && existingProject {
+	if i.flags.templatePath == "" && existingProject && initTypeSelect == initUnknown {
 		// no explicit --template, and azure.yaml exists, only initialize environment
 		initTypeSelect = initEnvironment
 	}
@@ -193,7 +243,7 @@ func (i *initAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 			return nil, err
 		}
 
-		err = i.initializeEnv(ctx, azdCtx)
+		_, err = i.initializeEnv(ctx, azdCtx)
 		if err != nil {
 			return nil, err
 		}
@@ -220,16 +270,43 @@ func (i *initAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 
 		err = i.repoInitializer.InitFromApp(ctx, azdCtx, func() error {
-			return i.initializeEnv(ctx, azdCtx)
+			_, err := i.initializeEnv(ctx, azdCtx)
+			return err
 		})
 		if err != nil {
 			return nil, err
 		}
 	case initEnvironment:
-		err = i.initializeEnv(ctx, azdCtx)
+		_, err = i.initializeEnv(ctx, azdCtx)
 		if err != nil {
 			return nil, err
 		}
+	case initMinimal:
+		tracing.SetUsageAttributes(fields.InitMethod.String("minimal"))
+
+		header = "Project scaffolding created!"
+		followUp = "Add your services to " + output.WithHighLightFormat(azdcontext.ProjectFileName) +
+			", then run " + color.BlueString("azd up") + " to provision and deploy them to Azure."
+		if err := i.repoInitializer.InitializeScaffolding(ctx, azdCtx); err != nil {
+			return nil, err
+		}
+
+		env, err := i.initializeEnv(ctx, azdCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		envManager, err := i.lazyEnvManager.GetValue()
+		if err != nil {
+			return nil, err
+		}
+
+		prompter := prompt.NewDefaultPrompter(env, i.console, i.accountManager, i.azCli)
+		if err := provisioning.EnsureSubscriptionAndLocation(
+			ctx, envManager, env, prompter, func(_ account.Location) bool { return true },
+		); err != nil {
+			return nil, err
+		}
 	default:
 		panic("unhandled init type")
 	}
@@ -249,6 +326,7 @@ const (
 	initFromApp
 	initAppTemplate
 	initEnvironment
+	initMinimal
 )
 
 func promptInitType(console input.Console, ctx context.Context) (initType, error) {
@@ -276,9 +354,11 @@ func promptInitType(console input.Console, ctx context.Context) (initType, error
 func (i *initAction) initializeTemplate(
 	ctx context.Context,
 	azdCtx *azdcontext.AzdContext) error {
-	err := i.repoInitializer.PromptIfNonEmpty(ctx, azdCtx)
-	if err != nil {
-		return err
+	if !i.flags.force {
+		err := i.repoInitializer.PromptIfNonEmpty(ctx, azdCtx)
+		if err != nil {
+			return err
+		}
 	}
 
 	if i.flags.templatePath == "" {
@@ -298,7 +378,7 @@ func (i *initAction) initializeTemplate(
 			return err
 		}
 
-		err = i.repoInitializer.Initialize(ctx, azdCtx, gitUri, i.flags.templateBranch)
+		err = i.repoInitializer.Initialize(ctx, azdCtx, gitUri, i.flags.templateBranch, i.flags.force)
 		if err != nil {
 			return fmt.Errorf("init from template repository: %w", err)
 		}
@@ -314,14 +394,14 @@ func (i *initAction) initializeTemplate(
 
 func (i *initAction) initializeEnv(
 	ctx context.Context,
-	azdCtx *azdcontext.AzdContext) error {
+	azdCtx *azdcontext.AzdContext) (*environment.Environment, error) {
 	envName, err := azdCtx.GetDefaultEnvironmentName()
 	if err != nil {
-		return fmt.Errorf("retrieving default environment name: %w", err)
+		return nil, fmt.Errorf("retrieving default environment name: %w", err)
 	}
 
 	if envName != "" {
-		return environment.NewEnvironmentInitError(envName)
+		return nil, environment.NewEnvironmentInitError(envName)
 	}
 
 	base := filepath.Base(azdCtx.ProjectDirectory())
@@ -340,7 +420,7 @@ func (i *initAction) initializeEnv(
 	// it here after the template is hydrated and the context is available
 	envManager, err := i.lazyEnvManager.GetValue()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	envSpec := environment.Spec{
@@ -352,11 +432,93 @@ func (i *initAction) initializeEnv(
 
 	env, err := envManager.Create(ctx, envSpec)
 	if err != nil {
-		return fmt.Errorf("loading environment: %w", err)
+		return nil, fmt.Errorf("loading environment: %w", err)
+	}
+
+	if err := i.setParameters(ctx, azdCtx, envManager, env); err != nil {
+		return nil, err
 	}
 
 	if err := azdCtx.SetDefaultEnvironmentName(env.GetEnvName()); err != nil {
-		return fmt.Errorf("saving default environment: %w", err)
+		return nil, fmt.Errorf("saving default environment: %w", err)
+	}
+
+	return env, nil
+}
+
+// parseParameterFlags parses the KEY=VALUE values of '--set' into a map. A key set more than once keeps the last value.
+func parseParameterFlags(values []string) (map[string]string, error) {
+	parameters := map[string]string{}
+	for _, value := range values {
+		key, paramValue, found := strings.Cut(value, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid value '%s' for '--set', expected KEY=VALUE", value)
+		}
+
+		parameters[strings.TrimSpace(key)] = paramValue
+	}
+
+	return parameters, nil
+}
+
+// setParameters saves the infrastructure parameters set with '--set' in the config of the new environment, where
+// provisioning reads the values of parameters it would otherwise prompt for. When the project uses a bicep module, the
+// parameters must be declared by the module and the values are converted to the declared types.
+func (i *initAction) setParameters(
+	ctx context.Context,
+	azdCtx *azdcontext.AzdContext,
+	envManager environment.Manager,
+	env *environment.Environment,
+) error {
+	parameters, err := parseParameterFlags(i.flags.parameters)
+	if err != nil || len(parameters) == 0 {
+		return err
+	}
+
+	projectConfig, err := project.Load(ctx, azdCtx.ProjectPath())
+	if err != nil {
+		return fmt.Errorf("loading project to set parameters: %w", err)
+	}
+
+	var declaredTypes map[string]provisioning.ParameterType
+	if projectConfig.Infra.Provider == provisioning.Bicep || projectConfig.Infra.Provider == "" {
+		module := projectConfig.Infra.Module
+		if module == "" {
+			module = bicep.DefaultModule
+		}
+
+		modulePath := filepath.Join(projectConfig.Path, projectConfig.Infra.Path, module+".bicep")
+		declaredTypes, err = bicep.DeclaredParameterTypes(modulePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("reading parameters of '%s': %w", modulePath, err)
+		}
+	}
+
+	keys := maps.Keys(parameters)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		var value any = parameters[key]
+
+		// Without a module to read the declarations from, the values are saved as strings
+		if declaredTypes != nil {
+			paramType, declared := declaredTypes[key]
+			if !declared {
+				return fmt.Errorf("invalid value for '--set': the template has no parameter named '%s'", key)
+			}
+
+			if value, err = bicep.ParseParameterValue(paramType, parameters[key]); err != nil {
+				return fmt.Errorf("invalid value for parameter '%s' of type %s: %w", key, paramType, err)
+			}
+		}
+
+		if err := env.Config.Set(fmt.Sprintf("infra.parameters.%s", key), value); err != nil {
+			return fmt.Errorf("setting parameter '%s': %w", key, err)
+		}
+	}
+
+	if err := envManager.Save(ctx, env); err != nil {
+		return fmt.Errorf("saving environment: %w", err)
 	}
 
 	return nil
@@ -382,6 +544,8 @@ func getCmdInitHelpFooter(*cobra.Command) string {
 			output.WithHighLightFormat("azd init --template"),
 			output.WithWarningFormat("[GitHub repo URL]"),
 		),
+		"Create only azure.yaml and the .azure directory, to bring existing code under azd.": output.WithHighLightFormat(
+			"azd init --minimal"),
 		"Initialize a template to your current local directory from a branch other than main.": fmt.Sprintf("%s %s %s %s",
 			output.WithHighLightFormat("azd init --template"),
 			output.WithWarningFormat("[GitHub repo URL]"),
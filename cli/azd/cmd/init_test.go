@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseParameterFlags(t *testing.T) {
+	parameters, err := parseParameterFlags([]string{"location=eastus2", "tags={\"a\":\"b=c\"}", "count=1", "count=2"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"location": "eastus2",
+		"tags":     "{\"a\":\"b=c\"}",
+		"count":    "2",
+	}, parameters)
+
+	_, err = parseParameterFlags([]string{"location"})
+	require.Error(t, err)
+
+	_, err = parseParameterFlags([]string{"=eastus2"})
+	require.Error(t, err)
+}
+
+func Test_InitAction_SetParameters(t *testing.T) {
+	newProject := func(t *testing.T, module string) *azdcontext.AzdContext {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte("name: test\n"), 0600))
+
+		if module != "" {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "infra"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "infra", "main.bicep"), []byte(module), 0600))
+		}
+
+		return azdcontext.NewAzdContextWithDirectory(dir)
+	}
+
+	newEnvManager := func() *mockenv.MockEnvManager {
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		return envManager
+	}
+
+	module := "param location string\nparam instanceCount int\nparam enableLogs bool\nparam zones array\n"
+
+	t.Run("CoercesToDeclaredTypes", func(t *testing.T) {
+		envManager := newEnvManager()
+		env := environment.New("test")
+		action := &initAction{flags: &initFlags{
+			parameters: []string{"location=eastus2", "instanceCount=3", "enableLogs=true", `zones=["1","2"]`},
+		}}
+
+		err := action.setParameters(context.Background(), newProject(t, module), envManager, env)
+		require.NoError(t, err)
+		envManager.AssertCalled(t, "Save", mock.Anything, env)
+
+		expected := map[string]any{
+			"location":      "eastus2",
+			"instanceCount": 3,
+			"enableLogs":    true,
+			"zones":         []any{"1", "2"},
+		}
+		for key, value := range expected {
+			actual, has := env.Config.Get("infra.parameters." + key)
+			require.True(t, has, key)
+			require.Equal(t, value, actual, key)
+		}
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		action := &initAction{flags: &initFlags{parameters: []string{"instanceCount=three"}}}
+
+		err := action.setParameters(context.Background(), newProject(t, module), newEnvManager(), environment.New("test"))
+		require.ErrorContains(t, err, "instanceCount")
+	})
+
+	t.Run("UndeclaredParameter", func(t *testing.T) {
+		action := &initAction{flags: &initFlags{parameters: []string{"unknown=value"}}}
+
+		err := action.setParameters(context.Background(), newProject(t, module), newEnvManager(), environment.New("test"))
+		require.ErrorContains(t, err, "unknown")
+	})
+
+	t.Run("NoModule", func(t *testing.T) {
+		env := environment.New("test")
+		action := &initAction{flags: &initFlags{parameters: []string{"instanceCount=3", "location=eastus2"}}}
+
+		err := action.setParameters(context.Background(), newProject(t, ""), newEnvManager(), env)
+		require.NoError(t, err)
+
+		value, has := env.Config.Get("infra.parameters.instanceCount")
+		require.True(t, has)
+		require.Equal(t, "3", value)
+	})
+
+	t.Run("NoParameters", func(t *testing.T) {
+		envManager := newEnvManager()
+		action := &initAction{flags: &initFlags{}}
+
+		err := action.setParameters(context.Background(), newProject(t, module), envManager, environment.New("test"))
+		require.NoError(t, err)
+		envManager.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+)
+
+// interruptExitCode is the exit code used when azd is forced to exit by a second interrupt, matching the exit code shells
+// use for processes terminated by SIGINT.
+const interruptExitCode = 130
+
+// withInterruptCancellation returns a copy of ctx that is canceled when azd receives an interrupt, like when the user
+// presses Ctrl+C, giving the running action a chance to clean up, like canceling an in-flight deployment. A second
+// interrupt exits azd immediately. The returned function stops handling interrupts and must be called when the action
+// completes.
+func withInterruptCancellation(ctx context.Context, writer io.Writer) (context.Context, func()) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt)
+
+	ctx, stop := cancelOnInterrupt(ctx, signals, writer, os.Exit)
+	return ctx, func() {
+		signal.Stop(signals)
+		stop()
+	}
+}
+
+// cancelOnInterrupt cancels the returned context on the first value received from signals and calls exit on the second.
+func cancelOnInterrupt(
+	ctx context.Context,
+	signals <-chan os.Signal,
+	writer io.Writer,
+	exit func(code int),
+) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+
+		fmt.Fprintln(writer, output.WithWarningFormat("\nCanceling, press Ctrl+C again to exit immediately..."))
+		cancel()
+
+		select {
+		case <-signals:
+			exit(interruptExitCode)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
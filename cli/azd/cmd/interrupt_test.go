@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_cancelOnInterrupt(t *testing.T) {
+	t.Run("FirstInterruptCancels", func(t *testing.T) {
+		signals := make(chan os.Signal, 2)
+		exitCodes := make(chan int, 1)
+		writer := &bytes.Buffer{}
+
+		ctx, stop := cancelOnInterrupt(context.Background(), signals, writer, func(code int) { exitCodes <- code })
+		defer stop()
+		require.NoError(t, ctx.Err())
+
+		signals <- os.Interrupt
+		<-ctx.Done()
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+		require.Contains(t, writer.String(), "press Ctrl+C again to exit immediately")
+
+		select {
+		case <-exitCodes:
+			require.Fail(t, "exit should only be called on the second interrupt")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		signals <- os.Interrupt
+		require.Equal(t, interruptExitCode, <-exitCodes)
+	})
+
+	t.Run("StopWithoutInterrupt", func(t *testing.T) {
+		signals := make(chan os.Signal, 2)
+		ctx, stop := cancelOnInterrupt(context.Background(), signals, &bytes.Buffer{}, func(code int) {
+			require.Fail(t, "exit should not be called")
+		})
+
+		stop()
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
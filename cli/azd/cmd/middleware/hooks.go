@@ -6,12 +6,14 @@ import (
 	"log"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
 )
 
@@ -26,6 +28,7 @@ type HooksMiddleware struct {
 	commandRunner     exec.CommandRunner
 	console           input.Console
 	options           *Options
+	globalOptions     *internal.GlobalCommandOptions
 }
 
 // Creates a new instance of the Hooks middleware
@@ -36,6 +39,7 @@ func NewHooksMiddleware(
 	commandRunner exec.CommandRunner,
 	console input.Console,
 	options *Options,
+	globalOptions *internal.GlobalCommandOptions,
 ) Middleware {
 	return &HooksMiddleware{
 		lazyEnvManager:    lazyEnvManager,
@@ -44,6 +48,7 @@ func NewHooksMiddleware(
 		commandRunner:     commandRunner,
 		console:           console,
 		options:           options,
+		globalOptions:     globalOptions,
 	}
 }
 
@@ -51,6 +56,15 @@ func NewHooksMiddleware(
 func (m *HooksMiddleware) Run(ctx context.Context, next NextFn) (*actions.ActionResult, error) {
 	ctx, _ = getServiceHooksRegistered(ctx)
 
+	if m.globalOptions.NoHooks {
+		// Child actions, like provision and deploy within up, share the flag with their parent, so only warn once.
+		if !m.options.IsChildAction() {
+			m.console.Message(ctx, output.WithWarningFormat("WARNING: Hooks were skipped, since '--hooks=false' is set."))
+		}
+
+		return next(ctx)
+	}
+
 	env, err := m.lazyEnv.GetValue()
 	if err != nil {
 		log.Println("azd environment is not available, skipping all hook registrations.")
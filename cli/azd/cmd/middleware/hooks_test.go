@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -110,6 +111,47 @@ func Test_CommandHooks_Middleware_ValidProjectWithNoHooks(t *testing.T) {
 	require.True(t, *actionRan)
 }
 
+func Test_CommandHooks_Middleware_HooksDisabled(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := createAzdContext(t)
+
+	envName := "test"
+	runOptions := Options{CommandPath: "command"}
+
+	projectConfig := project.ProjectConfig{
+		Name: envName,
+		Hooks: map[string]*ext.HookConfig{
+			"precommand": {
+				Run:   "echo 'hello'",
+				Shell: ext.ShellTypeBash,
+			},
+		},
+	}
+
+	err := ensureAzdValid(mockContext, azdContext, envName, &projectConfig)
+	require.NoError(t, err)
+
+	nextFn, actionRan := createNextFn()
+	hookRan := setupHookMock(mockContext, 0)
+	result, err := runMiddlewareWithGlobalOptions(
+		mockContext,
+		azdContext,
+		envName,
+		&projectConfig,
+		&runOptions,
+		&internal.GlobalCommandOptions{NoHooks: true},
+		nextFn,
+	)
+
+	require.NotNil(t, result)
+	require.NoError(t, err)
+
+	// Hook will not run since hooks are disabled, but the action still runs
+	require.False(t, *hookRan)
+	require.True(t, *actionRan)
+	require.Contains(t, strings.Join(mockContext.Console.Output(), "\n"), "Hooks were skipped")
+}
+
 func Test_CommandHooks_Middleware_PreHookWithError(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	azdContext := createAzdContext(t)
@@ -320,6 +362,19 @@ func runMiddleware(
 	projectConfig *project.ProjectConfig,
 	runOptions *Options,
 	nextFn NextFn,
+) (*actions.ActionResult, error) {
+	return runMiddlewareWithGlobalOptions(
+		mockContext, azdContext, envName, projectConfig, runOptions, &internal.GlobalCommandOptions{}, nextFn)
+}
+
+func runMiddlewareWithGlobalOptions(
+	mockContext *mocks.MockContext,
+	azdContext *azdcontext.AzdContext,
+	envName string,
+	projectConfig *project.ProjectConfig,
+	runOptions *Options,
+	globalOptions *internal.GlobalCommandOptions,
+	nextFn NextFn,
 ) (*actions.ActionResult, error) {
 	env := environment.NewWithValues(envName, nil)
 
@@ -347,6 +402,7 @@ func runMiddleware(
 		mockContext.CommandRunner,
 		mockContext.Console,
 		runOptions,
+		globalOptions,
 	)
 
 	result, err := middleware.Run(*mockContext.Context, nextFn)
@@ -18,6 +18,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -26,6 +27,7 @@ import (
 type monitorFlags struct {
 	monitorLive     bool
 	monitorLogs     bool
+	monitorMetrics  bool
 	monitorOverview bool
 	global          *internal.GlobalCommandOptions
 	envFlag
@@ -39,6 +41,7 @@ func (m *monitorFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommand
 		"Open a browser to Application Insights Live Metrics. Live Metrics is currently not supported for Python apps.",
 	)
 	local.BoolVar(&m.monitorLogs, "logs", false, "Open a browser to Application Insights Logs.")
+	local.BoolVar(&m.monitorMetrics, "metrics", false, "Open a browser to Application Insights Metrics.")
 	local.BoolVar(&m.monitorOverview, "overview", false, "Open a browser to Application Insights Overview Dashboard.")
 	m.envFlag.Bind(local, global)
 	m.global = global
@@ -53,52 +56,76 @@ func newMonitorFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions)
 
 func newMonitorCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "monitor",
+		Use:   "monitor [service]",
 		Short: fmt.Sprintf("Monitor a deployed application. %s", output.WithWarningFormat("(Beta)")),
+		Args:  cobra.MaximumNArgs(1),
 	}
 }
 
 type monitorAction struct {
 	azdCtx               *azdcontext.AzdContext
 	env                  *environment.Environment
+	projectConfig        *project.ProjectConfig
+	resourceManager      project.ResourceManager
 	subResolver          account.SubscriptionTenantResolver
 	azCli                azcli.AzCli
 	deploymentOperations azapi.DeploymentOperations
 	console              input.Console
 	flags                *monitorFlags
+	args                 []string
 }
 
 func newMonitorAction(
 	azdCtx *azdcontext.AzdContext,
 	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	resourceManager project.ResourceManager,
 	subResolver account.SubscriptionTenantResolver,
 	azCli azcli.AzCli,
 	deploymentOperations azapi.DeploymentOperations,
 	console input.Console,
 	flags *monitorFlags,
+	args []string,
 ) actions.Action {
 	return &monitorAction{
 		azdCtx:               azdCtx,
 		env:                  env,
+		projectConfig:        projectConfig,
+		resourceManager:      resourceManager,
 		azCli:                azCli,
 		deploymentOperations: deploymentOperations,
 		console:              console,
 		flags:                flags,
 		subResolver:          subResolver,
+		args:                 args,
 	}
 }
 
 func (m *monitorAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	if !m.flags.monitorLive && !m.flags.monitorLogs && !m.flags.monitorOverview {
+	if !m.flags.monitorLive && !m.flags.monitorLogs && !m.flags.monitorMetrics && !m.flags.monitorOverview {
 		m.flags.monitorOverview = true
 	}
 
+	var serviceConfig *project.ServiceConfig
+	if len(m.args) == 1 {
+		svc, has := m.projectConfig.Services[m.args[0]]
+		if !has {
+			return nil, unknownServiceError(m.projectConfig, m.args[0])
+		}
+
+		serviceConfig = svc
+	}
+
 	if m.env.GetSubscriptionId() == "" {
 		return nil, errors.New(
 			"infrastructure has not been provisioned. Run `azd provision`",
 		)
 	}
 
+	if serviceConfig != nil {
+		return m.monitorService(ctx, serviceConfig)
+	}
+
 	resourceManager := infra.NewAzureResourceManager(m.azCli, m.deploymentOperations)
 	resourceGroups, err := resourceManager.GetResourceGroupsForEnvironment(
 		ctx, m.env.GetSubscriptionId(), m.env.GetEnvName())
@@ -126,7 +153,7 @@ func (m *monitorAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		}
 	}
 
-	if len(insightsResources) == 0 && (m.flags.monitorLive || m.flags.monitorLogs) {
+	if len(insightsResources) == 0 && (m.flags.monitorLive || m.flags.monitorLogs || m.flags.monitorMetrics) {
 		return nil, fmt.Errorf("application does not contain an Application Insights resource")
 	}
 
@@ -139,28 +166,117 @@ func (m *monitorAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		return nil, err
 	}
 
+	for _, url := range monitorUrls(m.flags, tenantId, insightsResources, portalResources) {
+		openWithDefaultBrowser(ctx, m.console, url)
+	}
+
+	return nil, nil
+}
+
+// monitorService opens the panels selected by the flags for a single service. The overview, logs and metrics are opened
+// on the service resource itself, since the dashboard and Application Insights cover the whole application. Live
+// metrics are only available from Application Insights, so they use the Application Insights resources in the resource
+// group of the service.
+func (m *monitorAction) monitorService(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+) (*actions.ActionResult, error) {
+	subscriptionId := m.env.GetSubscriptionId()
+	resourceGroupName, err := m.resourceManager.GetResourceGroupName(ctx, subscriptionId, m.projectConfig)
+	if err != nil {
+		return nil, fmt.Errorf("finding resource group: %w", err)
+	}
+
+	serviceResource, err := m.resourceManager.GetServiceResource(
+		ctx, subscriptionId, resourceGroupName, serviceConfig, "azd monitor")
+	if err != nil {
+		return nil, err
+	}
+
+	var insightsResources []azcli.AzCliResource
+	if m.flags.monitorLive {
+		resources, err := m.azCli.ListResourceGroupResources(ctx, subscriptionId, resourceGroupName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing resources: %w", err)
+		}
+
+		for _, resource := range resources {
+			if resource.Type == string(infra.AzureResourceTypeAppInsightComponent) {
+				insightsResources = append(insightsResources, resource)
+			}
+		}
+
+		if len(insightsResources) == 0 {
+			return nil, fmt.Errorf("application does not contain an Application Insights resource")
+		}
+	}
+
+	tenantId, err := m.subResolver.LookupTenant(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, url := range monitorServiceUrls(m.flags, tenantId, serviceResource, insightsResources) {
+		openWithDefaultBrowser(ctx, m.console, url)
+	}
+
+	return nil, nil
+}
+
+// monitorServiceUrls returns the portal URLs of the panels selected by flags for the service resource.
+func monitorServiceUrls(
+	flags *monitorFlags,
+	tenantId string,
+	serviceResource azcli.AzCliResource,
+	insightsResources []azcli.AzCliResource,
+) []string {
+	urls := monitorUrls(&monitorFlags{monitorLive: flags.monitorLive}, tenantId, insightsResources, nil)
+
+	if flags.monitorLogs {
+		urls = append(urls, fmt.Sprintf("https://portal.azure.com/#@%s/resource%s/logs", tenantId, serviceResource.Id))
+	}
+
+	if flags.monitorMetrics {
+		urls = append(urls, fmt.Sprintf("https://portal.azure.com/#@%s/resource%s/metrics", tenantId, serviceResource.Id))
+	}
+
+	if flags.monitorOverview {
+		urls = append(urls, fmt.Sprintf("https://portal.azure.com/#@%s/resource%s/overview", tenantId, serviceResource.Id))
+	}
+
+	return urls
+}
+
+// monitorUrls returns the portal URLs of the panels selected by flags, one per matching resource.
+func monitorUrls(
+	flags *monitorFlags,
+	tenantId string,
+	insightsResources []azcli.AzCliResource,
+	portalResources []azcli.AzCliResource,
+) []string {
+	var urls []string
+
 	for _, insightsResource := range insightsResources {
-		if m.flags.monitorLive {
-			openWithDefaultBrowser(ctx, m.console,
-				fmt.Sprintf("https://app.azure.com/%s%s/quickPulse", tenantId, insightsResource.Id),
-			)
+		if flags.monitorLive {
+			urls = append(urls, fmt.Sprintf("https://app.azure.com/%s%s/quickPulse", tenantId, insightsResource.Id))
 		}
 
-		if m.flags.monitorLogs {
-			openWithDefaultBrowser(ctx, m.console,
-				fmt.Sprintf("https://app.azure.com/%s%s/logs", tenantId, insightsResource.Id))
+		if flags.monitorLogs {
+			urls = append(urls, fmt.Sprintf("https://app.azure.com/%s%s/logs", tenantId, insightsResource.Id))
+		}
+
+		if flags.monitorMetrics {
+			urls = append(urls, fmt.Sprintf("https://app.azure.com/%s%s/metrics", tenantId, insightsResource.Id))
 		}
 	}
 
 	for _, portalResource := range portalResources {
-		if m.flags.monitorOverview {
-			openWithDefaultBrowser(ctx, m.console,
-				fmt.Sprintf("https://portal.azure.com/#@%s/dashboard/arm%s", tenantId, portalResource.Id),
-			)
+		if flags.monitorOverview {
+			urls = append(urls, fmt.Sprintf("https://portal.azure.com/#@%s/dashboard/arm%s", tenantId, portalResource.Id))
 		}
 	}
 
-	return nil, nil
+	return urls
 }
 
 func getCmdMonitorHelpDescription(*cobra.Command) string {
@@ -172,8 +288,12 @@ func getCmdMonitorHelpDescription(*cobra.Command) string {
 
 func getCmdMonitorHelpFooter(c *cobra.Command) string {
 	return generateCmdHelpSamplesBlock(map[string]string{
-		"Open Application Insights Overview Dashboard.": output.WithHighLightFormat("azd monitor --overview"),
-		"Open Application Insights Live Metrics.":       output.WithHighLightFormat("azd monitor --live"),
-		"Open Application Insights Logs.":               output.WithHighLightFormat("azd monitor --logs"),
+		"Open Application Insights Overview Dashboard.":        output.WithHighLightFormat("azd monitor --overview"),
+		"Open Application Insights Live Metrics.":              output.WithHighLightFormat("azd monitor --live"),
+		"Open Application Insights Logs.":                      output.WithHighLightFormat("azd monitor --logs"),
+		"Open Application Insights Metrics.":                   output.WithHighLightFormat("azd monitor --metrics"),
+		"Open Application Insights Logs and Metrics.":          output.WithHighLightFormat("azd monitor --logs --metrics"),
+		"Open the Azure Portal overview of the service 'api'.": output.WithHighLightFormat("azd monitor api"),
+		"Open the logs of the service 'api'.":                  output.WithHighLightFormat("azd monitor api --logs"),
 	})
 }
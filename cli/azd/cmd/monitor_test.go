@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockarmresources"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_monitorUrls(t *testing.T) {
+	insights := []azcli.AzCliResource{{Id: "/subscriptions/SUB/resourceGroups/RG/providers/insights/components/AI"}}
+	dashboards := []azcli.AzCliResource{{Id: "/subscriptions/SUB/resourceGroups/RG/providers/portal/dashboards/DASH"}}
+
+	t.Run("Overview", func(t *testing.T) {
+		urls := monitorUrls(&monitorFlags{monitorOverview: true}, "TENANT", insights, dashboards)
+		require.Equal(t, []string{
+			"https://portal.azure.com/#@TENANT/dashboard/arm" + dashboards[0].Id,
+		}, urls)
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		urls := monitorUrls(&monitorFlags{monitorLogs: true, monitorMetrics: true}, "TENANT", insights, dashboards)
+		require.Equal(t, []string{
+			"https://app.azure.com/TENANT" + insights[0].Id + "/logs",
+			"https://app.azure.com/TENANT" + insights[0].Id + "/metrics",
+		}, urls)
+	})
+}
+
+func Test_monitorAction_Service(t *testing.T) {
+	const apiId = "/subscriptions/SUB/resourceGroups/RG/providers/Microsoft.App/containerApps/api"
+	const webId = "/subscriptions/SUB/resourceGroups/RG/providers/Microsoft.Web/sites/web"
+	const insightsId = "/subscriptions/SUB/resourceGroups/RG/providers/Microsoft.Insights/components/ai"
+
+	newAction := func(t *testing.T, flags *monitorFlags, args []string) (*monitorAction, *[]string) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockarmresources.AddAzResourceListMock(
+			mockContext.HttpClient,
+			convert.RefOf("RG"),
+			[]*armresources.GenericResourceExpanded{
+				{
+					ID:       convert.RefOf(apiId),
+					Name:     convert.RefOf("api"),
+					Location: convert.RefOf("eastus2"),
+					Type:     convert.RefOf(string(infra.AzureResourceTypeContainerApp)),
+					Tags:     map[string]*string{azure.TagKeyAzdServiceName: convert.RefOf("api")},
+				},
+				{
+					ID:       convert.RefOf(webId),
+					Name:     convert.RefOf("web"),
+					Location: convert.RefOf("eastus2"),
+					Type:     convert.RefOf(string(infra.AzureResourceTypeWebSite)),
+					Tags:     map[string]*string{azure.TagKeyAzdServiceName: convert.RefOf("web")},
+				},
+				{
+					ID:       convert.RefOf(insightsId),
+					Name:     convert.RefOf("ai"),
+					Location: convert.RefOf("eastus2"),
+					Type:     convert.RefOf(string(infra.AzureResourceTypeAppInsightComponent)),
+				},
+			})
+
+		env := environment.NewWithValues("envA", map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUB",
+			environment.ResourceGroupEnvVarName:  "RG",
+		})
+
+		azCli := mockazcli.NewAzCliFromMockContext(mockContext)
+		projectConfig := &project.ProjectConfig{
+			Services: map[string]*project.ServiceConfig{
+				"api": {Name: "api", Host: project.ContainerAppTarget},
+				"web": {Name: "web", Host: project.AppServiceTarget},
+			},
+		}
+
+		var urls []string
+		overrideBrowser = func(ctx context.Context, console input.Console, url string) {
+			urls = append(urls, url)
+		}
+		t.Cleanup(func() { overrideBrowser = nil })
+
+		action := newMonitorAction(
+			nil,
+			env,
+			projectConfig,
+			project.NewResourceManager(env, azCli, mockazcli.NewDeploymentOperationsServiceFromMockContext(mockContext)),
+			&mockSubscriptionTenantResolver{TenantId: "TENANT"},
+			azCli,
+			mockazcli.NewDeploymentOperationsServiceFromMockContext(mockContext),
+			mockContext.Console,
+			flags,
+			args,
+		).(*monitorAction)
+
+		return action, &urls
+	}
+
+	t.Run("Overview", func(t *testing.T) {
+		action, urls := newAction(t, &monitorFlags{}, []string{"api"})
+		_, err := action.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{"https://portal.azure.com/#@TENANT/resource" + apiId + "/overview"}, *urls)
+	})
+
+	t.Run("LiveAndLogs", func(t *testing.T) {
+		action, urls := newAction(t, &monitorFlags{monitorLive: true, monitorLogs: true}, []string{"web"})
+		_, err := action.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"https://app.azure.com/TENANT" + insightsId + "/quickPulse",
+			"https://portal.azure.com/#@TENANT/resource" + webId + "/logs",
+		}, *urls)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		action, _ := newAction(t, &monitorFlags{}, []string{"worker"})
+		_, err := action.Run(context.Background())
+		require.ErrorContains(t, err, "service name 'worker' doesn't exist, valid service names are: api, web")
+	})
+}
@@ -25,6 +25,7 @@ import (
 
 type pipelineConfigFlags struct {
 	pipeline.PipelineManagerArgs
+	dryRun bool
 	global *internal.GlobalCommandOptions
 	envFlag
 }
@@ -66,6 +67,12 @@ func (pc *pipelineConfigFlags) Bind(local *pflag.FlagSet, global *internal.Globa
 	// there no customer input using --provider
 	local.StringVar(&pc.PipelineProvider, "provider", "",
 		"The pipeline provider to use (github for Github Actions and azdo for Azure Pipelines).")
+	local.BoolVar(
+		&pc.dryRun,
+		"dry-run",
+		false,
+		"Lists the changes that would be made to Azure and the pipeline provider, without making them.",
+	)
 	pc.envFlag.Bind(local, global)
 	pc.global = global
 }
@@ -157,6 +164,10 @@ func (p *pipelineConfigAction) Run(ctx context.Context) (*actions.ActionResult,
 
 	pipelineProviderName := p.manager.CiProviderName()
 
+	if p.flags.dryRun {
+		return p.preview(ctx, pipelineProviderName)
+	}
+
 	// Command title
 	p.console.MessageUxItem(ctx, &ux.MessageTitle{
 		Title: fmt.Sprintf("Configure your %s pipeline", pipelineProviderName),
@@ -179,6 +190,32 @@ func (p *pipelineConfigAction) Run(ctx context.Context) (*actions.ActionResult,
 	}, nil
 }
 
+// preview prints the changes pipeline config would make, without making them.
+func (p *pipelineConfigAction) preview(ctx context.Context, pipelineProviderName string) (*actions.ActionResult, error) {
+	p.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: fmt.Sprintf("Preview the configuration of your %s pipeline", pipelineProviderName),
+	})
+
+	changes, err := p.manager.Preview(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.console.Message(ctx, "The following changes would be made:")
+	for _, change := range changes {
+		p.console.Message(ctx, fmt.Sprintf("  - %s", change))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "No changes were made.",
+			FollowUp: fmt.Sprintf("Run %s without %s to apply these changes.",
+				output.WithHighLightFormat("azd pipeline config"),
+				output.WithHighLightFormat("--dry-run")),
+		},
+	}, nil
+}
+
 func getCmdPipelineHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription(
 		fmt.Sprintf("Manage integrating your application with deployment pipelines. %s", output.WithWarningFormat("(Beta)")),
@@ -229,6 +266,9 @@ func getCmdPipelineConfigHelpFooter(c *cobra.Command) string {
 			output.WithHighLightFormat("azd pipeline config --principal-name"),
 			output.WithWarningFormat("[Principal name]"),
 		),
+		"Preview the changes to configure a deployment pipeline, without making them.": output.WithHighLightFormat(
+			"azd pipeline config --dry-run",
+		),
 		"Configure a deployment pipeline for 'app-test' environment": fmt.Sprintf("%s %s",
 			output.WithHighLightFormat("azd pipeline config -e"),
 			output.WithWarningFormat("app-test"),
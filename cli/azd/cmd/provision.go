@@ -2,16 +2,20 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/cmd/middleware"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -24,6 +28,9 @@ type provisionFlags struct {
 	noProgress            bool
 	preview               bool
 	ignoreDeploymentState bool
+	verbose               bool
+	useStack              bool
+	skipPermissionCheck   bool
 	global                *internal.GlobalCommandOptions
 	*envFlag
 }
@@ -37,6 +44,16 @@ func (i *provisionFlags) bindNonCommon(local *pflag.FlagSet, global *internal.Gl
 	local.BoolVar(&i.noProgress, "no-progress", false, "Suppresses progress information.")
 	//deprecate:Flag hide --no-progress
 	_ = local.MarkHidden("no-progress")
+	local.BoolVar(
+		&i.useStack,
+		"use-stack",
+		false,
+		"Deploys the infrastructure as an Azure deployment stack instead of a standard deployment (bicep only).")
+	local.BoolVar(
+		&i.skipPermissionCheck,
+		"skip-permission-check",
+		false,
+		"Skips checking that the current account can create deployments at the target scope (bicep only).")
 	i.global = global
 }
 
@@ -47,6 +64,11 @@ func (i *provisionFlags) bindCommon(local *pflag.FlagSet, global *internal.Globa
 		"no-state",
 		false,
 		"Do not use latest Deployment State (bicep only).")
+	local.BoolVar(
+		&i.verbose,
+		"verbose",
+		false,
+		"Summarize the resources created, updated and left unchanged by the deployment (bicep only).")
 
 	i.envFlag = &envFlag{}
 	i.envFlag.Bind(local, global)
@@ -164,6 +186,11 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 	}
 
 	p.projectConfig.Infra.IgnoreDeploymentState = p.flags.ignoreDeploymentState
+	p.projectConfig.Infra.ChangeSummary = p.flags.verbose
+	p.projectConfig.Infra.SkipPermissionCheck = p.flags.skipPermissionCheck
+	if p.flags.useStack {
+		p.projectConfig.Infra.DeploymentStack = true
+	}
 	if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, p.projectConfig.Infra); err != nil {
 		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
 	}
@@ -242,6 +269,15 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 	}
 
+	if deployResult.Changes != nil && p.formatter.Kind() != output.JsonFormat {
+		p.console.Message(ctx, fmt.Sprintf(
+			"Resources created: %d, updated: %d, unchanged: %d",
+			deployResult.Changes.Created,
+			deployResult.Changes.Updated,
+			deployResult.Changes.Unchanged,
+		))
+	}
+
 	if p.formatter.Kind() == output.JsonFormat {
 		stateResult, err := p.provisionManager.State(ctx, nil)
 		if err != nil {
@@ -270,6 +306,106 @@ func (p *provisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 	}, nil
 }
 
+// provisionActionName is the container name of the underlying provision action run by 'provision', 'up' and
+// 'infra create'.
+const provisionActionName = "azd-provision-core-action"
+
+// provisionInitAction backs the 'provision' command. It initializes the project with 'azd init' when no azure.yaml
+// can be found and then provisions the Azure resources, without deploying any services.
+type provisionInitAction struct {
+	flags                      *provisionFlags
+	lazyAzdCtx                 *lazy.Lazy[*azdcontext.AzdContext]
+	initActionInitializer      actions.ActionInitializer[*initAction]
+	provisionActionInitializer actions.ActionInitializer[*provisionAction]
+	console                    input.Console
+	runner                     middleware.MiddlewareContext
+}
+
+func newProvisionInitAction(
+	flags *provisionFlags,
+	lazyAzdCtx *lazy.Lazy[*azdcontext.AzdContext],
+	initActionInitializer actions.ActionInitializer[*initAction],
+	provisionActionInitializer actions.ActionInitializer[*provisionAction],
+	console input.Console,
+	runner middleware.MiddlewareContext,
+) actions.Action {
+	return &provisionInitAction{
+		flags:                      flags,
+		lazyAzdCtx:                 lazyAzdCtx,
+		initActionInitializer:      initActionInitializer,
+		provisionActionInitializer: provisionActionInitializer,
+		console:                    console,
+		runner:                     runner,
+	}
+}
+
+func (p *provisionInitAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	initialized := false
+	if _, err := p.lazyAzdCtx.GetValue(); errors.Is(err, azdcontext.ErrNoProject) {
+		if err := p.initProject(ctx); err != nil {
+			return nil, err
+		}
+
+		initialized = true
+	} else if err != nil {
+		return nil, err
+	}
+
+	// A missing environment is created by resolving the provision action, which prompts for the environment
+	// name, subscription and location in the same way 'azd init' does.
+	provision, err := p.provisionActionInitializer()
+	if err != nil {
+		return nil, err
+	}
+
+	provision.flags = p.flags
+
+	// The hooks registered for this command were skipped when no project existed yet, run provision as a child action
+	// so that any hooks defined by the newly initialized project are honored.
+	if initialized {
+		return p.runner.RunChildAction(ctx, &middleware.Options{CommandPath: "provision"}, provision)
+	}
+
+	return provision.Run(ctx)
+}
+
+func (p *provisionInitAction) initProject(ctx context.Context) error {
+	if p.flags.global.NoPrompt {
+		return azdcontext.ErrNoProject
+	}
+
+	confirm, err := p.console.Confirm(ctx, input.ConsoleOptions{
+		Message:      "No azd project was found in the current directory. Would you like to initialize one now?",
+		DefaultValue: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		return azdcontext.ErrNoProject
+	}
+
+	initialize, err := p.initActionInitializer()
+	if err != nil {
+		return err
+	}
+
+	initialize.flags = &initFlags{
+		global:  p.flags.global,
+		envFlag: *p.flags.envFlag,
+	}
+
+	if _, err := p.runner.RunChildAction(ctx, &middleware.Options{CommandPath: "init"}, initialize); err != nil {
+		return err
+	}
+
+	// Separate the init output from the provision output
+	p.console.Message(ctx, "")
+
+	return nil
+}
+
 // deployResultToUx creates the ux element to display from a provision preview
 func deployResultToUx(previewResult *provisioning.DeployPreviewResult) ux.UxItem {
 	var operations []*ux.Resource
@@ -290,6 +426,7 @@ func getCmdProvisionHelpDescription(c *cobra.Command) string {
 		"Provision the Azure resources for an application."+
 			" This step may take a while depending on the resources provisioned."+
 			" You should run %s any time you update your Bicep or Terraform file."+
+			" When no project is found, you are prompted to initialize one first."+
 			"\n\nThis command prompts you to input the following:",
 		output.WithHighLightFormat(c.CommandPath())), []string{
 		formatHelpNote("Azure location: The Azure location where your resources will be deployed."),
@@ -0,0 +1,152 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type provisionPreviewFlags struct {
+	global *internal.GlobalCommandOptions
+	envFlag
+}
+
+func (f *provisionPreviewFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.envFlag.Bind(local, global)
+	f.global = global
+}
+
+func newProvisionPreviewFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *provisionPreviewFlags {
+	flags := &provisionPreviewFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newProvisionPreviewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "preview",
+		Short: "Preview the changes provisioning would make to the Azure resources of the application.",
+		Args:  cobra.NoArgs,
+	}
+}
+
+type provisionPreviewAction struct {
+	flags            *provisionPreviewFlags
+	provisionManager *provisioning.Manager
+	projectConfig    *project.ProjectConfig
+	env              *environment.Environment
+	console          input.Console
+	formatter        output.Formatter
+}
+
+func newProvisionPreviewAction(
+	flags *provisionPreviewFlags,
+	provisionManager *provisioning.Manager,
+	projectConfig *project.ProjectConfig,
+	env *environment.Environment,
+	console input.Console,
+	formatter output.Formatter,
+) actions.Action {
+	return &provisionPreviewAction{
+		flags:            flags,
+		provisionManager: provisionManager,
+		projectConfig:    projectConfig,
+		env:              env,
+		console:          console,
+		formatter:        formatter,
+	}
+}
+
+// Run previews provisioning with the provider of the project, like a what-if of the bicep deployment or a terraform
+// plan, and displays the changes in the same way for every provider.
+func (p *provisionPreviewAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	p.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Previewing Azure resource changes (azd provision preview)",
+		TitleNote: "This is a preview. No changes will be applied to your Azure resources.",
+	})
+
+	startTime := time.Now()
+
+	if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, p.projectConfig.Infra); err != nil {
+		return nil, fmt.Errorf("initializing provisioning manager: %w", err)
+	}
+
+	previewResult, err := p.provisionManager.Preview(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("previewing provisioning: %w", err)
+	}
+
+	result := provisionPreviewResult(previewResult.Preview)
+	if p.formatter.Kind() == output.JsonFormat {
+		return &actions.ActionResult{Result: result}, nil
+	}
+
+	p.console.MessageUxItem(ctx, deployResultToUx(previewResult))
+	p.console.Message(ctx, provisionPreviewSummary(result.Summary))
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Generated provisioning preview in %s.", ux.DurationAsText(since(startTime))),
+		},
+	}, nil
+}
+
+// provisionPreviewResult converts the preview of a provider to the output of `azd provision preview`.
+func provisionPreviewResult(preview *provisioning.DeploymentPreview) contracts.ProvisionPreviewResult {
+	result := contracts.ProvisionPreviewResult{
+		Summary: map[string]int{},
+		Changes: []contracts.ProvisionPreviewChange{},
+	}
+
+	for changeType, count := range preview.ChangeCounts() {
+		result.Summary[string(changeType)] = count
+	}
+
+	if preview.Properties == nil {
+		return result
+	}
+
+	for _, change := range preview.Properties.Changes {
+		result.Changes = append(result.Changes, contracts.ProvisionPreviewChange{
+			ChangeType:   string(change.ChangeType),
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+			ResourceId:   change.ResourceId.Id,
+		})
+	}
+
+	return result
+}
+
+// provisionPreviewSummary describes the number of resources to create, modify and delete, like
+// "Resources to create: 2, modify: 1, delete: 0".
+func provisionPreviewSummary(summary map[string]int) string {
+	counts := []string{
+		fmt.Sprintf("Resources to create: %d", summary[string(provisioning.ChangeTypeCreate)]),
+		fmt.Sprintf("modify: %d", summary[string(provisioning.ChangeTypeModify)]),
+		fmt.Sprintf("delete: %d", summary[string(provisioning.ChangeTypeDelete)]),
+	}
+
+	if unchanged := summary[string(provisioning.ChangeTypeNoChange)]; unchanged > 0 {
+		counts = append(counts, fmt.Sprintf("unchanged: %d", unchanged))
+	}
+
+	return strings.Join(counts, ", ")
+}
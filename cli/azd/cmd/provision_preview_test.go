@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePreviewProvider is a provisioning provider that returns a fixed change set from Preview.
+type fakePreviewProvider struct {
+	provisioning.Provider
+	preview *provisioning.DeploymentPreview
+}
+
+func (p *fakePreviewProvider) Initialize(ctx context.Context, projectPath string, options provisioning.Options) error {
+	return nil
+}
+
+func (p *fakePreviewProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	return &provisioning.DeployPreviewResult{Preview: p.preview}, nil
+}
+
+func Test_ProvisionPreviewAction(t *testing.T) {
+	// The manager maps the resource types of the preview in place, so each action gets its own preview
+	newPreview := func() *provisioning.DeploymentPreview {
+		return &provisioning.DeploymentPreview{
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: []*provisioning.DeploymentPreviewChange{
+					{
+						ChangeType:   provisioning.ChangeTypeCreate,
+						ResourceType: "Microsoft.Web/sites",
+						Name:         "app-web",
+						ResourceId:   provisioning.Resource{Id: "/subscriptions/SUB/resourceGroups/RG/providers/Microsoft.Web/sites/app-web"},
+					},
+					{
+						ChangeType:   provisioning.ChangeTypeCreate,
+						ResourceType: "Microsoft.Web/serverfarms",
+						Name:         "plan-web",
+					},
+					{
+						ChangeType:   provisioning.ChangeTypeModify,
+						ResourceType: "Microsoft.KeyVault/vaults",
+						Name:         "kv-app",
+					},
+				},
+			},
+		}
+	}
+
+	newAction := func(mockContext *mocks.MockContext, formatter output.Formatter) *provisionPreviewAction {
+		provider := &fakePreviewProvider{preview: newPreview()}
+		require.NoError(t, mockContext.Container.RegisterNamedSingleton(
+			string(provisioning.Test),
+			func() provisioning.Provider { return provider },
+		))
+
+		env := environment.NewWithValues("test", nil)
+		provisionManager := provisioning.NewManager(
+			mockContext.Container,
+			&mockenv.MockEnvManager{},
+			env,
+			mockContext.Console,
+			mockContext.AlphaFeaturesManager,
+			nil,
+		)
+
+		return &provisionPreviewAction{
+			flags:            &provisionPreviewFlags{},
+			provisionManager: provisionManager,
+			projectConfig: &project.ProjectConfig{
+				Infra: provisioning.Options{Provider: provisioning.Test},
+			},
+			env:       env,
+			console:   mockContext.Console,
+			formatter: formatter,
+		}
+	}
+
+	t.Run("Json", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+
+		actionResult, err := newAction(mockContext, &output.JsonFormatter{}).Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, contracts.ProvisionPreviewResult{
+			Summary: map[string]int{"Create": 2, "Modify": 1},
+			Changes: []contracts.ProvisionPreviewChange{
+				{
+					ChangeType:   "Create",
+					ResourceType: "Web App",
+					Name:         "app-web",
+					ResourceId:   "/subscriptions/SUB/resourceGroups/RG/providers/Microsoft.Web/sites/app-web",
+				},
+				{ChangeType: "Create", ResourceType: "App Service plan", Name: "plan-web"},
+				{ChangeType: "Modify", ResourceType: "Key Vault", Name: "kv-app"},
+			},
+		}, actionResult.Result)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+
+		actionResult, err := newAction(mockContext, &output.NoneFormatter{}).Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Nil(t, actionResult.Result)
+		require.Contains(t, actionResult.Message.Header, "Generated provisioning preview")
+		require.Contains(
+			t, strings.Join(mockContext.Console.Output(), "\n"), "Resources to create: 2, modify: 1, delete: 0")
+	})
+}
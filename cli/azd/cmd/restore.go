@@ -24,6 +24,7 @@ import (
 
 type restoreFlags struct {
 	all         bool
+	noCache     bool
 	global      *internal.GlobalCommandOptions
 	serviceName string
 	envFlag
@@ -45,6 +46,12 @@ func (r *restoreFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommand
 	)
 	//deprecate:flag hide --service
 	_ = local.MarkHidden("service")
+	local.BoolVar(
+		&r.noCache,
+		"no-cache",
+		false,
+		"Bypasses package manager caches when restoring dependencies. Supported for npm, pip and NuGet.",
+	)
 }
 
 func newRestoreFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *restoreFlags {
@@ -163,6 +170,20 @@ func (ra *restoreAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			continue
 		}
 
+		if ra.flags.noCache {
+			if project.SupportsRestoreNoCache(svc.Language) {
+				svc.RestoreNoCache = true
+			} else {
+				ra.console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: fmt.Sprintf(
+						"--no-cache is not supported for service %s (language '%s'), cached dependencies may be used",
+						svc.Name,
+						svc.Language,
+					),
+				})
+			}
+		}
+
 		restoreTask := ra.serviceManager.Restore(ctx, svc)
 		go func() {
 			for restoreProgress := range restoreTask.Progress() {
@@ -210,6 +231,10 @@ func getCmdRestoreHelpDescription(*cobra.Command) string {
 				"to use the Visual Studio Code extension.",
 				output.WithLinkFormat("https://aka.ms/azure-dev/vscode"),
 			)),
+			formatHelpNote(fmt.Sprintf("Use %s to bypass package manager caches. It's supported for npm (js, ts), "+
+				"pip (python) and NuGet (.NET) services, other services are restored with a warning.",
+				output.WithHighLightFormat("--no-cache"),
+			)),
 		})
 }
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/telemetry"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -75,6 +78,18 @@ func NewRootCmd(staticHelp bool, middlewareChain []*actions.MiddlewareRegistrati
 		Command: rootCmd,
 		FlagsResolver: func(cmd *cobra.Command) *internal.GlobalCommandOptions {
 			rootCmd.PersistentFlags().StringVarP(&opts.Cwd, "cwd", "C", "", "Sets the current working directory.")
+			rootCmd.PersistentFlags().StringVar(
+				&opts.ProjectFile,
+				"project-file",
+				"",
+				"Sets the name of the project file to use instead of azure.yaml.")
+			rootCmd.PersistentFlags().StringVarP(
+				&opts.EnvironmentName,
+				environmentNameFlag,
+				"e",
+				// Set the default value to AZURE_ENV_NAME value if available
+				os.Getenv(environment.EnvNameEnvVarName),
+				"The name of the environment to use.")
 			rootCmd.PersistentFlags().
 				BoolVar(&opts.EnableDebugLogging, "debug", false, "Enables debugging and diagnostics logging.")
 			rootCmd.PersistentFlags().
@@ -83,6 +98,14 @@ func NewRootCmd(staticHelp bool, middlewareChain []*actions.MiddlewareRegistrati
 					"no-prompt",
 					false,
 					"Accepts the default value instead of prompting, or it fails if there is no default.")
+			rootCmd.PersistentFlags().
+				BoolVar(&opts.NoColor, "no-color", false, "Disables colors and styling in console output.")
+			rootCmd.PersistentFlags().
+				Var(
+					&negatedBoolValue{&opts.NoHooks},
+					"hooks",
+					"Runs the hooks defined in "+azdcontext.ProjectFileName+". Set to false to skip all hooks.")
+			rootCmd.PersistentFlags().Lookup("hooks").NoOptDefVal = "true"
 
 			// The telemetry system is responsible for reading these flags value and using it to configure the telemetry
 			// system, but we still need to add it to our flag set so that when we parse the command line with Cobra we
@@ -128,7 +151,7 @@ func NewRootCmd(staticHelp bool, middlewareChain []*actions.MiddlewareRegistrati
 		Command:        newShowCmd(),
 		FlagsResolver:  newShowFlags,
 		ActionResolver: newShowAction,
-		OutputFormats:  []output.Format{output.JsonFormat},
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat, output.YamlFormat},
 		DefaultFormat:  output.NoneFormat,
 	})
 
@@ -191,11 +214,11 @@ func NewRootCmd(staticHelp bool, middlewareChain []*actions.MiddlewareRegistrati
 		}).
 		UseMiddleware("hooks", middleware.NewHooksMiddleware)
 
-	root.
+	provision := root.
 		Add("provision", &actions.ActionDescriptorOptions{
 			Command:        newProvisionCmd(),
 			FlagsResolver:  newProvisionFlags,
-			ActionResolver: newProvisionAction,
+			ActionResolver: newProvisionInitAction,
 			OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
 			DefaultFormat:  output.NoneFormat,
 			HelpOptions: actions.ActionHelpOptions{
@@ -211,9 +234,21 @@ func NewRootCmd(staticHelp bool, middlewareChain []*actions.MiddlewareRegistrati
 				log.Println("Skipping provision hooks due to preview flag.")
 				return false
 			}
+			// The middleware of provision is also used by its subcommands, like 'provision preview'
+			if descriptor.Name != "provision" {
+				return false
+			}
 			return true
 		})
 
+	provision.Add("preview", &actions.ActionDescriptorOptions{
+		Command:        newProvisionPreviewCmd(),
+		FlagsResolver:  newProvisionPreviewFlags,
+		ActionResolver: newProvisionPreviewAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
 	root.
 		Add("package", &actions.ActionDescriptorOptions{
 			Command:        newPackageCmd(),
@@ -386,3 +421,31 @@ func getCmdRootHelpCommands(cmd *cobra.Command) (result string) {
 	}
 	return strings.Join(paragraph, "\n")
 }
+
+// negatedBoolValue is a boolean flag value that stores the negation of the value set on the command line, so a flag
+// that defaults to true can be bound to an option whose zero value keeps the default behavior.
+type negatedBoolValue struct {
+	target *bool
+}
+
+func (v *negatedBoolValue) Set(value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	*v.target = !parsed
+	return nil
+}
+
+func (v *negatedBoolValue) String() string {
+	if v.target == nil {
+		return "true"
+	}
+
+	return strconv.FormatBool(!*v.target)
+}
+
+func (v *negatedBoolValue) Type() string {
+	return "bool"
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
@@ -17,6 +20,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
@@ -25,15 +30,48 @@ import (
 )
 
 type showFlags struct {
-	global *internal.GlobalCommandOptions
+	serviceName string
+	outputFile  string
+	resources   bool
+	watch       time.Duration
+	global      *internal.GlobalCommandOptions
 	envFlag
 }
 
 func (s *showFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(&s.serviceName, "service", "", "Only shows information for the specified service.")
+	local.StringVar(
+		&s.outputFile,
+		"output-file",
+		"",
+		//nolint:lll
+		"Writes the output to the specified file. Paths starting with ./ or ../ are relative to the current directory, other relative paths are relative to the project root.",
+	)
+	local.BoolVar(
+		&s.resources,
+		"resources",
+		false,
+		"Lists the Azure resources provisioned for the environment, including their resource IDs.",
+	)
+	local.DurationVar(
+		&s.watch,
+		"watch",
+		0,
+		//nolint:lll
+		"Refreshes the output on an interval until interrupted, every 5s unless an interval is given, like --watch=30s. Only supported with '--output table'.",
+	)
+	local.Lookup("watch").NoOptDefVal = defaultShowWatchInterval.String()
 	s.envFlag.Bind(local, global)
 	s.global = global
 }
 
+const (
+	// defaultShowWatchInterval is the refresh interval of `azd show --watch` when no interval is given.
+	defaultShowWatchInterval = 5 * time.Second
+	// minShowWatchInterval is the shortest refresh interval accepted by `azd show --watch`.
+	minShowWatchInterval = time.Second
+)
+
 func newShowFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *showFlags {
 	flags := &showFlags{}
 	flags.Bind(cmd.Flags(), global)
@@ -60,6 +98,7 @@ type showAction struct {
 	envManager           environment.Manager
 	deploymentOperations azapi.DeploymentOperations
 	azdCtx               *azdcontext.AzdContext
+	serviceLocator       ioc.ServiceLocator
 	flags                *showFlags
 }
 
@@ -72,6 +111,7 @@ func newShowAction(
 	deploymentOperations azapi.DeploymentOperations,
 	projectConfig *project.ProjectConfig,
 	azdCtx *azdcontext.AzdContext,
+	serviceLocator ioc.ServiceLocator,
 	flags *showFlags,
 ) actions.Action {
 	return &showAction{
@@ -83,20 +123,120 @@ func newShowAction(
 		envManager:           envManager,
 		deploymentOperations: deploymentOperations,
 		azdCtx:               azdCtx,
+		serviceLocator:       serviceLocator,
 		flags:                flags,
 	}
 }
 
 func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if s.flags.serviceName != "" && !s.projectConfig.HasService(s.flags.serviceName) {
+		return nil, unknownServiceError(s.projectConfig, s.flags.serviceName)
+	}
+
+	if s.flags.serviceName != "" && s.flags.resources {
+		return nil, errors.New("--service and --resources cannot be used together")
+	}
+
+	if s.flags.watch != 0 {
+		if s.formatter.Kind() != output.TableFormat {
+			return nil, errors.New("--watch is only supported with '--output table'")
+		}
+
+		if s.flags.outputFile != "" {
+			return nil, errors.New("--watch and --output-file cannot be used together")
+		}
+
+		if s.flags.watch < minShowWatchInterval {
+			return nil, fmt.Errorf("the --watch interval must be at least %s", minShowWatchInterval)
+		}
+
+		return nil, s.watch(ctx)
+	}
+
+	res, err := s.showResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, formatOptions := s.formatArgs(res)
+	if s.flags.outputFile == "" {
+		return &actions.ActionResult{Result: result, FormatOptions: formatOptions}, nil
+	}
+
+	// The result is written to the file instead of the command output
+	outputPath, err := resolveOutputFilePath(s.flags.outputFile, s.azdCtx.ProjectDirectory())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), osutil.PermissionDirectory); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("creating output file: %w", err)
+	}
+	defer file.Close()
+
+	if err := s.formatter.Format(result, file, formatOptions); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// clearScreen is the ANSI escape sequence that moves the cursor to the top left corner and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watch renders the show output every --watch interval, clearing the screen between refreshes, until ctx is canceled,
+// for example when the user presses Ctrl+C.
+func (s *showAction) watch(ctx context.Context) error {
+	for {
+		res, err := s.showResult(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(s.writer, clearScreen)
+		result, formatOptions := s.formatArgs(res)
+		if err := s.formatter.Format(result, s.writer, formatOptions); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(
+			s.writer,
+			"\nRefreshed at %s, refreshing every %s. Press Ctrl+C to stop.\n",
+			time.Now().Format(time.TimeOnly),
+			s.flags.watch,
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.flags.watch):
+		}
+	}
+}
+
+// showResult gets the information displayed by `azd show`.
+func (s *showAction) showResult(ctx context.Context) (contracts.ShowResult, error) {
 	res := contracts.ShowResult{
 		Name:     s.projectConfig.Name,
 		Services: make(map[string]contracts.ShowService, len(s.projectConfig.Services)),
 	}
 
 	for name, svc := range s.projectConfig.Services {
+		if s.flags.serviceName != "" && name != s.flags.serviceName {
+			continue
+		}
+
 		path, err := getFullPathToProjectForService(svc)
 		if err != nil {
-			return nil, err
+			return contracts.ShowResult{}, err
 		}
 
 		showSvc := contracts.ShowService{
@@ -127,19 +267,46 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 
 	}
 
-	if env, err := s.envManager.Get(ctx, environmentName); err != nil {
+	env, err := s.envManager.Get(ctx, environmentName)
+	if err != nil && s.flags.resources {
+		return contracts.ShowResult{}, fmt.Errorf("loading environment to list resources: %w", err)
+	} else if err != nil {
 		log.Printf("could not load environment: %s, resource ids will not be available", err)
 	} else {
-		if subId := env.GetSubscriptionId(); subId == "" {
+		if subId := env.GetSubscriptionId(); subId == "" && s.flags.resources {
+			return contracts.ShowResult{}, fmt.Errorf(
+				"environment '%s' has not been provisioned, run 'azd provision' first", env.GetEnvName())
+		} else if subId == "" {
 			log.Printf("provision has not been run, resource ids will not be available")
 		} else {
 			azureResourceManager := infra.NewAzureResourceManager(s.azCli, s.deploymentOperations)
 			resourceManager := project.NewResourceManager(env, s.azCli, s.deploymentOperations)
 			envName := env.GetEnvName()
 
-			rgName, err := azureResourceManager.FindResourceGroupForEnvironment(ctx, subId, envName)
+			rgName := env.Getenv(environment.ResourceGroupEnvVarName)
+			if rgName == "" {
+				rgName, err = azureResourceManager.FindResourceGroupForEnvironment(ctx, subId, envName)
+			}
+
+			if err != nil && s.flags.resources {
+				return contracts.ShowResult{}, fmt.Errorf("finding resource group for environment '%s': %w", envName, err)
+			}
+
+			if err == nil && s.flags.resources {
+				resources, err := s.listResources(ctx, subId, rgName)
+				if err != nil {
+					return contracts.ShowResult{}, err
+				}
+
+				res.Resources = resources
+			}
+
 			if err == nil {
 				for svcName, serviceConfig := range s.projectConfig.Services {
+					if _, has := res.Services[svcName]; !has {
+						continue
+					}
+
 					resources, err := resourceManager.GetServiceResources(ctx, subId, rgName, serviceConfig)
 					if err == nil {
 						resourceIds := make([]string, len(resources))
@@ -150,6 +317,7 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 						resSvc := res.Services[svcName]
 						resSvc.Target = &contracts.ShowTargetArm{
 							ResourceIds: resourceIds,
+							Endpoints:   s.serviceEndpoints(ctx, resourceManager, subId, serviceConfig),
 						}
 						res.Services[svcName] = resSvc
 					} else {
@@ -165,7 +333,223 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 	}
 
-	return nil, s.formatter.Format(res, s.writer, nil)
+	return res, nil
+}
+
+// formatArgs returns the value written for the show result in the selected output format, and the options of its formatter.
+func (s *showAction) formatArgs(res contracts.ShowResult) (any, any) {
+	if s.formatter.Kind() == output.TableFormat && s.flags.resources {
+		return res.Resources, output.TableFormatterOptions{
+			Columns: []output.Column{
+				{
+					Heading:       "NAME",
+					ValueTemplate: "{{.Name}}",
+				},
+				{
+					Heading:       "TYPE",
+					ValueTemplate: "{{.Type}}",
+				},
+				{
+					Heading:       "LOCATION",
+					ValueTemplate: "{{.Location}}",
+				},
+			},
+		}
+	}
+
+	if s.formatter.Kind() == output.TableFormat {
+		return showServiceRows(res), output.TableFormatterOptions{
+			Columns: []output.Column{
+				{
+					Heading:       "SERVICE",
+					ValueTemplate: "{{.Name}}",
+				},
+				{
+					Heading:       "LANGUAGE",
+					ValueTemplate: "{{.Language}}",
+				},
+				{
+					Heading:       "PATH",
+					ValueTemplate: "{{.Path}}",
+				},
+				{
+					Heading:       "ENDPOINTS",
+					ValueTemplate: "{{.Endpoints}}",
+				},
+				{
+					Heading:       "RESOURCE",
+					ValueTemplate: "{{.ResourceId}}",
+				},
+			},
+		}
+	}
+
+	if s.flags.serviceName != "" {
+		return res.Services[s.flags.serviceName], nil
+	}
+
+	return res, nil
+}
+
+// listResources returns the resource group of the environment and the resources it contains, sorted by resource ID.
+// Resources are listed from the resource group rather than from the deployment, so they are available whenever the
+// resource group is known, for example after `azd env refresh`.
+func (s *showAction) listResources(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+) ([]contracts.ShowResource, error) {
+	groupResources, err := s.azCli.ListResourceGroupResources(ctx, subscriptionId, resourceGroupName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing resources in resource group '%s': %w", resourceGroupName, err)
+	}
+
+	resources := []contracts.ShowResource{}
+	groups, err := s.azCli.ListResourceGroup(ctx, subscriptionId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing resource groups: %w", err)
+	}
+
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, resourceGroupName) {
+			resources = append(resources, showResource(group))
+		}
+	}
+
+	for _, resource := range groupResources {
+		resources = append(resources, showResource(resource))
+	}
+
+	slices.SortFunc(resources, func(a, b contracts.ShowResource) int {
+		return strings.Compare(strings.ToLower(a.Id), strings.ToLower(b.Id))
+	})
+
+	return resources, nil
+}
+
+func showResource(resource azcli.AzCliResource) contracts.ShowResource {
+	return contracts.ShowResource{
+		Id:       resource.Id,
+		Name:     resource.Name,
+		Type:     resource.Type,
+		Location: resource.Location,
+	}
+}
+
+// resolveOutputFilePath resolves the path given to --output-file. Absolute paths are used as-is. Relative paths that
+// explicitly start with ./ or ../ are resolved against the current working directory, so they behave like any other
+// shell path. All other relative paths are resolved against the project root, giving scripts that run from
+// subdirectories a predictable output location.
+func resolveOutputFilePath(path string, projectDir string) (string, error) {
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+
+	if isCwdRelativePath(path) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", fmt.Errorf("resolving output file path: %w", err)
+		}
+
+		return absPath, nil
+	}
+
+	return filepath.Join(projectDir, path), nil
+}
+
+// isCwdRelativePath reports whether the path explicitly starts with the current or parent directory.
+func isCwdRelativePath(path string) bool {
+	for _, prefix := range []string{".", ".."} {
+		if path == prefix ||
+			strings.HasPrefix(path, prefix+"/") ||
+			strings.HasPrefix(path, prefix+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serviceEndpoints gets the endpoints exposed by the deployed service. Errors are logged and no endpoints are returned,
+// since the service may not have been deployed yet.
+func (s *showAction) serviceEndpoints(
+	ctx context.Context,
+	resourceManager project.ResourceManager,
+	subId string,
+	serviceConfig *project.ServiceConfig,
+) []string {
+	// The service manager depends on the environment, which is only resolved here, once the environment is known to
+	// exist, so that resolving it doesn't prompt the user to create one.
+	var serviceManager project.ServiceManager
+	if err := s.serviceLocator.Resolve(&serviceManager); err != nil {
+		log.Printf("ignoring error resolving service manager, endpoints will not be available: %v", err)
+		return nil
+	}
+
+	targetResource, err := resourceManager.GetTargetResource(ctx, subId, serviceConfig)
+	if err != nil {
+		log.Printf("ignoring error determining target resource for service %s: %v", serviceConfig.Name, err)
+		return nil
+	}
+
+	serviceTarget, err := serviceManager.GetServiceTarget(ctx, serviceConfig)
+	if err != nil {
+		log.Printf("ignoring error determining service target for service %s: %v", serviceConfig.Name, err)
+		return nil
+	}
+
+	endpoints, err := serviceTarget.Endpoints(ctx, serviceConfig, targetResource)
+	if err != nil {
+		log.Printf("ignoring error determining endpoints for service %s: %v", serviceConfig.Name, err)
+		return nil
+	}
+
+	return endpoints
+}
+
+// showServiceRow is a single row of the table output of `azd show`.
+type showServiceRow struct {
+	Name       string
+	Language   contracts.ShowType
+	Path       string
+	Endpoints  string
+	ResourceId string
+}
+
+// showServiceRows flattens the services of a show result into table rows, sorted by service name. A service that targets
+// multiple resources produces one row per resource.
+func showServiceRows(res contracts.ShowResult) []showServiceRow {
+	names := make([]string, 0, len(res.Services))
+	for name := range res.Services {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	rows := []showServiceRow{}
+	for _, name := range names {
+		svc := res.Services[name]
+		row := showServiceRow{
+			Name:     name,
+			Language: svc.Project.Type,
+			Path:     svc.Project.Path,
+		}
+
+		if svc.Target != nil {
+			row.Endpoints = strings.Join(svc.Target.Endpoints, ", ")
+		}
+
+		if svc.Target == nil || len(svc.Target.ResourceIds) == 0 {
+			rows = append(rows, row)
+			continue
+		}
+
+		for _, resourceId := range svc.Target.ResourceIds {
+			row.ResourceId = resourceId
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
 }
 
 func showTypeFromLanguage(language project.ServiceLanguageKind) contracts.ShowType {
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_showServiceRows(t *testing.T) {
+	res := contracts.ShowResult{
+		Name: "app",
+		Services: map[string]contracts.ShowService{
+			"web": {
+				Project: contracts.ShowServiceProject{Path: "src/web", Type: contracts.ShowTypeNode},
+			},
+			"api": {
+				Project: contracts.ShowServiceProject{Path: "src/api", Type: contracts.ShowTypePython},
+				Target: &contracts.ShowTargetArm{
+					ResourceIds: []string{"RESOURCE_ID_1", "RESOURCE_ID_2"},
+					Endpoints:   []string{"https://api.example.com/", "https://api-2.example.com/"},
+				},
+			},
+		},
+	}
+
+	rows := showServiceRows(res)
+
+	require.Equal(t, []showServiceRow{
+		{
+			Name:       "api",
+			Language:   contracts.ShowTypePython,
+			Path:       "src/api",
+			Endpoints:  "https://api.example.com/, https://api-2.example.com/",
+			ResourceId: "RESOURCE_ID_1",
+		},
+		{
+			Name:       "api",
+			Language:   contracts.ShowTypePython,
+			Path:       "src/api",
+			Endpoints:  "https://api.example.com/, https://api-2.example.com/",
+			ResourceId: "RESOURCE_ID_2",
+		},
+		{Name: "web", Language: contracts.ShowTypeNode, Path: "src/web"},
+	}, rows)
+}
+
+func Test_unknownServiceError(t *testing.T) {
+	projectConfig := &project.ProjectConfig{
+		Services: map[string]*project.ServiceConfig{
+			"web": {Name: "web"},
+			"api": {Name: "api"},
+		},
+	}
+
+	err := unknownServiceError(projectConfig, "worker")
+	require.EqualError(t, err, "service name 'worker' doesn't exist, valid service names are: api, web")
+}
+
+func Test_resolveOutputFilePath(t *testing.T) {
+	projectDir := t.TempDir()
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("ProjectRelative", func(t *testing.T) {
+		path, err := resolveOutputFilePath(filepath.Join("out", "show.json"), projectDir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(projectDir, "out", "show.json"), path)
+	})
+
+	t.Run("CwdRelative", func(t *testing.T) {
+		path, err := resolveOutputFilePath("./show.json", projectDir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(cwd, "show.json"), path)
+
+		path, err = resolveOutputFilePath("../show.json", projectDir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(filepath.Dir(cwd), "show.json"), path)
+	})
+
+	t.Run("Absolute", func(t *testing.T) {
+		absPath := filepath.Join(t.TempDir(), "show.json")
+		path, err := resolveOutputFilePath(absPath, projectDir)
+		require.NoError(t, err)
+		require.Equal(t, absPath, path)
+	})
+}
+
+func Test_showAction_listResources(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(request.URL.Path, "/subscriptions/SUBSCRIPTION_ID/resourcegroups")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.ResourceGroupListResult{
+			Value: []*armresources.ResourceGroup{
+				{
+					ID:       to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-other"),
+					Name:     to.Ptr("rg-other"),
+					Type:     to.Ptr("Microsoft.Resources/resourceGroups"),
+					Location: to.Ptr("westus"),
+				},
+				{
+					ID:       to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-app"),
+					Name:     to.Ptr("rg-app"),
+					Type:     to.Ptr("Microsoft.Resources/resourceGroups"),
+					Location: to.Ptr("eastus2"),
+				},
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(request.URL.Path, "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-app/resources")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.ResourceListResult{
+			Value: []*armresources.GenericResourceExpanded{
+				{
+					ID:       to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-app/providers/Microsoft.Web/sites/web"),
+					Name:     to.Ptr("web"),
+					Type:     to.Ptr("Microsoft.Web/sites"),
+					Location: to.Ptr("eastus2"),
+				},
+				{
+					ID:       to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-app/providers/Microsoft.KeyVault/vaults/kv"),
+					Name:     to.Ptr("kv"),
+					Type:     to.Ptr("Microsoft.KeyVault/vaults"),
+					Location: to.Ptr("eastus2"),
+				},
+			},
+		})
+	})
+
+	action := &showAction{azCli: mockazcli.NewAzCliFromMockContext(mockContext)}
+	resources, err := action.listResources(*mockContext.Context, "SUBSCRIPTION_ID", "rg-app")
+	require.NoError(t, err)
+
+	names := make([]string, len(resources))
+	for i, resource := range resources {
+		names[i] = resource.Name
+	}
+
+	// The resource group is included and resources are sorted by resource ID
+	require.Equal(t, []string{"rg-app", "kv", "web"}, names)
+	require.Equal(t, "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-app", resources[0].Id)
+	require.Equal(t, "Microsoft.KeyVault/vaults", resources[1].Type)
+}
+
+func Test_showAction_watch(t *testing.T) {
+	projectDir := t.TempDir()
+	projectConfig, err := project.Parse(context.Background(), `
+name: test-proj
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+`)
+	require.NoError(t, err)
+	projectConfig.Path = filepath.Join(projectDir, "azure.yaml")
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Get", mock.Anything, mock.Anything).Return((*environment.Environment)(nil), environment.ErrNotFound)
+
+	newAction := func(format output.Format, writer io.Writer, watch time.Duration) *showAction {
+		formatter, err := output.NewFormatter(string(format))
+		require.NoError(t, err)
+
+		return &showAction{
+			projectConfig: projectConfig,
+			formatter:     formatter,
+			writer:        writer,
+			envManager:    envManager,
+			azdCtx:        azdcontext.NewAzdContextWithDirectory(projectDir),
+			flags:         &showFlags{watch: watch},
+		}
+	}
+
+	t.Run("RefreshesUntilCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		buf := &bytes.Buffer{}
+		_, err := newAction(output.TableFormat, buf, time.Second).Run(ctx)
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(buf.String(), clearScreen))
+		require.Contains(t, buf.String(), "web")
+		require.Contains(t, buf.String(), "refreshing every 1s. Press Ctrl+C to stop.")
+	})
+
+	t.Run("JsonFormat", func(t *testing.T) {
+		_, err := newAction(output.JsonFormat, io.Discard, time.Second).Run(context.Background())
+		require.ErrorContains(t, err, "--watch is only supported with '--output table'")
+	})
+
+	t.Run("IntervalTooShort", func(t *testing.T) {
+		_, err := newAction(output.TableFormat, io.Discard, time.Millisecond).Run(context.Background())
+		require.ErrorContains(t, err, "the --watch interval must be at least 1s")
+	})
+}
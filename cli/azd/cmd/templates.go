@@ -10,6 +10,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"slices"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
@@ -17,6 +18,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"github.com/spf13/cobra"
 )
 
@@ -80,12 +82,40 @@ func templatesActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 }
 
 type templateListFlags struct {
-	source string
+	source  string
+	tags    []string
+	columns []string
+	refresh bool
+	offline bool
 }
 
 func newTemplateListFlags(cmd *cobra.Command) *templateListFlags {
 	flags := &templateListFlags{}
 	cmd.Flags().StringVarP(&flags.source, "source", "s", "", "Filters templates by source.")
+	cmd.Flags().StringArrayVar(
+		&flags.tags,
+		"tag",
+		[]string{},
+		"Filters templates by tag. Can be specified multiple times; templates must match all tags.",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.columns,
+		"columns",
+		[]string{},
+		"Columns to display in table output (name, description, source, repository-path, repository-url).",
+	)
+	cmd.Flags().BoolVar(
+		&flags.refresh,
+		"refresh",
+		false,
+		"Fetches the templates of remote sources again instead of using the local cache.",
+	)
+	cmd.Flags().BoolVar(
+		&flags.offline,
+		"offline",
+		false,
+		"Only lists the templates cached locally, without fetching remote sources.",
+	)
 
 	return flags
 }
@@ -120,12 +150,6 @@ func newTemplateListAction(
 }
 
 func (tl *templateListAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	options := &templates.ListOptions{Source: tl.flags.source}
-	listedTemplates, err := tl.templateManager.ListTemplates(ctx, options)
-	if err != nil {
-		return nil, err
-	}
-
 	// get clickable link for a repo path
 	clickableUrl := func(text string) string {
 		url, err := templates.Absolute(text)
@@ -136,23 +160,59 @@ func (tl *templateListAction) Run(ctx context.Context) (*actions.ActionResult, e
 		return output.WithHyperlink(url, text)
 	}
 
-	if tl.formatter.Kind() == output.TableFormat {
-		columns := []output.Column{
-			{
-				Heading:       "Name",
-				ValueTemplate: "{{.Name}}",
-			},
-			{
-				Heading:       "Source",
-				ValueTemplate: "{{.Source}}",
+	columns := []output.Column{
+		{
+			Heading:       "Name",
+			ValueTemplate: "{{.Name}}",
+		},
+		{
+			Heading:       "Source",
+			ValueTemplate: "{{.Source}}",
+		},
+		{
+			Heading:       "Repository Path",
+			ValueTemplate: "{{.RepositoryPath}}",
+			Transformer:   clickableUrl,
+		},
+	}
+
+	if len(tl.flags.columns) > 0 {
+		// The default columns are cloned, so the optional columns are never appended into their backing array
+		allColumns := append(slices.Clone(columns),
+			output.Column{
+				Heading:       "Description",
+				ValueTemplate: "{{.Description}}",
 			},
-			{
-				Heading:       "Repository Path",
-				ValueTemplate: "{{.RepositoryPath}}",
-				Transformer:   clickableUrl,
+			output.Column{
+				Heading:       "Repository URL",
+				ValueTemplate: "{{.RepositoryUrl}}",
 			},
+		)
+
+		selected, err := output.SelectColumns(allColumns, tl.flags.columns)
+		if err != nil {
+			return nil, err
 		}
 
+		columns = selected
+	}
+
+	if tl.flags.refresh && tl.flags.offline {
+		return nil, errors.New("'--refresh' and '--offline' cannot be used together")
+	}
+
+	options := &templates.ListOptions{
+		Source:  tl.flags.source,
+		Tags:    tl.flags.tags,
+		Refresh: tl.flags.refresh,
+		Offline: tl.flags.offline,
+	}
+	listedTemplates, err := tl.templateManager.ListTemplates(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if tl.formatter.Kind() == output.TableFormat {
 		err = tl.formatter.Format(listedTemplates, tl.writer, output.TableFormatterOptions{
 			Columns: columns,
 		})
@@ -167,6 +227,7 @@ type templateShowAction struct {
 	formatter       output.Formatter
 	writer          io.Writer
 	templateManager *templates.TemplateManager
+	gitCli          git.GitCli
 	path            string
 }
 
@@ -174,12 +235,14 @@ func newTemplateShowAction(
 	formatter output.Formatter,
 	writer io.Writer,
 	templateManager *templates.TemplateManager,
+	gitCli git.GitCli,
 	args []string,
 ) actions.Action {
 	return &templateShowAction{
 		formatter:       formatter,
 		writer:          writer,
 		templateManager: templateManager,
+		gitCli:          gitCli,
 		path:            args[0],
 	}
 }
@@ -187,6 +250,11 @@ func newTemplateShowAction(
 func (a *templateShowAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	matchingTemplate, err := a.templateManager.GetTemplate(ctx, a.path)
 
+	// Templates that aren't in any configured source can still be shown by their repository URL
+	if errors.Is(err, templates.ErrTemplateNotFound) && templates.IsRemoteTemplatePath(a.path) {
+		matchingTemplate, err = templates.FetchTemplate(ctx, a.gitCli, a.path)
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -509,6 +577,9 @@ func getCmdTemplateHelpFooter(*cobra.Command) string {
 		"View the details of an azd template.": output.WithHighLightFormat(
 			"azd template show <template-name>",
 		),
+		"View the details of a template by its repository URL.": output.WithHighLightFormat(
+			"azd template show <repository-url>",
+		),
 	})
 }
 
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_templateListAction_Columns(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	mockContext := mocks.NewMockContext(context.Background())
+
+	// Only the built-in templates are listed, so nothing is fetched
+	userConfigManager := config.NewUserConfigManager(config.NewFileConfigManager(config.NewManager()))
+	userConfig := config.NewEmptyConfig()
+	require.NoError(t, userConfig.Set("template.sources.default", map[string]any{}))
+	require.NoError(t, userConfigManager.Save(userConfig))
+
+	templateManager, err := templates.NewTemplateManager(
+		templates.NewSourceManager(userConfigManager, mockContext.HttpClient))
+	require.NoError(t, err)
+
+	formatter, err := output.NewFormatter(string(output.TableFormat))
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	action := newTemplateListAction(
+		&templateListFlags{columns: []string{"name", "repository-url"}},
+		formatter,
+		buf,
+		templateManager,
+	)
+
+	_, err = action.Run(*mockContext.Context)
+	require.NoError(t, err)
+
+	header := strings.Fields(strings.Split(buf.String(), "\n")[0])
+	require.Equal(t, []string{"Name", "Repository", "URL"}, header)
+	require.Contains(t, buf.String(), "https://github.com/")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,12 +19,16 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
 )
 
 type upFlags struct {
 	provisionFlags
 	deployFlags
-	global *internal.GlobalCommandOptions
+	noProvision bool
+	noDeploy    bool
+	force       bool
+	global      *internal.GlobalCommandOptions
 	envFlag
 }
 
@@ -35,6 +40,25 @@ func (u *upFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptio
 	u.provisionFlags.setCommon(&u.envFlag)
 	u.deployFlags.bindNonCommon(local, global)
 	u.deployFlags.setCommon(&u.envFlag)
+
+	local.BoolVar(
+		&u.noProvision,
+		"no-provision",
+		false,
+		"Skips provisioning Azure resources, and only packages and deploys the project.",
+	)
+	local.BoolVar(
+		&u.noDeploy,
+		"no-deploy",
+		false,
+		"Skips packaging and deploying the project, and only provisions Azure resources.",
+	)
+	local.BoolVar(
+		&u.force,
+		"force",
+		false,
+		"Runs all phases, including those completed by a previous run of azd up that failed.",
+	)
 }
 
 func newUpFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *upFlags {
@@ -54,6 +78,7 @@ func newUpCmd() *cobra.Command {
 type upAction struct {
 	flags                      *upFlags
 	env                        *environment.Environment
+	envManager                 environment.Manager
 	projectConfig              *project.ProjectConfig
 	packageActionInitializer   actions.ActionInitializer[*packageAction]
 	provisionActionInitializer actions.ActionInitializer[*provisionAction]
@@ -67,6 +92,7 @@ type upAction struct {
 func newUpAction(
 	flags *upFlags,
 	env *environment.Environment,
+	envManager environment.Manager,
 	_ auth.LoggedInGuard,
 	projectConfig *project.ProjectConfig,
 	packageActionInitializer actions.ActionInitializer[*packageAction],
@@ -80,6 +106,7 @@ func newUpAction(
 	return &upAction{
 		flags:                      flags,
 		env:                        env,
+		envManager:                 envManager,
 		projectConfig:              projectConfig,
 		packageActionInitializer:   packageActionInitializer,
 		provisionActionInitializer: provisionActionInitializer,
@@ -92,6 +119,10 @@ func newUpAction(
 }
 
 func (u *upAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if u.flags.noProvision && u.flags.noDeploy {
+		return nil, errors.New("'--no-provision' and '--no-deploy' cannot be used together, at least one phase must run")
+	}
+
 	if u.flags.provisionFlags.noProgress {
 		fmt.Fprintln(
 			u.console.Handles().Stderr,
@@ -111,37 +142,76 @@ func (u *upAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 			output.WithWarningFormat("WARNING: The '--service' flag is deprecated and will be removed in a future release."))
 	}
 
-	err := u.provisioningManager.Initialize(ctx, u.projectConfig.Path, u.projectConfig.Infra)
-	if err != nil {
-		return nil, err
+	// Provisioning is skipped when a previous run failed after completing it, so the run resumes with the failed phase.
+	// With --no-deploy there is no later phase to resume with, so provisioning runs.
+	runProvision := !u.flags.noProvision
+	if runProvision && !u.flags.force && !u.flags.noDeploy && u.phaseCompleted(upPhaseProvision) {
+		u.console.MessageUxItem(ctx, &ux.DoneMessage{
+			Message: "Skipping provisioning, completed by the previous run of azd up. Use --force to provision again",
+		})
+		runProvision = false
+	}
+
+	if runProvision {
+		err := u.provisioningManager.Initialize(ctx, u.projectConfig.Path, u.projectConfig.Infra)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	startTime := time.Now()
 
-	packageAction, err := u.packageActionInitializer()
-	if err != nil {
-		return nil, err
-	}
-	packageOptions := &middleware.Options{CommandPath: "package"}
-	_, err = u.runner.RunChildAction(ctx, packageOptions, packageAction)
-	if err != nil {
-		return nil, err
+	if !u.flags.noDeploy {
+		// The services are packaged before deploying, so the container flags of deploy must be applied first
+		isTargetService := func(svc *project.ServiceConfig) bool {
+			return u.flags.deployFlags.serviceName == "" || svc.Name == u.flags.deployFlags.serviceName
+		}
+		if err := applyContainerFlags(u.projectConfig, &u.flags.deployFlags, isTargetService); err != nil {
+			return nil, err
+		}
+
+		packageAction, err := u.packageActionInitializer()
+		if err != nil {
+			return nil, err
+		}
+		packageOptions := &middleware.Options{CommandPath: "package"}
+		_, err = u.runner.RunChildAction(ctx, packageOptions, packageAction)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	provision, err := u.provisionActionInitializer()
-	if err != nil {
-		return nil, err
+	var provisionResult *actions.ActionResult
+	if runProvision {
+		provision, err := u.provisionActionInitializer()
+		if err != nil {
+			return nil, err
+		}
+
+		provision.flags = &u.flags.provisionFlags
+		provisionOptions := &middleware.Options{CommandPath: "provision"}
+		provisionResult, err = u.runner.RunChildAction(ctx, provisionOptions, provision)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	provision.flags = &u.flags.provisionFlags
-	provisionOptions := &middleware.Options{CommandPath: "provision"}
-	provisionResult, err := u.runner.RunChildAction(ctx, provisionOptions, provision)
-	if err != nil {
-		return nil, err
+	if u.flags.noDeploy {
+		if err := u.resetCompletedPhases(ctx); err != nil {
+			return nil, err
+		}
+
+		return provisionResult, nil
 	}
 
-	// Print an additional newline to separate provision from deploy
-	u.console.Message(ctx, "")
+	if runProvision {
+		if err := u.completePhase(ctx, upPhaseProvision); err != nil {
+			return nil, err
+		}
+
+		// Print an additional newline to separate provision from deploy
+		u.console.Message(ctx, "")
+	}
 
 	deploy, err := u.deployActionInitializer()
 	if err != nil {
@@ -155,11 +225,20 @@ func (u *upAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		deploy.flags.serviceName = ""
 	}
 	deployOptions := &middleware.Options{CommandPath: "deploy"}
-	_, err = u.runner.RunChildAction(ctx, deployOptions, deploy)
+	deployResult, err := u.runner.RunChildAction(ctx, deployOptions, deploy)
 	if err != nil {
 		return nil, err
 	}
 
+	// The run completed, so the next run starts from the first phase again
+	if err := u.resetCompletedPhases(ctx); err != nil {
+		return nil, err
+	}
+
+	if provisionResult == nil {
+		return deployResult, nil
+	}
+
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
 			Header: fmt.Sprintf("Your application was provisioned and deployed to Azure in %s.",
@@ -169,9 +248,75 @@ func (u *upAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	}, nil
 }
 
+const (
+	upPhaseProvision = "provision"
+
+	// upCompletedPhasesKey is the environment config path of the phases completed by a run of azd up that hasn't finished.
+	upCompletedPhasesKey = "up.completedPhases"
+)
+
+// phaseCompleted returns true when the phase was completed by a previous run of azd up that failed in a later phase.
+func (u *upAction) phaseCompleted(phase string) bool {
+	completed, has := u.env.Config.Get(upCompletedPhasesKey)
+	if !has {
+		return false
+	}
+
+	phases, ok := completed.([]any)
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(phases, any(phase))
+}
+
+// completePhase records in the environment that the phase completed, so a re-run can skip it if a later phase fails.
+func (u *upAction) completePhase(ctx context.Context, phase string) error {
+	if u.phaseCompleted(phase) {
+		return nil
+	}
+
+	phases, _ := u.env.Config.Get(upCompletedPhasesKey)
+	completed, _ := phases.([]any)
+	if err := u.env.Config.Set(upCompletedPhasesKey, append(completed, phase)); err != nil {
+		return fmt.Errorf("recording completed phase '%s': %w", phase, err)
+	}
+
+	if err := u.envManager.Save(ctx, u.env); err != nil {
+		return fmt.Errorf("saving environment: %w", err)
+	}
+
+	return nil
+}
+
+// resetCompletedPhases removes the phases recorded by completePhase from the environment, once a run finished.
+func (u *upAction) resetCompletedPhases(ctx context.Context) error {
+	if _, has := u.env.Config.Get(upCompletedPhasesKey); !has {
+		return nil
+	}
+
+	if err := u.env.Config.Unset(upCompletedPhasesKey); err != nil {
+		return fmt.Errorf("resetting completed phases: %w", err)
+	}
+
+	if err := u.envManager.Save(ctx, u.env); err != nil {
+		return fmt.Errorf("saving environment: %w", err)
+	}
+
+	return nil
+}
+
 func getCmdUpHelpDescription(c *cobra.Command) string {
 	return generateCmdHelpDescription(
 		fmt.Sprintf("Executes the %s and %s commands in a single step.",
 			output.WithHighLightFormat("azd provision"),
-			output.WithHighLightFormat("azd deploy")), nil)
+			output.WithHighLightFormat("azd deploy")),
+		[]string{
+			formatHelpNote(fmt.Sprintf("Use %s or %s to run only one of the phases.",
+				output.WithHighLightFormat("--no-deploy"),
+				output.WithHighLightFormat("--no-provision"))),
+			formatHelpNote(fmt.Sprintf("When a run fails after provisioning, the next run skips provisioning and "+
+				"resumes with the failed phase. Use %s to run all phases.",
+				output.WithHighLightFormat("--force"))),
+		})
 }
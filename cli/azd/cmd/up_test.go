@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/cmd/middleware"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpAction_NoPhases(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	action := &upAction{
+		flags:   &upFlags{noProvision: true, noDeploy: true},
+		console: mockContext.Console,
+	}
+
+	_, err := action.Run(*mockContext.Context)
+	require.ErrorContains(t, err, "cannot be used together")
+}
+
+// fakeUpRunner records the child actions run by azd up, without running them.
+type fakeUpRunner struct {
+	commands []string
+	errs     map[string]error
+	onRun    func(commandPath string)
+}
+
+func (r *fakeUpRunner) RunChildAction(
+	ctx context.Context,
+	runOptions *middleware.Options,
+	action actions.Action,
+) (*actions.ActionResult, error) {
+	r.commands = append(r.commands, runOptions.CommandPath)
+	if r.onRun != nil {
+		r.onRun(runOptions.CommandPath)
+	}
+
+	return nil, r.errs[runOptions.CommandPath]
+}
+
+// newTestUpAction creates an up action that runs its child actions with the runner and provisions with the test provider.
+func newTestUpAction(
+	t *testing.T,
+	mockContext *mocks.MockContext,
+	runner *fakeUpRunner,
+	flags *upFlags,
+) *upAction {
+	require.NoError(t, mockContext.Container.RegisterNamedSingleton(
+		string(provisioning.Test),
+		func() provisioning.Provider { return &fakePreviewProvider{} },
+	))
+
+	env := environment.New("test")
+	return &upAction{
+		flags:         flags,
+		env:           env,
+		projectConfig: &project.ProjectConfig{Infra: provisioning.Options{Provider: provisioning.Test}},
+		packageActionInitializer: func() (*packageAction, error) {
+			return &packageAction{}, nil
+		},
+		provisionActionInitializer: func() (*provisionAction, error) {
+			return &provisionAction{}, nil
+		},
+		deployActionInitializer: func() (*deployAction, error) {
+			return &deployAction{}, nil
+		},
+		console: mockContext.Console,
+		runner:  runner,
+		provisioningManager: provisioning.NewManager(
+			mockContext.Container,
+			&mockenv.MockEnvManager{},
+			env,
+			mockContext.Console,
+			mockContext.AlphaFeaturesManager,
+			nil,
+		),
+	}
+}
+
+func Test_UpAction_Resume(t *testing.T) {
+	newAction := func(mockContext *mocks.MockContext, runner *fakeUpRunner, flags *upFlags) *upAction {
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		action := newTestUpAction(t, mockContext, runner, flags)
+		action.envManager = envManager
+		require.NoError(t, action.env.Config.Set(upCompletedPhasesKey, []any{upPhaseProvision}))
+		return action
+	}
+
+	t.Run("SkipsCompletedProvision", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{}
+		action := newAction(mockContext, runner, &upFlags{})
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, []string{"package", "deploy"}, runner.commands)
+
+		// The run completed, so the completed phases are reset
+		require.False(t, action.phaseCompleted(upPhaseProvision))
+	})
+
+	t.Run("FailedDeployAgain", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{errs: map[string]error{"deploy": errors.New("deploy failed")}}
+		action := newAction(mockContext, runner, &upFlags{})
+
+		_, err := action.Run(*mockContext.Context)
+		require.ErrorContains(t, err, "deploy failed")
+		require.Equal(t, []string{"package", "deploy"}, runner.commands)
+		require.True(t, action.phaseCompleted(upPhaseProvision))
+	})
+
+	t.Run("NoDeploy", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{}
+		action := newAction(mockContext, runner, &upFlags{noDeploy: true})
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, []string{"provision"}, runner.commands)
+		require.False(t, action.phaseCompleted(upPhaseProvision))
+	})
+
+	t.Run("Force", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{}
+		action := newAction(mockContext, runner, &upFlags{force: true})
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, []string{"package", "provision", "deploy"}, runner.commands)
+		require.False(t, action.phaseCompleted(upPhaseProvision))
+	})
+
+	t.Run("FailedDeployKeepsCompletedPhases", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{errs: map[string]error{"deploy": errors.New("deploy failed")}}
+		action := newTestUpAction(t, mockContext, runner, &upFlags{})
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+		action.envManager = envManager
+
+		_, err := action.Run(*mockContext.Context)
+		require.ErrorContains(t, err, "deploy failed")
+		require.Equal(t, []string{"package", "provision", "deploy"}, runner.commands)
+		require.True(t, action.phaseCompleted(upPhaseProvision))
+		envManager.AssertCalled(t, "Save", mock.Anything, action.env)
+	})
+
+	t.Run("CompletePhase", func(t *testing.T) {
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+		action := &upAction{env: environment.New("test"), envManager: envManager}
+
+		require.False(t, action.phaseCompleted(upPhaseProvision))
+		require.NoError(t, action.completePhase(context.Background(), upPhaseProvision))
+		require.True(t, action.phaseCompleted(upPhaseProvision))
+
+		// Completing the phase again doesn't record it twice
+		require.NoError(t, action.completePhase(context.Background(), upPhaseProvision))
+		phases, _ := action.env.Config.Get(upCompletedPhasesKey)
+		require.Equal(t, []any{upPhaseProvision}, phases)
+		envManager.AssertNumberOfCalls(t, "Save", 1)
+	})
+}
+
+func Test_UpAction_SkipPhases(t *testing.T) {
+	t.Run("NoProvision", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{}
+
+		_, err := newTestUpAction(t, mockContext, runner, &upFlags{noProvision: true}).Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, []string{"package", "deploy"}, runner.commands)
+	})
+
+	t.Run("NoDeploy", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{}
+
+		_, err := newTestUpAction(t, mockContext, runner, &upFlags{noDeploy: true}).Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, []string{"provision"}, runner.commands)
+	})
+
+	t.Run("AllPhases", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		runner := &fakeUpRunner{}
+
+		envManager := &mockenv.MockEnvManager{}
+		envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+		action := newTestUpAction(t, mockContext, runner, &upFlags{})
+		action.envManager = envManager
+
+		_, err := action.Run(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, []string{"package", "provision", "deploy"}, runner.commands)
+	})
+}
+
+func Test_UpAction_ContainerFlagsAppliedBeforePackage(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	web := &project.ServiceConfig{
+		Name: "web",
+		Host: project.ContainerAppTarget,
+		Docker: project.DockerProjectOptions{
+			BuildArgs: []string{"VERSION=1"},
+		},
+	}
+
+	var packagedTag string
+	var packagedBuildArgs []string
+	runner := &fakeUpRunner{
+		onRun: func(commandPath string) {
+			if commandPath == "package" {
+				packagedTag = web.Docker.ImageTag
+				packagedBuildArgs = slices.Clone(web.Docker.BuildArgs)
+			}
+		},
+	}
+
+	action := &upAction{
+		flags: &upFlags{
+			noProvision: true,
+			deployFlags: deployFlags{imageTag: "v2", buildArgs: []string{"VERSION=2"}},
+		},
+		env:           environment.New("test"),
+		projectConfig: &project.ProjectConfig{Services: map[string]*project.ServiceConfig{"web": web}},
+		packageActionInitializer: func() (*packageAction, error) {
+			return &packageAction{}, nil
+		},
+		deployActionInitializer: func() (*deployAction, error) {
+			return &deployAction{}, nil
+		},
+		console: mockContext.Console,
+		runner:  runner,
+	}
+
+	_, err := action.Run(*mockContext.Context)
+	require.NoError(t, err)
+	require.Equal(t, []string{"package", "deploy"}, runner.commands)
+	require.Equal(t, "v2", packagedTag)
+	require.Equal(t, []string{"VERSION=1", "VERSION=2"}, packagedBuildArgs)
+}
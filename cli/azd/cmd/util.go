@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path"
 	"strings"
 	"time"
 
@@ -125,6 +126,56 @@ func getTargetServiceName(
 	return targetServiceName, nil
 }
 
+// unknownServiceError returns an error for a service name that is not defined in the project, listing the valid names.
+func unknownServiceError(projectConfig *project.ProjectConfig, serviceName string) error {
+	serviceNames := make([]string, 0, len(projectConfig.Services))
+	for _, svc := range projectConfig.GetServicesStable() {
+		serviceNames = append(serviceNames, svc.Name)
+	}
+
+	return fmt.Errorf(
+		"service name '%s' doesn't exist, valid service names are: %s",
+		serviceName,
+		strings.Join(serviceNames, ", "),
+	)
+}
+
+// isServicePattern returns true when name contains glob characters, like api-*, and so may match several services.
+func isServicePattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// matchServiceNames returns the names of the services that match the glob pattern, like api-*, in the stable order
+// services are deployed in. It's an error when no service matches.
+func matchServiceNames(projectConfig *project.ProjectConfig, pattern string) ([]string, error) {
+	matches := []string{}
+	for _, svc := range projectConfig.GetServicesStable() {
+		matched, err := path.Match(pattern, svc.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service pattern '%s': %w", pattern, err)
+		}
+
+		if matched {
+			matches = append(matches, svc.Name)
+		}
+	}
+
+	if len(matches) == 0 {
+		serviceNames := make([]string, 0, len(projectConfig.Services))
+		for _, svc := range projectConfig.GetServicesStable() {
+			serviceNames = append(serviceNames, svc.Name)
+		}
+
+		return nil, fmt.Errorf(
+			"no services match '%s', valid service names are: %s",
+			pattern,
+			strings.Join(serviceNames, ", "),
+		)
+	}
+
+	return matches, nil
+}
+
 // Calculate the total time since t, excluding user interaction time.
 func since(t time.Time) time.Duration {
 	userInteractTime := tracing.InteractTimeMs.Load()
@@ -85,3 +85,41 @@ func Test_getResourceGroupFollowUpPreview(t *testing.T) {
 
 	require.Contains(t, followUp, "You can view the current resources under the resource group Name in Azure Portal:")
 }
+
+func Test_matchServiceNames(t *testing.T) {
+	projectConfig := &project.ProjectConfig{
+		Services: map[string]*project.ServiceConfig{
+			"api-orders":   {Name: "api-orders"},
+			"api-payments": {Name: "api-payments"},
+			"web":          {Name: "web"},
+		},
+	}
+
+	t.Run("Pattern", func(t *testing.T) {
+		names, err := matchServiceNames(projectConfig, "api-*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"api-orders", "api-payments"}, names)
+	})
+
+	t.Run("SingleCharacter", func(t *testing.T) {
+		names, err := matchServiceNames(projectConfig, "we?")
+		require.NoError(t, err)
+		require.Equal(t, []string{"web"}, names)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		_, err := matchServiceNames(projectConfig, "worker-*")
+		require.EqualError(t, err, "no services match 'worker-*', valid service names are: api-orders, api-payments, web")
+	})
+
+	t.Run("InvalidPattern", func(t *testing.T) {
+		_, err := matchServiceNames(projectConfig, "api-[")
+		require.ErrorContains(t, err, "invalid service pattern 'api-['")
+	})
+
+	t.Run("IsServicePattern", func(t *testing.T) {
+		require.True(t, isServicePattern("api-*"))
+		require.True(t, isServicePattern("api-[ab]"))
+		require.False(t, isServicePattern("api-orders"))
+	})
+}
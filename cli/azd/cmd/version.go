@@ -5,14 +5,10 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"io"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
-	"github.com/azure/azure-dev/cli/azd/pkg/input"
-	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -33,42 +29,21 @@ func newVersionFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions)
 }
 
 type versionAction struct {
-	flags     *versionFlags
-	formatter output.Formatter
-	writer    io.Writer
-	console   input.Console
+	flags *versionFlags
 }
 
-func newVersionAction(
-	flags *versionFlags,
-	formatter output.Formatter,
-	writer io.Writer,
-	console input.Console,
-) actions.Action {
+func newVersionAction(flags *versionFlags) actions.Action {
 	return &versionAction{
-		flags:     flags,
-		formatter: formatter,
-		writer:    writer,
-		console:   console,
+		flags: flags,
 	}
 }
 
 func (v *versionAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	switch v.formatter.Kind() {
-	case output.NoneFormat:
-		fmt.Fprintf(v.console.Handles().Stdout, "azd version %s\n", internal.Version)
-	case output.JsonFormat:
-		var result contracts.VersionResult
-		versionSpec := internal.VersionInfo()
+	var result contracts.VersionResult
+	versionSpec := internal.VersionInfo()
 
-		result.Azd.Commit = versionSpec.Commit
-		result.Azd.Version = versionSpec.Version.String()
+	result.Azd.Commit = versionSpec.Commit
+	result.Azd.Version = versionSpec.Version.String()
 
-		err := v.formatter.Format(result, v.writer, nil)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return nil, nil
+	return &actions.ActionResult{Result: result}, nil
 }
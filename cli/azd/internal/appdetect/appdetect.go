@@ -22,6 +22,8 @@ const (
 	JavaScript Language = "js"
 	TypeScript Language = "ts"
 	Python     Language = "python"
+	Go         Language = "go"
+	Ruby       Language = "ruby"
 )
 
 func (pt Language) Display() string {
@@ -36,6 +38,10 @@ func (pt Language) Display() string {
 		return "TypeScript"
 	case Python:
 		return "Python"
+	case Go:
+		return "Go"
+	case Ruby:
+		return "Ruby"
 	}
 
 	return ""
@@ -161,12 +167,19 @@ type projectDetector interface {
 var allDetectors = []projectDetector{
 	// Order here determines precedence when two projects are in the same directory.
 	// This is unlikely to occur in practice, but reordering could help to break the tie in these cases.
-	&javaDetector{},
+	newMarkerDetector(Java),
 	&dotNetDetector{},
 	&pythonDetector{},
 	&javaScriptDetector{},
 }
 
+// optionalDetectors detect the languages that azd doesn't host yet. They only run when their language is included with an
+// option like WithGo, since their projects would otherwise hide the projects nested under them.
+var optionalDetectors = []projectDetector{
+	newMarkerDetector(Go),
+	newMarkerDetector(Ruby),
+}
+
 // Detect detects projects located under a directory.
 func Detect(root string, options ...DetectOption) ([]Project, error) {
 	config := newConfig(options...)
@@ -21,6 +21,10 @@ func TestDetect(t *testing.T) {
 	err := copyTestDataDir(t, "**", dir)
 	require.NoError(t, err)
 
+	// A go.mod file can't be embedded from testdata, since it makes its directory a separate module
+	err = writeGoModule(dir)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name    string
 		options []DetectOption
@@ -110,6 +114,31 @@ func TestDetect(t *testing.T) {
 				},
 			},
 		},
+		{
+			"OptionalLanguages",
+			[]DetectOption{
+				WithGo(),
+				WithJava(),
+				WithRuby(),
+			},
+			[]Project{
+				{
+					Language:      Go,
+					Path:          "go",
+					DetectionRule: "Inferred by presence of: go.mod",
+				},
+				{
+					Language:      Java,
+					Path:          "java",
+					DetectionRule: "Inferred by presence of: pom.xml",
+				},
+				{
+					Language:      Ruby,
+					Path:          "ruby",
+					DetectionRule: "Inferred by presence of: Gemfile",
+				},
+			},
+		},
 		{
 			"ExcludeLanguages",
 			[]DetectOption{
@@ -219,6 +248,36 @@ func TestDetectNested(t *testing.T) {
 	})
 }
 
+// Verifies that a project of a language that is only detected when included doesn't hide the nested projects.
+func TestDetectNestedUnderOptionalLanguage(t *testing.T) {
+	dir := t.TempDir()
+
+	// A go.mod file at the root, with a JavaScript app nested under it
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/testapp\n\ngo 1.20\n"), 0600)
+	require.NoError(t, err)
+
+	err = copyTestDataDir(t, "**/javascript/**", filepath.Join(dir, "web"))
+	require.NoError(t, err)
+
+	projects, err := Detect(dir)
+	require.NoError(t, err)
+
+	require.Len(t, projects, 1)
+	require.Equal(t, projects[0], Project{
+		Language:      JavaScript,
+		Path:          filepath.Join(dir, "web", "javascript"),
+		DetectionRule: "Inferred by presence of: package.json",
+	})
+
+	// When Go is included, the Go project at the root hides the nested project
+	projects, err = Detect(dir, WithGo())
+	require.NoError(t, err)
+
+	require.Len(t, projects, 1)
+	require.Equal(t, Go, projects[0].Language)
+	require.Equal(t, dir, projects[0].Path)
+}
+
 func copyTestDataDir(t *testing.T, glob string, dst string) error {
 	root := "testdata"
 	return fs.WalkDir(testDataFs, root, func(name string, d fs.DirEntry, err error) error {
@@ -251,3 +310,12 @@ func copyTestDataDir(t *testing.T, glob string, dst string) error {
 		return os.WriteFile(targetPath, contents, osutil.PermissionFile)
 	})
 }
+
+func writeGoModule(dir string) error {
+	err := os.MkdirAll(filepath.Join(dir, "go"), osutil.PermissionDirectory)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "go", "go.mod"), []byte("module example.com/testapp\n\ngo 1.20\n"), 0600)
+}
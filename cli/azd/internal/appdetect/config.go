@@ -60,6 +60,12 @@ func setDetectors(c *languageConfig) {
 			c.detectors = append(c.detectors, d)
 		}
 	}
+
+	for _, d := range optionalDetectors {
+		if languages[d.Language()] {
+			c.detectors = append(c.detectors, d)
+		}
+	}
 }
 
 type DetectOption interface {
@@ -90,7 +96,8 @@ type detectConfig struct {
 
 // Config that relates to project languages
 type languageConfig struct {
-	// Project languages to be detected. If unset, all known project languages are included.
+	// Project languages to be detected. If unset, all known project languages are included, except Go and Ruby which
+	// are only detected when included.
 	IncludeLanguages []Language
 	// Project languages to be excluded from detection.
 	ExcludeLanguages []Language
@@ -252,3 +259,37 @@ func (o *excludeJavaScript) applyLang(c languageConfig) languageConfig {
 func WithoutJavaScript() LanguageOption {
 	return &excludeJavaScript{}
 }
+
+type includeGo struct {
+}
+
+func (o *includeGo) apply(c detectConfig) detectConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Go)
+	return c
+}
+
+func (o *includeGo) applyLang(c languageConfig) languageConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Go)
+	return c
+}
+
+func WithGo() LanguageOption {
+	return &includeGo{}
+}
+
+type includeRuby struct {
+}
+
+func (o *includeRuby) apply(c detectConfig) detectConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Ruby)
+	return c
+}
+
+func (o *includeRuby) applyLang(c languageConfig) languageConfig {
+	c.IncludeLanguages = append(c.IncludeLanguages, Ruby)
+	return c
+}
+
+func WithRuby() LanguageOption {
+	return &includeRuby{}
+}
@@ -1,27 +0,0 @@
-package appdetect
-
-import (
-	"io/fs"
-	"strings"
-)
-
-type javaDetector struct {
-}
-
-func (jd *javaDetector) Language() Language {
-	return Java
-}
-
-func (jd *javaDetector) DetectProject(path string, entries []fs.DirEntry) (*Project, error) {
-	for _, entry := range entries {
-		if strings.ToLower(entry.Name()) == "pom.xml" {
-			return &Project{
-				Language:      Java,
-				Path:          path,
-				DetectionRule: "Inferred by presence of: " + entry.Name(),
-			}, nil
-		}
-	}
-
-	return nil, nil
-}
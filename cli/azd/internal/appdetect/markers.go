@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package appdetect
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// markerRule infers the language of a project from a marker file in the project directory.
+type markerRule struct {
+	// Pattern is a glob pattern, like go.mod or *.gemspec, matched against file names case-insensitively.
+	Pattern string
+	// Language is the language of the project when the pattern matches.
+	Language Language
+}
+
+// markerRegistry holds the rules of the languages detected only by the presence of a marker file. Languages whose
+// detection reads the project files, like Python or JavaScript dependencies, have their own detectors.
+//
+// A language is detected by adding its rules here, and a detector for it to allDetectors, or to optionalDetectors while
+// azd init can't map it to a service language.
+var markerRegistry = []markerRule{
+	{Pattern: "pom.xml", Language: Java},
+	{Pattern: "go.mod", Language: Go},
+	{Pattern: "Gemfile", Language: Ruby},
+	{Pattern: "*.gemspec", Language: Ruby},
+}
+
+// markerDetector detects the projects of a language with the rules of markerRegistry.
+type markerDetector struct {
+	language Language
+}
+
+func newMarkerDetector(language Language) *markerDetector {
+	return &markerDetector{language: language}
+}
+
+func (md *markerDetector) Language() Language {
+	return md.language
+}
+
+func (md *markerDetector) DetectProject(path string, entries []fs.DirEntry) (*Project, error) {
+	for _, rule := range markerRegistry {
+		if rule.Language != md.language {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			match, err := filepath.Match(strings.ToLower(rule.Pattern), strings.ToLower(entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			if match {
+				return &Project{
+					Language:      md.language,
+					Path:          path,
+					DetectionRule: "Inferred by presence of: " + entry.Name(),
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
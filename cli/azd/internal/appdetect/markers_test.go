@@ -0,0 +1,65 @@
+package appdetect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkerDetector(t *testing.T) {
+	tests := []struct {
+		name     string
+		language Language
+		files    []string
+		want     string
+	}{
+		{"Go", Go, []string{"go.mod", "main.go"}, "Inferred by presence of: go.mod"},
+		{"JavaMaven", Java, []string{"pom.xml"}, "Inferred by presence of: pom.xml"},
+		{"JavaMavenCaseInsensitive", Java, []string{"POM.xml"}, "Inferred by presence of: POM.xml"},
+		{"Ruby", Ruby, []string{"Gemfile", "config.ru"}, "Inferred by presence of: Gemfile"},
+		{"RubyGemspec", Ruby, []string{"testgem.gemspec"}, "Inferred by presence of: testgem.gemspec"},
+		{"NoMarker", Go, []string{"main.go"}, ""},
+		{"OtherLanguageMarker", Ruby, []string{"go.mod"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				err := os.WriteFile(filepath.Join(dir, file), []byte{}, osutil.PermissionFile)
+				require.NoError(t, err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+
+			project, err := newMarkerDetector(tt.language).DetectProject(dir, entries)
+			require.NoError(t, err)
+
+			if tt.want == "" {
+				require.Nil(t, project)
+				return
+			}
+
+			require.NotNil(t, project)
+			require.Equal(t, tt.language, project.Language)
+			require.Equal(t, dir, project.Path)
+			require.Equal(t, tt.want, project.DetectionRule)
+		})
+	}
+}
+
+func TestMarkerDetectorSkipsDirectories(t *testing.T) {
+	dir := t.TempDir()
+	err := os.Mkdir(filepath.Join(dir, "go.mod"), osutil.PermissionDirectory)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	project, err := newMarkerDetector(Go).DetectProject(dir, entries)
+	require.NoError(t, err)
+	require.Nil(t, project)
+}
@@ -7,6 +7,14 @@ type GlobalCommandOptions struct {
 	// easier)
 	Cwd string
 
+	// ProjectFile overrides the name of the project file, azure.yaml, that azd looks for when discovering the project.
+	// It's set with `--project-file`, for any command.
+	ProjectFile string
+
+	// EnvironmentName is the name of the environment to use instead of the default environment. It's set with
+	// `-e/--environment`, for any command. Commands that bind their own environment flag take precedence.
+	EnvironmentName string
+
 	// EnableDebugLogging indicates you should turn on verbose/debug logging in your command any
 	// launched tools. It's enabled with `--debug`, for any command.
 	EnableDebugLogging bool
@@ -15,6 +23,14 @@ type GlobalCommandOptions struct {
 	// if there is no default value the prompt returns an error.
 	NoPrompt bool
 
+	// NoColor disables colors and styling in console output. It's enabled with `--no-color`, for any command. Color is
+	// also disabled when the NO_COLOR environment variable is set.
+	NoColor bool
+
+	// NoHooks skips running the command and service hooks defined in azure.yaml. It's enabled with `--hooks=false`,
+	// for any command.
+	NoHooks bool
+
 	// EnableTelemetry indicates if telemetry should be sent.
 	// The rootCmd will disable this based if the environment variable
 	// AZURE_DEV_COLLECT_TELEMETRY is set to 'no'.
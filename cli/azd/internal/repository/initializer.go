@@ -41,12 +41,14 @@ func NewInitializer(
 
 // Initializes a local repository in the project directory from a remote repository.
 //
-// A confirmation prompt is displayed for any existing files to be overwritten.
+// A confirmation prompt is displayed for any existing files to be overwritten. When force is set, existing files are kept
+// without prompting, and the project file of the template is merged into the existing project file.
 func (i *Initializer) Initialize(
 	ctx context.Context,
 	azdCtx *azdcontext.AzdContext,
 	templateUrl string,
-	templateBranch string) error {
+	templateBranch string,
+	force bool) error {
 	var err error
 	stepMessage := fmt.Sprintf("Downloading template code to: %s", output.WithLinkFormat("%s", azdCtx.ProjectDirectory()))
 	i.console.ShowSpinner(ctx, stepMessage, input.Step)
@@ -71,7 +73,12 @@ func (i *Initializer) Initialize(
 		return err
 	}
 
-	skipStagingFiles, err := i.promptForDuplicates(ctx, staging, target)
+	var skipStagingFiles map[string]struct{}
+	if force {
+		skipStagingFiles, err = i.skipDuplicates(ctx, staging, target)
+	} else {
+		skipStagingFiles, err = i.promptForDuplicates(ctx, staging, target)
+	}
 	if err != nil {
 		return err
 	}
@@ -192,6 +199,56 @@ func (i *Initializer) promptForDuplicates(
 	return nil, nil
 }
 
+// skipDuplicates keeps the existing version of any duplicate files detected, and reports the files that were kept.
+// The project file is the exception, the template project file is merged into the existing one.
+// The list of absolute source file paths to skip are returned.
+func (i *Initializer) skipDuplicates(
+	ctx context.Context, staging string, target string) (skipSourceFiles map[string]struct{}, err error) {
+	duplicateFiles, err := determineDuplicates(staging, target)
+	if err != nil {
+		return nil, fmt.Errorf("checking for overwrites: %w", err)
+	}
+
+	if len(duplicateFiles) == 0 {
+		return nil, nil
+	}
+
+	i.console.StopSpinner(ctx, "", input.StepDone)
+
+	skipSourceFiles = make(map[string]struct{}, len(duplicateFiles))
+	skippedFiles := []string{}
+	for _, file := range duplicateFiles {
+		// this also cleans the result, which is important for matching
+		sourceFile := filepath.Join(staging, file)
+		skipSourceFiles[sourceFile] = struct{}{}
+
+		if file == azdcontext.ProjectFileName {
+			if err := mergeProjectFile(filepath.Join(target, file), sourceFile); err != nil {
+				return nil, err
+			}
+
+			i.console.MessageUxItem(ctx, &ux.DoneMessage{
+				Message: fmt.Sprintf("Merged the template %s into the existing file", azdcontext.ProjectFileName),
+			})
+			continue
+		}
+
+		skippedFiles = append(skippedFiles, file)
+	}
+
+	if len(skippedFiles) > 0 {
+		i.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: "The following files are present both locally and in the template, and were kept unchanged:",
+		})
+
+		for _, file := range skippedFiles {
+			i.console.Message(ctx, fmt.Sprintf(" * %s", file))
+		}
+	}
+
+	return skipSourceFiles, nil
+}
+
 func (i *Initializer) gitInitialize(ctx context.Context,
 	target string,
 	executableFilesToRestore []string,
@@ -265,6 +322,13 @@ func parseExecutableFiles(stagedFilesOutput string) ([]string, error) {
 	return executableFiles, nil
 }
 
+// InitializeScaffolding creates only the azd control files: an azure.yaml without services, the .azure directory, and
+// an entry for .azure in .gitignore. Unlike InitializeMinimal, no infrastructure files are written and no git repository
+// is initialized, which gives a clean starting point for bringing existing code under azd.
+func (i *Initializer) InitializeScaffolding(ctx context.Context, azdCtx *azdcontext.AzdContext) error {
+	return i.writeCoreAssets(ctx, azdCtx)
+}
+
 // Initializes a minimal azd project.
 func (i *Initializer) InitializeMinimal(ctx context.Context, azdCtx *azdcontext.AzdContext) error {
 	projectDir := azdCtx.ProjectDirectory()
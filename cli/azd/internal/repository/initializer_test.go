@@ -83,7 +83,7 @@ func Test_Initializer_Initialize(t *testing.T) {
 				})
 
 			i := NewInitializer(console, git.NewGitCli(mockRunner))
-			err := i.Initialize(ctx, azdCtx, "local", "")
+			err := i.Initialize(ctx, azdCtx, "local", "", false)
 			require.NoError(t, err)
 
 			verifyTemplateCopied(t, testDataPath(tt.templateDir), projectDir, verifyOptions{})
@@ -96,6 +96,25 @@ func Test_Initializer_Initialize(t *testing.T) {
 	}
 }
 
+func Test_Initializer_Initialize_BranchNotFound(t *testing.T) {
+	projectDir := t.TempDir()
+	ctx := context.Background()
+	azdCtx := azdcontext.NewAzdContextWithDirectory(projectDir)
+	console := mockinput.NewMockConsole()
+	mockRunner := mockexec.NewMockCommandRunner()
+	mockRunner.When(func(args exec.RunArgs, command string) bool {
+		return slices.Contains(args.Args, "clone")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		stderr := "fatal: Remote branch missing-branch not found in upstream origin"
+		return exec.NewRunResult(128, "", stderr), fmt.Errorf("exit code: 128, stderr: %s", stderr)
+	})
+
+	i := NewInitializer(console, git.NewGitCli(mockRunner))
+	err := i.Initialize(ctx, azdCtx, "local", "missing-branch", false)
+	require.ErrorIs(t, err, git.ErrBranchNotFound)
+	require.Contains(t, err.Error(), "missing-branch")
+}
+
 func Test_Initializer_InitializeWithOverwritePrompt(t *testing.T) {
 	templateDir := "template"
 	tests := []struct {
@@ -146,7 +165,7 @@ func Test_Initializer_InitializeWithOverwritePrompt(t *testing.T) {
 				})
 
 			i := NewInitializer(console, git.NewGitCli(mockRunner))
-			err = i.Initialize(context.Background(), azdCtx, "local", "")
+			err = i.Initialize(context.Background(), azdCtx, "local", "", false)
 			require.NoError(t, err)
 
 			switch tt.selection {
@@ -180,6 +199,63 @@ func Test_Initializer_InitializeWithOverwritePrompt(t *testing.T) {
 	}
 }
 
+func Test_Initializer_InitializeWithForce(t *testing.T) {
+	templateDir := "template"
+	originalReadme := "ORIGINAL"
+	projectDir := t.TempDir()
+	azdCtx := azdcontext.NewAzdContextWithDirectory(projectDir)
+	// set up a duplicate file, and an existing project file that is merged with the template
+	err := os.WriteFile(filepath.Join(projectDir, "README.md"), []byte(originalReadme), osutil.PermissionFile)
+	require.NoError(t, err, "setting up duplicate readme.md")
+	err = os.WriteFile(azdCtx.ProjectPath(), []byte("name: existing-app\n"), osutil.PermissionFile)
+	require.NoError(t, err, "setting up existing azure.yaml")
+
+	// no prompts are expected
+	console := mockinput.NewMockConsole()
+
+	realRunner := exec.NewCommandRunner(nil)
+	mockRunner := mockexec.NewMockCommandRunner()
+	mockRunner.When(func(args exec.RunArgs, command string) bool { return true }).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			// Stub out git clone, otherwise run actual command
+			if slices.Contains(args.Args, "clone") && slices.Contains(args.Args, "local") {
+				stagingDir := args.Args[len(args.Args)-1]
+				copyTemplate(t, testDataPath(templateDir), stagingDir)
+				_, err := realRunner.Run(context.Background(), exec.NewRunArgs("git", "-C", stagingDir, "init"))
+				require.NoError(t, err)
+
+				return exec.NewRunResult(0, "", ""), nil
+			}
+
+			return realRunner.Run(context.Background(), args)
+		})
+
+	i := NewInitializer(console, git.NewGitCli(mockRunner))
+	err = i.Initialize(context.Background(), azdCtx, "local", "", true)
+	require.NoError(t, err)
+
+	// skipped
+	content, err := os.ReadFile(filepath.Join(projectDir, "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, originalReadme, string(content))
+
+	// merged
+	content, err = os.ReadFile(azdCtx.ProjectPath())
+	require.NoError(t, err)
+	require.Equal(t, "name: existing-app\nmetadata:\n  template: azd-test/webapptest@v1\n", string(content))
+
+	verifyTemplateCopied(t, testDataPath(templateDir), projectDir, verifyOptions{
+		Skip: func(src string) (bool, error) {
+			return src == testDataPath(templateDir, "README.md.txt") ||
+				src == testDataPath(templateDir, "azure.yaml.txt"), nil
+		},
+	})
+
+	output := strings.Join(console.Output(), "\n")
+	require.Contains(t, output, "were kept unchanged")
+	require.Contains(t, output, "README.md")
+}
+
 // Copy all files from source to target, removing *.txt suffix.
 func copyTemplate(t *testing.T, source string, target string) {
 	err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
@@ -409,6 +485,36 @@ func verifyProjectFile(t *testing.T, azdCtx *azdcontext.AzdContext, content stri
 	require.NoError(t, err)
 }
 
+func Test_Initializer_InitializeScaffolding(t *testing.T) {
+	projectDir := t.TempDir()
+	azdCtx := azdcontext.NewAzdContextWithDirectory(projectDir)
+
+	// Existing code is left untouched
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "app.py"), []byte("print('hello')"), osutil.PermissionFile))
+
+	console := mockinput.NewMockConsole()
+	mockRunner := mockexec.NewMockCommandRunner()
+	i := NewInitializer(console, git.NewGitCli(mockRunner))
+	err := i.InitializeScaffolding(context.Background(), azdCtx)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(projectDir)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	// Only the azd control files are written, without any template, infrastructure or git files
+	require.ElementsMatch(t, []string{".azure", ".gitignore", "app.py", "azure.yaml"}, names)
+	require.Equal(t, "print('hello')", readFile(t, filepath.Join(projectDir, "app.py")))
+
+	projectConfig, err := project.Load(context.Background(), azdCtx.ProjectPath())
+	require.NoError(t, err)
+	require.Empty(t, projectConfig.Services)
+}
+
 func Test_determineDuplicates(t *testing.T) {
 	type args struct {
 		sourceFiles []string
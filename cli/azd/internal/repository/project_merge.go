@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"gopkg.in/yaml.v3"
+)
+
+// mergeProjectFile merges the project file at templatePath into the project file at path.
+// Values already present in path are kept, and keys only defined by the template, like services or hooks, are added.
+// Services already defined in path are left untouched, only the services missing from it are added.
+func mergeProjectFile(path string, templatePath string) error {
+	existing, err := readYamlDocument(path)
+	if err != nil {
+		return err
+	}
+
+	template, err := readYamlDocument(templatePath)
+	if err != nil {
+		return err
+	}
+
+	mergeYamlNodes(existing, template)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(existing); err != nil {
+		return fmt.Errorf("encoding merged project file: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encoding merged project file: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing merged project file: %w", err)
+	}
+
+	return nil
+}
+
+func readYamlDocument(path string) (*yaml.Node, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading project file: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("parsing project file %s: %w", path, err)
+	}
+
+	return &document, nil
+}
+
+// mergeYamlNodes adds the keys of the mapping src that are missing from the mapping dst, merging mappings present in both.
+// Scalars and sequences present in dst are left unchanged.
+func mergeYamlNodes(dst *yaml.Node, src *yaml.Node) {
+	// An empty document has no content, in which case the whole of src is used
+	if dst.Kind == 0 || (dst.Kind == yaml.DocumentNode && len(dst.Content) == 0) {
+		*dst = *src
+		return
+	}
+
+	if dst.Kind == yaml.DocumentNode && src.Kind == yaml.DocumentNode {
+		if len(src.Content) > 0 {
+			mergeProjectNodes(dst.Content[0], src.Content[0])
+		}
+
+		return
+	}
+
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return
+	}
+
+	for k := 0; k+1 < len(src.Content); k += 2 {
+		key, value := src.Content[k], src.Content[k+1]
+		if existing := mappingValue(dst, key.Value); existing != nil {
+			mergeYamlNodes(existing, value)
+		} else {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+}
+
+// mergeProjectNodes merges the root mapping src of a project file into the root mapping dst, like mergeYamlNodes.
+// The services of the template are only added when dst doesn't define a service with the same name, so the settings of
+// the services defined by the user are never changed.
+func mergeProjectNodes(dst *yaml.Node, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return
+	}
+
+	for k := 0; k+1 < len(src.Content); k += 2 {
+		key, value := src.Content[k], src.Content[k+1]
+		existing := mappingValue(dst, key.Value)
+		switch {
+		case existing == nil:
+			dst.Content = append(dst.Content, key, value)
+		case key.Value == "services" && existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			for s := 0; s+1 < len(value.Content); s += 2 {
+				if mappingValue(existing, value.Content[s].Value) == nil {
+					existing.Content = append(existing.Content, value.Content[s], value.Content[s+1])
+				}
+			}
+		default:
+			mergeYamlNodes(existing, value)
+		}
+	}
+}
+
+// mappingValue returns the value of key in the mapping node, or nil when the key isn't present.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for k := 0; k+1 < len(mapping.Content); k += 2 {
+		if mapping.Content[k].Value == key {
+			return mapping.Content[k+1]
+		}
+	}
+
+	return nil
+}
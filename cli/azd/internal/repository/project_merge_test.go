@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mergeProjectFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		template string
+		expected string
+	}{
+		{
+			name:     "AddsMissingKeys",
+			existing: "name: my-app\n",
+			template: heredoc.Doc(`
+				name: template-app
+				metadata:
+				  template: todo@1.0.0
+			`),
+			expected: heredoc.Doc(`
+				name: my-app
+				metadata:
+				  template: todo@1.0.0
+			`),
+		},
+		{
+			name: "AddsMissingServices",
+			existing: heredoc.Doc(`
+				# my app
+				name: my-app
+				services:
+				  api:
+				    project: ./src/api # the api
+				    language: py
+			`),
+			template: heredoc.Doc(`
+				name: template-app
+				services:
+				  api:
+				    project: ./api
+				    language: js
+				    host: containerapp
+				  web:
+				    project: ./web
+				    host: staticwebapp
+			`),
+			expected: heredoc.Doc(`
+				# my app
+				name: my-app
+				services:
+				  api:
+				    project: ./src/api # the api
+				    language: py
+				  web:
+				    project: ./web
+				    host: staticwebapp
+			`),
+		},
+		{
+			name:     "KeepsSequences",
+			existing: "name: my-app\nrequiredVersions:\n  - a\n",
+			template: "name: template-app\nrequiredVersions:\n  - b\n",
+			expected: "name: my-app\nrequiredVersions:\n  - a\n",
+		},
+		{
+			name:     "EmptyExisting",
+			existing: "",
+			template: "name: template-app\n",
+			expected: "name: template-app\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "azure.yaml")
+			templatePath := filepath.Join(dir, "template.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.existing), osutil.PermissionFile))
+			require.NoError(t, os.WriteFile(templatePath, []byte(tt.template), osutil.PermissionFile))
+
+			err := mergeProjectFile(path, templatePath)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func Test_mergeProjectFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "azure.yaml")
+	templatePath := filepath.Join(dir, "template.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: [my-app"), osutil.PermissionFile))
+	require.NoError(t, os.WriteFile(templatePath, []byte("name: template-app\n"), osutil.PermissionFile))
+
+	err := mergeProjectFile(path, templatePath)
+	require.ErrorContains(t, err, "parsing project file")
+}
@@ -15,7 +15,7 @@ import (
 //
 //nolint:lll
 const cTelemetryNoticeText = `The Azure Developer CLI collects usage data and sends that usage data to Microsoft in order to help us improve your experience.
-You can opt-out of telemetry by setting the AZURE_DEV_COLLECT_TELEMETRY environment variable to 'no' in the shell you use.
+You can opt-out of telemetry by running 'azd config set telemetry.enabled false', or by setting the AZURE_DEV_COLLECT_TELEMETRY environment variable to 'no' in the shell you use.
 
 Read more about Azure Developer CLI telemetry: https://github.com/Azure/azure-dev#data-collection`
 
@@ -6,11 +6,13 @@ package telemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
@@ -65,9 +67,17 @@ func getTelemetryDirectory() (string, error) {
 	return telemetryDir, nil
 }
 
+// the user config setting that enables or disables telemetry, overridden by AZURE_DEV_COLLECT_TELEMETRY
+const telemetryEnabledConfigKey = "telemetry.enabled"
+
 func IsTelemetryEnabled() bool {
 	// If the user has opted out of telemetry directly, don't collect telemetry.
-	if os.Getenv(collectTelemetryEnvVar) == "no" {
+	// The environment variable, when set, overrides the user config.
+	if value, has := os.LookupEnv(collectTelemetryEnvVar); has {
+		if value == "no" {
+			return false
+		}
+	} else if enabled, has := telemetryEnabledInConfig(); has && !enabled {
 		return false
 	}
 
@@ -79,6 +89,37 @@ func IsTelemetryEnabled() bool {
 	return true
 }
 
+// telemetryEnabledInConfig returns the value of `telemetry.enabled` in the user config, and whether it's set.
+func telemetryEnabledInConfig() (bool, bool) {
+	configFilePath, err := config.GetUserConfigFilePath()
+	if err != nil {
+		return false, false
+	}
+
+	userConfig, err := config.NewFileConfigManager(config.NewManager()).Load(configFilePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("failed to load user config for telemetry settings: %v", err)
+		}
+
+		return false, false
+	}
+
+	value, has := userConfig.Get(telemetryEnabledConfigKey)
+	if !has {
+		return false, false
+	}
+
+	// Values set with `azd config set` are stored as strings
+	enabled, err := strconv.ParseBool(fmt.Sprint(value))
+	if err != nil {
+		log.Printf("ignoring invalid value '%v' for %s: %v", value, telemetryEnabledConfigKey, err)
+		return false, false
+	}
+
+	return enabled, true
+}
+
 // Returns the singleton TelemetrySystem instance.
 // Returns nil if telemetry failed to initialize, or user has disabled telemetry.
 func GetTelemetrySystem() *TelemetrySystem {
@@ -2,6 +2,8 @@ package telemetry
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -81,6 +83,52 @@ func TestGetTelemetrySystem(t *testing.T) {
 	}
 }
 
+func TestIsTelemetryEnabled_UserConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		configValue   string
+		envVarValue   string
+		expectEnabled bool
+	}{
+		{"ConfigDisabled", "false", "unset", false},
+		{"ConfigEnabled", "true", "unset", true},
+		{"ConfigInvalid", "sometimes", "unset", true},
+		{"EnvVarOverridesConfigDisabled", "false", "yes", true},
+		{"EnvVarOverridesConfigEnabled", "true", "no", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			ostest.Setenv(t, "AZD_CONFIG_DIR", configDir)
+			err := os.WriteFile(
+				filepath.Join(configDir, "config.json"),
+				[]byte(`{"telemetry":{"enabled":"`+tt.configValue+`"}}`),
+				0600)
+			require.NoError(t, err)
+
+			if tt.envVarValue == "unset" {
+				ostest.Unsetenv(t, collectTelemetryEnvVar)
+			} else {
+				ostest.Setenv(t, collectTelemetryEnvVar, tt.envVarValue)
+			}
+
+			assert.Equal(t, tt.expectEnabled, IsTelemetryEnabled())
+
+			// The telemetry system, and with it any emitter, is only created when telemetry is enabled
+			ts := GetTelemetrySystem()
+			if tt.expectEnabled {
+				require.NotNil(t, ts)
+				assert.NoError(t, ts.Shutdown(context.Background()))
+			} else {
+				assert.Nil(t, ts)
+			}
+
+			once = sync.Once{}
+			instance = nil
+		})
+	}
+}
+
 func TestTelemetrySystem_RunBackgroundUpload(t *testing.T) {
 	type args struct {
 		ctx                context.Context
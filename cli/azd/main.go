@@ -60,7 +60,7 @@ func main() {
 
 	cmdErr := cmd.NewRootCmd(false, nil).ExecuteContext(ctx)
 
-	if !isJsonOutput() {
+	if !isJsonOutput() && !isOutputSuppressed() {
 		if firstNotice := telemetry.FirstNotice(); firstNotice != "" {
 			fmt.Fprintln(os.Stderr, output.WithWarningFormat(firstNotice))
 		}
@@ -74,8 +74,8 @@ func main() {
 	// a version is not explicitly applied at build time (i.e. dev builds installed with `go install`)
 	//
 	// Don't write this message when JSON output is enabled, since in that case we use stderr to return structured
-	// information about command progress.
-	if !isJsonOutput() && ok {
+	// information about command progress, or when output is suppressed with `--output none`.
+	if !isJsonOutput() && !isOutputSuppressed() && ok {
 		if internal.IsDevVersion() {
 			// This is a dev build (i.e. built using `go install without setting a version`) - don't print a warning in this
 			// case
@@ -327,6 +327,16 @@ func isDebugEnabled() bool {
 
 // isJsonOutput checks to see if `--output` was passed with the value `json`
 func isJsonOutput() bool {
+	return outputFlag() == "json"
+}
+
+// isOutputSuppressed checks to see if `--output` was passed with the value `none`
+func isOutputSuppressed() bool {
+	return outputFlag() == "none"
+}
+
+// outputFlag returns the value of `--output` on the command line, or an empty string when it is not set
+func outputFlag() string {
 	output := ""
 	flags := pflag.NewFlagSet("", pflag.ContinueOnError)
 
@@ -344,7 +354,7 @@ func isJsonOutput() bool {
 
 	_ = flags.Parse(os.Args[1:])
 
-	return output == "json"
+	return output
 }
 
 func readToEndAndClose(r io.ReadCloser) (string, error) {
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"slices"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 )
 
@@ -29,6 +31,7 @@ type Manager interface {
 	HasDefaultSubscription() bool
 	HasDefaultLocation() bool
 	GetAccountDefaults(ctx context.Context) (*Account, error)
+	GetDefaultLocation(ctx context.Context, subscriptionId string) (*Location, error)
 	GetDefaultLocationName(ctx context.Context) string
 	GetDefaultSubscriptionID(ctx context.Context) string
 	GetSubscriptions(ctx context.Context) ([]Subscription, error)
@@ -36,6 +39,7 @@ type Manager interface {
 	GetLocations(ctx context.Context, subscriptionId string) ([]Location, error)
 	SetDefaultSubscription(ctx context.Context, subscriptionId string) (*Subscription, error)
 	SetDefaultLocation(ctx context.Context, subscriptionId string, location string) (*Location, error)
+	EnsureSubscriptionAccess(ctx context.Context, subscriptionId string, resourceGroupName string) error
 }
 
 // Manages azd account configuration
@@ -90,7 +94,7 @@ func (m *manager) GetAccountDefaults(ctx context.Context) (*Account, error) {
 	if subscription == nil {
 		location = &defaultLocation
 	} else {
-		location, err = m.getDefaultLocation(ctx, subscription.Id)
+		location, err = m.GetDefaultLocation(ctx, subscription.Id)
 		if err != nil {
 			return nil, fmt.Errorf("failed retrieving default location: %w", err)
 		}
@@ -294,7 +298,7 @@ func (m *manager) GetDefaultLocationName(ctx context.Context) string {
 
 // Gets the default Azure location stored in configuration
 // When specified in azd config, will return the location when valid, otherwise azd global default (eastus2)
-func (m *manager) getDefaultLocation(ctx context.Context, subscriptionId string) (*Location, error) {
+func (m *manager) GetDefaultLocation(ctx context.Context, subscriptionId string) (*Location, error) {
 	configLocation, ok := m.config.Get(defaultLocationKeyPath)
 	if !ok {
 		return &defaultLocation, nil
@@ -316,3 +320,39 @@ func (m *manager) getDefaultLocation(ctx context.Context, subscriptionId string)
 
 	return &allLocations[index], nil
 }
+
+// EnsureSubscriptionAccess checks that the current account is allowed to create deployments in the given subscription,
+// or in the given resource group when resourceGroupName is not empty. A resource group that doesn't exist yet is created
+// by the deployment, so the subscription is checked instead. An error wrapping ErrInsufficientPermissions is returned
+// when the account does not have the required access.
+func (m *manager) EnsureSubscriptionAccess(ctx context.Context, subscriptionId string, resourceGroupName string) error {
+	permissions, err := m.subManager.ListPermissions(ctx, subscriptionId, resourceGroupName)
+
+	var responseErr *azcore.ResponseError
+	if resourceGroupName != "" && errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+		log.Printf("resource group '%s' not found, checking permissions for the subscription", resourceGroupName)
+		resourceGroupName = ""
+		permissions, err = m.subManager.ListPermissions(ctx, subscriptionId, resourceGroupName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("checking permissions for subscription '%s': %w", subscriptionId, err)
+	}
+
+	if hasPermission(permissions, deploymentWriteAction) {
+		return nil
+	}
+
+	scope := fmt.Sprintf("subscription '%s'", subscriptionId)
+	if resourceGroupName != "" {
+		scope = fmt.Sprintf("resource group '%s' in %s", resourceGroupName, scope)
+	}
+
+	return fmt.Errorf(
+		"%w: the current account is not allowed to create deployments (%s) in %s. "+
+			"Ensure the account has the Contributor or Owner role assigned.",
+		ErrInsufficientPermissions,
+		deploymentWriteAction,
+		scope,
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
@@ -548,6 +549,71 @@ var allTestSubscriptions []*armsubscriptions.Subscription = []*armsubscriptions.
 	},
 }
 
+func Test_EnsureSubscriptionAccess(t *testing.T) {
+	setupPermissionsMock := func(mockHttp *mockhttp.MockHttpClient, permissions []Permission) {
+		mockHttp.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet &&
+				strings.HasSuffix(request.URL.Path, "/providers/Microsoft.Authorization/permissions")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, permissionListResult{Value: permissions})
+		})
+	}
+
+	newManager := func(t *testing.T, mockHttp *mockhttp.MockHttpClient) Manager {
+		manager, err := NewManager(
+			mockconfig.NewMockConfigManager().WithConfig(config.NewEmptyConfig()),
+			NewSubscriptionsManagerWithCache(
+				NewSubscriptionsService(
+					&mocks.MockMultiTenantCredentialProvider{},
+					mockHttp,
+				),
+				NewBypassSubscriptionsCache(),
+			),
+		)
+		require.NoError(t, err)
+
+		return manager
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		mockHttp := mockhttp.NewMockHttpUtil()
+		setupAccountMocks(mockHttp)
+		setupPermissionsMock(mockHttp, []Permission{{Actions: []string{"*"}}})
+
+		manager := newManager(t, mockHttp)
+		err := manager.EnsureSubscriptionAccess(context.Background(), "SUBSCRIPTION_01", "")
+		require.NoError(t, err)
+	})
+
+	t.Run("InsufficientPermissions", func(t *testing.T) {
+		mockHttp := mockhttp.NewMockHttpUtil()
+		setupAccountMocks(mockHttp)
+		setupPermissionsMock(mockHttp, []Permission{{Actions: []string{"*/read"}}})
+
+		manager := newManager(t, mockHttp)
+		err := manager.EnsureSubscriptionAccess(context.Background(), "SUBSCRIPTION_01", "RESOURCE_GROUP")
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+		require.ErrorContains(t, err, "resource group 'RESOURCE_GROUP' in subscription 'SUBSCRIPTION_01'")
+	})
+
+	t.Run("ResourceGroupNotFound", func(t *testing.T) {
+		mockHttp := mockhttp.NewMockHttpUtil()
+		setupAccountMocks(mockHttp)
+		setupPermissionsMock(mockHttp, []Permission{{Actions: []string{"*/read"}}})
+		mockHttp.When(func(request *http.Request) bool {
+			return strings.Contains(request.URL.Path, "/resourceGroups/RESOURCE_GROUP/")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateEmptyHttpResponse(request, http.StatusNotFound)
+		})
+
+		manager := newManager(t, mockHttp)
+		err := manager.EnsureSubscriptionAccess(context.Background(), "SUBSCRIPTION_01", "RESOURCE_GROUP")
+		require.ErrorIs(t, err, ErrInsufficientPermissions)
+		require.ErrorContains(t, err, "in subscription 'SUBSCRIPTION_01'")
+		require.NotContains(t, err.Error(), "RESOURCE_GROUP")
+	})
+}
+
 func setupGetSubscriptionMock(mockHttp *mockhttp.MockHttpClient, subscription *Subscription, err error) {
 	if err != nil {
 		isSub := func(request *http.Request) bool {
@@ -24,3 +24,10 @@ type Location struct {
 	// region name (e.g "(US) West US 2")
 	RegionalDisplayName string `json:"regionalDisplayName"`
 }
+
+// Permission is a set of actions the current account is allowed to perform at a given scope,
+// as returned by the Azure authorization permissions API.
+type Permission struct {
+	Actions    []string `json:"actions"`
+	NotActions []string `json:"notActions"`
+}
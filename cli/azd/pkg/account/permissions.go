@@ -0,0 +1,42 @@
+package account
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrInsufficientPermissions is returned when the current account is missing permissions required for an operation
+var ErrInsufficientPermissions = errors.New("insufficient permissions")
+
+// deploymentWriteAction is the action required to create ARM deployments, which is the minimum access
+// needed to provision infrastructure for an application.
+const deploymentWriteAction = "Microsoft.Resources/deployments/write"
+
+// hasPermission reports whether the set of permissions allows the specified action. An action is allowed when it
+// matches any of the actions of a permission, and none of the not-actions of that same permission.
+// Actions are matched case-insensitively and may contain '*' wildcards.
+func hasPermission(permissions []Permission, action string) bool {
+	for _, permission := range permissions {
+		if matchesAnyAction(permission.Actions, action) && !matchesAnyAction(permission.NotActions, action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAnyAction(patterns []string, action string) bool {
+	for _, pattern := range patterns {
+		if actionPatternRegex(pattern).MatchString(action) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func actionPatternRegex(pattern string) *regexp.Regexp {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile("(?i)^" + expr + "$")
+}
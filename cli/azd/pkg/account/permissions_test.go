@@ -0,0 +1,58 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hasPermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []Permission
+		expected    bool
+	}{
+		{
+			name:        "Owner",
+			permissions: []Permission{{Actions: []string{"*"}}},
+			expected:    true,
+		},
+		{
+			name: "Contributor",
+			permissions: []Permission{{
+				Actions:    []string{"*"},
+				NotActions: []string{"Microsoft.Authorization/*/Delete", "Microsoft.Authorization/*/Write"},
+			}},
+			expected: true,
+		},
+		{
+			name:        "Reader",
+			permissions: []Permission{{Actions: []string{"*/read"}}},
+			expected:    false,
+		},
+		{
+			name:        "ExplicitAction",
+			permissions: []Permission{{Actions: []string{"microsoft.resources/deployments/*"}}},
+			expected:    true,
+		},
+		{
+			name: "ExcludedByNotAction",
+			permissions: []Permission{{
+				Actions:    []string{"*"},
+				NotActions: []string{"Microsoft.Resources/deployments/write"},
+			}},
+			expected: false,
+		},
+		{
+			name:        "NoPermissions",
+			permissions: []Permission{},
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, hasPermission(tt.permissions, deploymentWriteAction))
+		})
+	}
+}
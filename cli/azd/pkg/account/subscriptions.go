@@ -3,14 +3,20 @@ package account
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/compare"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
@@ -177,6 +183,74 @@ func (s *SubscriptionsService) ListTenants(ctx context.Context) ([]armsubscripti
 	return tenants, nil
 }
 
+// ListPermissions lists the permissions the current account has on the given subscription, or on the given
+// resource group when resourceGroupName is not empty.
+func (s *SubscriptionsService) ListPermissions(
+	ctx context.Context,
+	subscriptionId string,
+	tenantId string,
+	resourceGroupName string,
+) ([]Permission, error) {
+	cred, err := s.credentialProvider.GetTokenCredential(ctx, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	options := clientOptions(s.httpClient, s.userAgent)
+	pipeline, err := armruntime.NewPipeline(
+		"azd-permissions", azdinternal.Version, cred, runtime.PipelineOptions{}, options)
+	if err != nil {
+		return nil, fmt.Errorf("creating permissions pipeline: %w", err)
+	}
+
+	// The endpoint of the cloud the client is configured for, which the pipeline also requests tokens for
+	cloudConfig := options.Cloud
+	if cloudConfig.Services == nil {
+		cloudConfig = cloud.AzurePublic
+	}
+	endpoint := strings.TrimSuffix(cloudConfig.Services[cloud.ResourceManager].Endpoint, "/")
+
+	scope := azure.SubscriptionRID(subscriptionId)
+	if resourceGroupName != "" {
+		scope = azure.ResourceGroupRID(subscriptionId, resourceGroupName)
+	}
+
+	requestUrl := fmt.Sprintf(
+		"%s%s/providers/Microsoft.Authorization/permissions?api-version=2022-04-01", endpoint, scope)
+
+	permissions := []Permission{}
+	for requestUrl != "" {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, requestUrl)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		response, err := pipeline.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !runtime.HasStatusCode(response, http.StatusOK) {
+			return nil, runtime.NewResponseError(response)
+		}
+
+		page, err := httputil.ReadRawResponse[permissionListResult](response)
+		if err != nil {
+			return nil, fmt.Errorf("reading permissions: %w", err)
+		}
+
+		permissions = append(permissions, page.Value...)
+		requestUrl = page.NextLink
+	}
+
+	return permissions, nil
+}
+
+type permissionListResult struct {
+	Value    []Permission `json:"value"`
+	NextLink string       `json:"nextLink"`
+}
+
 func clientOptions(httpClient httputil.HttpClient, userAgent string) *arm.ClientOptions {
 	return &arm.ClientOptions{
 		ClientOptions: policy.ClientOptions{
@@ -309,6 +309,21 @@ func (m *SubscriptionsManager) GetSubscription(ctx context.Context, subscription
 	return &sub, nil
 }
 
+// ListPermissions lists the permissions the current account has on the given subscription, or on the given
+// resource group when resourceGroupName is not empty.
+func (m *SubscriptionsManager) ListPermissions(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+) ([]Permission, error) {
+	tenantId, err := m.LookupTenant(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.service.ListPermissions(ctx, subscriptionId, tenantId, resourceGroupName)
+}
+
 func toSubscriptions(azSubs []*armsubscriptions.Subscription, userAccessTenantId string) []Subscription {
 	if azSubs == nil {
 		return nil
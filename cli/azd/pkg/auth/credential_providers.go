@@ -50,6 +50,8 @@ func (t *multiTenantCredentialProvider) GetTokenCredential(
 		return nil, err
 	}
 
-	t.tenantCredentials.Store(tenantId, credential)
-	return credential, nil
+	// Cache the tokens per scope, as the same tenant credential is used for different audiences.
+	scopedCredential := newScopedTokenCredential(credential)
+	t.tenantCredentials.Store(tenantId, scopedCredential)
+	return scopedCredential, nil
 }
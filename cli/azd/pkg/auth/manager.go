@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
@@ -22,6 +23,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/github"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
@@ -44,6 +46,11 @@ const cCurrentUserKey = "auth.account.currentUser"
 // it ourselves. The value should be a string as specified by [strconv.ParseBool].
 const cUseAzCliAuthKey = "auth.useAzCliAuth"
 
+// cUseAzCliKey is the key we use in config to denote that we want to reuse the account signed in to the az CLI when az is
+// installed and signed in, and fall back to our own authentication otherwise. The value should be a string as specified by
+// [strconv.ParseBool].
+const cUseAzCliKey = "auth.useAzCli"
+
 // cAuthConfigFileName is the name of the file we store in the user configuration directory which is used to persist
 // auth related configuration information (e.g. the home account id of the current user). This information is not secret.
 const cAuthConfigFileName = "auth.json"
@@ -97,6 +104,15 @@ type Manager struct {
 	ghClient            *github.FederatedTokenClient
 	httpClient          HttpClient
 	console             input.Console
+	commandRunner       exec.CommandRunner
+
+	// Whether the az CLI is installed and signed in, checked once when auth.useAzCli is set, see useAzCli.
+	azCliSignedIn     bool
+	azCliSignedInOnce sync.Once
+
+	// Credential for the current user that caches access tokens per set of scopes, see GetToken.
+	scopedCredential   *scopedTokenCredential
+	scopedCredentialMu sync.Mutex
 }
 
 func NewManager(
@@ -104,6 +120,7 @@ func NewManager(
 	userConfigManager config.UserConfigManager,
 	httpClient HttpClient,
 	console input.Console,
+	commandRunner exec.CommandRunner,
 ) (*Manager, error) {
 	cfgRoot, err := config.GetUserConfigDir()
 	if err != nil {
@@ -142,6 +159,7 @@ func NewManager(
 		ghClient:            ghClient,
 		httpClient:          httpClient,
 		console:             console,
+		commandRunner:       commandRunner,
 	}, nil
 }
 
@@ -158,9 +176,31 @@ func EnsureLoggedInCredential(ctx context.Context, credential azcore.TokenCreden
 	return &token, nil
 }
 
-// CredentialForCurrentUser returns a TokenCredential instance for the current user. If `auth.useLegacyAzCliAuth` is set to
-// a truthy value in config, an instance of azidentity.AzureCLICredential is returned instead. To accept the default options,
-// pass nil.
+// GetToken acquires an access token for the current user for the given scopes. Tokens are cached per set of scopes,
+// which allows callers that need different audiences (e.g. ARM and Microsoft Graph) to share the same signed in user
+// without re-authenticating.
+func (m *Manager) GetToken(ctx context.Context, scopes []string) (azcore.AccessToken, error) {
+	m.scopedCredentialMu.Lock()
+	if m.scopedCredential == nil {
+		credential, err := m.CredentialForCurrentUser(ctx, nil)
+		if err != nil {
+			m.scopedCredentialMu.Unlock()
+			return azcore.AccessToken{}, err
+		}
+
+		m.scopedCredential = newScopedTokenCredential(credential)
+	}
+	scopedCredential := m.scopedCredential
+	m.scopedCredentialMu.Unlock()
+
+	return scopedCredential.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: scopes,
+	})
+}
+
+// CredentialForCurrentUser returns a TokenCredential instance for the current user. If `auth.useAzCliAuth` is set to
+// a truthy value in config, or `auth.useAzCli` is and az is signed in, an instance of azidentity.AzureCLICredential is
+// returned instead. To accept the default options, pass nil.
 func (m *Manager) CredentialForCurrentUser(
 	ctx context.Context,
 	options *CredentialForCurrentUserOptions,
@@ -183,8 +223,8 @@ func (m *Manager) CredentialForCurrentUser(
 		return nil, fmt.Errorf("fetching current user: %w", err)
 	}
 
-	if shouldUseLegacyAuth(userConfig) {
-		log.Printf("delegating auth to az since %s is set to true", cUseAzCliAuthKey)
+	if m.useAzCli(ctx, userConfig) {
+		log.Printf("delegating auth to az since %s or %s is set to true", cUseAzCliAuthKey, cUseAzCliKey)
 		cred, err := azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{
 			TenantID: options.TenantID,
 		})
@@ -258,7 +298,8 @@ func (m *Manager) CredentialForCurrentUser(
 		if ps.ClientSecret != nil {
 			return m.newCredentialFromClientSecret(tenantID, *currentUser.ClientID, *ps.ClientSecret)
 		} else if ps.ClientCertificate != nil {
-			return m.newCredentialFromClientCertificate(tenantID, *currentUser.ClientID, *ps.ClientCertificate)
+			return m.newCredentialFromClientCertificate(
+				tenantID, *currentUser.ClientID, *ps.ClientCertificate, ps.ClientCertificatePassword)
 		} else if ps.FederatedAuth != nil && ps.FederatedAuth.TokenProvider != nil {
 			return m.newCredentialFromFederatedTokenProvider(
 				tenantID, *currentUser.ClientID, *ps.FederatedAuth.TokenProvider)
@@ -269,15 +310,55 @@ func (m *Manager) CredentialForCurrentUser(
 }
 
 func shouldUseLegacyAuth(cfg config.Config) bool {
-	if useLegacyAuth, has := cfg.Get(cUseAzCliAuthKey); has {
-		if use, err := strconv.ParseBool(useLegacyAuth.(string)); err == nil && use {
-			return true
+	return configBool(cfg, cUseAzCliAuthKey)
+}
+
+// configBool returns true when the value at path in cfg is a string set to a truthy value.
+func configBool(cfg config.Config, path string) bool {
+	if value, has := cfg.Get(path); has {
+		if value, ok := value.(string); ok {
+			if use, err := strconv.ParseBool(value); err == nil && use {
+				return true
+			}
 		}
 	}
 
 	return false
 }
 
+// useAzCli returns true when auth is delegated to the az CLI. It always is when auth.useAzCliAuth is set to a truthy value
+// in config. When auth.useAzCli is, it is only delegated if the az CLI is installed and signed in, which is checked once,
+// and azd falls back to its own authentication otherwise.
+func (m *Manager) useAzCli(ctx context.Context, cfg config.Config) bool {
+	if shouldUseLegacyAuth(cfg) {
+		return true
+	}
+
+	if !configBool(cfg, cUseAzCliKey) {
+		return false
+	}
+
+	m.azCliSignedInOnce.Do(func() {
+		m.azCliSignedIn = azCliSignedIn(ctx, m.commandRunner)
+		if !m.azCliSignedIn {
+			log.Printf("%s is set to true, but az is not available, using azd auth instead", cUseAzCliKey)
+		}
+	})
+
+	return m.azCliSignedIn
+}
+
+// azCliSignedIn returns true when the az CLI is installed and an account is signed in to it.
+func azCliSignedIn(ctx context.Context, commandRunner exec.CommandRunner) bool {
+	_, err := commandRunner.Run(ctx, exec.NewRunArgs("az", "account", "show", "--output", "json"))
+	if err != nil {
+		log.Printf("az is not installed or not signed in: %v", err)
+		return false
+	}
+
+	return true
+}
+
 func ShouldUseCloudShellAuth() bool {
 	if useCloudShellAuth, has := os.LookupEnv(cUseCloudShellAuthEnvVar); has {
 		if use, err := strconv.ParseBool(useCloudShellAuth); err == nil && use {
@@ -309,7 +390,7 @@ func (m *Manager) GetLoggedInServicePrincipalTenantID(ctx context.Context) (*str
 		return nil, fmt.Errorf("fetching current user: %w", err)
 	}
 
-	if shouldUseLegacyAuth(cfg) {
+	if m.useAzCli(ctx, cfg) {
 		// When delegating to az, we have no way to determine what principal was used
 		return nil, nil
 	}
@@ -379,13 +460,19 @@ func (m *Manager) newCredentialFromClientCertificate(
 	tenantID string,
 	clientID string,
 	clientCertificate string,
+	clientCertificatePassword *string,
 ) (azcore.TokenCredential, error) {
 	certData, err := base64.StdEncoding.DecodeString(clientCertificate)
 	if err != nil {
 		return nil, fmt.Errorf("decoding certificate: %w: %w", err, ErrNoCurrentUser)
 	}
 
-	certs, key, err := azidentity.ParseCertificates(certData, nil)
+	var password []byte
+	if clientCertificatePassword != nil {
+		password = []byte(*clientCertificatePassword)
+	}
+
+	certs, key, err := azidentity.ParseCertificates(certData, password)
 	if err != nil {
 		return nil, fmt.Errorf("parsing certificate: %w: %w", err, ErrNoCurrentUser)
 	}
@@ -573,10 +660,18 @@ func (m *Manager) LoginWithServicePrincipalSecret(
 	return cred, nil
 }
 
+// LoginWithServicePrincipalCertificate logs in as a service principal using a PEM or PKCS#12 (PFX) certificate that
+// contains the private key. certPassword decrypts an encrypted certificate and is ignored when empty. The certificate
+// and password are stored in the credential cache, so later commands can authenticate without prompting.
 func (m *Manager) LoginWithServicePrincipalCertificate(
-	ctx context.Context, tenantId, clientId string, certData []byte,
+	ctx context.Context, tenantId, clientId string, certData []byte, certPassword string,
 ) (azcore.TokenCredential, error) {
-	certs, key, err := azidentity.ParseCertificates(certData, nil)
+	var password []byte
+	if certPassword != "" {
+		password = []byte(certPassword)
+	}
+
+	certs, key, err := azidentity.ParseCertificates(certData, password)
 	if err != nil {
 		return nil, fmt.Errorf("parsing certificate: %w", err)
 	}
@@ -587,14 +682,15 @@ func (m *Manager) LoginWithServicePrincipalCertificate(
 	}
 
 	encodedCert := base64.StdEncoding.EncodeToString(certData)
+	secret := &persistedSecret{
+		ClientCertificate: &encodedCert,
+	}
 
-	if err := m.saveLoginForServicePrincipal(
-		tenantId,
-		clientId,
-		&persistedSecret{
-			ClientCertificate: &encodedCert,
-		},
-	); err != nil {
+	if certPassword != "" {
+		secret.ClientCertificatePassword = &certPassword
+	}
+
+	if err := m.saveLoginForServicePrincipal(tenantId, clientId, secret); err != nil {
 		return nil, err
 	}
 
@@ -626,6 +722,10 @@ func (m *Manager) LoginWithServicePrincipalFederatedTokenProvider(
 
 // Logout signs out the current user and removes any cached authentication information
 func (m *Manager) Logout(ctx context.Context) error {
+	m.scopedCredentialMu.Lock()
+	m.scopedCredential = nil
+	m.scopedCredentialMu.Unlock()
+
 	act, err := m.getSignedInAccount(ctx)
 	if err != nil && !errors.Is(err, ErrNoCurrentUser) {
 		return fmt.Errorf("fetching current user: %w", err)
@@ -841,6 +941,9 @@ type persistedSecret struct {
 	// base64 string.
 	ClientCertificate *string `json:"clientCertificate,omitempty"`
 
+	// The password used to decrypt the client certificate, when the certificate is encrypted.
+	ClientCertificatePassword *string `json:"clientCertificatePassword,omitempty"`
+
 	// The federated auth credential.
 	FederatedAuth *federatedAuth `json:"federatedAuth,omitempty"`
 }
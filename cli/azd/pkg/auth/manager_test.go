@@ -19,8 +19,10 @@ import (
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/public"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/github"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
 	"github.com/stretchr/testify/require"
 )
@@ -99,7 +101,7 @@ func TestServicePrincipalLoginClientCertificate(t *testing.T) {
 	}
 
 	cred, err := m.LoginWithServicePrincipalCertificate(
-		context.Background(), "testClientId", "testTenantId", cTestClientCertificate,
+		context.Background(), "testClientId", "testTenantId", cTestClientCertificate, "",
 	)
 
 	require.NoError(t, err)
@@ -119,6 +121,43 @@ func TestServicePrincipalLoginClientCertificate(t *testing.T) {
 	require.True(t, errors.Is(err, ErrNoCurrentUser))
 }
 
+//go:embed testdata/certificate-encrypted.pfx
+var cTestEncryptedClientCertificate []byte
+
+func TestServicePrincipalLoginEncryptedClientCertificate(t *testing.T) {
+	credentialCache := &memoryCache{
+		cache: make(map[string][]byte),
+	}
+
+	m := Manager{
+		configManager:     newMemoryConfigManager(),
+		userConfigManager: newMemoryUserConfigManager(),
+		credentialCache:   credentialCache,
+	}
+
+	_, err := m.LoginWithServicePrincipalCertificate(
+		context.Background(), "testClientId", "testTenantId", cTestEncryptedClientCertificate, "",
+	)
+	require.Error(t, err)
+
+	_, err = m.LoginWithServicePrincipalCertificate(
+		context.Background(), "testClientId", "testTenantId", cTestEncryptedClientCertificate, "wrong-password",
+	)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "wrong-password")
+
+	cred, err := m.LoginWithServicePrincipalCertificate(
+		context.Background(), "testClientId", "testTenantId", cTestEncryptedClientCertificate, "azd-test",
+	)
+	require.NoError(t, err)
+	require.IsType(t, new(azidentity.ClientCertificateCredential), cred)
+
+	// The password is persisted, so the credential can be created without prompting
+	cred, err = m.CredentialForCurrentUser(context.Background(), nil)
+	require.NoError(t, err)
+	require.IsType(t, new(azidentity.ClientCertificateCredential), cred)
+}
+
 func TestServicePrincipalLoginFederatedTokenProvider(t *testing.T) {
 	credentialCache := &memoryCache{
 		cache: make(map[string][]byte),
@@ -177,8 +216,10 @@ func TestLegacyAzCliCredentialSupport(t *testing.T) {
 	err = mgr.Save(cfg)
 	require.NoError(t, err)
 
+	// az is used without checking whether it's signed in
 	m := Manager{
 		userConfigManager: mgr,
+		commandRunner:     mockexec.NewMockCommandRunner(),
 	}
 
 	cred, err := m.CredentialForCurrentUser(context.Background(), nil)
@@ -187,6 +228,50 @@ func TestLegacyAzCliCredentialSupport(t *testing.T) {
 	require.IsType(t, new(azidentity.AzureCLICredential), cred)
 }
 
+func TestAzCliCredentialSupport(t *testing.T) {
+	newManager := func(t *testing.T, commandRunner exec.CommandRunner) *Manager {
+		mgr := newMemoryUserConfigManager()
+
+		cfg, err := mgr.Load()
+		require.NoError(t, err)
+
+		err = cfg.Set(cUseAzCliKey, "true")
+		require.NoError(t, err)
+
+		err = mgr.Save(cfg)
+		require.NoError(t, err)
+
+		return &Manager{
+			configManager:     newMemoryConfigManager(),
+			userConfigManager: mgr,
+			commandRunner:     commandRunner,
+		}
+	}
+
+	t.Run("SignedIn", func(t *testing.T) {
+		commandRunner := mockexec.NewMockCommandRunner()
+		commandRunner.When(func(args exec.RunArgs, command string) bool {
+			return command == "az account show --output json"
+		}).Respond(exec.NewRunResult(0, `{"id": "SUBSCRIPTION_ID"}`, ""))
+
+		cred, err := newManager(t, commandRunner).CredentialForCurrentUser(context.Background(), nil)
+
+		require.NoError(t, err)
+		require.IsType(t, new(azidentity.AzureCLICredential), cred)
+	})
+
+	t.Run("FallbackWhenNotSignedIn", func(t *testing.T) {
+		commandRunner := mockexec.NewMockCommandRunner()
+		commandRunner.When(func(args exec.RunArgs, command string) bool {
+			return command == "az account show --output json"
+		}).SetError(errors.New("Please run 'az login' to setup account."))
+
+		// azd auth is used instead, which has no signed in user either
+		_, err := newManager(t, commandRunner).CredentialForCurrentUser(context.Background(), nil)
+		require.ErrorIs(t, err, ErrNoCurrentUser)
+	})
+}
+
 func TestCloudShellCredentialSupport(t *testing.T) {
 	t.Setenv("AZD_IN_CLOUDSHELL", "1")
 	m := Manager{
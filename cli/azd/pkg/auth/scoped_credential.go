@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// tokenRefreshMargin is how long before expiration a cached token is considered stale and is refreshed.
+const tokenRefreshMargin = 5 * time.Minute
+
+// scopedTokenCredential wraps a credential and caches the access tokens it returns per set of requested scopes, so a
+// single credential can serve requests for different audiences (e.g. ARM and Microsoft Graph) without re-acquiring
+// tokens on each request.
+type scopedTokenCredential struct {
+	credential azcore.TokenCredential
+	now        func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]azcore.AccessToken
+}
+
+func newScopedTokenCredential(credential azcore.TokenCredential) *scopedTokenCredential {
+	return &scopedTokenCredential{
+		credential: credential,
+		now:        time.Now,
+		tokens:     map[string]azcore.AccessToken{},
+	}
+}
+
+func (c *scopedTokenCredential) GetToken(
+	ctx context.Context,
+	options policy.TokenRequestOptions,
+) (azcore.AccessToken, error) {
+	key := scopesCacheKey(options)
+
+	c.mu.Lock()
+	token, has := c.tokens[key]
+	c.mu.Unlock()
+
+	if has && token.ExpiresOn.After(c.now().Add(tokenRefreshMargin)) {
+		return token, nil
+	}
+
+	// The lock isn't held while the token is requested, so requests for other scopes aren't blocked by it
+	token, err := c.credential.GetToken(ctx, options)
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+
+	c.mu.Lock()
+	c.tokens[key] = token
+	c.mu.Unlock()
+
+	return token, nil
+}
+
+// scopesCacheKey returns a key which is the same for requests of the same scopes, regardless of their order. The key
+// includes every field of the request options, so requests that differ in any way get their own token.
+func scopesCacheKey(options policy.TokenRequestOptions) string {
+	scopes := slices.Clone(options.Scopes)
+	slices.Sort(scopes)
+
+	return options.TenantID + "|" + strings.Join(scopes, " ")
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/require"
+)
+
+type countingCredential struct {
+	now      time.Time
+	lifetime time.Duration
+	calls    map[string]int
+}
+
+func (c *countingCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	scopes := strings.Join(options.Scopes, " ")
+	c.calls[scopes]++
+
+	return azcore.AccessToken{
+		Token:     scopes,
+		ExpiresOn: c.now.Add(c.lifetime),
+	}, nil
+}
+
+func TestScopedTokenCredential(t *testing.T) {
+	now := time.Now()
+	armScopes := []string{"https://management.azure.com//.default"}
+	graphScopes := []string{"https://graph.microsoft.com//.default"}
+
+	t.Run("CachesPerScope", func(t *testing.T) {
+		inner := &countingCredential{now: now, lifetime: time.Hour, calls: map[string]int{}}
+		cred := newScopedTokenCredential(inner)
+		cred.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: armScopes})
+			require.NoError(t, err)
+			require.Equal(t, armScopes[0], token.Token)
+
+			token, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: graphScopes})
+			require.NoError(t, err)
+			require.Equal(t, graphScopes[0], token.Token)
+		}
+
+		require.Equal(t, 1, inner.calls[armScopes[0]])
+		require.Equal(t, 1, inner.calls[graphScopes[0]])
+	})
+
+	t.Run("ScopeOrderIgnored", func(t *testing.T) {
+		inner := &countingCredential{now: now, lifetime: time.Hour, calls: map[string]int{}}
+		cred := newScopedTokenCredential(inner)
+		cred.now = func() time.Time { return now }
+
+		_, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"a", "b"}})
+		require.NoError(t, err)
+		_, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"b", "a"}})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, inner.calls["a b"])
+		require.Equal(t, 0, inner.calls["b a"])
+	})
+
+	t.Run("RefreshesExpiringToken", func(t *testing.T) {
+		inner := &countingCredential{now: now, lifetime: tokenRefreshMargin - time.Minute, calls: map[string]int{}}
+		cred := newScopedTokenCredential(inner)
+		cred.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			_, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: armScopes})
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, inner.calls[armScopes[0]])
+	})
+
+	t.Run("DoesNotBlockOtherScopes", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		inner := &blockingCredential{blockedScope: armScopes[0], started: started, release: release, now: now}
+		cred := newScopedTokenCredential(inner)
+		cred.now = func() time.Time { return now }
+
+		armDone := make(chan error)
+		go func() {
+			_, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: armScopes})
+			armDone <- err
+		}()
+		<-started
+
+		// The graph token is returned while the request of the arm token is still in progress
+		token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: graphScopes})
+		require.NoError(t, err)
+		require.Equal(t, graphScopes[0], token.Token)
+
+		close(release)
+		require.NoError(t, <-armDone)
+	})
+}
+
+// blockingCredential blocks requests for blockedScope until release is closed, and closes started once such a request
+// is in progress.
+type blockingCredential struct {
+	blockedScope string
+	started      chan struct{}
+	release      chan struct{}
+	now          time.Time
+}
+
+func (c *blockingCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	scopes := strings.Join(options.Scopes, " ")
+	if scopes == c.blockedScope {
+		close(c.started)
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return azcore.AccessToken{}, ctx.Err()
+		}
+	}
+
+	return azcore.AccessToken{
+		Token:     scopes,
+		ExpiresOn: c.now.Add(time.Hour),
+	}, nil
+}
@@ -51,6 +51,54 @@ func GetOidFromAccessToken(token string) (string, error) {
 	return *claims.Oid, nil
 }
 
+// TokenClaims are the claims of an access token that identify the principal it was issued to.
+type TokenClaims struct {
+	// The object id of the principal.
+	Oid string `json:"oid"`
+	// The tenant the token was issued by.
+	Tid string `json:"tid"`
+	// The application id, set for tokens issued to service principals.
+	AppId string `json:"appid"`
+	// The user principal name, set for tokens issued to users.
+	Upn string `json:"upn"`
+	// The username, set for tokens issued to users, including guest users without an upn.
+	PreferredUsername string `json:"preferred_username"`
+	// The legacy username claim, used when neither upn nor preferred_username are set.
+	UniqueName string `json:"unique_name"`
+}
+
+// Username returns the name of the user the token was issued to, or an empty string when it was issued to a service
+// principal.
+func (c TokenClaims) Username() string {
+	for _, name := range []string{c.Upn, c.PreferredUsername, c.UniqueName} {
+		if name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// GetClaimsFromAccessToken extracts the claims that identify the principal from an access token.
+func GetClaimsFromAccessToken(token string) (TokenClaims, error) {
+	matches := jwtClaimsRegex.FindStringSubmatch(token)
+	if len(matches) != 2 {
+		return TokenClaims{}, errors.New("malformed access token")
+	}
+
+	bytes, err := base64.RawURLEncoding.DecodeString(matches[1])
+	if err != nil {
+		return TokenClaims{}, err
+	}
+
+	var claims TokenClaims
+	if err := json.Unmarshal(bytes, &claims); err != nil {
+		return TokenClaims{}, err
+	}
+
+	return claims, nil
+}
+
 func getTidClaimFromAccessToken(token string) (string, error) {
 	matches := jwtClaimsRegex.FindStringSubmatch(token)
 	if len(matches) != 2 {
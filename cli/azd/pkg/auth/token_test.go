@@ -66,3 +66,38 @@ func TestGetTenantIdFromToken(t *testing.T) {
 	require.Error(t, err)
 
 }
+
+func TestGetClaimsFromAccessToken(t *testing.T) {
+	// a token issued to a user, with oid, tid and upn claims
+	claims, err := GetClaimsFromAccessToken(
+		// cspell: disable-next-line
+		"eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." +
+			"eyJvaWQiOiJ1c2VyLW9pZCIsInRpZCI6InRlc3QtdGVuYW50IiwidXBuIjoidXNlckBjb250b3NvLmNvbSJ9.sig",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "user-oid", claims.Oid)
+	require.Equal(t, "test-tenant", claims.Tid)
+	require.Equal(t, "user@contoso.com", claims.Username())
+
+	// a token issued to a service principal, with oid, tid and appid claims
+	claims, err = GetClaimsFromAccessToken(
+		// cspell: disable-next-line
+		"eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.eyJvaWQiOiJzcC1vaWQiLCJ0aWQiOiJ0ZXN0LXRlbmFudCIsImFwcGlkIjoiYXBwLWlkIn0.sig",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "sp-oid", claims.Oid)
+	require.Equal(t, "app-id", claims.AppId)
+	require.Equal(t, "", claims.Username())
+
+	// a token issued to a guest user, with a preferred_username claim but no upn
+	claims, err = GetClaimsFromAccessToken(
+		// cspell: disable-next-line
+		"eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." +
+			"eyJvaWQiOiJndWVzdC1vaWQiLCJwcmVmZXJyZWRfdXNlcm5hbWUiOiJndWVzdEBmYWJyaWthbS5jb20ifQ.sig",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "guest@fabrikam.com", claims.Username())
+
+	_, err = GetClaimsFromAccessToken("not-a-token")
+	require.Error(t, err)
+}
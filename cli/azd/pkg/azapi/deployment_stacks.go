@@ -0,0 +1,223 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+)
+
+// DeploymentStacks deploys templates as Azure deployment stacks, which manage the resources they deploy so the resources
+// are deleted together with the stack. Stacks are at subscription scope when the resource group name is empty.
+type DeploymentStacks interface {
+	GetDeploymentStack(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		stackName string,
+	) (*azsdk.DeploymentStack, error)
+	DeployStack(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		location string,
+		stackName string,
+		armTemplate azure.RawArmTemplate,
+		parameters azure.ArmParameters,
+		tags map[string]*string,
+	) (*armresources.DeploymentExtended, error)
+	DeleteDeploymentStack(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		stackName string,
+	) error
+}
+
+var (
+	ErrDeploymentStackNotFound = errors.New("deployment stack not found")
+)
+
+type deploymentStacks struct {
+	credentialProvider account.SubscriptionCredentialProvider
+	httpClient         httputil.HttpClient
+	userAgent          string
+}
+
+func NewDeploymentStacks(
+	credentialProvider account.SubscriptionCredentialProvider,
+	httpClient httputil.HttpClient,
+) DeploymentStacks {
+	return &deploymentStacks{
+		credentialProvider: credentialProvider,
+		httpClient:         httpClient,
+		userAgent:          azdinternal.UserAgent(),
+	}
+}
+
+func (ds *deploymentStacks) GetDeploymentStack(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	stackName string,
+) (*azsdk.DeploymentStack, error) {
+	client, err := ds.createDeploymentStacksClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, err := client.Get(ctx, resourceGroupName, stackName)
+	if err != nil {
+		var errDetails *azcore.ResponseError
+		if errors.As(err, &errDetails) && errDetails.StatusCode == http.StatusNotFound {
+			return nil, ErrDeploymentStackNotFound
+		}
+
+		return nil, fmt.Errorf("getting deployment stack '%s': %w", stackName, err)
+	}
+
+	return stack, nil
+}
+
+// DeployStack creates or updates the stack with the template and parameters, and waits for the deployment to complete.
+// Resources that are removed from the template, and the resource groups that are no longer used, are deleted.
+func (ds *deploymentStacks) DeployStack(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	location string,
+	stackName string,
+	armTemplate azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+	tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	client, err := ds.createDeploymentStacksClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := azsdk.DeploymentStack{
+		Tags: tags,
+		Properties: &azsdk.DeploymentStackProperties{
+			Template:   armTemplate,
+			Parameters: parameters,
+			ActionOnUnmanage: &azsdk.DeploymentStackActionOnUnmanage{
+				Resources:      azsdk.DeploymentStackUnmanageActionDelete,
+				ResourceGroups: azsdk.DeploymentStackUnmanageActionDelete,
+			},
+			DenySettings: &azsdk.DeploymentStackDenySettings{
+				Mode: azsdk.DeploymentStackDenySettingsModeNone,
+			},
+		},
+	}
+	// Resource group stacks are located in their resource group
+	if resourceGroupName == "" {
+		stack.Location = to.Ptr(location)
+	}
+
+	poller, err := client.BeginCreateOrUpdate(ctx, resourceGroupName, stackName, stack)
+	if err != nil {
+		return nil, fmt.Errorf("starting deployment stack '%s': %w", stackName, err)
+	}
+
+	result, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"deploying stack '%s':\n\nDeployment Error Details:\n%w",
+			stackName,
+			createDeploymentError(err),
+		)
+	}
+
+	return DeploymentFromStack(&result), nil
+}
+
+// DeleteDeploymentStack deletes the stack, together with the resources and resource groups it manages, and waits for the
+// deletion to complete.
+func (ds *deploymentStacks) DeleteDeploymentStack(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	stackName string,
+) error {
+	client, err := ds.createDeploymentStacksClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDelete(ctx, resourceGroupName, stackName, azsdk.DeploymentStackUnmanageActionDelete)
+	if err != nil {
+		return fmt.Errorf("starting deletion of deployment stack '%s': %w", stackName, err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("deleting deployment stack '%s': %w", stackName, err)
+	}
+
+	return nil
+}
+
+// DeploymentFromStack describes the most recent deployment of the stack as a deployment, with the outputs of the stack
+// and the resources it manages as the output resources.
+func DeploymentFromStack(stack *azsdk.DeploymentStack) *armresources.DeploymentExtended {
+	deployment := &armresources.DeploymentExtended{
+		ID:         stack.Id,
+		Name:       stack.Name,
+		Location:   stack.Location,
+		Tags:       stack.Tags,
+		Properties: &armresources.DeploymentPropertiesExtended{},
+	}
+
+	if stack.Properties == nil {
+		return deployment
+	}
+
+	deployment.Properties.Outputs = stack.Properties.Outputs
+	for _, resource := range stack.Properties.Resources {
+		deployment.Properties.OutputResources = append(
+			deployment.Properties.OutputResources, &armresources.ResourceReference{ID: resource.Id})
+	}
+
+	// Stacks report their state in lower case, like "succeeded", deployments in pascal case
+	if stack.Properties.ProvisioningState != nil {
+		for _, state := range armresources.PossibleProvisioningStateValues() {
+			if strings.EqualFold(string(state), *stack.Properties.ProvisioningState) {
+				deployment.Properties.ProvisioningState = to.Ptr(state)
+				break
+			}
+		}
+	}
+
+	return deployment
+}
+
+func (ds *deploymentStacks) createDeploymentStacksClient(
+	ctx context.Context,
+	subscriptionId string,
+) (*azsdk.DeploymentStacksClient, error) {
+	credential, err := ds.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	options := azsdk.DefaultClientOptionsBuilder(ctx, ds.httpClient, ds.userAgent).BuildArmClientOptions()
+	client, err := azsdk.NewDeploymentStacksClient(subscriptionId, credential, options)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployment stacks client: %w", err)
+	}
+
+	return client, nil
+}
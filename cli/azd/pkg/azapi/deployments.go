@@ -76,6 +76,13 @@ type Deployments interface {
 		parameters azure.ArmParameters,
 	) (*armresources.WhatIfOperationResult, error)
 	DeleteSubscriptionDeployment(ctx context.Context, subscriptionId string, deploymentName string) error
+	CancelSubscriptionDeployment(ctx context.Context, subscriptionId string, deploymentName string) error
+	CancelResourceGroupDeployment(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		deploymentName string,
+	) error
 	CalculateTemplateHash(
 		ctx context.Context,
 		subscriptionId string,
@@ -407,6 +414,38 @@ func (ds *deployments) DeleteSubscriptionDeployment(
 	return nil
 }
 
+// CancelSubscriptionDeployment cancels a subscription deployment that is accepted or running. Resources that were already
+// created by the deployment are left in place.
+func (ds *deployments) CancelSubscriptionDeployment(
+	ctx context.Context, subscriptionId string, deploymentName string) error {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	if _, err := deploymentClient.CancelAtSubscriptionScope(ctx, deploymentName, nil); err != nil {
+		return fmt.Errorf("canceling deployment: %w", err)
+	}
+
+	return nil
+}
+
+// CancelResourceGroupDeployment cancels a resource group deployment that is accepted or running. Resources that were
+// already created by the deployment are left in place.
+func (ds *deployments) CancelResourceGroupDeployment(
+	ctx context.Context, subscriptionId string, resourceGroupName string, deploymentName string) error {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	if _, err := deploymentClient.Cancel(ctx, resourceGroupName, deploymentName, nil); err != nil {
+		return fmt.Errorf("canceling deployment: %w", err)
+	}
+
+	return nil
+}
+
 type AzCliDeploymentPropertiesDependency struct {
 	AzCliDeploymentPropertiesBasicDependency
 	DependsOn []AzCliDeploymentPropertiesBasicDependency `json:"dependsOn"`
@@ -529,8 +568,5 @@ func createDeploymentError(err error) error {
 }
 
 func (ds *deployments) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptionsBuilder {
-	return azsdk.NewClientOptionsBuilder().
-		WithTransport(ds.httpClient).
-		WithPerCallPolicy(azsdk.NewUserAgentPolicy(ds.userAgent)).
-		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
+	return azsdk.DefaultClientOptionsBuilder(ctx, ds.httpClient, ds.userAgent)
 }
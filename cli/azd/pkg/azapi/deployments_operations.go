@@ -125,8 +125,5 @@ func (dp *deploymentOperations) ListResourceGroupDeploymentOperations(
 }
 
 func (dp *deploymentOperations) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptionsBuilder {
-	return azsdk.NewClientOptionsBuilder().
-		WithTransport(dp.httpClient).
-		WithPerCallPolicy(azsdk.NewUserAgentPolicy(dp.userAgent)).
-		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
+	return azsdk.DefaultClientOptionsBuilder(ctx, dp.httpClient, dp.userAgent)
 }
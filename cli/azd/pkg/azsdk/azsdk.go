@@ -13,5 +13,6 @@ func DefaultClientOptionsBuilder(
 	return NewClientOptionsBuilder().
 		WithTransport(httpClient).
 		WithPerCallPolicy(NewUserAgentPolicy(userAgent)).
-		WithPerCallPolicy(NewMsCorrelationPolicy(ctx))
+		WithPerCallPolicy(NewMsCorrelationPolicy(ctx)).
+		WithPerRetryPolicy(NewDebugLoggingPolicy())
 }
@@ -2,11 +2,19 @@ package azsdk
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/pem"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,6 +50,57 @@ func TestCreateArmOptions(t *testing.T) {
 	})
 }
 
+func TestDefaultClientOptionsBuilder_Transport(t *testing.T) {
+	var userAgent string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// sendRequest sends a request through an SDK pipeline built from the default client options
+	sendRequest := func(t *testing.T) error {
+		transport, err := httputil.NewTransport()
+		require.NoError(t, err)
+
+		builder := DefaultClientOptionsBuilder(
+			context.Background(), &http.Client{Transport: transport}, "custom-user-agent")
+		options := builder.BuildCoreClientOptions()
+		options.Retry.MaxRetries = -1
+		pipeline := runtime.NewPipeline("azsdk", "1.0.0", runtime.PipelineOptions{}, options)
+
+		req, err := runtime.NewRequest(context.Background(), http.MethodGet, server.URL)
+		require.NoError(t, err)
+
+		res, err := pipeline.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		return nil
+	}
+
+	t.Run("UntrustedCertificate", func(t *testing.T) {
+		ostest.Unsetenv(t, httputil.CaBundleEnvVarName)
+
+		var certErr *tls.CertificateVerificationError
+		require.ErrorAs(t, sendRequest(t), &certErr)
+	})
+
+	t.Run("CaBundle", func(t *testing.T) {
+		caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+		caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caBundlePath, caBundle, 0600))
+		ostest.Setenv(t, httputil.CaBundleEnvVarName, caBundlePath)
+
+		// The request reaches the server through the SDK policies, so the CA bundle applies to all SDK clients
+		require.NoError(t, sendRequest(t))
+		require.Contains(t, userAgent, "custom-user-agent")
+	})
+}
+
 func TestCreateCoreOptions(t *testing.T) {
 	t.Run("WithDefaults", func(t *testing.T) {
 		builder := NewClientOptionsBuilder()
@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azsdk
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// debugLoggingPolicy logs the method, URL, status and correlation id of each HTTP request.
+// Headers and bodies are never logged, since they may contain bearer tokens and secrets.
+type debugLoggingPolicy struct {
+}
+
+// NewDebugLoggingPolicy creates a policy that logs each HTTP request and response. Logs are only written with `--debug`,
+// since azd discards log output otherwise.
+func NewDebugLoggingPolicy() policy.Policy {
+	return &debugLoggingPolicy{}
+}
+
+func (p *debugLoggingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	// Avoid formatting the log entries when they would be discarded
+	if log.Writer() == io.Discard {
+		return req.Next()
+	}
+
+	rawRequest := req.Raw()
+	requestUrl := redactedUrl(rawRequest.URL)
+	start := time.Now()
+
+	res, err := req.Next()
+	duration := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("HTTP %s %s failed after %s: %v", rawRequest.Method, requestUrl, duration, err)
+		return res, err
+	}
+
+	correlationId := res.Header.Get(cMsCorrelationIdHeader)
+	if correlationId == "" {
+		correlationId = rawRequest.Header.Get(cMsCorrelationIdHeader)
+	}
+
+	log.Printf(
+		"HTTP %s %s: %d in %s (correlation id: %s)",
+		rawRequest.Method,
+		requestUrl,
+		res.StatusCode,
+		duration,
+		correlationId,
+	)
+
+	return res, err
+}
+
+// redactedUrl returns the URL with the values of query parameters redacted, since they may contain SAS tokens.
+// The api-version parameter is kept since it's useful when debugging.
+func redactedUrl(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+	redacted.User = nil
+
+	query := redacted.Query()
+	for key := range query {
+		if key != "api-version" {
+			query.Set(key, "REDACTED")
+		}
+	}
+
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
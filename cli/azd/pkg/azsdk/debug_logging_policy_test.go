@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azsdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockhttp"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_debugLoggingPolicy_Do(t *testing.T) {
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.SpanContext{}.WithTraceID(traceId))
+
+	var authorization string
+	httpClient := mockhttp.NewMockHttpUtil()
+	httpClient.When(func(request *http.Request) bool {
+		return true
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		authorization = request.Header.Get("Authorization")
+		return mocks.CreateEmptyHttpResponse(request, http.StatusNotFound)
+	})
+
+	clientOptions := DefaultClientOptionsBuilder(ctx, httpClient, "custom-user-agent").BuildArmClientOptions()
+	client, err := armresources.NewClient("SUBSCRIPTION_ID", &mocks.MockCredentials{}, clientOptions)
+	require.NoError(t, err)
+
+	originalWriter := log.Writer()
+	t.Cleanup(func() { log.SetOutput(originalWriter) })
+
+	t.Run("Debug", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.SetOutput(buf)
+
+		_, _ = client.GetByID(ctx, "RESOURCE_ID", "2021-04-01", nil)
+
+		logs := buf.String()
+		require.Contains(t, logs, "HTTP GET https://management.azure.com/RESOURCE_ID?api-version=2021-04-01: 404")
+		require.Contains(t, logs, traceId.String())
+
+		// The request carried the bearer token, which isn't logged
+		require.Equal(t, "Bearer ABC123", authorization)
+		require.NotContains(t, logs, "ABC123")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		log.SetOutput(io.Discard)
+		authorization = ""
+
+		// Without logging, the policy passes the request and the response through unchanged
+		_, err := client.GetByID(ctx, "RESOURCE_ID", "2021-04-01", nil)
+		var respErr *azcore.ResponseError
+		require.ErrorAs(t, err, &respErr)
+		require.Equal(t, http.StatusNotFound, respErr.StatusCode)
+		require.Equal(t, "Bearer ABC123", authorization)
+	})
+}
+
+func Test_redactedUrl(t *testing.T) {
+	u, err := url.Parse("https://account.blob.core.windows.net/container?api-version=2021-04-01&sig=SECRET&sv=2021")
+	require.NoError(t, err)
+
+	redacted := redactedUrl(u)
+	require.NotContains(t, redacted, "SECRET")
+	require.Contains(t, redacted, "api-version=2021-04-01")
+	require.Contains(t, redacted, "sig=REDACTED")
+}
@@ -0,0 +1,207 @@
+package azsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+)
+
+const deploymentStacksApiVersion = "2024-03-01"
+
+// Actions taken on the resources, resource groups and management groups that are no longer managed by a deployment stack,
+// after they're removed from the template or the stack is deleted.
+const (
+	DeploymentStackUnmanageActionDelete = "delete"
+	DeploymentStackUnmanageActionDetach = "detach"
+)
+
+// DeploymentStackDenySettingsModeNone doesn't deny any operation on the resources managed by a deployment stack.
+const DeploymentStackDenySettingsModeNone = "none"
+
+// DeploymentStack is an Azure deployment stack, which deploys a template and manages the resources it creates, so they
+// can be deleted together with the stack.
+// More info can be found at https://learn.microsoft.com/azure/azure-resource-manager/bicep/deployment-stacks
+type DeploymentStack struct {
+	Id         *string                    `json:"id,omitempty"`
+	Name       *string                    `json:"name,omitempty"`
+	Location   *string                    `json:"location,omitempty"`
+	Tags       map[string]*string         `json:"tags,omitempty"`
+	Properties *DeploymentStackProperties `json:"properties,omitempty"`
+}
+
+type DeploymentStackProperties struct {
+	Template          azure.RawArmTemplate             `json:"template,omitempty"`
+	Parameters        azure.ArmParameters              `json:"parameters,omitempty"`
+	ActionOnUnmanage  *DeploymentStackActionOnUnmanage `json:"actionOnUnmanage,omitempty"`
+	DenySettings      *DeploymentStackDenySettings     `json:"denySettings,omitempty"`
+	ProvisioningState *string                          `json:"provisioningState,omitempty"`
+	// DeploymentId is the resource id of the deployment the stack created for its most recent update.
+	DeploymentId *string                      `json:"deploymentId,omitempty"`
+	Outputs      any                          `json:"outputs,omitempty"`
+	Resources    []*DeploymentStackResource   `json:"resources,omitempty"`
+	Error        *DeploymentStackErrorDetails `json:"error,omitempty"`
+}
+
+type DeploymentStackActionOnUnmanage struct {
+	Resources        string `json:"resources"`
+	ResourceGroups   string `json:"resourceGroups,omitempty"`
+	ManagementGroups string `json:"managementGroups,omitempty"`
+}
+
+type DeploymentStackDenySettings struct {
+	Mode string `json:"mode"`
+}
+
+// DeploymentStackResource is a resource managed by a deployment stack.
+type DeploymentStackResource struct {
+	Id     *string `json:"id,omitempty"`
+	Status *string `json:"status,omitempty"`
+}
+
+type DeploymentStackErrorDetails struct {
+	Code    *string `json:"code,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// DeploymentStacksClient manages Azure deployment stacks at subscription and resource group scope
+type DeploymentStacksClient struct {
+	subscriptionId string
+	pipeline       runtime.Pipeline
+}
+
+// Creates a new DeploymentStacksClient instance
+func NewDeploymentStacksClient(
+	subscriptionId string,
+	credential azcore.TokenCredential,
+	options *arm.ClientOptions,
+) (*DeploymentStacksClient, error) {
+	if options == nil {
+		options = &arm.ClientOptions{}
+	}
+
+	pipeline, err := armruntime.NewPipeline("deployment-stacks", "1.0.0", credential, runtime.PipelineOptions{}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating HTTP pipeline: %w", err)
+	}
+
+	return &DeploymentStacksClient{
+		subscriptionId: subscriptionId,
+		pipeline:       pipeline,
+	}, nil
+}
+
+// Begins creating or updating the deployment stack with the given name. The stack is created at subscription scope
+// when resourceGroupName is empty.
+func (c *DeploymentStacksClient) BeginCreateOrUpdate(
+	ctx context.Context,
+	resourceGroupName string,
+	stackName string,
+	stack DeploymentStack,
+) (*runtime.Poller[DeploymentStack], error) {
+	req, err := runtime.NewRequest(ctx, http.MethodPut, c.stackUrl(resourceGroupName, stackName))
+	if err != nil {
+		return nil, fmt.Errorf("creating deployment stack request: %w", err)
+	}
+
+	req.Raw().Header.Set("Accept", "application/json")
+	if err := runtime.MarshalAsJSON(req, stack); err != nil {
+		return nil, err
+	}
+
+	response, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK, http.StatusCreated) {
+		return nil, runtime.NewResponseError(response)
+	}
+
+	return runtime.NewPoller[DeploymentStack](response, c.pipeline, nil)
+}
+
+// Gets the deployment stack with the given name. The stack is looked up at subscription scope when resourceGroupName is
+// empty.
+func (c *DeploymentStacksClient) Get(
+	ctx context.Context,
+	resourceGroupName string,
+	stackName string,
+) (*DeploymentStack, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, c.stackUrl(resourceGroupName, stackName))
+	if err != nil {
+		return nil, fmt.Errorf("creating deployment stack request: %w", err)
+	}
+
+	req.Raw().Header.Set("Accept", "application/json")
+	response, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		return nil, runtime.NewResponseError(response)
+	}
+
+	var stack DeploymentStack
+	if err := runtime.UnmarshalAsJSON(response, &stack); err != nil {
+		return nil, err
+	}
+
+	return &stack, nil
+}
+
+// Begins deleting the deployment stack with the given name. The resources and resource groups managed by the stack are
+// deleted or detached according to unmanageAction.
+func (c *DeploymentStacksClient) BeginDelete(
+	ctx context.Context,
+	resourceGroupName string,
+	stackName string,
+	unmanageAction string,
+) (*runtime.Poller[json.RawMessage], error) {
+	req, err := runtime.NewRequest(ctx, http.MethodDelete, c.stackUrl(resourceGroupName, stackName))
+	if err != nil {
+		return nil, fmt.Errorf("creating deployment stack request: %w", err)
+	}
+
+	query := req.Raw().URL.Query()
+	query.Set("api-version", deploymentStacksApiVersion)
+	query.Set("unmanageAction.Resources", unmanageAction)
+	query.Set("unmanageAction.ResourceGroups", unmanageAction)
+	req.Raw().URL.RawQuery = query.Encode()
+	req.Raw().Header.Set("Accept", "application/json")
+
+	response, err := c.pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK, http.StatusAccepted, http.StatusNoContent) {
+		return nil, runtime.NewResponseError(response)
+	}
+
+	return runtime.NewPoller[json.RawMessage](response, c.pipeline, nil)
+}
+
+// Creates the URL of the deployment stack, at resource group scope when resourceGroupName is set
+func (c *DeploymentStacksClient) stackUrl(resourceGroupName string, stackName string) string {
+	scope := azure.SubscriptionRID(c.subscriptionId)
+	if resourceGroupName != "" {
+		scope = azure.ResourceGroupRID(c.subscriptionId, resourceGroupName)
+	}
+
+	return fmt.Sprintf(
+		"https://%s%s/providers/Microsoft.Resources/deploymentStacks/%s?api-version=%s",
+		azure.ManagementHostName,
+		scope,
+		url.PathEscape(stackName),
+		deploymentStacksApiVersion,
+	)
+}
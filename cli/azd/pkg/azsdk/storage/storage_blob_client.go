@@ -9,7 +9,11 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/lease"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 )
@@ -27,6 +31,8 @@ const (
 
 var (
 	ErrContainerNotFound = errors.New("container not found")
+	// ErrLeaseAlreadyPresent is returned when acquiring a lease on a blob that is leased by another client.
+	ErrLeaseAlreadyPresent = errors.New("blob is leased by another client")
 )
 
 type BlobClient interface {
@@ -41,6 +47,14 @@ type BlobClient interface {
 
 	// Items returns a list of blobs in the configured storage account container.
 	Items(ctx context.Context) ([]*Blob, error)
+
+	// AcquireLease acquires a lease on a blob for the given duration, which must be between 15 and 60 seconds, and
+	// returns the lease ID. The blob is created empty when it doesn't exist. ErrLeaseAlreadyPresent is returned when
+	// another client holds a lease on the blob.
+	AcquireLease(ctx context.Context, blobPath string, duration time.Duration) (string, error)
+
+	// ReleaseLease releases a lease acquired with AcquireLease.
+	ReleaseLease(ctx context.Context, blobPath string, leaseId string) error
 }
 
 // NewBlobClient creates a new BlobClient instance to manage blobs within a container.
@@ -137,6 +151,68 @@ func (bc *blobClient) Delete(ctx context.Context, blobPath string) error {
 	return nil
 }
 
+// AcquireLease acquires a lease on a blob for the given duration, creating the blob when it doesn't exist.
+func (bc *blobClient) AcquireLease(ctx context.Context, blobPath string, duration time.Duration) (string, error) {
+	if err := bc.ensureContainerExists(ctx); err != nil {
+		return "", err
+	}
+
+	leaseClient, err := bc.leaseClient(blobPath, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := leaseClient.AcquireLease(ctx, int32(duration.Seconds()), nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		// Leases can only be acquired on existing blobs. Another client may create the blob at the same time, in which
+		// case the blob already exists and the lease can still be acquired.
+		_, err = bc.client.UploadBuffer(ctx, bc.config.ContainerName, blobPath, []byte{}, &azblob.UploadBufferOptions{
+			AccessConditions: &blob.AccessConditions{
+				ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfNoneMatch: to.Ptr(azcore.ETagAny)},
+			},
+		})
+		if err != nil && !bloberror.HasCode(err, bloberror.BlobAlreadyExists, bloberror.ConditionNotMet) {
+			return "", fmt.Errorf("failed to create blob '%s', %w", blobPath, err)
+		}
+
+		res, err = leaseClient.AcquireLease(ctx, int32(duration.Seconds()), nil)
+	}
+
+	if bloberror.HasCode(err, bloberror.LeaseAlreadyPresent) {
+		return "", fmt.Errorf("'%s': %w", blobPath, ErrLeaseAlreadyPresent)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to acquire lease on blob '%s', %w", blobPath, err)
+	}
+
+	return *res.LeaseID, nil
+}
+
+// ReleaseLease releases a lease acquired with AcquireLease.
+func (bc *blobClient) ReleaseLease(ctx context.Context, blobPath string, leaseId string) error {
+	leaseClient, err := bc.leaseClient(blobPath, &leaseId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := leaseClient.ReleaseLease(ctx, nil); err != nil {
+		return fmt.Errorf("failed to release lease on blob '%s', %w", blobPath, err)
+	}
+
+	return nil
+}
+
+func (bc *blobClient) leaseClient(blobPath string, leaseId *string) (*lease.BlobClient, error) {
+	blobClient := bc.client.ServiceClient().NewContainerClient(bc.config.ContainerName).NewBlobClient(blobPath)
+	leaseClient, err := lease.NewBlobClient(blobClient, &lease.BlobClientOptions{LeaseID: leaseId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lease client for blob '%s', %w", blobPath, err)
+	}
+
+	return leaseClient, nil
+}
+
 // Check if the specified container exists
 // If it doesn't already exist then create it
 func (bc *blobClient) ensureContainerExists(ctx context.Context) error {
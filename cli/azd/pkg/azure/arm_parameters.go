@@ -14,7 +14,21 @@ type ArmParameterFile struct {
 	Parameters     ArmParameters `json:"parameters"`
 }
 
-// ArmParameterValue wraps the configured value for the parameter.
+// ArmParameterValue wraps the configured value for the parameter. A parameter whose value is a secret stored in key
+// vault sets KeyVaultReference instead of Value.
 type ArmParameterValue struct {
-	Value any `json:"value"`
+	Value             any                         `json:"value,omitempty"`
+	KeyVaultReference *KeyVaultParameterReference `json:"reference,omitempty"`
+}
+
+// KeyVaultParameterReference is a reference to a key vault secret used as the value of a parameter.
+type KeyVaultParameterReference struct {
+	KeyVault      KeyVaultReference `json:"keyVault"`
+	SecretName    string            `json:"secretName"`
+	SecretVersion string            `json:"secretVersion,omitempty"`
+}
+
+// KeyVaultReference identifies the key vault holding the secret of a KeyVaultParameterReference.
+type KeyVaultReference struct {
+	ResourceId string `json:"id"`
 }
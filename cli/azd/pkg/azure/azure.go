@@ -3,8 +3,16 @@
 
 package azure
 
+import "strings"
+
 // ManagementHostName is the host name for the ARM Management Plane.
 const ManagementHostName = "management.azure.com"
 
 // ManagementScope is the scope to use when requesting tokens for the ARM Management Plane.
 const ManagementScope = "https://management.azure.com//.default"
+
+// EscapeODataString escapes a value for a single-quoted string literal in an OData filter, like "name eq '<value>'", by
+// doubling the single quotes it contains.
+func EscapeODataString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"strings"
+)
+
+// KeyDescriptor describes a configuration path recognized by azd.
+type KeyDescriptor struct {
+	// Key is the dot separated configuration path. Segments enclosed in angle brackets, like <name>, are placeholders
+	// that match any single segment.
+	Key string `json:"key"`
+	// Description is a short description of the setting.
+	Description string `json:"description"`
+	// Project is true for settings that can also be set in the project azure.yaml, where they take precedence over the
+	// user configuration.
+	Project bool `json:"project,omitempty"`
+}
+
+// knownKeys is the registry of configuration paths that users can set with `azd config set`.
+var knownKeys = []KeyDescriptor{
+	{Key: "alpha.<feature>", Description: "Enables the alpha feature with the given name. See `azd config list-alpha`."},
+	{Key: "alpha.all", Description: "Enables all alpha features."},
+	{
+		Key: "auth.prewarmScopes",
+		Description: "Comma separated scopes whose tokens `azd auth login` acquires and caches, in addition to the " +
+			"ones set with --scope.",
+	},
+	{
+		Key: "auth.useAzCli",
+		Description: "Uses the account signed in to the Azure CLI, and falls back to the azd account when the Azure CLI " +
+			"isn't installed or signed in.",
+	},
+	{Key: "auth.useAzCliAuth", Description: "Uses the Azure CLI to authenticate instead of the azd account."},
+	{Key: "defaults.location", Description: "The default Azure location used when creating environments."},
+	{
+		Key:         "defaults.output",
+		Description: "The default output format of commands, like json. Overridden by --output.",
+		Project:     true,
+	},
+	{Key: "defaults.subscription", Description: "The default Azure subscription used when creating environments."},
+	{
+		Key: "env.redactPatterns",
+		Description: "Comma separated regular expressions matching the keys whose values `azd env get-values` " +
+			"redacts unless --reveal is set.",
+	},
+	{
+		Key:         "state.remote.backend",
+		Description: "The backend used to store remote environment state.",
+		Project:     true,
+	},
+	{
+		Key:         "state.remote.config.accountName",
+		Description: "The storage account used by the remote state backend.",
+		Project:     true,
+	},
+	{
+		Key:         "state.remote.config.containerName",
+		Description: "The blob container used by the remote state backend.",
+		Project:     true,
+	},
+	{
+		Key:         "state.remote.config.endpoint",
+		Description: "The storage endpoint used by the remote state backend.",
+		Project:     true,
+	},
+	{Key: "telemetry.enabled", Description: "Enables collecting usage data. Overridden by AZURE_DEV_COLLECT_TELEMETRY."},
+	{Key: "template.sources.<key>.location", Description: "The path or URL of a custom template source."},
+	{Key: "template.sources.<key>.name", Description: "The display name of a custom template source."},
+	{Key: "template.sources.<key>.type", Description: "The type of a custom template source, like file or url."},
+}
+
+// KnownKeys returns the configuration paths recognized by azd, sorted by key.
+func KnownKeys() []KeyDescriptor {
+	keys := make([]KeyDescriptor, len(knownKeys))
+	copy(keys, knownKeys)
+	return keys
+}
+
+// IsKnownKey returns true when path is a configuration path recognized by azd, or the parent section of one,
+// like `defaults`.
+func IsKnownKey(path string) bool {
+	segments := strings.Split(path, ".")
+
+	for _, known := range knownKeys {
+		knownSegments := strings.Split(known.Key, ".")
+		if len(segments) > len(knownSegments) {
+			continue
+		}
+
+		if segmentsMatch(segments, knownSegments[:len(segments)]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsProjectKey returns true when path is a configuration path that can be set in the project azure.yaml, or the parent
+// section of one, like `state.remote`.
+func IsProjectKey(path string) bool {
+	segments := strings.Split(path, ".")
+
+	for _, known := range knownKeys {
+		knownSegments := strings.Split(known.Key, ".")
+		if !known.Project || len(segments) > len(knownSegments) {
+			continue
+		}
+
+		if segmentsMatch(segments, knownSegments[:len(segments)]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func segmentsMatch(segments []string, knownSegments []string) bool {
+	for i, segment := range segments {
+		known := knownSegments[i]
+		isPlaceholder := strings.HasPrefix(known, "<") && strings.HasSuffix(known, ">")
+
+		if segment == "" || (!isPlaceholder && segment != known) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// maxKeySuggestionDistance is the largest edit distance between an unknown path and a known key for the known key to be
+// suggested.
+const maxKeySuggestionDistance = 3
+
+// SuggestKey returns the known configuration key closest to path, for suggesting a correction when path is not a known
+// key. Placeholder segments of known keys are filled in from path.
+func SuggestKey(path string) (string, bool) {
+	segments := strings.Split(path, ".")
+	suggestion := ""
+	bestDistance := maxKeySuggestionDistance + 1
+
+	for _, known := range knownKeys {
+		knownSegments := strings.Split(known.Key, ".")
+		for i, knownSegment := range knownSegments {
+			if strings.HasPrefix(knownSegment, "<") && strings.HasSuffix(knownSegment, ">") && i < len(segments) {
+				knownSegments[i] = segments[i]
+			}
+		}
+
+		candidate := strings.Join(knownSegments, ".")
+		if distance := editDistance(path, candidate); distance < bestDistance {
+			suggestion = candidate
+			bestDistance = distance
+		}
+	}
+
+	return suggestion, suggestion != ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
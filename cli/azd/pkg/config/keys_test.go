@@ -0,0 +1,62 @@
+package config
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_KnownKeys_Sorted(t *testing.T) {
+	keys := KnownKeys()
+	require.True(t, sort.SliceIsSorted(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	}))
+}
+
+func Test_IsKnownKey(t *testing.T) {
+	tests := []struct {
+		path  string
+		known bool
+	}{
+		{path: "defaults.location", known: true},
+		{path: "defaults", known: true},
+		{path: "alpha.all", known: true},
+		{path: "alpha.resourceGroupDeployments", known: true},
+		{path: "template.sources.local.location", known: true},
+		{path: "template.sources.local", known: true},
+		{path: "state.remote", known: true},
+		{path: "defaults.locaton", known: false},
+		{path: "platfrom.type", known: false},
+		{path: "defaults.location.value", known: false},
+		{path: "defaults..location", known: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			require.Equal(t, test.known, IsKnownKey(test.path))
+		})
+	}
+}
+
+func Test_SuggestKey(t *testing.T) {
+	suggestion, ok := SuggestKey("defaults.locaton")
+	require.True(t, ok)
+	require.Equal(t, "defaults.location", suggestion)
+
+	suggestion, ok = SuggestKey("template.source.local.type")
+	require.True(t, ok)
+	require.Equal(t, "template.sources.local.type", suggestion)
+
+	_, ok = SuggestKey("something.else.entirely")
+	require.False(t, ok)
+}
+
+func Test_IsProjectKey(t *testing.T) {
+	require.True(t, IsProjectKey("state.remote.backend"))
+	require.True(t, IsProjectKey("state.remote.config.accountName"))
+	require.True(t, IsProjectKey("state.remote"))
+	require.True(t, IsProjectKey("defaults.output"))
+	require.False(t, IsProjectKey("defaults.location"))
+	require.False(t, IsProjectKey("state.remote.unknown"))
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UserConfigManager_UsesConfigDirOverride(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	userConfigManager := NewUserConfigManager(NewFileConfigManager(NewManager()))
+
+	azdConfig, err := userConfigManager.Load()
+	require.NoError(t, err)
+
+	err = azdConfig.Set("defaults.location", "westus2")
+	require.NoError(t, err)
+	err = userConfigManager.Save(azdConfig)
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(configDir, "config.json"))
+
+	reloaded, err := userConfigManager.Load()
+	require.NoError(t, err)
+	location, ok := reloaded.Get("defaults.location")
+	require.True(t, ok)
+	require.Equal(t, "westus2", location)
+}
@@ -2,7 +2,10 @@ package containerapps
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v2"
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
@@ -11,6 +14,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
 	"github.com/benbjohnson/clock"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // ContainerAppService exposes operations for managing Azure Container Apps
@@ -30,6 +35,49 @@ type ContainerAppService interface {
 		appName string,
 		imageName string,
 	) error
+	// Gets the name of the latest revision of the specified container app that was successfully provisioned
+	GetLatestReadyRevisionName(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+	) (string, error)
+	// Reverts the specified container app to serve traffic from an existing revision
+	RollbackRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionName string,
+	) error
+	// Deactivates the revisions created by azd for the specified container app, except for the most recent ones.
+	// Returns the names of the deactivated revisions.
+	PruneRevisions(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		keep int,
+	) ([]string, error)
+	// Gets the environment variables of the first container of the specified container app. Variables that reference
+	// a secret have the value of the secret.
+	GetEnvironmentVariables(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+	) (map[string]string, error)
+	// Sets the environment variables of the first container of the specified container app and removes the `removed`
+	// ones, keeping the other existing variables, which adds a new revision. Variables that reference a secret keep the
+	// reference and the secret is updated instead.
+	SetEnvironmentVariables(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		values map[string]string,
+		removed []string,
+	) error
 }
 
 // NewContainerAppService creates a new ContainerAppService
@@ -139,6 +187,321 @@ func (cas *containerAppService) AddRevision(
 	return nil
 }
 
+// Gets the name of the latest revision of the specified container app that was successfully provisioned
+func (cas *containerAppService) GetLatestReadyRevisionName(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+) (string, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName)
+	if err != nil {
+		return "", fmt.Errorf("getting container app: %w", err)
+	}
+
+	if containerApp.Properties == nil || containerApp.Properties.LatestReadyRevisionName == nil {
+		return "", fmt.Errorf("container app '%s' does not have a ready revision", appName)
+	}
+
+	return *containerApp.Properties.LatestReadyRevisionName, nil
+}
+
+// Reverts the specified container app to serve traffic from an existing revision.
+// In multiple revision mode the revision is activated and receives all traffic. In single revision mode
+// a new revision is created from the template of the specified revision.
+func (cas *containerAppService) RollbackRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	if *containerApp.Properties.Configuration.ActiveRevisionsMode == armappcontainers.ActiveRevisionsModeMultiple {
+		_, err := revisionsClient.ActivateRevision(ctx, resourceGroupName, appName, revisionName, nil)
+		if err != nil {
+			return fmt.Errorf("activating revision '%s': %w", revisionName, err)
+		}
+
+		containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+		if err != nil {
+			return fmt.Errorf("syncing secrets: %w", err)
+		}
+
+		err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, revisionName)
+		if err != nil {
+			return fmt.Errorf("setting traffic weights: %w", err)
+		}
+
+		return nil
+	}
+
+	revisionResponse, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return fmt.Errorf("getting revision '%s': %w", revisionName, err)
+	}
+
+	// Revision names are unique, so the previous template is deployed under a new suffix
+	revision := revisionResponse.Revision
+	revision.Properties.Template.RevisionSuffix = convert.RefOf(fmt.Sprintf("azd-rollback-%d", cas.clock.Now().Unix()))
+
+	containerApp.Properties.Template = revision.Properties.Template
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("updating container app revision: %w", err)
+	}
+
+	return nil
+}
+
+// Deactivates the active revisions created by azd for the specified container app, keeping the `keep` most recent ones.
+// Revisions that were not created by azd, the latest revision and revisions serving traffic are never deactivated.
+func (cas *containerAppService) PruneRevisions(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	keep int,
+) ([]string, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName)
+	if err != nil {
+		return nil, fmt.Errorf("getting container app: %w", err)
+	}
+
+	latestRevisionName := ""
+	if containerApp.Properties != nil && containerApp.Properties.LatestRevisionName != nil {
+		latestRevisionName = *containerApp.Properties.LatestRevisionName
+	}
+
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revisions created by azd are named after the revision suffixes set by AddRevision and RollbackRevision
+	azdRevisionPrefix := fmt.Sprintf("%s--azd-", appName)
+	revisions := []*armappcontainers.Revision{}
+
+	pager := revisionsClient.NewListRevisionsPager(resourceGroupName, appName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing revisions: %w", err)
+		}
+
+		for _, revision := range page.Value {
+			if revision.Name == nil || revision.Properties == nil ||
+				!strings.HasPrefix(*revision.Name, azdRevisionPrefix) ||
+				!convert.ToValueWithDefault(revision.Properties.Active, false) {
+				continue
+			}
+
+			revisions = append(revisions, revision)
+		}
+	}
+
+	// Most recent revisions first
+	slices.SortFunc(revisions, func(a, b *armappcontainers.Revision) bool {
+		return createdTime(a).After(createdTime(b))
+	})
+
+	pruned := []string{}
+	for i, revision := range revisions {
+		if i < keep ||
+			*revision.Name == latestRevisionName ||
+			convert.ToValueWithDefault(revision.Properties.TrafficWeight, 0) > 0 {
+			continue
+		}
+
+		_, err := revisionsClient.DeactivateRevision(ctx, resourceGroupName, appName, *revision.Name, nil)
+		if err != nil {
+			return pruned, fmt.Errorf("deactivating revision '%s': %w", *revision.Name, err)
+		}
+
+		pruned = append(pruned, *revision.Name)
+	}
+
+	return pruned, nil
+}
+
+func createdTime(revision *armappcontainers.Revision) time.Time {
+	if revision.Properties == nil || revision.Properties.CreatedTime == nil {
+		return time.Time{}
+	}
+
+	return *revision.Properties.CreatedTime
+}
+
+// Gets the environment variables of the first container of the specified container app
+func (cas *containerAppService) GetEnvironmentVariables(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+) (map[string]string, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := firstContainer(containerApp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Secret values are not returned with the container app, so they are only listed when a variable references one
+	var secrets map[string]string
+	if slices.ContainsFunc(container.Env, func(envVar *armappcontainers.EnvironmentVar) bool {
+		return envVar.SecretRef != nil
+	}) {
+		secrets, err = cas.listSecretValues(ctx, subscriptionId, resourceGroupName, appName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	values := map[string]string{}
+	for _, envVar := range container.Env {
+		if envVar.Name == nil {
+			continue
+		}
+
+		if envVar.SecretRef != nil {
+			values[*envVar.Name] = secrets[*envVar.SecretRef]
+		} else {
+			values[*envVar.Name] = convert.ToValueWithDefault(envVar.Value, "")
+		}
+	}
+
+	return values, nil
+}
+
+// Sets the environment variables of the first container of the specified container app, keeping the other existing
+// variables. Variables that reference a secret keep the reference, and the referenced secret is set to the new value.
+func (cas *containerAppService) SetEnvironmentVariables(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	values map[string]string,
+	removed []string,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName)
+	if err != nil {
+		return err
+	}
+
+	container, err := firstContainer(containerApp)
+	if err != nil {
+		return err
+	}
+
+	names := maps.Keys(values)
+	slices.Sort(names)
+
+	// secretValues are the new values of the secrets referenced by the variables, by secret name
+	secretValues := map[string]string{}
+	for _, name := range names {
+		index := slices.IndexFunc(container.Env, func(envVar *armappcontainers.EnvironmentVar) bool {
+			return envVar.Name != nil && *envVar.Name == name
+		})
+
+		if index >= 0 && container.Env[index].SecretRef != nil {
+			secretName := *container.Env[index].SecretRef
+			if err := checkSecretSettable(containerApp, name, secretName); err != nil {
+				return err
+			}
+
+			secretValues[secretName] = values[name]
+			continue
+		}
+
+		envVar := &armappcontainers.EnvironmentVar{
+			Name:  convert.RefOf(name),
+			Value: convert.RefOf(values[name]),
+		}
+
+		if index < 0 {
+			container.Env = append(container.Env, envVar)
+		} else {
+			container.Env[index] = envVar
+		}
+	}
+
+	env := []*armappcontainers.EnvironmentVar{}
+	for _, envVar := range container.Env {
+		if envVar.Name == nil || !slices.Contains(removed, *envVar.Name) {
+			env = append(env, envVar)
+		}
+	}
+	container.Env = env
+
+	// The suffix differs from the one used by AddRevision, so both can add a revision within the same second
+	containerApp.Properties.Template.RevisionSuffix = convert.RefOf(fmt.Sprintf("azd-%d-env", cas.clock.Now().Unix()))
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	for _, secret := range containerApp.Properties.Configuration.Secrets {
+		if value, has := secretValues[convert.ToValueWithDefault(secret.Name, "")]; has {
+			secret.Value = convert.RefOf(value)
+		}
+	}
+
+	if err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp); err != nil {
+		return fmt.Errorf("updating container app environment variables: %w", err)
+	}
+
+	return nil
+}
+
+// checkSecretSettable returns an error when the secret referenced by the environment variable can't be set by azd, because
+// it doesn't exist or its value is stored in Azure Key Vault.
+func checkSecretSettable(containerApp *armappcontainers.ContainerApp, envVarName string, secretName string) error {
+	index := slices.IndexFunc(containerApp.Properties.Configuration.Secrets, func(secret *armappcontainers.Secret) bool {
+		return secret.Name != nil && *secret.Name == secretName
+	})
+	if index < 0 {
+		return fmt.Errorf("secret '%s' referenced by environment variable '%s' was not found", secretName, envVarName)
+	}
+
+	if containerApp.Properties.Configuration.Secrets[index].KeyVaultURL != nil {
+		return fmt.Errorf(
+			"environment variable '%s' references secret '%s' stored in Azure Key Vault, update the secret in the vault instead",
+			envVarName,
+			secretName,
+		)
+	}
+
+	return nil
+}
+
+// firstContainer returns the first container of the template of the container app
+func firstContainer(containerApp *armappcontainers.ContainerApp) (*armappcontainers.Container, error) {
+	if containerApp.Properties == nil ||
+		containerApp.Properties.Template == nil ||
+		len(containerApp.Properties.Template.Containers) == 0 {
+		return nil, errors.New("container app has no containers")
+	}
+
+	return containerApp.Properties.Template.Containers[0], nil
+}
+
 func (cas *containerAppService) syncSecrets(
 	ctx context.Context,
 	subscriptionId string,
@@ -177,6 +540,33 @@ func (cas *containerAppService) syncSecrets(
 	return containerApp, nil
 }
 
+// listSecretValues gets the values of the secrets of the specified container app, by secret name
+func (cas *containerAppService) listSecretValues(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+) (map[string]string, error) {
+	appClient, err := cas.createContainerAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	secretsResponse, err := appClient.ListSecrets(ctx, resourceGroupName, appName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing secrets: %w", err)
+	}
+
+	values := map[string]string{}
+	for _, secret := range secretsResponse.SecretsCollection.Value {
+		if secret.Name != nil {
+			values[*secret.Name] = convert.ToValueWithDefault(secret.Value, "")
+		}
+	}
+
+	return values, nil
+}
+
 func (cas *containerAppService) setTrafficWeights(
 	ctx context.Context,
 	subscriptionId string,
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
@@ -150,3 +152,326 @@ func Test_ContainerApp_AddRevision(t *testing.T) {
 	require.Equal(t, updatedImageName, *updatedContainerApp.Properties.Template.Containers[0].Image)
 	require.Equal(t, "azd-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
 }
+
+func Test_ContainerApp_RollbackRevision(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	previousImageName := "PREVIOUS_IMAGE_NAME"
+	previousRevisionName := "PREVIOUS_REVISION_NAME"
+
+	newContainerApp := func(mode armappcontainers.ActiveRevisionsMode) *armappcontainers.ContainerApp {
+		return &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName:      convert.RefOf("FAILED_REVISION_NAME"),
+				LatestReadyRevisionName: &previousRevisionName,
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: convert.RefOf(mode),
+					Ingress:             &armappcontainers.Ingress{},
+				},
+				Template: &armappcontainers.Template{
+					Containers: []*armappcontainers.Container{
+						{
+							Image: convert.RefOf("FAILED_IMAGE_NAME"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("SingleRevisionMode", func(t *testing.T) {
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeSingle)
+		revision := &armappcontainers.Revision{
+			Properties: &armappcontainers.RevisionProperties{
+				Template: &armappcontainers.Template{
+					RevisionSuffix: convert.RefOf("azd-1"),
+					Containers: []*armappcontainers.Container{
+						{
+							Image: &previousImageName,
+						},
+					},
+				},
+			},
+		}
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+			revision,
+		)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			containerApp,
+		)
+
+		cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
+		revisionName, err := cas.GetLatestReadyRevisionName(*mockContext.Context, subscriptionId, resourceGroup, appName)
+		require.NoError(t, err)
+		require.Equal(t, previousRevisionName, revisionName)
+
+		err = cas.RollbackRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, revisionName)
+		require.NoError(t, err)
+
+		var updatedContainerApp *armappcontainers.ContainerApp
+		jsonDecoder := json.NewDecoder(updateContainerAppRequest.Body)
+		err = jsonDecoder.Decode(&updatedContainerApp)
+		require.NoError(t, err)
+		require.Equal(t, previousImageName, *updatedContainerApp.Properties.Template.Containers[0].Image)
+		require.Equal(t, "azd-rollback-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
+	})
+
+	t.Run("MultipleRevisionMode", func(t *testing.T) {
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeMultiple)
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		activateRequest := mockazsdk.MockContainerAppRevisionActivate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+		)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			containerApp,
+		)
+
+		cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
+		err := cas.RollbackRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, activateRequest.Method)
+
+		var updatedContainerApp *armappcontainers.ContainerApp
+		jsonDecoder := json.NewDecoder(updateContainerAppRequest.Body)
+		err = jsonDecoder.Decode(&updatedContainerApp)
+		require.NoError(t, err)
+		traffic := updatedContainerApp.Properties.Configuration.Ingress.Traffic
+		require.Len(t, traffic, 1)
+		require.Equal(t, previousRevisionName, *traffic[0].RevisionName)
+		require.Equal(t, int32(100), *traffic[0].Weight)
+	})
+}
+
+func Test_ContainerApp_PruneRevisions(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName: convert.RefOf("APP_NAME--azd-5"),
+			Configuration: &armappcontainers.Configuration{
+				ActiveRevisionsMode: convert.RefOf(armappcontainers.ActiveRevisionsModeMultiple),
+			},
+		},
+	}
+
+	newRevision := func(name string, created int64, active bool, trafficWeight int32) *armappcontainers.Revision {
+		return &armappcontainers.Revision{
+			Name: &name,
+			Properties: &armappcontainers.RevisionProperties{
+				Active:        &active,
+				CreatedTime:   convert.RefOf(time.Unix(created, 0).UTC()),
+				TrafficWeight: &trafficWeight,
+			},
+		}
+	}
+
+	// Listed out of order to verify revisions are ordered by creation time
+	revisions := []*armappcontainers.Revision{
+		newRevision("APP_NAME--azd-3", 3, true, 0),
+		newRevision("APP_NAME--azd-5", 5, true, 80),
+		newRevision("APP_NAME--manual", 0, true, 0),
+		newRevision("APP_NAME--azd-0", 0, false, 0),
+		newRevision("APP_NAME--azd-1", 1, true, 0),
+		newRevision("APP_NAME--azd-2", 2, true, 20),
+		newRevision("APP_NAME--azd-4", 4, true, 0),
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+	_ = mockazsdk.MockContainerAppRevisionsList(mockContext, subscriptionId, resourceGroup, appName, revisions)
+	deactivateRequests := map[string]*http.Request{}
+	for _, revision := range revisions {
+		deactivateRequests[*revision.Name] = mockazsdk.MockContainerAppRevisionDeactivate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			*revision.Name,
+		)
+	}
+
+	cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
+	pruned, err := cas.PruneRevisions(*mockContext.Context, subscriptionId, resourceGroup, appName, 2)
+	require.NoError(t, err)
+
+	// The two most recent revisions are kept, revisions serving traffic, inactive revisions and revisions not created
+	// by azd are skipped
+	require.Equal(t, []string{"APP_NAME--azd-3", "APP_NAME--azd-1"}, pruned)
+	for name, request := range deactivateRequests {
+		if name == "APP_NAME--azd-3" || name == "APP_NAME--azd-1" {
+			require.Equal(t, http.MethodPost, request.Method, name)
+		} else {
+			require.Empty(t, request.Method, name)
+		}
+	}
+}
+
+func Test_ContainerApp_SetEnvironmentVariables(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+
+	newContainerApp := func() *armappcontainers.ContainerApp {
+		return &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: convert.RefOf(armappcontainers.ActiveRevisionsModeSingle),
+					Secrets: []*armappcontainers.Secret{
+						{Name: convert.RefOf("db-password")},
+						{Name: convert.RefOf("api-key"), KeyVaultURL: convert.RefOf("https://vault/secrets/api-key")},
+					},
+				},
+				Template: &armappcontainers.Template{
+					RevisionSuffix: convert.RefOf("azd-100"),
+					Containers: []*armappcontainers.Container{
+						{
+							Image: convert.RefOf("IMAGE_NAME"),
+							Env: []*armappcontainers.EnvironmentVar{
+								{Name: convert.RefOf("KEEP"), Value: convert.RefOf("kept")},
+								{Name: convert.RefOf("MODE"), Value: convert.RefOf("debug")},
+								{Name: convert.RefOf("OLD"), Value: convert.RefOf("removed")},
+								{Name: convert.RefOf("DB_PASSWORD"), SecretRef: convert.RefOf("db-password")},
+								{Name: convert.RefOf("API_KEY"), SecretRef: convert.RefOf("api-key")},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	setupMocks := func(mockContext *mocks.MockContext) *http.Request {
+		containerApp := newContainerApp()
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppSecretsList(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			&armappcontainers.SecretsCollection{
+				Value: []*armappcontainers.ContainerAppSecret{
+					{Name: convert.RefOf("db-password"), Value: convert.RefOf("secret")},
+					{Name: convert.RefOf("api-key"), Value: convert.RefOf("vault-value")},
+				},
+			},
+		)
+
+		return mockazsdk.MockContainerAppUpdate(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		updateContainerAppRequest := setupMocks(mockContext)
+
+		cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
+
+		// Variables that reference a secret have the value of the secret
+		values, err := cas.GetEnvironmentVariables(*mockContext.Context, subscriptionId, resourceGroup, appName)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"KEEP":        "kept",
+			"MODE":        "debug",
+			"OLD":         "removed",
+			"DB_PASSWORD": "secret",
+			"API_KEY":     "vault-value",
+		}, values)
+
+		err = cas.SetEnvironmentVariables(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			map[string]string{
+				"MODE":        "release",
+				"DB_PASSWORD": "new-secret",
+				"NEW":         "added",
+			},
+			[]string{"OLD"},
+		)
+		require.NoError(t, err)
+
+		var updatedContainerApp *armappcontainers.ContainerApp
+		jsonDecoder := json.NewDecoder(updateContainerAppRequest.Body)
+		err = jsonDecoder.Decode(&updatedContainerApp)
+		require.NoError(t, err)
+
+		// Existing variables are kept in place, new ones are appended and removed ones are dropped. Secret references
+		// are kept, with the referenced secret set to the new value.
+		env := map[string]string{}
+		names := []string{}
+		for _, envVar := range updatedContainerApp.Properties.Template.Containers[0].Env {
+			if envVar.SecretRef != nil {
+				env[*envVar.Name] = "secretref:" + *envVar.SecretRef
+			} else {
+				env[*envVar.Name] = *envVar.Value
+			}
+			names = append(names, *envVar.Name)
+		}
+		require.Equal(t, []string{"KEEP", "MODE", "DB_PASSWORD", "API_KEY", "NEW"}, names)
+		require.Equal(t, map[string]string{
+			"KEEP":        "kept",
+			"MODE":        "release",
+			"DB_PASSWORD": "secretref:db-password",
+			"API_KEY":     "secretref:api-key",
+			"NEW":         "added",
+		}, env)
+
+		secrets := map[string]string{}
+		for _, secret := range updatedContainerApp.Properties.Configuration.Secrets {
+			secrets[*secret.Name] = *secret.Value
+		}
+		require.Equal(t, map[string]string{"db-password": "new-secret", "api-key": "vault-value"}, secrets)
+		require.Equal(t, "azd-0-env", *updatedContainerApp.Properties.Template.RevisionSuffix)
+	})
+
+	t.Run("KeyVaultSecret", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		updateContainerAppRequest := setupMocks(mockContext)
+
+		cas := NewContainerAppService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient, clock.NewMock())
+		err := cas.SetEnvironmentVariables(
+			*mockContext.Context,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			map[string]string{"API_KEY": "plain"},
+			nil,
+		)
+		require.ErrorContains(t, err, "references secret 'api-key' stored in Azure Key Vault")
+		require.Empty(t, updateContainerAppRequest.Method)
+	})
+}
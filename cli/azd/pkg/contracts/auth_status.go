@@ -0,0 +1,30 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+import "time"
+
+// AccountType are the values of the "type" property of an AuthStatusResult
+type AccountType string
+
+const (
+	AccountTypeUser             AccountType = "user"
+	AccountTypeServicePrincipal AccountType = "servicePrincipal"
+)
+
+// AuthStatusResult is the contract for the output of `azd auth status`.
+type AuthStatusResult struct {
+	// The result of checking for a valid access token.
+	Status LoginStatus `json:"status"`
+	// The type of the signed in account.
+	Type AccountType `json:"type,omitempty"`
+	// The user principal name of a user, or the application id of a service principal.
+	Account string `json:"account,omitempty"`
+	// The object id of the signed in account.
+	ObjectId string `json:"objectId,omitempty"`
+	// The tenant of the access token.
+	TenantId string `json:"tenantId,omitempty"`
+	// When status is `LoginStatusSuccess`, the time at which the access token expires.
+	ExpiresOn *time.Time `json:"expiresOn,omitempty"`
+}
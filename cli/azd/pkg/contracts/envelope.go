@@ -8,7 +8,8 @@ import "time"
 type EventDataType string
 
 const (
-	ConsoleMessageEventDataType EventDataType = "consoleMessage"
+	ConsoleMessageEventDataType       EventDataType = "consoleMessage"
+	ResourceProvisioningEventDataType EventDataType = "resourceProvisioning"
 )
 
 type EventEnvelope struct {
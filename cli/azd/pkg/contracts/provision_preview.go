@@ -0,0 +1,18 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+package contracts
+
+// ProvisionPreviewResult is the contract for the output of `azd provision preview`.
+type ProvisionPreviewResult struct {
+	// Summary is the number of resources by the change provisioning would make to them, like "Create" or "Modify".
+	Summary map[string]int           `json:"summary"`
+	Changes []ProvisionPreviewChange `json:"changes"`
+}
+
+// ProvisionPreviewChange is the contract for a resource in the "changes" array of a ProvisionPreviewResult.
+type ProvisionPreviewChange struct {
+	ChangeType   string `json:"changeType"`
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	ResourceId   string `json:"resourceId,omitempty"`
+}
@@ -0,0 +1,19 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+// ResourceProvisioning is the data of the event emitted when the provisioning state of a resource changes while a
+// deployment is in progress.
+type ResourceProvisioning struct {
+	// The ID of the resource.
+	ResourceId string `json:"resourceId"`
+	// The name of the resource.
+	ResourceName string `json:"resourceName"`
+	// The type of the resource, like Microsoft.Web/sites.
+	ResourceType string `json:"resourceType"`
+	// The provisioning state of the resource before the change, empty when the resource was first seen.
+	PreviousState string `json:"previousState,omitempty"`
+	// The provisioning state of the resource, like Running, Succeeded or Failed.
+	State string `json:"state"`
+}
@@ -16,6 +16,17 @@ const (
 type ShowResult struct {
 	Name     string                 `json:"name"`
 	Services map[string]ShowService `json:"services"`
+	// Resources contains the Azure resources provisioned for the environment. Only set when using `--resources`.
+	Resources []ShowResource `json:"resources,omitempty"`
+}
+
+// ShowResource is the contract for an Azure resource provisioned for the environment, as returned by
+// `azd show --resources`.
+type ShowResource struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
 }
 
 // ShowService is the contract for a service returned by `azd show`
@@ -40,4 +51,6 @@ type ShowServiceProject struct {
 // is deployed to.
 type ShowTargetArm struct {
 	ResourceIds []string `json:"resourceIds"`
+	// Endpoints contains the endpoints the service exposes, when they can be determined.
+	Endpoints []string `json:"endpoints,omitempty"`
 }
@@ -2,6 +2,8 @@
 // Licensed under the MIT License.
 package contracts
 
+import "fmt"
+
 // VersionResult is the contract for the output of `azd version`
 type VersionResult struct {
 	Azd struct {
@@ -9,3 +11,8 @@ type VersionResult struct {
 		Commit  string `json:"commit"`
 	} `json:"azd"`
 }
+
+// String returns the version as displayed by `azd version` without an output format.
+func (v VersionResult) String() string {
+	return fmt.Sprintf("azd version %s (commit %s)", v.Azd.Version, v.Azd.Commit)
+}
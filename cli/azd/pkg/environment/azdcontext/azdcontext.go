@@ -18,6 +18,7 @@ const ConfigFileVersion = 1
 
 type AzdContext struct {
 	projectDirectory string
+	projectFileName  string
 }
 
 func (c *AzdContext) ProjectDirectory() string {
@@ -29,7 +30,16 @@ func (c *AzdContext) SetProjectDirectory(dir string) {
 }
 
 func (c *AzdContext) ProjectPath() string {
-	return filepath.Join(c.ProjectDirectory(), ProjectFileName)
+	return filepath.Join(c.ProjectDirectory(), c.ProjectFileName())
+}
+
+// ProjectFileName returns the name of the project file, which is azure.yaml unless overridden.
+func (c *AzdContext) ProjectFileName() string {
+	if c.projectFileName == "" {
+		return ProjectFileName
+	}
+
+	return c.projectFileName
 }
 
 func (c *AzdContext) EnvironmentDirectory() string {
@@ -94,6 +104,16 @@ var (
 // The project file is first searched for in the current directory, if not found, the parent directory is searched
 // recursively up to root. If no project file is found, errNoProject is returned.
 func NewAzdContext() (*AzdContext, error) {
+	return NewAzdContextWithProjectFile(ProjectFileName)
+}
+
+// Creates context with project directory set to the nearest project file with the specified file name, for example
+// azure.staging.yaml. The project file is searched for the same way as NewAzdContext does.
+func NewAzdContextWithProjectFile(projectFileName string) (*AzdContext, error) {
+	if filepath.Base(projectFileName) != projectFileName {
+		return nil, fmt.Errorf("project file '%s' must be a file name, not a path", projectFileName)
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get the current directory: %w", err)
@@ -107,11 +127,15 @@ func NewAzdContext() (*AzdContext, error) {
 	}
 
 	for {
-		projectFilePath := filepath.Join(searchDir, ProjectFileName)
+		projectFilePath := filepath.Join(searchDir, projectFileName)
 		stat, err := os.Stat(projectFilePath)
 		if os.IsNotExist(err) || (err == nil && stat.IsDir()) {
 			parent := filepath.Dir(searchDir)
 			if parent == searchDir {
+				if projectFileName != ProjectFileName {
+					return nil, fmt.Errorf("project file '%s' was not found: %w", projectFileName, ErrNoProject)
+				}
+
 				return nil, ErrNoProject
 			}
 			searchDir = parent
@@ -126,6 +150,7 @@ func NewAzdContext() (*AzdContext, error) {
 
 	return &AzdContext{
 		projectDirectory: searchDir,
+		projectFileName:  projectFileName,
 	}, nil
 }
 
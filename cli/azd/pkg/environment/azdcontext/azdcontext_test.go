@@ -0,0 +1,54 @@
+package azdcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewAzdContextWithProjectFile(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ProjectFileName), nil, osutil.PermissionFile))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "azure.staging.yaml"), nil, osutil.PermissionFile))
+
+	nestedDir := filepath.Join(projectDir, "src", "api")
+	require.NoError(t, os.MkdirAll(nestedDir, osutil.PermissionDirectory))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(nestedDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	// Resolve symlinks in the temp dir (e.g. /var -> /private/var on macOS) to compare paths
+	projectDir, err = filepath.EvalSymlinks(projectDir)
+	require.NoError(t, err)
+
+	t.Run("Default", func(t *testing.T) {
+		azdCtx, err := NewAzdContext()
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(projectDir, ProjectFileName), azdCtx.ProjectPath())
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		azdCtx, err := NewAzdContextWithProjectFile("azure.staging.yaml")
+		require.NoError(t, err)
+		require.Equal(t, projectDir, azdCtx.ProjectDirectory())
+		require.Equal(t, filepath.Join(projectDir, "azure.staging.yaml"), azdCtx.ProjectPath())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewAzdContextWithProjectFile("azure.missing.yaml")
+		require.ErrorIs(t, err, ErrNoProject)
+		require.ErrorContains(t, err, "azure.missing.yaml")
+	})
+
+	t.Run("Path", func(t *testing.T) {
+		_, err := NewAzdContextWithProjectFile(filepath.Join("config", "azure.yaml"))
+		require.Error(t, err)
+	})
+}
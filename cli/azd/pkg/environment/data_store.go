@@ -36,6 +36,12 @@ type DataStore interface {
 
 	// Saves the environment to the persistent data store
 	Save(ctx context.Context, env *Environment) error
+
+	// Deletes the environment with the specified name from the persistent data store
+	Delete(ctx context.Context, name string) error
+
+	// Renames the environment with the specified name to newName in the persistent data store
+	Rename(ctx context.Context, name string, newName string) error
 }
 
 type LocalDataStore DataStore
@@ -5,12 +5,15 @@ package environment
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 
 	"maps"
+	"slices"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/joho/godotenv"
@@ -41,11 +44,22 @@ const AksClusterEnvVarName = "AZURE_AKS_CLUSTER_NAME"
 // ResourceGroupEnvVarName is the name of the azure resource group that should be used for deployments
 const ResourceGroupEnvVarName = "AZURE_RESOURCE_GROUP"
 
+// referencesConfigPath is the environment config path of the keys set with [Environment.DotenvSetReferences], whose values
+// have their `${KEY}` references resolved when read.
+const referencesConfigPath = "dotenv.references"
+
+// listsConfigPath is the environment config path of the keys set with [Environment.DotenvSetList], whose values hold a
+// JSON array.
+const listsConfigPath = "dotenv.lists"
+
 // The zero value of an Environment is not valid. Use [New] to create one. When writing tests,
 // [Ephemeral] and [EphemeralWithValues] are useful to create environments which are not persisted to disk.
 type Environment struct {
 	name string
 
+	// mu guards dotenv and deletedKeys, which services deployed in parallel update concurrently.
+	mu sync.RWMutex
+
 	// dotenv is a map of keys to values, persisted to the `.env` file stored in this environment's [Root].
 	dotenv map[string]string
 
@@ -98,6 +112,21 @@ func IsValidEnvironmentName(name string) bool {
 	return EnvironmentNameRegexp.MatchString(name)
 }
 
+// reservedEnvironmentNames are names which match [EnvironmentNameRegexp] but can't be used as the name of the environment
+// directory, either on any platform or on Windows.
+var reservedEnvironmentNames = []string{
+	".", "..",
+	"con", "prn", "aux", "nul",
+	"com1", "com2", "com3", "com4", "com5", "com6", "com7", "com8", "com9",
+	"lpt1", "lpt2", "lpt3", "lpt4", "lpt5", "lpt6", "lpt7", "lpt8", "lpt9",
+}
+
+// IsReservedEnvironmentName returns true when name can't be used as an environment name because it isn't a usable
+// directory name, like `..` or `NUL`.
+func IsReservedEnvironmentName(name string) bool {
+	return slices.Contains(reservedEnvironmentNames, strings.ToLower(name))
+}
+
 // CleanName returns a version of [name] where all characters not allowed in an environment name have been replaced
 // with hyphens
 func CleanName(name string) string {
@@ -124,7 +153,7 @@ func CleanName(name string) string {
 // Getenv behaves like os.Getenv, except that any keys in the `.env` file associated with this environment are considered
 // first.
 func (e *Environment) Getenv(key string) string {
-	if v, has := e.dotenv[key]; has {
+	if v, has := e.lookupDotenv(key); has {
 		return v
 	}
 
@@ -134,30 +163,211 @@ func (e *Environment) Getenv(key string) string {
 // LookupEnv behaves like os.LookupEnv, except that any keys in the `.env` file associated with this environment are
 // considered first.
 func (e *Environment) LookupEnv(key string) (string, bool) {
-	if v, has := e.dotenv[key]; has {
+	if v, has := e.lookupDotenv(key); has {
 		return v, true
 	}
 
 	return os.LookupEnv(key)
 }
 
+// lookupDotenv returns the value of key in the `.env` file, with its references resolved when it was set with
+// DotenvSetReferences.
+func (e *Environment) lookupDotenv(key string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	v, has := e.dotenv[key]
+	if !has {
+		return "", false
+	}
+
+	references := e.referenceKeys()
+	if !references[key] {
+		return v, true
+	}
+
+	v, _, _ = newInterpolator(e.dotenv, references, false).resolve(key)
+	return v, true
+}
+
 // DotenvDelete removes the given key from the .env file in the environment, it is a no-op if the key
 // does not exist. [Save] should be called to ensure this change is persisted.
 func (e *Environment) DotenvDelete(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	delete(e.dotenv, key)
 	e.deletedKeys[key] = struct{}{}
+	e.setMarkedKey(referencesConfigPath, key, false)
+	e.setMarkedKey(listsConfigPath, key, false)
 }
 
-// Dotenv returns a copy of the key value pairs from the .env file in the environment.
+// Dotenv returns a copy of the key value pairs from the .env file in the environment. The values set with
+// DotenvSetReferences have their references resolved.
 func (e *Environment) Dotenv() map[string]string {
-	return maps.Clone(e.dotenv)
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.resolvedDotenv()
+}
+
+// resolvedDotenv returns a copy of dotenv with the references of the values set with DotenvSetReferences resolved.
+// References to keys that are not set, or that form a cycle, are kept as they are. The caller must hold mu.
+func (e *Environment) resolvedDotenv() map[string]string {
+	values := maps.Clone(e.dotenv)
+
+	references := e.referenceKeys()
+	if len(references) == 0 {
+		return values
+	}
+
+	i := newInterpolator(e.dotenv, references, false)
+	for key := range references {
+		if _, has := values[key]; has {
+			values[key], _, _ = i.resolve(key)
+		}
+	}
+
+	return values
 }
 
 // DotenvSet sets the value of [key] to [value] in the .env file associated with the environment. [Save] should be
 // called to ensure this change is persisted.
 func (e *Environment) DotenvSet(key string, value string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	e.dotenv[key] = value
 	delete(e.deletedKeys, key)
+	e.setMarkedKey(referencesConfigPath, key, false)
+	e.setMarkedKey(listsConfigPath, key, false)
+}
+
+// DotenvSetList sets the value of [key] to [items], stored as a JSON array, like [DotenvSet], and records that the value
+// is a list, which [ListKeys] returns.
+func (e *Environment) DotenvSetList(key string, items []any) error {
+	value, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encoding list: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dotenv[key] = string(value)
+	delete(e.deletedKeys, key)
+	e.setMarkedKey(referencesConfigPath, key, false)
+	e.setMarkedKey(listsConfigPath, key, true)
+	return nil
+}
+
+// ListKeys returns the sorted keys whose values were set with [DotenvSetList].
+func (e *Environment) ListKeys() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	keys := []string{}
+	for key := range e.markedKeys(listsConfigPath) {
+		if _, has := e.dotenv[key]; has {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// DotenvSetReferences sets the value of [key] to [value] like [DotenvSet], and resolves the `${KEY}` references in
+// [value] to other values of the environment each time it is read, so later changes to the referenced values are picked
+// up. `$${` stands for a literal `${`. An error is returned when [value] references a key that is not set, or when the
+// references form a cycle.
+func (e *Environment) DotenvSetReferences(key string, value string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	values := maps.Clone(e.dotenv)
+	values[key] = value
+	references := e.referenceKeys()
+	references[key] = true
+	if _, _, err := newInterpolator(values, references, true).resolve(key); err != nil {
+		return err
+	}
+
+	e.dotenv[key] = value
+	delete(e.deletedKeys, key)
+	e.setMarkedKey(referencesConfigPath, key, true)
+	e.setMarkedKey(listsConfigPath, key, false)
+	return nil
+}
+
+// ExpandValue returns [value] with its `${KEY}` references replaced by the current values of the environment, resolved
+// like the values set with [DotenvSetReferences]. An error is returned when [value] references a key that is not set.
+func (e *Environment) ExpandValue(value string) (string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return newInterpolator(e.dotenv, e.referenceKeys(), true).expand("", value)
+}
+
+// referenceKeys returns the keys set with DotenvSetReferences. The caller must hold mu.
+func (e *Environment) referenceKeys() map[string]bool {
+	return e.markedKeys(referencesConfigPath)
+}
+
+// markedKeys returns the keys recorded at path in the environment config, like the keys set with DotenvSetReferences.
+// The caller must hold mu.
+func (e *Environment) markedKeys(path string) map[string]bool {
+	keys := map[string]bool{}
+	if e.Config == nil {
+		return keys
+	}
+
+	value, has := e.Config.Get(path)
+	if !has {
+		return keys
+	}
+
+	items, _ := value.([]any)
+	for _, item := range items {
+		if key, ok := item.(string); ok {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
+
+// setMarkedKey records at path in the environment config whether key is marked, like the keys set with
+// DotenvSetReferences. The caller must hold mu for writing.
+func (e *Environment) setMarkedKey(path string, key string, marked bool) {
+	keys := e.markedKeys(path)
+	if keys[key] == marked {
+		return
+	}
+
+	if marked {
+		keys[key] = true
+	} else {
+		delete(keys, key)
+	}
+
+	if len(keys) == 0 {
+		_ = e.Config.Unset(path)
+		return
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+
+	items := make([]any, 0, len(sorted))
+	for _, key := range sorted {
+		items = append(items, key)
+	}
+
+	_ = e.Config.Set(path, items)
 }
 
 // GetEnvName is shorthand for Getenv(EnvNameEnvVarName)
@@ -212,14 +422,26 @@ func (e *Environment) SetServiceProperty(serviceName string, propertyName string
 // Creates a slice of key value pairs, based on the entries in the `.env` file like `KEY=VALUE` that
 // can be used to pass into command runner or similar constructs.
 func (e *Environment) Environ() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	envVars := []string{}
-	for k, v := range e.dotenv {
+	for k, v := range e.resolvedDotenv() {
 		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
 	}
 
 	return envVars
 }
 
+// setDotenv replaces the values of the environment with values loaded from a data store, which have no pending deletions.
+func (e *Environment) setDotenv(values map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dotenv = values
+	e.deletedKeys = make(map[string]struct{})
+}
+
 // fixupUnquotedDotenv is a workaround for behavior in how godotenv.Marshal handles numeric like values.  Marshaling
 // a map[string]string to a dotenv file, if a value can be successfully parsed with strconv.Atoi, it will be written in
 // the dotenv file without quotes and the value written will be the value returned by strconv.Atoi. This can lead to dropping
@@ -258,11 +480,11 @@ func fixupUnquotedDotenv(values map[string]string, dotenv string) string {
 // Prepare dotenv for saving and returns a marshalled string that can be save to the underlying data store
 // Instead of calling `godotenv.Write` directly, we need to save the file ourselves, so we can fixup any numeric values
 // that were incorrectly unquoted.
-func marshallDotEnv(env *Environment) (string, error) {
-	marshalled, err := godotenv.Marshal(env.dotenv)
+func marshallDotEnv(values map[string]string) (string, error) {
+	marshalled, err := godotenv.Marshal(values)
 	if err != nil {
 		return "", fmt.Errorf("marshalling .env: %w", err)
 	}
 
-	return fixupUnquotedDotenv(env.dotenv, marshalled), nil
+	return fixupUnquotedDotenv(values, marshalled), nil
 }
@@ -183,6 +183,23 @@ func TestCleanName(t *testing.T) {
 	require.Equal(t, "was-CLEANED-with--bad--things-(123)", CleanName("was CLEANED with *bad* things (123)"))
 }
 
+func TestDotenvSetList(t *testing.T) {
+	env := NewWithValues("test", map[string]string{"SCOPES": `["read"]`})
+	require.NoError(t, env.DotenvSetList("ALLOWED_ORIGINS", []any{"a", "b"}))
+	require.Equal(t, `["a","b"]`, env.Getenv("ALLOWED_ORIGINS"))
+	require.Equal(t, []string{"ALLOWED_ORIGINS"}, env.ListKeys())
+
+	// Setting or deleting the value some other way removes the mark
+	env.DotenvSet("ALLOWED_ORIGINS", `["c"]`)
+	require.Empty(t, env.ListKeys())
+
+	require.NoError(t, env.DotenvSetList("ALLOWED_ORIGINS", []any{"a"}))
+	env.DotenvDelete("ALLOWED_ORIGINS")
+	require.Empty(t, env.ListKeys())
+	_, has := env.Config.Get(listsConfigPath)
+	require.False(t, has)
+}
+
 func TestRoundTripNumberWithLeadingZeros(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	envManager, _ := createEnvManager(t, mockContext, t.TempDir())
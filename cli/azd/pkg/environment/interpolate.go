@@ -0,0 +1,125 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// referenceRegex matches the `${KEY}` references in an environment value, and the `$${` escape that stands for a literal
+// `${`.
+var referenceRegex = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// HasReferences returns true when value contains `${KEY}` references, or the `$${` escape.
+func HasReferences(value string) bool {
+	return referenceRegex.MatchString(value)
+}
+
+// interpolator resolves the `${KEY}` references of environment values. Only the values of the keys in references have
+// their own references resolved, the values of the other keys are used as they are.
+type interpolator struct {
+	values     map[string]string
+	references map[string]bool
+	// strict makes references to keys that are not set, and cycles, an error. Otherwise, these references are kept as
+	// they are.
+	strict   bool
+	resolved map[string]string
+	// resolving holds the keys being resolved, outermost first, to detect cycles.
+	resolving []string
+}
+
+func newInterpolator(values map[string]string, references map[string]bool, strict bool) *interpolator {
+	return &interpolator{
+		values:     values,
+		references: references,
+		strict:     strict,
+		resolved:   map[string]string{},
+	}
+}
+
+// resolve returns the value of key with its references resolved. When key is part of a cycle and the interpolator isn't
+// strict, false is returned.
+func (i *interpolator) resolve(key string) (string, bool, error) {
+	if !i.references[key] {
+		return i.values[key], true, nil
+	}
+
+	if value, has := i.resolved[key]; has {
+		return value, true, nil
+	}
+
+	for idx, resolving := range i.resolving {
+		if resolving == key {
+			if !i.strict {
+				return "", false, nil
+			}
+
+			cycle := append(append([]string{}, i.resolving[idx:]...), key)
+			return "", false, fmt.Errorf("environment values reference each other in a cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	i.resolving = append(i.resolving, key)
+	value, err := i.expand(key, i.values[key])
+	i.resolving = i.resolving[:len(i.resolving)-1]
+	if err != nil {
+		return "", false, err
+	}
+
+	i.resolved[key] = value
+	return value, true, nil
+}
+
+// expand replaces the references in value, which is the value of key, or a value not stored in the environment when key
+// is empty.
+func (i *interpolator) expand(key string, value string) (string, error) {
+	matches := referenceRegex.FindAllStringSubmatchIndex(value, -1)
+	if len(matches) == 0 {
+		return value, nil
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, match := range matches {
+		sb.WriteString(value[last:match[0]])
+		last = match[1]
+
+		// The `$${` escape has no captured key
+		if match[2] < 0 {
+			sb.WriteString("${")
+			continue
+		}
+
+		ref := value[match[2]:match[3]]
+		if _, has := i.values[ref]; !has {
+			if !i.strict {
+				sb.WriteString(value[match[0]:match[1]])
+				continue
+			}
+
+			if key == "" {
+				return "", fmt.Errorf("the value references '%s', which is not set", ref)
+			}
+
+			return "", fmt.Errorf("'%s' references '%s', which is not set", key, ref)
+		}
+
+		resolved, ok, err := i.resolve(ref)
+		if err != nil {
+			return "", err
+		}
+
+		if !ok {
+			sb.WriteString(value[match[0]:match[1]])
+			continue
+		}
+
+		sb.WriteString(resolved)
+	}
+
+	sb.WriteString(value[last:])
+	return sb.String(), nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDotenvSetReferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NestedReferences", func(t *testing.T) {
+		t.Parallel()
+
+		env := NewWithValues("test", map[string]string{
+			"APP_NAME": "web",
+			"DOMAIN":   "azurewebsites.net",
+		})
+		require.NoError(t, env.DotenvSetReferences("WEB_HOSTNAME", "${APP_NAME}.${DOMAIN}"))
+		require.NoError(t, env.DotenvSetReferences("URL", "https://${WEB_HOSTNAME}/api"))
+
+		require.Equal(t, "https://web.azurewebsites.net/api", env.Getenv("URL"))
+		require.Equal(t, "web.azurewebsites.net", env.Dotenv()["WEB_HOSTNAME"])
+		require.Contains(t, env.Environ(), "URL=https://web.azurewebsites.net/api")
+
+		// Later changes to the referenced values are picked up
+		env.DotenvSet("APP_NAME", "api")
+		require.Equal(t, "https://api.azurewebsites.net/api", env.Getenv("URL"))
+
+		// The stored value keeps the references
+		require.Equal(t, "https://${WEB_HOSTNAME}/api", env.dotenv["URL"])
+	})
+
+	t.Run("Escape", func(t *testing.T) {
+		t.Parallel()
+
+		env := NewWithValues("test", map[string]string{"NAME": "web"})
+		require.NoError(t, env.DotenvSetReferences("TEMPLATE", "$${NAME} is ${NAME}"))
+		require.Equal(t, "${NAME} is web", env.Getenv("TEMPLATE"))
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		t.Parallel()
+
+		env := NewWithValues("test", nil)
+		env.DotenvSet("C", "c")
+		require.NoError(t, env.DotenvSetReferences("B", "x-${C}"))
+		require.NoError(t, env.DotenvSetReferences("A", "${B}"))
+
+		err := env.DotenvSetReferences("C", "${A}")
+		require.ErrorContains(t, err, "cycle: C -> A -> B -> C")
+		require.Equal(t, "x-c", env.Getenv("A"))
+	})
+
+	t.Run("SelfReference", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewWithValues("test", nil).DotenvSetReferences("A", "${A}")
+		require.ErrorContains(t, err, "cycle: A -> A")
+	})
+
+	t.Run("Undefined", func(t *testing.T) {
+		t.Parallel()
+
+		env := NewWithValues("test", nil)
+		err := env.DotenvSetReferences("URL", "https://${WEB_HOSTNAME}/api")
+		require.ErrorContains(t, err, "'URL' references 'WEB_HOSTNAME', which is not set")
+		_, has := env.LookupEnv("URL")
+		require.False(t, has)
+	})
+
+	t.Run("ReferenceRemoved", func(t *testing.T) {
+		t.Parallel()
+
+		env := NewWithValues("test", map[string]string{"WEB_HOSTNAME": "web"})
+		require.NoError(t, env.DotenvSetReferences("URL", "https://${WEB_HOSTNAME}/api"))
+
+		// A reference to a key that is no longer set is kept as it is
+		env.DotenvDelete("WEB_HOSTNAME")
+		require.Equal(t, "https://${WEB_HOSTNAME}/api", env.Getenv("URL"))
+	})
+
+	t.Run("DotenvSetReplacesReferences", func(t *testing.T) {
+		t.Parallel()
+
+		env := NewWithValues("test", map[string]string{"NAME": "web"})
+		require.NoError(t, env.DotenvSetReferences("TEMPLATE", "${NAME}"))
+
+		env.DotenvSet("TEMPLATE", "${NAME}")
+		require.Equal(t, "${NAME}", env.Getenv("TEMPLATE"))
+		_, has := env.Config.Get(referencesConfigPath)
+		require.False(t, has)
+	})
+}
+
+func TestPlainValuesNotResolved(t *testing.T) {
+	t.Parallel()
+
+	// Values that were not set with DotenvSetReferences are used as they are, even when they look like references
+	env := NewWithValues("test", map[string]string{
+		"SCRIPT":   "echo ${HOME} $${PATH}",
+		"WEB_NAME": "web",
+		"URL":      "https://${WEB_NAME}/api",
+	})
+	require.Equal(t, "echo ${HOME} $${PATH}", env.Getenv("SCRIPT"))
+	require.Equal(t, "https://${WEB_NAME}/api", env.Dotenv()["URL"])
+
+	// A referenced value that was not set with DotenvSetReferences is used as it is
+	require.NoError(t, env.DotenvSetReferences("COMMAND", "run: ${SCRIPT}"))
+	require.Equal(t, "run: echo ${HOME} $${PATH}", env.Getenv("COMMAND"))
+}
+
+func TestExpandValue(t *testing.T) {
+	t.Parallel()
+
+	env := NewWithValues("test", map[string]string{"APP_NAME": "web"})
+	require.NoError(t, env.DotenvSetReferences("WEB_HOSTNAME", "${APP_NAME}.azurewebsites.net"))
+
+	value, err := env.ExpandValue("https://${WEB_HOSTNAME}/api")
+	require.NoError(t, err)
+	require.Equal(t, "https://web.azurewebsites.net/api", value)
+
+	_, err = env.ExpandValue("${MISSING}")
+	require.ErrorContains(t, err, "'MISSING', which is not set")
+}
+
+func TestHasReferences(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, HasReferences("https://${WEB_HOSTNAME}/api"))
+	require.True(t, HasReferences("$${NAME}"))
+	require.False(t, HasReferences("$5 and ${not a key}"))
+}
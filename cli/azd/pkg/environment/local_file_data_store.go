@@ -4,23 +4,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/gofrs/flock"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"golang.org/x/exp/slices"
 )
 
+// lockFileName is the name of the file used to guard writes to the environment state
+const lockFileName = ".lock"
+
+// defaultLockTimeout is how long a writer waits for another process to release the environment lock. The lock is
+// held by the operating system, so a lock left behind by a process that exited is released immediately.
+const defaultLockTimeout = 30 * time.Second
+
+const lockRetryDelay = 100 * time.Millisecond
+
 // LocalFileDataStore is a DataStore implementation that stores environment data in the local file system.
 type LocalFileDataStore struct {
 	azdContext    *azdcontext.AzdContext
 	configManager config.FileConfigManager
+	lockTimeout   time.Duration
 }
 
 // NewLocalFileDataStore creates a new LocalFileDataStore instance
@@ -28,6 +42,7 @@ func NewLocalFileDataStore(azdContext *azdcontext.AzdContext, configManager conf
 	return &LocalFileDataStore{
 		azdContext:    azdContext,
 		configManager: configManager,
+		lockTimeout:   defaultLockTimeout,
 	}
 }
 
@@ -95,64 +110,97 @@ func (fs *LocalFileDataStore) Get(ctx context.Context, name string) (*Environmen
 
 // Reload reloads the environment from the persistent data store
 func (fs *LocalFileDataStore) Reload(ctx context.Context, env *Environment) error {
-	// Reload env values
-	if envMap, err := godotenv.Read(fs.EnvPath(env)); errors.Is(err, os.ErrNotExist) {
-		env.dotenv = make(map[string]string)
-		env.deletedKeys = make(map[string]struct{})
-	} else if err != nil {
-		return fmt.Errorf("loading .env: %w", err)
-	} else {
-		env.dotenv = envMap
-		env.deletedKeys = make(map[string]struct{})
+	// The lock is only taken when the environment exists, so reloading doesn't create its directory. It keeps a save by
+	// another writer from being read half way.
+	if _, err := os.Stat(fs.azdContext.EnvironmentRoot(env.name)); err == nil {
+		unlock, err := fs.lock(ctx, env.name)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 	}
 
-	// Reload env config
-	if cfg, err := fs.configManager.Load(fs.ConfigPath(env)); errors.Is(err, os.ErrNotExist) {
-		env.Config = config.NewEmptyConfig()
-	} else if err != nil {
-		return fmt.Errorf("loading config: %w", err)
-	} else {
-		env.Config = cfg
+	values, cfg, err := fs.load(env)
+	if err != nil {
+		return err
 	}
 
-	if env.GetEnvName() != "" {
-		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.GetEnvName()))
+	env.mu.Lock()
+	env.dotenv = values
+	env.deletedKeys = make(map[string]struct{})
+	env.Config = cfg
+	env.mu.Unlock()
+
+	if name := values[EnvNameEnvVarName]; name != "" {
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, name))
 	}
 
-	if _, err := uuid.Parse(env.GetSubscriptionId()); err == nil {
-		tracing.SetGlobalAttributes(fields.SubscriptionIdKey.String(env.GetSubscriptionId()))
+	subscriptionId := values[SubscriptionIdEnvVarName]
+	if _, err := uuid.Parse(subscriptionId); err == nil {
+		tracing.SetGlobalAttributes(fields.SubscriptionIdKey.String(subscriptionId))
 	} else {
-		tracing.SetGlobalAttributes(fields.StringHashed(fields.SubscriptionIdKey, env.GetSubscriptionId()))
+		tracing.SetGlobalAttributes(fields.StringHashed(fields.SubscriptionIdKey, subscriptionId))
 	}
 
 	return nil
 }
 
+// load reads the values and the config of the environment from the files of the environment directory.
+func (fs *LocalFileDataStore) load(env *Environment) (map[string]string, config.Config, error) {
+	values, err := godotenv.Read(fs.EnvPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		values = make(map[string]string)
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("loading .env: %w", err)
+	}
+
+	cfg, err := fs.configManager.Load(fs.ConfigPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		cfg = config.NewEmptyConfig()
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	return values, cfg, nil
+}
+
 // Save saves the environment to the persistent data store
 func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment) error {
+	unlock, err := fs.lock(ctx, env.name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// The environment is locked for the whole save, including the reload, so values set by services deployed in parallel
+	// are neither lost nor written by two saves of the environment at the same time.
+	env.mu.Lock()
+	defer env.mu.Unlock()
+
 	// Update configuration
 	if err := fs.configManager.Save(env.Config, fs.ConfigPath(env)); err != nil {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
-	// Cache current values & reload to get any new env vars
-	currentValues := env.dotenv
-	deletedValues := env.deletedKeys
-	if err := fs.Reload(ctx, env); err != nil {
+	// Reload to get any new env vars, and overlay the current values and deletions before saving
+	values, cfg, err := fs.load(env)
+	if err != nil {
 		return fmt.Errorf("failed reloading env vars, %w", err)
 	}
 
-	// Overlay current values before saving
-	for key, value := range currentValues {
-		env.dotenv[key] = value
+	for key, value := range env.dotenv {
+		values[key] = value
 	}
 
-	// Replay deletion
-	for key := range deletedValues {
-		delete(env.dotenv, key)
+	for key := range env.deletedKeys {
+		delete(values, key)
 	}
 
-	marshalled, err := marshallDotEnv(env)
+	env.dotenv = values
+	env.deletedKeys = make(map[string]struct{})
+	env.Config = cfg
+
+	marshalled, err := marshallDotEnv(values)
 	if err != nil {
 		return fmt.Errorf("marshalling .env: %w", err)
 	}
@@ -172,6 +220,130 @@ func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment) error
 		return fmt.Errorf("saving .env: %w", err)
 	}
 
-	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.GetEnvName()))
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, values[EnvNameEnvVarName]))
+	return nil
+}
+
+// Delete removes the environment directory, including the .env and config files, from the local file system
+func (fs *LocalFileDataStore) Delete(ctx context.Context, name string) error {
+	envRoot := fs.azdContext.EnvironmentRoot(name)
+	if _, err := os.Stat(envRoot); err != nil {
+		return fmt.Errorf("'%s' %w, %w", name, ErrNotFound, err)
+	}
+
+	if err := os.RemoveAll(envRoot); err != nil {
+		return fmt.Errorf("deleting environment directory: %w", err)
+	}
+
 	return nil
 }
+
+// Rename moves the environment directory to the directory of newName, and updates the environment name in the .env file
+func (fs *LocalFileDataStore) Rename(ctx context.Context, name string, newName string) error {
+	envRoot := fs.azdContext.EnvironmentRoot(name)
+	if _, err := os.Stat(envRoot); err != nil {
+		return fmt.Errorf("'%s' %w, %w", name, ErrNotFound, err)
+	}
+
+	newEnvRoot := fs.azdContext.EnvironmentRoot(newName)
+	if _, err := os.Stat(newEnvRoot); err == nil {
+		return fmt.Errorf("'%s' %w", newName, ErrExists)
+	}
+
+	// The lock is held while the files are moved, so no other process writes the environment during the rename. A
+	// directory containing an open lock file can't be moved on Windows, so the files are moved to the new directory
+	// instead, and the previous directory is removed once the lock is released.
+	err := func() error {
+		unlock, err := fs.lock(ctx, name)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		if err := moveEnvironmentFiles(envRoot, newEnvRoot); err != nil {
+			return err
+		}
+
+		env, err := fs.Get(ctx, newName)
+		if err != nil {
+			return err
+		}
+
+		env.SetEnvName(newName)
+		return fs.Save(ctx, env)
+	}()
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(envRoot); err != nil {
+		return fmt.Errorf("removing previous environment directory: %w", err)
+	}
+
+	return nil
+}
+
+// moveEnvironmentFiles moves the files of the environment directory envRoot, except the lock file, to newEnvRoot. When a
+// file can't be moved, the files already moved are moved back.
+func moveEnvironmentFiles(envRoot string, newEnvRoot string) error {
+	entries, err := os.ReadDir(envRoot)
+	if err != nil {
+		return fmt.Errorf("reading environment directory: %w", err)
+	}
+
+	if err := os.MkdirAll(newEnvRoot, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating environment directory: %w", err)
+	}
+
+	moved := []string{}
+	for _, entry := range entries {
+		if entry.Name() == lockFileName {
+			continue
+		}
+
+		if err := os.Rename(filepath.Join(envRoot, entry.Name()), filepath.Join(newEnvRoot, entry.Name())); err != nil {
+			for _, name := range moved {
+				if err := os.Rename(filepath.Join(newEnvRoot, name), filepath.Join(envRoot, name)); err != nil {
+					log.Printf("failed to restore environment file %s: %v", name, err)
+				}
+			}
+
+			_ = os.RemoveAll(newEnvRoot)
+			return fmt.Errorf("moving environment file %s: %w", entry.Name(), err)
+		}
+
+		moved = append(moved, entry.Name())
+	}
+
+	return nil
+}
+
+// lock takes an exclusive lock on the environment directory, waiting up to the lock timeout for another process to
+// release it. The returned function releases the lock.
+func (fs *LocalFileDataStore) lock(ctx context.Context, name string) (func(), error) {
+	envRoot := fs.azdContext.EnvironmentRoot(name)
+	if err := os.MkdirAll(envRoot, osutil.PermissionDirectory); err != nil {
+		return nil, fmt.Errorf("creating environment directory: %w", err)
+	}
+
+	lockPath := filepath.Join(envRoot, lockFileName)
+	fl := flock.New(lockPath)
+
+	lockCtx, cancel := context.WithTimeout(ctx, fs.lockTimeout)
+	defer cancel()
+
+	locked, err := fl.TryLockContext(lockCtx, lockRetryDelay)
+	if !locked {
+		if err == nil || errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("'%s' %w", name, ErrLocked)
+		}
+
+		return nil, fmt.Errorf("locking file %s: %w", lockPath, err)
+	}
+
+	return func() {
+		if err := fl.Unlock(); err != nil {
+			log.Printf("failed to release environment lock: %v", err)
+		}
+	}, nil
+}
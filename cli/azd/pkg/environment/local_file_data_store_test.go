@@ -2,12 +2,18 @@ package environment
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/gofrs/flock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,6 +66,239 @@ func Test_LocalFileDataStore_SaveAndGet(t *testing.T) {
 	})
 }
 
+func Test_LocalFileDataStore_Delete(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+
+	t.Run("Success", func(t *testing.T) {
+		env1 := New("env1")
+		err := dataStore.Save(*mockContext.Context, env1)
+		require.NoError(t, err)
+
+		err = dataStore.Delete(*mockContext.Context, "env1")
+		require.NoError(t, err)
+
+		_, err = dataStore.Get(*mockContext.Context, "env1")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := dataStore.Delete(*mockContext.Context, "missing")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func Test_LocalFileDataStore_Rename(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+
+	t.Run("Success", func(t *testing.T) {
+		env1 := New("env1")
+		env1.DotenvSet("key1", "value1")
+		err := dataStore.Save(*mockContext.Context, env1)
+		require.NoError(t, err)
+		// Other files in the environment directory are moved too
+		statePath := filepath.Join(azdContext.EnvironmentRoot("env1"), "state.json")
+		require.NoError(t, os.WriteFile(statePath, []byte("{}"), osutil.PermissionFile))
+
+		err = dataStore.Rename(*mockContext.Context, "env1", "renamed")
+		require.NoError(t, err)
+
+		_, err = dataStore.Get(*mockContext.Context, "env1")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		env, err := dataStore.Get(*mockContext.Context, "renamed")
+		require.NoError(t, err)
+		require.Equal(t, "renamed", env.GetEnvName())
+		require.Equal(t, "value1", env.Getenv("key1"))
+		require.FileExists(t, filepath.Join(azdContext.EnvironmentRoot("renamed"), "state.json"))
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		require.NoError(t, dataStore.Save(*mockContext.Context, New("env2")))
+		require.NoError(t, dataStore.Save(*mockContext.Context, New("env3")))
+
+		err := dataStore.Rename(*mockContext.Context, "env2", "env3")
+		require.ErrorIs(t, err, ErrExists)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := dataStore.Rename(*mockContext.Context, "missing", "other")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("SameEnvironment", func(t *testing.T) {
+		// Services deployed in parallel set values on the same environment and save it concurrently
+		env := New("env3")
+		require.NoError(t, dataStore.Save(*mockContext.Context, env))
+
+		writers := 10
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				env.DotenvSet(fmt.Sprintf("KEY_%d", i), fmt.Sprintf("value%d", i))
+				errs <- dataStore.Save(*mockContext.Context, env)
+			}(i)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		saved, err := dataStore.Get(*mockContext.Context, "env3")
+		require.NoError(t, err)
+
+		for i := 0; i < writers; i++ {
+			require.Equal(t, fmt.Sprintf("value%d", i), env.Getenv(fmt.Sprintf("KEY_%d", i)))
+			require.Equal(t, fmt.Sprintf("value%d", i), saved.Getenv(fmt.Sprintf("KEY_%d", i)))
+		}
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		fileStore := dataStore.(*LocalFileDataStore)
+		fileStore.lockTimeout = 200 * time.Millisecond
+		require.NoError(t, dataStore.Save(*mockContext.Context, New("env4")))
+
+		// Simulates another process holding the lock
+		fl := flock.New(filepath.Join(azdContext.EnvironmentRoot("env4"), lockFileName))
+		require.NoError(t, fl.Lock())
+
+		err := dataStore.Rename(*mockContext.Context, "env4", "env5")
+		require.ErrorIs(t, err, ErrLocked)
+		require.NoDirExists(t, azdContext.EnvironmentRoot("env5"))
+
+		require.NoError(t, fl.Unlock())
+		require.NoError(t, dataStore.Rename(*mockContext.Context, "env4", "env5"))
+		require.NoDirExists(t, azdContext.EnvironmentRoot("env4"))
+	})
+}
+
+func Test_LocalFileDataStore_ConcurrentSave(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+
+	t.Run("AllWritesPersisted", func(t *testing.T) {
+		require.NoError(t, dataStore.Save(*mockContext.Context, New("env1")))
+
+		writers := 10
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				// Get reads the files under the lock, so it never sees a save of another writer half way
+				env, err := dataStore.Get(*mockContext.Context, "env1")
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				env.DotenvSet(fmt.Sprintf("KEY_%d", i), fmt.Sprintf("value%d", i))
+				errs <- dataStore.Save(*mockContext.Context, env)
+			}(i)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		env, err := dataStore.Get(*mockContext.Context, "env1")
+		require.NoError(t, err)
+
+		for i := 0; i < writers; i++ {
+			require.Equal(t, fmt.Sprintf("value%d", i), env.Getenv(fmt.Sprintf("KEY_%d", i)))
+		}
+	})
+
+	t.Run("SameEnvironment", func(t *testing.T) {
+		// Services deployed in parallel set values on the same environment and save it concurrently
+		env := New("env3")
+		require.NoError(t, dataStore.Save(*mockContext.Context, env))
+
+		writers := 10
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				env.DotenvSet(fmt.Sprintf("KEY_%d", i), fmt.Sprintf("value%d", i))
+				errs <- dataStore.Save(*mockContext.Context, env)
+			}(i)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		saved, err := dataStore.Get(*mockContext.Context, "env3")
+		require.NoError(t, err)
+
+		for i := 0; i < writers; i++ {
+			require.Equal(t, fmt.Sprintf("value%d", i), env.Getenv(fmt.Sprintf("KEY_%d", i)))
+			require.Equal(t, fmt.Sprintf("value%d", i), saved.Getenv(fmt.Sprintf("KEY_%d", i)))
+		}
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		fileStore := dataStore.(*LocalFileDataStore)
+		fileStore.lockTimeout = 200 * time.Millisecond
+
+		// Simulates another process holding the lock
+		fl := flock.New(filepath.Join(azdContext.EnvironmentRoot("env2"), lockFileName))
+		require.NoError(t, os.MkdirAll(azdContext.EnvironmentRoot("env2"), osutil.PermissionDirectory))
+		require.NoError(t, fl.Lock())
+
+		err := dataStore.Save(*mockContext.Context, New("env2"))
+		require.ErrorIs(t, err, ErrLocked)
+
+		require.NoError(t, fl.Unlock())
+		require.NoError(t, dataStore.Save(*mockContext.Context, New("env2")))
+	})
+
+	t.Run("ReadLocked", func(t *testing.T) {
+		fileStore := dataStore.(*LocalFileDataStore)
+		fileStore.lockTimeout = 200 * time.Millisecond
+		require.NoError(t, dataStore.Save(*mockContext.Context, New("env4")))
+
+		// Simulates another process saving the environment
+		fl := flock.New(filepath.Join(azdContext.EnvironmentRoot("env4"), lockFileName))
+		require.NoError(t, fl.Lock())
+
+		_, err := dataStore.Get(*mockContext.Context, "env4")
+		require.ErrorIs(t, err, ErrLocked)
+
+		require.NoError(t, fl.Unlock())
+		_, err = dataStore.Get(*mockContext.Context, "env4")
+		require.NoError(t, err)
+	})
+}
+
 func Test_LocalFileDataStore_Path(t *testing.T) {
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
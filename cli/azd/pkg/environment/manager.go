@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
@@ -38,6 +39,12 @@ type Spec struct {
 	Examples []string
 }
 
+// DeleteOptions controls which copies of an environment are removed by Manager.Delete
+type DeleteOptions struct {
+	// When set, the environment is also removed from the configured remote data store
+	IncludeRemote bool
+}
+
 const DotEnvFileName = ".env"
 const ConfigFileName = "config.json"
 
@@ -47,6 +54,9 @@ var (
 
 	// Error returned when an environment with a specified name cannot be found
 	ErrNotFound = errors.New("environment not found")
+
+	// Error returned when the environment state is being written by another process
+	ErrLocked = errors.New("environment is locked by another process")
 )
 
 // Manager is the interface used for managing instances of environments
@@ -57,6 +67,8 @@ type Manager interface {
 	Get(ctx context.Context, name string) (*Environment, error)
 	Save(ctx context.Context, env *Environment) error
 	Reload(ctx context.Context, env *Environment) error
+	Delete(ctx context.Context, name string, options DeleteOptions) error
+	Rename(ctx context.Context, name string, newName string) error
 	EnvPath(env *Environment) string
 	ConfigPath(env *Environment) string
 }
@@ -335,6 +347,93 @@ func (m *manager) Reload(ctx context.Context, env *Environment) error {
 	return m.local.Reload(ctx, env)
 }
 
+// Delete removes the environment from the local data store and, when requested, from the remote data store.
+// When the deleted environment is the default environment, the default environment is cleared.
+func (m *manager) Delete(ctx context.Context, name string, options DeleteOptions) error {
+	deleted := false
+
+	if err := m.local.Delete(ctx, name); err == nil {
+		deleted = true
+	} else if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("deleting local environment, %w", err)
+	}
+
+	if options.IncludeRemote && m.remote != nil {
+		if err := m.remote.Delete(ctx, name); err == nil {
+			deleted = true
+		} else if !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("deleting remote environment, %w", err)
+		}
+	}
+
+	if !deleted {
+		return fmt.Errorf("%s %w", name, ErrNotFound)
+	}
+
+	defaultEnvName, err := m.azdContext.GetDefaultEnvironmentName()
+	if err != nil {
+		return err
+	}
+
+	if defaultEnvName == name {
+		if err := m.azdContext.SetDefaultEnvironmentName(""); err != nil {
+			return fmt.Errorf("clearing default environment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Rename renames the environment in the local data store and, when the environment has remote state, in the remote data
+// store. When the renamed environment is the default environment, the default environment is updated.
+func (m *manager) Rename(ctx context.Context, name string, newName string) error {
+	if !IsValidEnvironmentName(newName) {
+		return errors.New(strings.TrimSpace(invalidEnvironmentNameMsg(newName)))
+	}
+
+	if IsReservedEnvironmentName(newName) {
+		return fmt.Errorf("environment name '%s' is reserved and can't be used", newName)
+	}
+
+	if _, err := m.local.Get(ctx, newName); err == nil {
+		return fmt.Errorf("'%s' %w", newName, ErrExists)
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if m.remote != nil {
+		if _, err := m.remote.Get(ctx, newName); err == nil {
+			return fmt.Errorf("'%s' %w remotely", newName, ErrExists)
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	if err := m.local.Rename(ctx, name, newName); err != nil {
+		return fmt.Errorf("renaming local environment, %w", err)
+	}
+
+	// Environments that were never saved remotely only exist locally
+	if m.remote != nil {
+		if err := m.remote.Rename(ctx, name, newName); err != nil && !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("renaming remote environment, %w", err)
+		}
+	}
+
+	defaultEnvName, err := m.azdContext.GetDefaultEnvironmentName()
+	if err != nil {
+		return err
+	}
+
+	if defaultEnvName == name {
+		if err := m.azdContext.SetDefaultEnvironmentName(newName); err != nil {
+			return fmt.Errorf("updating default environment: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // ensureValidEnvironmentName ensures the environment name is valid, if it is not, an error is printed
 // and the user is prompted for a new name.
 func (m *manager) ensureValidEnvironmentName(ctx context.Context, spec *Spec) error {
@@ -283,6 +283,143 @@ func Test_EnvManager_Save(t *testing.T) {
 	})
 }
 
+func Test_EnvManager_Delete(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	t.Run("LocalOnly", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.Delete(*mockContext.Context, "env1", DeleteOptions{})
+		require.NoError(t, err)
+
+		localDataStore.AssertCalled(t, "Delete", *mockContext.Context, "env1")
+		remoteDataStore.AssertNotCalled(t, "Delete", *mockContext.Context, "env1")
+	})
+
+	t.Run("IncludeRemote", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env3").Return(fmt.Errorf("env3 %w", ErrNotFound))
+		remoteDataStore.On("Delete", *mockContext.Context, "env3").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.Delete(*mockContext.Context, "env3", DeleteOptions{IncludeRemote: true})
+		require.NoError(t, err)
+
+		remoteDataStore.AssertCalled(t, "Delete", *mockContext.Context, "env3")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		localDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(fmt.Errorf("env1 %w", ErrNotFound))
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, nil)
+		err := manager.Delete(*mockContext.Context, "env1", DeleteOptions{IncludeRemote: true})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("ClearsDefault", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		require.NoError(t, azdContext.SetDefaultEnvironmentName("env1"))
+		localDataStore := &MockDataStore{}
+
+		localDataStore.On("Delete", *mockContext.Context, "env1").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, nil)
+		err := manager.Delete(*mockContext.Context, "env1", DeleteOptions{})
+		require.NoError(t, err)
+
+		defaultEnvName, err := azdContext.GetDefaultEnvironmentName()
+		require.NoError(t, err)
+		require.Equal(t, "", defaultEnvName)
+	})
+}
+
+func Test_EnvManager_Rename(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	notFound := fmt.Errorf("%w", ErrNotFound)
+
+	t.Run("LocalAndRemote", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		require.NoError(t, azdContext.SetDefaultEnvironmentName("env1"))
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Get", *mockContext.Context, "renamed").Return(nil, notFound)
+		remoteDataStore.On("Get", *mockContext.Context, "renamed").Return(nil, notFound)
+		localDataStore.On("Rename", *mockContext.Context, "env1", "renamed").Return(nil)
+		remoteDataStore.On("Rename", *mockContext.Context, "env1", "renamed").Return(nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.Rename(*mockContext.Context, "env1", "renamed")
+		require.NoError(t, err)
+
+		localDataStore.AssertCalled(t, "Rename", *mockContext.Context, "env1", "renamed")
+		remoteDataStore.AssertCalled(t, "Rename", *mockContext.Context, "env1", "renamed")
+
+		defaultEnvName, err := azdContext.GetDefaultEnvironmentName()
+		require.NoError(t, err)
+		require.Equal(t, "renamed", defaultEnvName)
+	})
+
+	t.Run("LocalOnly", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		require.NoError(t, azdContext.SetDefaultEnvironmentName("env2"))
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Get", *mockContext.Context, "renamed").Return(nil, notFound)
+		remoteDataStore.On("Get", *mockContext.Context, "renamed").Return(nil, notFound)
+		localDataStore.On("Rename", *mockContext.Context, "env1", "renamed").Return(nil)
+		remoteDataStore.On("Rename", *mockContext.Context, "env1", "renamed").Return(notFound)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.Rename(*mockContext.Context, "env1", "renamed")
+		require.NoError(t, err)
+
+		defaultEnvName, err := azdContext.GetDefaultEnvironmentName()
+		require.NoError(t, err)
+		require.Equal(t, "env2", defaultEnvName)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		localDataStore := &MockDataStore{}
+		remoteDataStore := &MockDataStore{}
+
+		localDataStore.On("Get", *mockContext.Context, "env2").Return(nil, notFound)
+		remoteDataStore.On("Get", *mockContext.Context, "env2").Return(New("env2"), nil)
+
+		manager := newManagerForTest(azdContext, mockContext.Console, localDataStore, remoteDataStore)
+		err := manager.Rename(*mockContext.Context, "env1", "env2")
+		require.ErrorIs(t, err, ErrExists)
+		localDataStore.AssertNotCalled(t, "Rename", *mockContext.Context, "env1", "env2")
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+		manager := newManagerForTest(azdContext, mockContext.Console, &MockDataStore{}, nil)
+
+		err := manager.Rename(*mockContext.Context, "env1", "my env")
+		require.ErrorContains(t, err, "is invalid")
+
+		err = manager.Rename(*mockContext.Context, "env1", "..")
+		require.ErrorContains(t, err, "is reserved")
+
+		err = manager.Rename(*mockContext.Context, "env1", "NUL")
+		require.ErrorContains(t, err, "is reserved")
+	})
+}
+
 func Test_EnvManager_CreateFromContainer(t *testing.T) {
 	t.Run("WithRemoteConfig", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
@@ -394,3 +531,13 @@ func (m *MockDataStore) Save(ctx context.Context, env *Environment) error {
 	args := m.Called(ctx, env)
 	return args.Error(0)
 }
+
+func (m *MockDataStore) Delete(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockDataStore) Rename(ctx context.Context, name string, newName string) error {
+	args := m.Called(ctx, name, newName)
+	return args.Error(0)
+}
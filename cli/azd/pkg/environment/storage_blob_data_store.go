@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
@@ -24,15 +26,23 @@ var (
 	ErrInvalidContainer = errors.New("storage container name is invalid.")
 )
 
+// leaseDuration is how long the lock on a remote environment is held. The lease expires on its own, so a lock left
+// behind by a process that exited is released after at most this duration.
+const leaseDuration = 60 * time.Second
+
+const remoteLockRetryDelay = time.Second
+
 type StorageBlobDataStore struct {
 	configManager config.Manager
 	blobClient    storage.BlobClient
+	lockTimeout   time.Duration
 }
 
 func NewStorageBlobDataStore(configManager config.Manager, blobClient storage.BlobClient) RemoteDataStore {
 	return &StorageBlobDataStore{
 		configManager: configManager,
 		blobClient:    blobClient,
+		lockTimeout:   defaultLockTimeout,
 	}
 }
 
@@ -61,6 +71,11 @@ func (sbd *StorageBlobDataStore) List(ctx context.Context) ([]*contracts.EnvList
 	envMap := map[string]*contracts.EnvListEnvironment{}
 
 	for _, blob := range blobs {
+		// The lock blob is created when saving, and doesn't make an environment on its own
+		if blob.Name == lockFileName {
+			continue
+		}
+
 		envName := filepath.Base(filepath.Dir(blob.Path))
 		env, has := envMap[envName]
 		if !has {
@@ -117,6 +132,16 @@ func (sbd *StorageBlobDataStore) Get(ctx context.Context, name string) (*Environ
 }
 
 func (sbd *StorageBlobDataStore) Save(ctx context.Context, env *Environment) error {
+	unlock, err := sbd.lock(ctx, env.name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// The environment is locked for the whole save, so values set by services deployed in parallel are uploaded together
+	env.mu.Lock()
+	defer env.mu.Unlock()
+
 	// Update configuration
 	cfgWriter := new(bytes.Buffer)
 
@@ -128,7 +153,7 @@ func (sbd *StorageBlobDataStore) Save(ctx context.Context, env *Environment) err
 		return fmt.Errorf("uploading config: %w", describeError(err))
 	}
 
-	marshalled, err := marshallDotEnv(env)
+	marshalled, err := marshallDotEnv(env.dotenv)
 	if err != nil {
 		return fmt.Errorf("marshalling .env: %w", err)
 	}
@@ -139,7 +164,7 @@ func (sbd *StorageBlobDataStore) Save(ctx context.Context, env *Environment) err
 		return fmt.Errorf("uploading .env: %w", describeError(err))
 	}
 
-	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.GetEnvName()))
+	tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.dotenv[EnvNameEnvVarName]))
 	return nil
 }
 
@@ -154,11 +179,9 @@ func (sbd *StorageBlobDataStore) Reload(ctx context.Context, env *Environment) e
 
 	envMap, err := godotenv.Parse(dotEnvBuffer)
 	if err != nil {
-		env.dotenv = make(map[string]string)
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(make(map[string]string))
 	} else {
-		env.dotenv = envMap
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(envMap)
 	}
 
 	// Reload config file
@@ -190,6 +213,88 @@ func (sbd *StorageBlobDataStore) Reload(ctx context.Context, env *Environment) e
 	return nil
 }
 
+// Delete removes all blobs stored for the environment with the specified name
+func (sbd *StorageBlobDataStore) Delete(ctx context.Context, name string) error {
+	blobs, err := sbd.blobClient.Items(ctx)
+	if err != nil {
+		normalizedErr := describeError(err)
+
+		if errors.Is(normalizedErr, storage.ErrContainerNotFound) {
+			return fmt.Errorf("%s %w", name, ErrNotFound)
+		}
+
+		return fmt.Errorf("listing blobs: %w", normalizedErr)
+	}
+
+	found := false
+	for _, blob := range blobs {
+		if filepath.Base(filepath.Dir(blob.Path)) != name {
+			continue
+		}
+
+		found = true
+		if err := sbd.blobClient.Delete(ctx, blob.Path); err != nil {
+			return fmt.Errorf("deleting blob '%s': %w", blob.Path, describeError(err))
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%s %w", name, ErrNotFound)
+	}
+
+	return nil
+}
+
+// lock takes an exclusive lock on the environment by leasing its lock blob, waiting up to the lock timeout for another
+// client to release it. The returned function releases the lock.
+func (sbd *StorageBlobDataStore) lock(ctx context.Context, name string) (func(), error) {
+	lockPath := fmt.Sprintf("%s/%s", name, lockFileName)
+
+	lockCtx, cancel := context.WithTimeout(ctx, sbd.lockTimeout)
+	defer cancel()
+
+	for {
+		leaseId, err := sbd.blobClient.AcquireLease(lockCtx, lockPath, leaseDuration)
+		if err == nil {
+			return func() {
+				if err := sbd.blobClient.ReleaseLease(ctx, lockPath, leaseId); err != nil {
+					log.Printf("failed to release environment lock: %v", err)
+				}
+			}, nil
+		}
+
+		if !errors.Is(err, storage.ErrLeaseAlreadyPresent) {
+			return nil, fmt.Errorf("locking environment: %w", describeError(err))
+		}
+
+		select {
+		case <-lockCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			return nil, fmt.Errorf("'%s' %w", name, ErrLocked)
+		case <-time.After(remoteLockRetryDelay):
+		}
+	}
+}
+
+// Rename copies the blobs of the environment with the specified name to newName, and removes the original blobs
+func (sbd *StorageBlobDataStore) Rename(ctx context.Context, name string, newName string) error {
+	env, err := sbd.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	env.name = newName
+	env.SetEnvName(newName)
+	if err := sbd.Save(ctx, env); err != nil {
+		return err
+	}
+
+	return sbd.Delete(ctx, name)
+}
+
 func describeError(err error) error {
 	var responseErr *azcore.ResponseError
 
@@ -198,6 +303,12 @@ func describeError(err error) error {
 		case "AuthorizationPermissionMismatch":
 			errorMsg := "Ensure your Azure account has `Storage Blob Contributor` role on the storage account or container."
 			return fmt.Errorf("%w %s %w", ErrAccessDenied, errorMsg, err)
+		case "AuthorizationFailure", "AuthenticationFailed":
+			//nolint:lll
+			errorMsg := "Ensure you are logged in with `azd auth login`, and that the storage account allows access from your network and with Microsoft Entra ID."
+			return fmt.Errorf("%w %s %w", ErrAccessDenied, errorMsg, err)
+		case "LeaseIdMissing", "LeaseAlreadyPresent":
+			return fmt.Errorf("%w: %w", ErrLocked, err)
 		case "InvalidResourceName":
 			//nolint:lll
 			errorMsg := "It must be between 3 and 63 characters in length, and must contain only lowercase letters, numbers, and dashes."
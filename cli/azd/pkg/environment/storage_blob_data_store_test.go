@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
@@ -75,6 +76,8 @@ func Test_StorageBlobDataStore_SaveAndGet(t *testing.T) {
 		blobClient.On("Download", *mockContext.Context, "env1/.env").Return(envReader, nil)
 		blobClient.On("Download", *mockContext.Context, "env1/config.json").Return(configReader, nil)
 		blobClient.On("Upload", *mockContext.Context, mock.AnythingOfType("string"), mock.Anything).Return(nil)
+		blobClient.On("AcquireLease", mock.Anything, "env1/.lock", leaseDuration).Return("LEASE_ID", nil)
+		blobClient.On("ReleaseLease", *mockContext.Context, "env1/.lock", "LEASE_ID").Return(nil)
 
 		env1 := New("env1")
 		env1.DotenvSet("key1", "value1")
@@ -87,6 +90,69 @@ func Test_StorageBlobDataStore_SaveAndGet(t *testing.T) {
 		require.Equal(t, "env1", env.name)
 		actual := env1.Getenv("key1")
 		require.Equal(t, "value1", actual)
+
+		blobClient.AssertCalled(t, "ReleaseLease", *mockContext.Context, "env1/.lock", "LEASE_ID")
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.On("AcquireLease", mock.Anything, "env1/.lock", leaseDuration).
+			Return("", storage.ErrLeaseAlreadyPresent)
+
+		dataStore := &StorageBlobDataStore{
+			configManager: configManager,
+			blobClient:    blobClient,
+			lockTimeout:   10 * time.Millisecond,
+		}
+
+		err := dataStore.Save(*mockContext.Context, New("env1"))
+		require.ErrorIs(t, err, ErrLocked)
+		blobClient.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_StorageBlobDataStore_ListSkipsLock(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	blobClient := &MockBlobClient{}
+	blobClient.On("Items", *mockContext.Context).Return([]*storage.Blob{
+		{Name: ".env", Path: "env1/.env"},
+		{Name: ".lock", Path: "env1/.lock"},
+		{Name: ".lock", Path: "env2/.lock"},
+	}, nil)
+
+	dataStore := NewStorageBlobDataStore(config.NewManager(), blobClient)
+	envList, err := dataStore.List(*mockContext.Context)
+	require.NoError(t, err)
+	require.Len(t, envList, 1)
+	require.Equal(t, "env1", envList[0].Name)
+}
+
+func Test_StorageBlobDataStore_Delete(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	configManager := config.NewManager()
+
+	t.Run("Success", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.On("Items", *mockContext.Context).Return(validBlobItems, nil)
+		blobClient.On("Delete", *mockContext.Context, mock.AnythingOfType("string")).Return(nil)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+		err := dataStore.Delete(*mockContext.Context, "env1")
+		require.NoError(t, err)
+
+		blobClient.AssertNumberOfCalls(t, "Delete", 2)
+		blobClient.AssertCalled(t, "Delete", *mockContext.Context, "env1/.env")
+		blobClient.AssertCalled(t, "Delete", *mockContext.Context, "env1/config.env")
+		blobClient.AssertNotCalled(t, "Delete", *mockContext.Context, "env2/.env")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		blobClient := &MockBlobClient{}
+		blobClient.On("Items", *mockContext.Context).Return(validBlobItems, nil)
+		dataStore := NewStorageBlobDataStore(configManager, blobClient)
+
+		err := dataStore.Delete(*mockContext.Context, "env3")
+		require.ErrorIs(t, err, ErrNotFound)
 	})
 }
 
@@ -133,6 +199,16 @@ func (m *MockBlobClient) Delete(ctx context.Context, blobPath string) error {
 	return args.Error(0)
 }
 
+func (m *MockBlobClient) AcquireLease(ctx context.Context, blobPath string, duration time.Duration) (string, error) {
+	args := m.Called(ctx, blobPath, duration)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockBlobClient) ReleaseLease(ctx context.Context, blobPath string, leaseId string) error {
+	args := m.Called(ctx, blobPath, leaseId)
+	return args.Error(0)
+}
+
 func (m *MockBlobClient) Items(ctx context.Context) ([]*storage.Blob, error) {
 	args := m.Called(ctx)
 
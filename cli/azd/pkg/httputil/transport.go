@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package httputil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// CaBundleEnvVarName is the environment variable with the path to a PEM file of additional CA certificates to trust,
+// for example the certificate of a TLS intercepting corporate proxy.
+const CaBundleEnvVarName = "AZURE_DEV_CA_BUNDLE"
+
+// NewTransport returns the HTTP transport used for all requests made by azd, including Azure SDK clients.
+// The transport uses the proxy configured with HTTPS_PROXY, HTTP_PROXY and NO_PROXY, and trusts the CA certificates in
+// the file referenced by AZURE_DEV_CA_BUNDLE, in addition to the system certificates.
+func NewTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	caBundlePath := os.Getenv(CaBundleEnvVarName)
+	if caBundlePath == "" {
+		return transport, nil
+	}
+
+	caBundle, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle from %s: %w", CaBundleEnvVarName, err)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if !rootCAs.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in CA bundle '%s'", caBundlePath)
+	}
+
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	return transport, nil
+}
@@ -0,0 +1,63 @@
+package httputil
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("Default", func(t *testing.T) {
+		ostest.Unsetenv(t, CaBundleEnvVarName)
+
+		transport, err := NewTransport()
+		require.NoError(t, err)
+		require.NotNil(t, transport.Proxy)
+
+		// The test server certificate is self-signed and isn't trusted by default
+		_, err = (&http.Client{Transport: transport}).Get(server.URL)
+		require.Error(t, err)
+	})
+
+	t.Run("CaBundle", func(t *testing.T) {
+		caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+		caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caBundlePath, caBundle, 0600))
+		ostest.Setenv(t, CaBundleEnvVarName, caBundlePath)
+
+		transport, err := NewTransport()
+		require.NoError(t, err)
+
+		res, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("InvalidCaBundle", func(t *testing.T) {
+		caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caBundlePath, []byte("not a certificate"), 0600))
+		ostest.Setenv(t, CaBundleEnvVarName, caBundlePath)
+
+		_, err := NewTransport()
+		require.Error(t, err)
+	})
+
+	t.Run("MissingCaBundle", func(t *testing.T) {
+		ostest.Setenv(t, CaBundleEnvVarName, filepath.Join(t.TempDir(), "missing.pem"))
+
+		_, err := NewTransport()
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+)
+
+// StackDeployment is a deployment that's submitted as an Azure deployment stack instead of a standard deployment. The stack
+// manages the resources it deploys, so they're deleted together with the stack. Each update of the stack creates a
+// standard deployment, which is used to report operations and to cancel the update.
+type StackDeployment struct {
+	Scope
+	stacks               azapi.DeploymentStacks
+	deploymentsService   azapi.Deployments
+	deploymentOperations azapi.DeploymentOperations
+	subscriptionId       string
+	resourceGroupName    string
+	location             string
+	name                 string
+}
+
+// NewSubscriptionStackDeployment creates a deployment stack with the given name at subscription scope.
+func NewSubscriptionStackDeployment(
+	stacks azapi.DeploymentStacks,
+	deploymentsService azapi.Deployments,
+	deploymentOperations azapi.DeploymentOperations,
+	location string, subscriptionId string, stackName string,
+) *StackDeployment {
+	return &StackDeployment{
+		Scope:                NewSubscriptionScope(deploymentsService, deploymentOperations, subscriptionId),
+		stacks:               stacks,
+		deploymentsService:   deploymentsService,
+		deploymentOperations: deploymentOperations,
+		subscriptionId:       subscriptionId,
+		location:             location,
+		name:                 stackName,
+	}
+}
+
+// NewResourceGroupStackDeployment creates a deployment stack with the given name in a resource group.
+func NewResourceGroupStackDeployment(
+	stacks azapi.DeploymentStacks,
+	deploymentsService azapi.Deployments,
+	deploymentOperations azapi.DeploymentOperations,
+	subscriptionId string, resourceGroupName string, stackName string,
+) *StackDeployment {
+	return &StackDeployment{
+		Scope: NewResourceGroupScope(
+			deploymentsService, deploymentOperations, subscriptionId, resourceGroupName),
+		stacks:               stacks,
+		deploymentsService:   deploymentsService,
+		deploymentOperations: deploymentOperations,
+		subscriptionId:       subscriptionId,
+		resourceGroupName:    resourceGroupName,
+		name:                 stackName,
+	}
+}
+
+// Name is the name of the deployment stack.
+func (s *StackDeployment) Name() string {
+	return s.name
+}
+
+// Gets the url to view the deployment stack in the Azure Portal
+func (s *StackDeployment) PortalUrl() string {
+	return fmt.Sprintf("https://portal.azure.com/#@/resource%s/overview", s.stackId())
+}
+
+// Gets the url to view the deployment stack outputs
+func (s *StackDeployment) OutputsUrl() string {
+	return fmt.Sprintf("https://portal.azure.com/#@/resource%s/outputs", s.stackId())
+}
+
+// Deploy creates or updates the deployment stack with a given template and set of parameters.
+func (s *StackDeployment) Deploy(
+	ctx context.Context, template azure.RawArmTemplate, parameters azure.ArmParameters, tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	return s.stacks.DeployStack(
+		ctx, s.subscriptionId, s.resourceGroupName, s.location, s.name, template, parameters, tags)
+}
+
+// DeployPreview previews the changes of the template with a standard what-if deployment, which deployment stacks don't
+// support. The preview doesn't include the resources the stack would delete.
+func (s *StackDeployment) DeployPreview(
+	ctx context.Context,
+	template azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+) (*armresources.WhatIfOperationResult, error) {
+	return s.standardDeployment(s.name).DeployPreview(ctx, template, parameters)
+}
+
+// Deployment fetches the deployment stack, described as a deployment.
+func (s *StackDeployment) Deployment(ctx context.Context) (*armresources.DeploymentExtended, error) {
+	stack, err := s.stacks.GetDeploymentStack(ctx, s.subscriptionId, s.resourceGroupName, s.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return azapi.DeploymentFromStack(stack), nil
+}
+
+// Operations returns the operations of the most recent update of the deployment stack.
+func (s *StackDeployment) Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error) {
+	deployment, err := s.latestDeployment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment.Operations(ctx)
+}
+
+// Cancel cancels the update of the deployment stack while it is running.
+func (s *StackDeployment) Cancel(ctx context.Context) error {
+	deployment, err := s.latestDeployment(ctx)
+	if err != nil {
+		return err
+	}
+
+	return deployment.Cancel(ctx)
+}
+
+// Delete deletes the deployment stack, together with the resources and resource groups it manages.
+func (s *StackDeployment) Delete(ctx context.Context) error {
+	return s.stacks.DeleteDeploymentStack(ctx, s.subscriptionId, s.resourceGroupName, s.name)
+}
+
+// latestDeployment returns the standard deployment created by the most recent update of the deployment stack.
+func (s *StackDeployment) latestDeployment(ctx context.Context) (Deployment, error) {
+	stack, err := s.stacks.GetDeploymentStack(ctx, s.subscriptionId, s.resourceGroupName, s.name)
+	if err != nil {
+		return nil, err
+	}
+
+	if stack.Properties == nil || stack.Properties.DeploymentId == nil {
+		return nil, errors.New("deployment stack has no deployment")
+	}
+
+	deploymentId, err := arm.ParseResourceID(*stack.Properties.DeploymentId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing deployment id of deployment stack: %w", err)
+	}
+
+	return s.standardDeployment(deploymentId.Name), nil
+}
+
+// standardDeployment returns the standard deployment with the given name, at the scope of the deployment stack.
+func (s *StackDeployment) standardDeployment(name string) Deployment {
+	if s.resourceGroupName == "" {
+		return NewSubscriptionDeployment(
+			s.deploymentsService, s.deploymentOperations, s.location, s.subscriptionId, name)
+	}
+
+	return NewResourceGroupDeployment(
+		s.deploymentsService, s.deploymentOperations, s.subscriptionId, s.resourceGroupName, name)
+}
+
+func (s *StackDeployment) stackId() string {
+	scope := azure.SubscriptionRID(s.subscriptionId)
+	if s.resourceGroupName != "" {
+		scope = azure.ResourceGroupRID(s.subscriptionId, s.resourceGroupName)
+	}
+
+	return fmt.Sprintf("%s/providers/Microsoft.Resources/deploymentStacks/%s", scope, s.name)
+}
@@ -13,6 +13,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -41,6 +42,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
 	"github.com/benbjohnson/clock"
 	"github.com/drone/envsubst"
+	"github.com/sethvargo/go-retry"
 	"golang.org/x/exp/maps"
 )
 
@@ -64,7 +66,9 @@ type BicepProvider struct {
 	azCli                 azcli.AzCli
 	deploymentsService    azapi.Deployments
 	deploymentOperations  azapi.DeploymentOperations
+	deploymentStacks      azapi.DeploymentStacks
 	prompters             prompt.Prompter
+	accountManager        account.Manager
 	curPrincipal          CurrentPrincipalIdProvider
 	alphaFeatureManager   *alpha.FeatureManager
 	clock                 clock.Clock
@@ -145,6 +149,10 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 	// We want to handle the case where the provider can `Initialize` even without a template, because we do this
 	// in a few of our end to end telemetry tests to speed things up.
 	if compileErr != nil {
+		if p.options.ResourceGroup != "" {
+			return fmt.Errorf("reading the target scope of the template: %w", compileErr)
+		}
+
 		return nil
 	}
 
@@ -153,6 +161,15 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 		return err
 	}
 
+	if p.options.ResourceGroup != "" && scope != azure.DeploymentScopeResourceGroup {
+		return fmt.Errorf(
+			"the template deploys to the %s scope, so it can't be deployed into resource group '%s'. "+
+				"Set 'targetScope' to 'resourceGroup' in the template, or omit the resource group",
+			scope,
+			p.options.ResourceGroup,
+		)
+	}
+
 	if scope == azure.DeploymentScopeResourceGroup {
 		if !p.alphaFeatureManager.IsEnabled(ResourceGroupDeploymentFeature) {
 			return ErrResourceGroupScopeNotSupported
@@ -160,7 +177,11 @@ func (p *BicepProvider) EnsureEnv(ctx context.Context) error {
 
 		p.console.WarnForFeature(ctx, ResourceGroupDeploymentFeature)
 
-		if p.env.Getenv(environment.ResourceGroupEnvVarName) == "" {
+		if p.options.ResourceGroup != "" {
+			if err := p.useResourceGroup(ctx, p.options.ResourceGroup); err != nil {
+				return err
+			}
+		} else if p.env.Getenv(environment.ResourceGroupEnvVarName) == "" {
 			rgName, err := p.prompters.PromptResourceGroup(ctx)
 			if err != nil {
 				return err
@@ -351,11 +372,20 @@ func (p *BicepProvider) plan(ctx context.Context) (*deploymentDetails, error) {
 			return nil, fmt.Errorf("resolving bicep parameters file: %w", err)
 		}
 
+		if p.options.ParametersFile != "" {
+			parameters, err = p.mergeParametersFile(ctx, compileResult.Template, parameters)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		configuredParameters, err := p.ensureParameters(ctx, compileResult.Template, parameters)
 		if err != nil {
 			return nil, err
 		}
 		compileResult.Parameters = configuredParameters
+	} else if p.options.ParametersFile != "" {
+		return nil, fmt.Errorf("a parameters file can't be used with the bicepparam module '%s'", modulePath)
 	}
 
 	deploymentScope, err := compileResult.Template.TargetScope()
@@ -375,6 +405,10 @@ func (p *BicepProvider) plan(ctx context.Context) (*deploymentDetails, error) {
 }
 
 func (p *BicepProvider) deploymentScope(deploymentScope azure.DeploymentScope) (infra.Deployment, error) {
+	if p.options.DeploymentStack {
+		return p.stackDeployment(deploymentScope)
+	}
+
 	if deploymentScope == azure.DeploymentScopeSubscription {
 		return infra.NewSubscriptionDeployment(
 			p.deploymentsService,
@@ -395,6 +429,31 @@ func (p *BicepProvider) deploymentScope(deploymentScope azure.DeploymentScope) (
 	return nil, fmt.Errorf("unsupported scope: %s", deploymentScope)
 }
 
+// stackDeployment returns the deployment stack of the environment, which is named after the environment, so every
+// provisioning updates the same stack.
+func (p *BicepProvider) stackDeployment(deploymentScope azure.DeploymentScope) (infra.Deployment, error) {
+	if deploymentScope == azure.DeploymentScopeSubscription {
+		return infra.NewSubscriptionStackDeployment(
+			p.deploymentStacks,
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.env.GetLocation(),
+			p.env.GetSubscriptionId(),
+			p.env.GetEnvName(),
+		), nil
+	} else if deploymentScope == azure.DeploymentScopeResourceGroup {
+		return infra.NewResourceGroupStackDeployment(
+			p.deploymentStacks,
+			p.deploymentsService,
+			p.deploymentOperations,
+			p.env.GetSubscriptionId(),
+			p.env.Getenv(environment.ResourceGroupEnvVarName),
+			p.env.GetEnvName(),
+		), nil
+	}
+	return nil, fmt.Errorf("unsupported scope: %s", deploymentScope)
+}
+
 // cArmDeploymentNameLengthMax is the maximum length of the name of a deployment in ARM.
 const cArmDeploymentNameLengthMax = 64
 
@@ -481,6 +540,9 @@ func parametersHash(templateParameters azure.ArmTemplateParameterDefinitions, pa
 		pValue := paramDefinition.DefaultValue
 		if param, exists := params[paramName]; exists {
 			pValue = param.Value
+			if param.KeyVaultReference != nil {
+				pValue = param.KeyVaultReference
+			}
 		}
 		nameAndValueParams[paramName] = pValue
 	}
@@ -528,6 +590,32 @@ func prevDeploymentEqualToCurrent(
 	return true
 }
 
+// useResourceGroup records an existing resource group as the AZURE_RESOURCE_GROUP of the environment, which the template
+// is deployed into.
+func (p *BicepProvider) useResourceGroup(ctx context.Context, resourceGroupName string) error {
+	subscriptionId := p.env.GetSubscriptionId()
+	exists, err := p.azCli.ResourceGroupExists(ctx, subscriptionId, resourceGroupName)
+	if err != nil {
+		return fmt.Errorf("looking up resource group '%s': %w", resourceGroupName, err)
+	}
+
+	if !exists {
+		return fmt.Errorf(
+			"resource group '%s' was not found in subscription '%s'. Create it first, or omit the resource group "+
+				"to use the one of the environment",
+			resourceGroupName,
+			subscriptionId,
+		)
+	}
+
+	p.env.DotenvSet(environment.ResourceGroupEnvVarName, resourceGroupName)
+	if err := p.envManager.Save(ctx, p.env); err != nil {
+		return fmt.Errorf("saving resource group name: %w", err)
+	}
+
+	return nil
+}
+
 func logDS(msg string, v ...any) {
 	log.Printf("%s : %s", "deployment-state: ", fmt.Sprintf(msg, v...))
 }
@@ -539,6 +627,11 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		logDS("Azure Deployment State is disabled by --ignore-ads arg.")
 	}
 
+	// A deployment stack is always updated, which also reverts the changes made to its resources outside of azd
+	if p.options.DeploymentStack {
+		logDS("Azure Deployment State is disabled for deployment stacks.")
+	}
+
 	bicepDeploymentData, err := p.plan(ctx)
 	if err != nil {
 		return nil, err
@@ -558,7 +651,7 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		logDS(parametersHashErr.Error())
 	}
 
-	if !p.ignoreDeploymentState && parametersHashErr == nil {
+	if !p.ignoreDeploymentState && !p.options.DeploymentStack && parametersHashErr == nil {
 		deploymentState, err := p.deploymentState(ctx, bicepDeploymentData, currentParamsHash)
 		if err == nil {
 			deployment.Outputs = p.createOutputParameters(
@@ -574,8 +667,15 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		logDS(err.Error())
 	}
 
+	// Fail before the deployment starts instead of surfacing RBAC errors part way through it
+	if !p.options.SkipPermissionCheck {
+		if err := p.ensureDeploymentAccess(ctx, bicepDeploymentData.CompiledBicep.Template); err != nil {
+			return nil, err
+		}
+	}
+
 	cancelProgress := make(chan bool)
-	defer func() { cancelProgress <- true }()
+	defer close(cancelProgress)
 	go func() {
 		// Disable reporting progress if needed
 		if use, err := strconv.ParseBool(os.Getenv("AZD_DEBUG_PROVISION_PROGRESS_DISABLE")); err == nil && use {
@@ -598,6 +698,10 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 			case <-cancelProgress:
 				timer.Stop()
 				return
+			case <-ctx.Done():
+				// The deployment was canceled, stop polling for progress
+				timer.Stop()
+				return
 			case <-timer.C:
 				if err := progressDisplay.ReportProgress(ctx, &queryStartTime); err != nil {
 					// We don't want to fail the whole deployment if a progress reporting error occurs
@@ -626,6 +730,10 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		deploymentTags,
 	)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, p.cancelDeployment(ctx, bicepDeploymentData.Target, err)
+		}
+
 		return nil, err
 	}
 
@@ -634,9 +742,21 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 		azapi.CreateDeploymentOutput(deployResult.Properties.Outputs),
 	)
 
-	return &DeployResult{
+	result := &DeployResult{
 		Deployment: deployment,
-	}, nil
+	}
+
+	if p.options.ChangeSummary {
+		// The summary is informational, failing to compute it doesn't fail the deployment
+		changes, err := p.deploymentChanges(ctx, bicepDeploymentData.Target, deployResult)
+		if err != nil {
+			log.Printf("summarizing changes of deployment '%s': %v", bicepDeploymentData.Target.Name(), err)
+		} else {
+			result.Changes = changes
+		}
+	}
+
+	return result, nil
 }
 
 // Preview runs deploy using the what-if argument
@@ -733,6 +853,26 @@ func (p *BicepProvider) scopeForTemplate(ctx context.Context, t azure.ArmTemplat
 	}
 }
 
+// ensureDeploymentAccess checks that the current account can create deployments at the target scope of the template.
+// Templates deployed to other scopes than the subscription or a resource group aren't checked.
+func (p *BicepProvider) ensureDeploymentAccess(ctx context.Context, t azure.ArmTemplate) error {
+	deploymentScope, err := t.TargetScope()
+	if err != nil {
+		return err
+	}
+
+	switch deploymentScope {
+	case azure.DeploymentScopeSubscription:
+		return p.accountManager.EnsureSubscriptionAccess(ctx, p.env.GetSubscriptionId(), "")
+	case azure.DeploymentScopeResourceGroup:
+		return p.accountManager.EnsureSubscriptionAccess(
+			ctx, p.env.GetSubscriptionId(), p.env.Getenv(environment.ResourceGroupEnvVarName))
+	default:
+		log.Printf("skipping permission check for deployment scope: %s", deploymentScope)
+		return nil
+	}
+}
+
 func (p *BicepProvider) inferScopeFromEnv(ctx context.Context) (infra.Scope, error) {
 	if resourceGroup, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
 		return infra.NewResourceGroupScope(
@@ -792,12 +932,25 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 	}
 
 	// TODO: Report progress, "Fetching resource groups"
-	deployments, err := p.findCompletedDeployments(ctx, p.env.GetEnvName(), scope, "")
-	if err != nil {
-		return nil, err
+	var deployment *armresources.DeploymentExtended
+	stack, isStack := deployScope.(*infra.StackDeployment)
+	if isStack {
+		deployment, err = stack.Deployment(ctx)
+		if errors.Is(err, azapi.ErrDeploymentStackNotFound) {
+			return nil, fmt.Errorf("no deployment stack found for environment %s", p.env.GetEnvName())
+		} else if err != nil {
+			return nil, err
+		}
+	} else {
+		deployments, err := p.findCompletedDeployments(ctx, p.env.GetEnvName(), scope, "")
+		if err != nil {
+			return nil, err
+		}
+
+		deployment = deployments[0]
 	}
 
-	rgsFromDeployment := resourceGroupsToDelete(deployments[0])
+	rgsFromDeployment := resourceGroupsToDelete(deployment)
 
 	// TODO: Report progress, "Fetching resources"
 	groupedResources, err := p.getAllResourcesToDelete(ctx, rgsFromDeployment)
@@ -840,7 +993,11 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 		return nil, fmt.Errorf("getting cognitive accounts to purge: %w", err)
 	}
 
-	if err := p.destroyResourceGroups(ctx, options, groupedResources, len(allResources)); err != nil {
+	if isStack {
+		if err := p.destroyDeploymentStack(ctx, options, stack, groupedResources, len(allResources)); err != nil {
+			return nil, fmt.Errorf("deleting deployment stack: %w", err)
+		}
+	} else if err := p.destroyResourceGroups(ctx, options, groupedResources, len(allResources)); err != nil {
 		return nil, fmt.Errorf("deleting resource groups: %w", err)
 	}
 
@@ -901,10 +1058,16 @@ func (p *BicepProvider) Destroy(ctx context.Context, options DestroyOptions) (*D
 	destroyResult := &DestroyResult{
 		InvalidatedEnvKeys: maps.Keys(p.createOutputParameters(
 			compileResult.Template.Outputs,
-			azapi.CreateDeploymentOutput(deployments[0].Properties.Outputs),
+			azapi.CreateDeploymentOutput(deployment.Properties.Outputs),
 		)),
 	}
 
+	// The deployment stack is deleted with its resources, so there's no deployment history to keep. A resource group
+	// scoped stack doesn't manage its resource group, which is kept.
+	if isStack {
+		return destroyResult, nil
+	}
+
 	// Since we have deleted the resource group, add AZURE_RESOURCE_GROUP to the list of invalidated env vars
 	// so it will be removed from the .env file.
 	if _, ok := scope.(*infra.ResourceGroupScope); ok {
@@ -1116,6 +1279,63 @@ func (p *BicepProvider) destroyResourceGroups(
 	options DestroyOptions,
 	groupedResources map[string][]azcli.AzCliResource,
 	resourceCount int,
+) error {
+	if err := p.confirmDestroy(ctx, options, groupedResources, resourceCount); err != nil {
+		return err
+	}
+
+	p.console.Message(ctx, output.WithGrayFormat("Deleting your resources can take some time.\n"))
+
+	for resourceGroup := range groupedResources {
+		message := fmt.Sprintf("Deleting resource group: %s",
+			output.WithHighLightFormat(resourceGroup),
+		)
+		p.console.ShowSpinner(ctx, message, input.Step)
+		err := p.azCli.DeleteResourceGroup(ctx, p.env.GetSubscriptionId(), resourceGroup)
+
+		p.console.StopSpinner(ctx, message, input.GetStepResultFormat(err))
+		if err != nil {
+			return err
+		}
+	}
+	// empty line at the end of all resource group deletion
+	p.console.Message(ctx, "")
+	return nil
+}
+
+// Deletes the deployment stack, which deletes the azure resources and resource groups it manages
+func (p *BicepProvider) destroyDeploymentStack(
+	ctx context.Context,
+	options DestroyOptions,
+	stack *infra.StackDeployment,
+	groupedResources map[string][]azcli.AzCliResource,
+	resourceCount int,
+) error {
+	if err := p.confirmDestroy(ctx, options, groupedResources, resourceCount); err != nil {
+		return err
+	}
+
+	p.console.Message(ctx, output.WithGrayFormat("Deleting your resources can take some time.\n"))
+
+	message := fmt.Sprintf("Deleting deployment stack: %s", output.WithHighLightFormat(stack.Name()))
+	p.console.ShowSpinner(ctx, message, input.Step)
+	err := stack.Delete(ctx)
+	p.console.StopSpinner(ctx, message, input.GetStepResultFormat(err))
+	if err != nil {
+		return err
+	}
+
+	// empty line at the end of the stack deletion
+	p.console.Message(ctx, "")
+	return nil
+}
+
+// Prompts for the confirmation to delete the resource groups, unless the deletion is forced
+func (p *BicepProvider) confirmDestroy(
+	ctx context.Context,
+	options DestroyOptions,
+	groupedResources map[string][]azcli.AzCliResource,
+	resourceCount int,
 ) error {
 	if !options.Force() {
 		p.console.MessageUxItem(ctx, &ux.MultilineMessage{
@@ -1139,22 +1359,6 @@ func (p *BicepProvider) destroyResourceGroups(
 		}
 	}
 
-	p.console.Message(ctx, output.WithGrayFormat("Deleting your resources can take some time.\n"))
-
-	for resourceGroup := range groupedResources {
-		message := fmt.Sprintf("Deleting resource group: %s",
-			output.WithHighLightFormat(resourceGroup),
-		)
-		p.console.ShowSpinner(ctx, message, input.Step)
-		err := p.azCli.DeleteResourceGroup(ctx, p.env.GetSubscriptionId(), resourceGroup)
-
-		p.console.StopSpinner(ctx, message, input.GetStepResultFormat(err))
-		if err != nil {
-			return err
-		}
-	}
-	// empty line at the end of all resource group deletion
-	p.console.Message(ctx, "")
 	return nil
 }
 
@@ -1539,20 +1743,12 @@ func (p *BicepProvider) purgeAPIManagement(
 }
 
 func (p *BicepProvider) mapBicepTypeToInterfaceType(s string) ParameterType {
-	switch s {
-	case "String", "string", "secureString", "securestring":
-		return ParameterTypeString
-	case "Bool", "bool":
-		return ParameterTypeBoolean
-	case "Int", "int":
-		return ParameterTypeNumber
-	case "Object", "object", "secureObject", "secureobject":
-		return ParameterTypeObject
-	case "Array", "array":
-		return ParameterTypeArray
-	default:
+	paramType, ok := parameterTypeOf(s)
+	if !ok {
 		panic(fmt.Sprintf("unexpected bicep type: '%s'", s))
 	}
+
+	return paramType
 }
 
 // Creates a normalized view of the azure output parameters and resolves inconsistencies in the output parameter name
@@ -1600,6 +1796,71 @@ func (p *BicepProvider) loadParameters(ctx context.Context) (map[string]azure.Ar
 		return nil, fmt.Errorf("reading parameters.json: %w", err)
 	}
 
+	return p.parseParameters(ctx, parametersBytes)
+}
+
+// mergeParametersFile reads the ARM parameters file set by Options.ParametersFile and merges its values over parameters,
+// with the values from the file taking precedence. Parameters that the template doesn't declare are ignored with a
+// warning, while values that can't be assigned to the declared parameter type are reported as an error.
+func (p *BicepProvider) mergeParametersFile(
+	ctx context.Context,
+	template azure.ArmTemplate,
+	parameters azure.ArmParameters,
+) (azure.ArmParameters, error) {
+	parametersBytes, err := os.ReadFile(p.options.ParametersFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading parameters file: %w", err)
+	}
+
+	fileParameters, err := p.parseParameters(ctx, parametersBytes)
+	if err != nil {
+		return nil, fmt.Errorf("resolving parameters file '%s': %w", p.options.ParametersFile, err)
+	}
+
+	merged := make(azure.ArmParameters, len(parameters)+len(fileParameters))
+	maps.Copy(merged, parameters)
+
+	var unknown []string
+	for key, value := range fileParameters {
+		param, has := template.Parameters[key]
+		if !has {
+			unknown = append(unknown, key)
+			continue
+		}
+
+		// A key vault reference is resolved by ARM during the deployment, so there is no value to check
+		if value.KeyVaultReference != nil {
+			merged[key] = value
+			continue
+		}
+
+		paramType := p.mapBicepTypeToInterfaceType(param.Type)
+		if !isValueAssignableToParameterType(paramType, armParameterFileValue(paramType, value.Value)) {
+			return nil, fmt.Errorf(
+				"parameters file '%s': value for parameter '%s' is not assignable to type '%s'",
+				p.options.ParametersFile,
+				key,
+				param.Type,
+			)
+		}
+
+		merged[key] = value
+	}
+
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		p.console.Message(ctx, output.WithWarningFormat(
+			"WARNING: The parameters file '%s' contains parameters that are not declared by the template: %s",
+			p.options.ParametersFile,
+			strings.Join(unknown, ", "),
+		))
+	}
+
+	return merged, nil
+}
+
+// parseParameters parses the contents of an ARM parameters file, doing environment and command substitutions.
+func (p *BicepProvider) parseParameters(ctx context.Context, parametersBytes []byte) (azure.ArmParameters, error) {
 	principalId, err := p.curPrincipal.CurrentPrincipalId(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetching current principal id: %w", err)
@@ -1821,7 +2082,217 @@ func (p *BicepProvider) deployModule(
 	armParameters azure.ArmParameters,
 	tags map[string]*string,
 ) (*armresources.DeploymentExtended, error) {
-	return target.Deploy(ctx, armTemplate, armParameters, tags)
+	var deployResult *armresources.DeploymentExtended
+
+	err := retry.Do(ctx, deployRetryBackoff(), func(ctx context.Context) error {
+		result, err := target.Deploy(ctx, armTemplate, armParameters, tags)
+		if err != nil {
+			switch class, reason := classifyDeploymentError(err); class {
+			case deploymentErrorRetryable:
+				log.Printf("deployment '%s' failed with a transient error, retrying: %v", target.Name(), err)
+				return retry.RetryableError(err)
+			case deploymentErrorTerminal:
+				return fmt.Errorf("%s: %w", reason, err)
+			default:
+				return err
+			}
+		}
+
+		deployResult = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deployResult, nil
+}
+
+// deploymentCancelTimeout is how long azd waits for the in-flight deployment to be canceled after provisioning was
+// interrupted.
+const deploymentCancelTimeout = 30 * time.Second
+
+// cancelDeployment cancels the in-flight deployment after provisioning was interrupted, like when the user pressed Ctrl+C,
+// and reports the resources the deployment created before it was canceled, which are left in place.
+func (p *BicepProvider) cancelDeployment(ctx context.Context, target infra.Deployment, deployErr error) error {
+	// The provisioning context is already canceled, so the cleanup runs with its own deadline
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deploymentCancelTimeout)
+	defer cancel()
+
+	p.console.StopSpinner(ctx, "Creating/Updating resources", input.StepFailed)
+
+	if err := target.Cancel(ctx); err != nil {
+		return fmt.Errorf(
+			"%w\n\ncanceling deployment '%s' failed, it may still be running. See %s: %w",
+			deployErr,
+			target.Name(),
+			target.PortalUrl(),
+			err,
+		)
+	}
+
+	p.console.Message(ctx, fmt.Sprintf("Deployment '%s' was canceled.", target.Name()))
+
+	operations, err := target.Operations(ctx)
+	if err != nil {
+		log.Printf("listing operations of canceled deployment '%s': %v", target.Name(), err)
+		return deployErr
+	}
+
+	created := []string{}
+	for _, operation := range operations {
+		if operation.Properties == nil ||
+			operation.Properties.TargetResource == nil ||
+			operation.Properties.TargetResource.ID == nil ||
+			convert.ToValueWithDefault(operation.Properties.ProvisioningOperation, "") !=
+				armresources.ProvisioningOperationCreate ||
+			!strings.EqualFold(convert.ToValueWithDefault(operation.Properties.ProvisioningState, ""), "Succeeded") {
+			continue
+		}
+
+		created = append(created, *operation.Properties.TargetResource.ID)
+	}
+
+	if len(created) > 0 {
+		slices.Sort(created)
+		p.console.Message(ctx, output.WithWarningFormat(
+			"The following resources were created before the deployment was canceled and were left in place:"))
+		for _, resourceId := range created {
+			p.console.Message(ctx, fmt.Sprintf("  - %s", resourceId))
+		}
+		p.console.Message(ctx, fmt.Sprintf(
+			"Run %s to finish provisioning, or %s to delete them.",
+			output.WithHighLightFormat("azd provision"),
+			output.WithHighLightFormat("azd down"),
+		))
+	}
+
+	return deployErr
+}
+
+// deploymentChanges counts the resources of a completed deployment by the change the deployment made to them. Resources
+// the deployment created are reported by their operation. Existing resources are updated when ARM reports a change to
+// them since the deployment started, and unchanged otherwise. Resources without a change time, like resource groups and
+// child resources, are reported as updated, since the deployment applied their definition.
+func (p *BicepProvider) deploymentChanges(
+	ctx context.Context,
+	target infra.Deployment,
+	deployResult *armresources.DeploymentExtended,
+) (*DeploymentChanges, error) {
+	// The change times are set by ARM, so they are compared with the start of the deployment as ARM reports it, and not
+	// with the local clock, which can be skewed.
+	deploymentStart, err := deploymentStartTime(deployResult)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceManager := infra.NewAzureResourceManager(p.azCli, p.deploymentOperations)
+	operations, err := resourceManager.GetDeploymentResourceOperations(ctx, target, &deploymentStart)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &DeploymentChanges{}
+	existing := []*arm.ResourceID{}
+	for _, operation := range operations {
+		if operation.Properties == nil ||
+			operation.Properties.TargetResource == nil ||
+			operation.Properties.TargetResource.ID == nil ||
+			convert.ToValueWithDefault(operation.Properties.ProvisioningOperation, "") !=
+				armresources.ProvisioningOperationCreate ||
+			!strings.EqualFold(convert.ToValueWithDefault(operation.Properties.ProvisioningState, ""), "Succeeded") ||
+			strings.EqualFold(
+				convert.ToValueWithDefault(operation.Properties.TargetResource.ResourceType, ""),
+				string(infra.AzureResourceTypeDeployment),
+			) {
+			continue
+		}
+
+		if convert.ToValueWithDefault(operation.Properties.StatusCode, "") == "Created" {
+			changes.Created++
+			continue
+		}
+
+		resourceId, err := arm.ParseResourceID(*operation.Properties.TargetResource.ID)
+		if err != nil || resourceId.ResourceGroupName == "" {
+			changes.Updated++
+			continue
+		}
+
+		existing = append(existing, resourceId)
+	}
+
+	// Change times are looked up once per resource group
+	changedTimes := map[string]*time.Time{}
+	listedGroups := map[string]bool{}
+	for _, resourceId := range existing {
+		groupKey := strings.ToLower(resourceId.SubscriptionID + "/" + resourceId.ResourceGroupName)
+		if listedGroups[groupKey] {
+			continue
+		}
+		listedGroups[groupKey] = true
+
+		resources, err := p.azCli.ListResourceGroupResources(
+			ctx,
+			resourceId.SubscriptionID,
+			resourceId.ResourceGroupName,
+			&azcli.ListResourceGroupResourcesOptions{Expand: to.Ptr("changedTime")},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("listing resources of resource group '%s': %w", resourceId.ResourceGroupName, err)
+		}
+
+		for _, resource := range resources {
+			changedTimes[strings.ToLower(resource.Id)] = resource.ChangedTime
+		}
+	}
+
+	for _, resourceId := range existing {
+		changedTime := changedTimes[strings.ToLower(resourceId.String())]
+		if changedTime != nil && changedTime.Before(deploymentStart) {
+			changes.Unchanged++
+		} else {
+			changes.Updated++
+		}
+	}
+
+	return changes, nil
+}
+
+// armDurationRegex matches the ISO 8601 durations reported by ARM, like "PT1M23.456S".
+var armDurationRegex = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// deploymentStartTime returns the time a completed deployment started, from the completion time and the duration ARM
+// reports for it.
+func deploymentStartTime(deployment *armresources.DeploymentExtended) (time.Time, error) {
+	if deployment == nil ||
+		deployment.Properties == nil ||
+		deployment.Properties.Timestamp == nil ||
+		deployment.Properties.Duration == nil {
+		return time.Time{}, errors.New("the deployment has no timestamp or duration")
+	}
+
+	match := armDurationRegex.FindStringSubmatch(*deployment.Properties.Duration)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("parsing deployment duration '%s'", *deployment.Properties.Duration)
+	}
+
+	var duration time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	for idx, unit := range units {
+		if match[idx+1] == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(match[idx+1], 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing deployment duration '%s': %w", *deployment.Properties.Duration, err)
+		}
+
+		duration += time.Duration(value * float64(unit))
+	}
+
+	return deployment.Properties.Timestamp.Add(-duration), nil
 }
 
 // Gets the folder path to the specified module
@@ -1862,6 +2333,11 @@ func (p *BicepProvider) ensureParameters(
 
 		// If a value is explicitly configured via a parameters file, use it.
 		if v, has := parameters[key]; has {
+			if v.KeyVaultReference != nil {
+				configuredParameters[key] = v
+				continue
+			}
+
 			configuredParameters[key] = azure.ArmParameterValue{
 				Value: armParameterFileValue(p.mapBicepTypeToInterfaceType(param.Type), v.Value),
 			}
@@ -1891,6 +2367,14 @@ func (p *BicepProvider) ensureParameters(
 			continue
 		}
 
+		// Location parameters use the default location from azd config when one is set, without prompting.
+		if location, has := p.defaultLocationParameter(ctx, param); has {
+			configuredParameters[key] = azure.ArmParameterValue{
+				Value: location,
+			}
+			continue
+		}
+
 		// Otherwise, prompt for the value.
 		value, err := p.promptForParameter(ctx, key, param)
 		if err != nil {
@@ -1991,10 +2475,12 @@ func NewBicepProvider(
 	azCli azcli.AzCli,
 	deploymentsService azapi.Deployments,
 	deploymentOperations azapi.DeploymentOperations,
+	deploymentStacks azapi.DeploymentStacks,
 	envManager environment.Manager,
 	env *environment.Environment,
 	console input.Console,
 	prompters prompt.Prompter,
+	accountManager account.Manager,
 	curPrincipal CurrentPrincipalIdProvider,
 	alphaFeatureManager *alpha.FeatureManager,
 	clock clock.Clock,
@@ -2007,7 +2493,9 @@ func NewBicepProvider(
 		azCli:                azCli,
 		deploymentsService:   deploymentsService,
 		deploymentOperations: deploymentOperations,
+		deploymentStacks:     deploymentStacks,
 		prompters:            prompters,
+		accountManager:       accountManager,
 		curPrincipal:         curPrincipal,
 		alphaFeatureManager:  alphaFeatureManager,
 		clock:                clock,
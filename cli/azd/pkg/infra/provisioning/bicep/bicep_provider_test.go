@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -23,6 +25,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -30,6 +33,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
@@ -62,6 +66,75 @@ func TestBicepPlan(t *testing.T) {
 	)
 }
 
+func TestBicepPlanParametersFile(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	infraProvider := createBicepProvider(t, mockContext)
+
+	t.Run("FileWins", func(t *testing.T) {
+		infraProvider.options.ParametersFile = writeParametersFile(t, map[string]any{
+			"location": "eastus",
+			"unknown":  "value",
+		})
+
+		deploymentPlan, err := infraProvider.plan(*mockContext.Context)
+		require.NoError(t, err)
+
+		configuredParameters := deploymentPlan.CompiledBicep.Parameters
+		require.Equal(t, "eastus", configuredParameters["location"].Value)
+		require.Equal(t, infraProvider.env.GetEnvName(), configuredParameters["environmentName"].Value)
+		require.NotContains(t, configuredParameters, "unknown")
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		infraProvider.options.ParametersFile = writeParametersFile(t, map[string]any{
+			"location": 1,
+		})
+
+		_, err := infraProvider.plan(*mockContext.Context)
+		require.ErrorContains(t, err, "value for parameter 'location' is not assignable to type 'string'")
+	})
+
+	t.Run("KeyVaultReference", func(t *testing.T) {
+		reference := &azure.KeyVaultParameterReference{
+			KeyVault:   azure.KeyVaultReference{ResourceId: "/subscriptions/sub/resourceGroups/rg/providers/vault"},
+			SecretName: "location",
+		}
+		infraProvider.options.ParametersFile = writeParameters(t, azure.ArmParameters{
+			"location": {KeyVaultReference: reference},
+		})
+
+		deploymentPlan, err := infraProvider.plan(*mockContext.Context)
+		require.NoError(t, err)
+
+		location := deploymentPlan.CompiledBicep.Parameters["location"]
+		require.Nil(t, location.Value)
+		require.Equal(t, reference, location.KeyVaultReference)
+	})
+}
+
+func writeParametersFile(t *testing.T, values map[string]any) string {
+	parameters := azure.ArmParameters{}
+	for key, value := range values {
+		parameters[key] = azure.ArmParameterValue{Value: value}
+	}
+
+	return writeParameters(t, parameters)
+}
+
+func writeParameters(t *testing.T, parameters azure.ArmParameters) string {
+	contents, err := json.Marshal(azure.ArmParameterFile{
+		ContentVersion: "1.0.0.0",
+		Parameters:     parameters,
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "main.parameters.json")
+	require.NoError(t, os.WriteFile(path, contents, osutil.PermissionFile))
+
+	return path
+}
+
 const paramsArmJson = `{
 	"$schema": "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#",
 	"contentVersion": "1.0.0.0",
@@ -309,6 +382,76 @@ func TestPlanForResourceGroup(t *testing.T) {
 		planResult.Target.(*infra.ResourceGroupDeployment).ResourceGroupName())
 }
 
+func TestBicepUseResourceGroup(t *testing.T) {
+	prepareResourceGroupMocks := func(mockContext *mocks.MockContext, schema string) {
+		require.NoError(t, mockContext.Config.Set("alpha.resourceGroupDeployments", "on"))
+
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(args.Cmd, "bicep") && strings.Contains(command, "--version")
+		}).Respond(exec.NewRunResult(0, fmt.Sprintf("Bicep CLI version %s (abcdef0123)", bicep.BicepVersion), ""))
+
+		armTemplate, err := json.Marshal(azure.ArmTemplate{
+			Schema:         schema,
+			ContentVersion: "1.0.0.0",
+			Parameters: azure.ArmTemplateParameterDefinitions{
+				"environmentName": {Type: "string"},
+				"location":        {Type: "string"},
+			},
+		})
+		require.NoError(t, err)
+
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(args.Cmd, "bicep") && args.Args[0] == "build"
+		}).Respond(exec.RunResult{Stdout: string(armTemplate)})
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodHead &&
+				strings.Contains(request.URL.Path, "/subscriptions/SUBSCRIPTION_ID/resourcegroups/")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(request.URL.Path, "/rg-existing") {
+				return mocks.CreateEmptyHttpResponse(request, http.StatusNoContent)
+			}
+
+			return mocks.CreateEmptyHttpResponse(request, http.StatusNotFound)
+		})
+	}
+
+	resourceGroupSchema := "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+	options := Options{Path: "infra", Module: "main", ResourceGroup: "rg-existing"}
+
+	t.Run("Exists", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareResourceGroupMocks(mockContext, resourceGroupSchema)
+
+		infraProvider := createBicepProviderWithOptions(t, mockContext, options)
+		require.Equal(t, "rg-existing", infraProvider.env.Getenv(environment.ResourceGroupEnvVarName))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareResourceGroupMocks(mockContext, resourceGroupSchema)
+
+		infraProvider := createBicepProviderWithOptions(t, mockContext, options)
+		infraProvider.options.ResourceGroup = "rg-missing"
+
+		err := infraProvider.EnsureEnv(*mockContext.Context)
+		require.ErrorContains(t, err, "resource group 'rg-missing' was not found")
+		require.Equal(t, "rg-existing", infraProvider.env.Getenv(environment.ResourceGroupEnvVarName))
+	})
+
+	t.Run("SubscriptionScope", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareBicepMocks(mockContext)
+
+		infraProvider := createBicepProvider(t, mockContext)
+		infraProvider.options.ResourceGroup = "rg-existing"
+
+		err := infraProvider.EnsureEnv(*mockContext.Context)
+		require.ErrorContains(t, err, "the template deploys to the subscription scope")
+		require.Empty(t, infraProvider.env.Getenv(environment.ResourceGroupEnvVarName))
+	})
+}
+
 func TestIsValueAssignableToParameterType(t *testing.T) {
 	cases := map[ParameterType]any{
 		ParameterTypeNumber:  1,
@@ -336,12 +479,123 @@ func TestIsValueAssignableToParameterType(t *testing.T) {
 	assert.False(t, isValueAssignableToParameterType(ParameterTypeNumber, json.Number("1.5")))
 }
 
+func TestBicepDeploymentStack(t *testing.T) {
+	stackPath := "/subscriptions/SUBSCRIPTION_ID/providers/Microsoft.Resources/deploymentStacks/test-env"
+	stackOptions := Options{
+		Path:            "infra",
+		Module:          "main",
+		DeploymentStack: true,
+	}
+
+	stack := azsdk.DeploymentStack{
+		Id:       to.Ptr(stackPath),
+		Name:     to.Ptr("test-env"),
+		Location: to.Ptr("westus2"),
+		Properties: &azsdk.DeploymentStackProperties{
+			ProvisioningState: to.Ptr("succeeded"),
+			Outputs: map[string]any{
+				"WEBSITE_URL": map[string]any{"value": "http://myapp.azurewebsites.net", "type": "string"},
+			},
+			Resources: []*azsdk.DeploymentStackResource{
+				{Id: to.Ptr("/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP")},
+			},
+		},
+	}
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Setenv("AZD_DEBUG_PROVISION_PROGRESS_DISABLE", "true")
+
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareBicepMocks(mockContext)
+
+		var submitted azsdk.DeploymentStack
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPut && request.URL.Path == stackPath
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			require.NoError(t, json.NewDecoder(request.Body).Decode(&submitted))
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, stack)
+		})
+
+		infraProvider := createBicepProviderWithOptions(t, mockContext, stackOptions)
+		result, err := infraProvider.Deploy(*mockContext.Context)
+		require.NoError(t, err)
+		require.Equal(t, "http://myapp.azurewebsites.net", result.Deployment.Outputs["WEBSITE_URL"].Value)
+
+		// Resources removed from the template are deleted by the stack
+		require.Equal(t, "westus2", *submitted.Location)
+		require.Equal(t, azsdk.DeploymentStackUnmanageActionDelete, submitted.Properties.ActionOnUnmanage.Resources)
+		require.Equal(t, azsdk.DeploymentStackUnmanageActionDelete, submitted.Properties.ActionOnUnmanage.ResourceGroups)
+		require.NotEmpty(t, submitted.Properties.Template)
+		require.Equal(t, "test-env", submitted.Properties.Parameters["environmentName"].Value)
+	})
+
+	t.Run("Destroy", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareBicepMocks(mockContext)
+		prepareDestroyMocks(mockContext)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet && request.URL.Path == stackPath
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, stack)
+		})
+
+		var deleteQuery url.Values
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodDelete && request.URL.Path == stackPath
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			deleteQuery = request.URL.Query()
+			return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+		})
+
+		resourceGroupDeleted := false
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodDelete &&
+				strings.HasSuffix(request.URL.Path, "subscriptions/SUBSCRIPTION_ID/resourcegroups/RESOURCE_GROUP")
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			resourceGroupDeleted = true
+			return httpRespondFn(request)
+		})
+
+		infraProvider := createBicepProviderWithOptions(t, mockContext, stackOptions)
+		destroyResult, err := infraProvider.Destroy(*mockContext.Context, NewDestroyOptions(true, true))
+		require.NoError(t, err)
+		require.Equal(t, []string{"WEBSITE_URL"}, destroyResult.InvalidatedEnvKeys)
+
+		// The stack deletes the resources and resource groups it manages
+		require.Equal(t, azsdk.DeploymentStackUnmanageActionDelete, deleteQuery.Get("unmanageAction.Resources"))
+		require.Equal(t, azsdk.DeploymentStackUnmanageActionDelete, deleteQuery.Get("unmanageAction.ResourceGroups"))
+		require.False(t, resourceGroupDeleted)
+
+		consoleOutput := strings.Join(mockContext.Console.Output(), "\n")
+		require.Contains(t, consoleOutput, "Deleting your resources can take some time")
+	})
+
+	t.Run("DestroyWithoutStack", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		prepareBicepMocks(mockContext)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet && request.URL.Path == stackPath
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateEmptyHttpResponse(request, http.StatusNotFound)
+		})
+
+		infraProvider := createBicepProviderWithOptions(t, mockContext, stackOptions)
+		_, err := infraProvider.Destroy(*mockContext.Context, NewDestroyOptions(true, true))
+		require.ErrorContains(t, err, "no deployment stack found for environment test-env")
+	})
+}
+
 func createBicepProvider(t *testing.T, mockContext *mocks.MockContext) *BicepProvider {
-	projectDir := "../../../../test/functional/testdata/samples/webapp"
-	options := Options{
+	return createBicepProviderWithOptions(t, mockContext, Options{
 		Path:   "infra",
 		Module: "main",
-	}
+	})
+}
+
+func createBicepProviderWithOptions(t *testing.T, mockContext *mocks.MockContext, options Options) *BicepProvider {
+	projectDir := "../../../../test/functional/testdata/samples/webapp"
 
 	env := environment.NewWithValues("test-env", map[string]string{
 		environment.LocationEnvVarName:       "westus2",
@@ -377,10 +631,12 @@ func createBicepProvider(t *testing.T, mockContext *mocks.MockContext) *BicepPro
 		azCli,
 		depService,
 		depOpService,
+		mockazcli.NewDeploymentStacksServiceFromMockContext(mockContext),
 		envManager,
 		env,
 		mockContext.Console,
 		prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, azCli),
+		accountManager,
 		&mockCurrentPrincipal{},
 		mockContext.AlphaFeaturesManager,
 		clock.NewMock(),
@@ -915,10 +1171,12 @@ func TestUserDefinedTypes(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		&mockenv.MockEnvManager{},
 		env,
 		mockContext.Console,
 		prompt.NewDefaultPrompter(env, mockContext.Console, nil, nil),
+		nil,
 		&mockCurrentPrincipal{},
 		mockContext.AlphaFeaturesManager,
 		clock.NewMock(),
@@ -1140,3 +1398,228 @@ const userDefinedParamsSample = `{
 		}
 	}
 }`
+
+func TestBicepDeployPermissionCheck(t *testing.T) {
+	stackPath := "/subscriptions/SUBSCRIPTION_ID/providers/Microsoft.Resources/deploymentStacks/test-env"
+	accessErr := fmt.Errorf("%w: not allowed", account.ErrInsufficientPermissions)
+
+	newProvider := func(t *testing.T, mockContext *mocks.MockContext, skipPermissionCheck bool) *BicepProvider {
+		t.Setenv("AZD_DEBUG_PROVISION_PROGRESS_DISABLE", "true")
+		prepareBicepMocks(mockContext)
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPut && request.URL.Path == stackPath
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, azsdk.DeploymentStack{
+				Id:         to.Ptr(stackPath),
+				Properties: &azsdk.DeploymentStackProperties{ProvisioningState: to.Ptr("succeeded")},
+			})
+		})
+
+		infraProvider := createBicepProviderWithOptions(t, mockContext, Options{
+			Path:                "infra",
+			Module:              "main",
+			DeploymentStack:     true,
+			SkipPermissionCheck: skipPermissionCheck,
+		})
+		infraProvider.accountManager = &mockaccount.MockAccountManager{SubscriptionAccessError: accessErr}
+		return infraProvider
+	}
+
+	t.Run("InsufficientPermissions", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		_, err := newProvider(t, mockContext, false).Deploy(*mockContext.Context)
+		require.ErrorIs(t, err, account.ErrInsufficientPermissions)
+	})
+
+	t.Run("Skipped", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		_, err := newProvider(t, mockContext, true).Deploy(*mockContext.Context)
+		require.NoError(t, err)
+	})
+}
+
+func TestBicepCancelDeployment(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	infraProvider := createBicepProvider(t, mockContext)
+
+	deploymentPath := "/subscriptions/SUBSCRIPTION_ID/providers/Microsoft.Resources/deployments/test-deployment"
+	cancelCalled := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && request.URL.Path == deploymentPath+"/cancel"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		cancelCalled = true
+		return mocks.CreateEmptyHttpResponse(request, http.StatusNoContent)
+	})
+
+	newOperation := func(resourceId string, operation armresources.ProvisioningOperation, state string) any {
+		return &armresources.DeploymentOperation{
+			Properties: &armresources.DeploymentOperationProperties{
+				ProvisioningOperation: &operation,
+				ProvisioningState:     &state,
+				TargetResource:        &armresources.TargetResource{ID: &resourceId},
+			},
+		}
+	}
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == deploymentPath+"/operations"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, map[string]any{
+			"value": []any{
+				newOperation("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test", armresources.ProvisioningOperationCreate,
+					"Succeeded"),
+				newOperation("/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test/providers/Microsoft.Web/sites/app",
+					armresources.ProvisioningOperationCreate, "Running"),
+			},
+		})
+	})
+
+	ctx, cancel := context.WithCancel(*mockContext.Context)
+	cancel()
+
+	deployErr := fmt.Errorf("deploying to subscription: %w", context.Canceled)
+	target := infra.NewSubscriptionDeployment(
+		infraProvider.deploymentsService,
+		infraProvider.deploymentOperations,
+		"westus2",
+		"SUBSCRIPTION_ID",
+		"test-deployment",
+	)
+
+	err := infraProvider.cancelDeployment(ctx, target, deployErr)
+	require.ErrorIs(t, err, context.Canceled)
+	require.True(t, cancelCalled)
+
+	// Only resources that were created are reported as left behind
+	consoleOutput := strings.Join(mockContext.Console.Output(), "\n")
+	require.Contains(t, consoleOutput, "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test\n")
+	require.NotContains(t, consoleOutput, "Microsoft.Web/sites/app")
+}
+
+func TestBicepDeploymentChanges(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	infraProvider := createBicepProvider(t, mockContext)
+
+	deploymentStart := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	resourceGroupPath := "/subscriptions/SUBSCRIPTION_ID/resourceGroups/rg-test"
+	deploymentPath := "/subscriptions/SUBSCRIPTION_ID/resourcegroups/rg-test/deployments/test-deployment"
+
+	newOperation := func(resourceId string, resourceType string, state string, statusCode string) any {
+		return &armresources.DeploymentOperation{
+			Properties: &armresources.DeploymentOperationProperties{
+				ProvisioningOperation: to.Ptr(armresources.ProvisioningOperationCreate),
+				ProvisioningState:     &state,
+				StatusCode:            &statusCode,
+				TargetResource:        &armresources.TargetResource{ID: &resourceId, ResourceType: &resourceType},
+			},
+		}
+	}
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == deploymentPath+"/operations"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, map[string]any{
+			"value": []any{
+				newOperation(resourceGroupPath+"/providers/Microsoft.Storage/storageAccounts/st",
+					"Microsoft.Storage/storageAccounts", "Succeeded", "Created"),
+				newOperation(resourceGroupPath+"/providers/Microsoft.Web/sites/app", "Microsoft.Web/sites",
+					"Succeeded", "OK"),
+				newOperation(resourceGroupPath+"/providers/Microsoft.Web/serverFarms/plan", "Microsoft.Web/serverFarms",
+					"Succeeded", "OK"),
+				newOperation(resourceGroupPath+"/providers/Microsoft.Web/sites/app/config/web",
+					"Microsoft.Web/sites/config", "Succeeded", "OK"),
+				newOperation(resourceGroupPath+"/providers/Microsoft.KeyVault/vaults/kv", "Microsoft.KeyVault/vaults",
+					"Failed", "Conflict"),
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == resourceGroupPath+"/resources"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		require.Equal(t, "changedTime", request.URL.Query().Get("$expand"))
+
+		newResource := func(name string, resourceType string, changedTime time.Time) *armresources.GenericResourceExpanded {
+			return &armresources.GenericResourceExpanded{
+				ID:          to.Ptr(fmt.Sprintf("%s/providers/%s/%s", resourceGroupPath, resourceType, name)),
+				Name:        &name,
+				Type:        &resourceType,
+				Location:    to.Ptr("westus2"),
+				ChangedTime: &changedTime,
+			}
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.ResourceListResult{
+			Value: []*armresources.GenericResourceExpanded{
+				newResource("st", "Microsoft.Storage/storageAccounts", deploymentStart.Add(time.Minute)),
+				newResource("app", "Microsoft.Web/sites", deploymentStart.Add(time.Minute)),
+				newResource("plan", "Microsoft.Web/serverFarms", deploymentStart.Add(-time.Hour)),
+			},
+		})
+	})
+
+	target := infra.NewResourceGroupDeployment(
+		infraProvider.deploymentsService,
+		infraProvider.deploymentOperations,
+		"SUBSCRIPTION_ID",
+		"rg-test",
+		"test-deployment",
+	)
+
+	// The deployment completed 2 minutes after it started
+	deployResult := &armresources.DeploymentExtended{
+		Properties: &armresources.DeploymentPropertiesExtended{
+			Timestamp: to.Ptr(deploymentStart.Add(2 * time.Minute)),
+			Duration:  to.Ptr("PT2M"),
+		},
+	}
+
+	changes, err := infraProvider.deploymentChanges(*mockContext.Context, target, deployResult)
+	require.NoError(t, err)
+
+	// The child config resource isn't listed with a change time, so it's reported as updated
+	require.Equal(t, &DeploymentChanges{Created: 1, Updated: 2, Unchanged: 1}, changes)
+}
+
+func TestDeploymentStartTime(t *testing.T) {
+	completed := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	newDeployment := func(duration string) *armresources.DeploymentExtended {
+		return &armresources.DeploymentExtended{
+			Properties: &armresources.DeploymentPropertiesExtended{
+				Timestamp: &completed,
+				Duration:  &duration,
+			},
+		}
+	}
+
+	tests := []struct {
+		duration string
+		want     time.Duration
+	}{
+		{"PT45.5S", 45*time.Second + 500*time.Millisecond},
+		{"PT1M23S", time.Minute + 23*time.Second},
+		{"PT2H", 2 * time.Hour},
+		{"P1DT1H", 25 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			start, err := deploymentStartTime(newDeployment(tt.duration))
+			require.NoError(t, err)
+			require.Equal(t, completed.Add(-tt.want), start)
+		})
+	}
+
+	t.Run("InvalidDuration", func(t *testing.T) {
+		_, err := deploymentStartTime(newDeployment("1m23s"))
+		require.ErrorContains(t, err, "parsing deployment duration '1m23s'")
+	})
+
+	t.Run("NoDuration", func(t *testing.T) {
+		_, err := deploymentStartTime(&armresources.DeploymentExtended{})
+		require.Error(t, err)
+	})
+}
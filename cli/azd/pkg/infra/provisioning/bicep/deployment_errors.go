@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/sethvargo/go-retry"
+)
+
+// deploymentErrorClass describes whether a failed deployment is worth retrying.
+type deploymentErrorClass int
+
+const (
+	// The failure is not recognized and is returned as-is.
+	deploymentErrorUnknown deploymentErrorClass = iota
+	// The failure is transient, such as throttling or a server error, and the deployment is retried.
+	deploymentErrorRetryable
+	// The failure can't be resolved by retrying, such as a policy denial or an exceeded quota.
+	deploymentErrorTerminal
+)
+
+// retryableDeploymentErrorCodes are ARM error codes for transient failures.
+var retryableDeploymentErrorCodes = map[string]struct{}{
+	"TooManyRequests":            {},
+	"Throttled":                  {},
+	"InternalServerError":        {},
+	"ServiceUnavailable":         {},
+	"BadGateway":                 {},
+	"GatewayTimeout":             {},
+	"ServerTimeout":              {},
+	"RetryableError":             {},
+	"AnotherOperationInProgress": {},
+}
+
+// terminalDeploymentErrorReasons maps ARM error codes for failures that can't be fixed by retrying to the reason
+// reported to the user.
+var terminalDeploymentErrorReasons = map[string]string{
+	"RequestDisallowedByPolicy": "the deployment was denied by an Azure Policy assignment",
+	"QuotaExceeded":             "the deployment exceeds a subscription quota",
+	"InsufficientQuota":         "the deployment exceeds a subscription quota",
+	"OperationNotAllowed":       "the operation is not allowed, usually because it exceeds a subscription quota",
+	"SkuNotAvailable":           "the requested SKU is not available in the selected location",
+	"AuthorizationFailed":       "the current account is not authorized to perform the deployment",
+	"InvalidTemplate":           "the deployment template is invalid",
+}
+
+// deployRetryBackoff is the backoff used when retrying deployments that failed with a transient error.
+var deployRetryBackoff = func() retry.Backoff {
+	backoff := retry.NewExponential(10 * time.Second)
+	backoff = retry.WithCappedDuration(time.Minute, backoff)
+	return retry.WithMaxRetries(3, backoff)
+}
+
+// classifyDeploymentError classifies a deployment error by the ARM error codes it contains. Terminal codes take
+// precedence over retryable ones. For terminal errors, the reason describing the failure is also returned.
+func classifyDeploymentError(err error) (deploymentErrorClass, string) {
+	var deploymentErr *azapi.AzureDeploymentError
+	if errors.As(err, &deploymentErr) && deploymentErr.Details != nil {
+		if reason, has := terminalDeploymentErrorReason(deploymentErr.Details); has {
+			return deploymentErrorTerminal, reason
+		}
+
+		if hasRetryableDeploymentErrorCode(deploymentErr.Details) {
+			return deploymentErrorRetryable, ""
+		}
+
+		return deploymentErrorUnknown, ""
+	}
+
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		if reason, has := terminalDeploymentErrorReasons[responseErr.ErrorCode]; has {
+			return deploymentErrorTerminal, reason
+		}
+
+		if _, has := retryableDeploymentErrorCodes[responseErr.ErrorCode]; has ||
+			responseErr.StatusCode == http.StatusTooManyRequests ||
+			responseErr.StatusCode >= http.StatusInternalServerError {
+			return deploymentErrorRetryable, ""
+		}
+	}
+
+	return deploymentErrorUnknown, ""
+}
+
+func terminalDeploymentErrorReason(line *azapi.DeploymentErrorLine) (string, bool) {
+	if reason, has := terminalDeploymentErrorReasons[line.Code]; has {
+		return reason, true
+	}
+
+	for _, inner := range line.Inner {
+		if inner == nil {
+			continue
+		}
+
+		if reason, has := terminalDeploymentErrorReason(inner); has {
+			return reason, true
+		}
+	}
+
+	return "", false
+}
+
+func hasRetryableDeploymentErrorCode(line *azapi.DeploymentErrorLine) bool {
+	if _, has := retryableDeploymentErrorCodes[line.Code]; has {
+		return true
+	}
+
+	for _, inner := range line.Inner {
+		if inner != nil && hasRetryableDeploymentErrorCode(inner) {
+			return true
+		}
+	}
+
+	return false
+}
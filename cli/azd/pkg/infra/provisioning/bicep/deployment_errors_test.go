@@ -0,0 +1,165 @@
+package bicep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/sethvargo/go-retry"
+	"github.com/stretchr/testify/require"
+)
+
+// armErrorResponse creates a deployment error the same way azapi does for a failed ARM deployment
+func armErrorResponse(code string, message string) error {
+	return fmt.Errorf(
+		"deploying to subscription:\n\nDeployment Error Details:\n%w",
+		azapi.NewAzureDeploymentError(fmt.Sprintf(
+			`{"error":{"code":"DeploymentFailed","message":"At least one resource deployment operation failed.",`+
+				`"details":[{"code":"%s","message":"%s"}]}}`,
+			code,
+			message,
+		)),
+	)
+}
+
+func Test_classifyDeploymentError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		class  deploymentErrorClass
+		reason string
+	}{
+		{
+			name:  "Throttled",
+			err:   armErrorResponse("TooManyRequests", "Too many requests, retry later."),
+			class: deploymentErrorRetryable,
+		},
+		{
+			name:  "TransientServerError",
+			err:   armErrorResponse("InternalServerError", "Encountered internal server error."),
+			class: deploymentErrorRetryable,
+		},
+		{
+			name:   "PolicyDenial",
+			err:    armErrorResponse("RequestDisallowedByPolicy", "Resource was disallowed by policy."),
+			class:  deploymentErrorTerminal,
+			reason: "the deployment was denied by an Azure Policy assignment",
+		},
+		{
+			name:   "Quota",
+			err:    armErrorResponse("QuotaExceeded", "Operation results in exceeding quota limits of Core."),
+			class:  deploymentErrorTerminal,
+			reason: "the deployment exceeds a subscription quota",
+		},
+		{
+			name:  "Unknown",
+			err:   armErrorResponse("ResourceNotFound", "The resource was not found."),
+			class: deploymentErrorUnknown,
+		},
+		{
+			name:  "ServiceUnavailableResponse",
+			err:   &azcore.ResponseError{StatusCode: http.StatusServiceUnavailable},
+			class: deploymentErrorRetryable,
+		},
+		{
+			name:  "BadRequestResponse",
+			err:   &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "BadRequest"},
+			class: deploymentErrorUnknown,
+		},
+		{
+			name:  "Other",
+			err:   errors.New("something went wrong"),
+			class: deploymentErrorUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			class, reason := classifyDeploymentError(test.err)
+			require.Equal(t, test.class, class)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func Test_deployModule_Retry(t *testing.T) {
+	originalBackoff := deployRetryBackoff
+	deployRetryBackoff = func() retry.Backoff {
+		return retry.WithMaxRetries(2, retry.NewConstant(time.Millisecond))
+	}
+	t.Cleanup(func() { deployRetryBackoff = originalBackoff })
+
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	infraProvider := createBicepProvider(t, mockContext)
+
+	t.Run("RetryableThenSuccess", func(t *testing.T) {
+		target := &mockDeployment{
+			errs: []error{armErrorResponse("ServiceUnavailable", "The service is unavailable.")},
+		}
+
+		result, err := infraProvider.deployModule(*mockContext.Context, target, nil, nil, nil)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, 2, target.attempts)
+	})
+
+	t.Run("RetryableExhausted", func(t *testing.T) {
+		throttled := armErrorResponse("TooManyRequests", "Too many requests, retry later.")
+		target := &mockDeployment{errs: []error{throttled, throttled, throttled}}
+
+		_, err := infraProvider.deployModule(*mockContext.Context, target, nil, nil, nil)
+		require.Error(t, err)
+		require.Equal(t, 3, target.attempts)
+	})
+
+	t.Run("TerminalFailsFast", func(t *testing.T) {
+		target := &mockDeployment{
+			errs: []error{armErrorResponse("RequestDisallowedByPolicy", "Resource was disallowed by policy.")},
+		}
+
+		_, err := infraProvider.deployModule(*mockContext.Context, target, nil, nil, nil)
+		require.ErrorContains(t, err, "the deployment was denied by an Azure Policy assignment")
+		require.Equal(t, 1, target.attempts)
+
+		var deploymentErr *azapi.AzureDeploymentError
+		require.True(t, errors.As(err, &deploymentErr))
+	})
+}
+
+// deploymentTarget allows embedding infra.Deployment, which has a Deployment method of its own
+type deploymentTarget = infra.Deployment
+
+// mockDeployment is a deployment that fails with the configured errors, in order, before succeeding.
+type mockDeployment struct {
+	deploymentTarget
+	errs     []error
+	attempts int
+}
+
+func (d *mockDeployment) Name() string {
+	return "test-env"
+}
+
+func (d *mockDeployment) Deploy(
+	ctx context.Context,
+	template azure.RawArmTemplate,
+	parameters azure.ArmParameters,
+	tags map[string]*string,
+) (*armresources.DeploymentExtended, error) {
+	d.attempts++
+	if d.attempts <= len(d.errs) {
+		return nil, d.errs[d.attempts-1]
+	}
+
+	return &armresources.DeploymentExtended{}, nil
+}
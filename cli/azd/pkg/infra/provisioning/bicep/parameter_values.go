@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+)
+
+// paramDeclarationRegex matches the parameter declarations of a bicep module, like `param location string`, capturing the
+// name and type of the parameter.
+var paramDeclarationRegex = regexp.MustCompile(`(?m)^\s*param\s+([A-Za-z_][A-Za-z0-9_]*)\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// DeclaredParameterTypes returns the types of the parameters declared in the bicep module at modulePath, keyed by
+// parameter name. The module is read without compiling it, so it can be used before bicep is installed. Parameters of
+// types other than the built-in types, like user-defined types, have an empty type.
+func DeclaredParameterTypes(modulePath string) (map[string]ParameterType, error) {
+	contents, err := os.ReadFile(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]ParameterType{}
+	for _, match := range paramDeclarationRegex.FindAllStringSubmatch(string(contents), -1) {
+		paramType, _ := parameterTypeOf(match[2])
+		types[match[1]] = paramType
+	}
+
+	return types, nil
+}
+
+// ParseParameterValue converts the text of a parameter value, like one set on the command line, to a value of the given
+// parameter type. Numbers and booleans are parsed, and objects and arrays are parsed as JSON. The text is returned
+// unchanged for strings and unknown types.
+func ParseParameterValue(paramType ParameterType, value string) (any, error) {
+	switch paramType {
+	case ParameterTypeNumber:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not an integer", value)
+		}
+		return int(i), nil
+	case ParameterTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a boolean, use true or false", value)
+		}
+		return b, nil
+	case ParameterTypeArray:
+		var a []any
+		if err := json.Unmarshal([]byte(value), &a); err != nil {
+			return nil, fmt.Errorf("'%s' is not a JSON array", value)
+		}
+		return a, nil
+	case ParameterTypeObject:
+		var o map[string]any
+		if err := json.Unmarshal([]byte(value), &o); err != nil {
+			return nil, fmt.Errorf("'%s' is not a JSON object", value)
+		}
+		return o, nil
+	default:
+		return value, nil
+	}
+}
+
+// parameterTypeOf returns the parameter type of a bicep or ARM type name, and false when the type is not a built-in type.
+func parameterTypeOf(s string) (ParameterType, bool) {
+	switch s {
+	case "String", "string", "secureString", "securestring":
+		return ParameterTypeString, true
+	case "Bool", "bool":
+		return ParameterTypeBoolean, true
+	case "Int", "int":
+		return ParameterTypeNumber, true
+	case "Object", "object", "secureObject", "secureobject":
+		return ParameterTypeObject, true
+	case "Array", "array":
+		return ParameterTypeArray, true
+	default:
+		return "", false
+	}
+}
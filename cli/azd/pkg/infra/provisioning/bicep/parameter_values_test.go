@@ -0,0 +1,87 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeclaredParameterTypes(t *testing.T) {
+	modulePath := filepath.Join(t.TempDir(), "main.bicep")
+	module := `targetScope = 'subscription'
+
+@minLength(1)
+param environmentName string
+param location string
+@secure()
+param adminPassword string
+param instanceCount int = 1
+param enableLogs bool
+param tags object = {}
+param zones array
+param custom customType
+
+// param commented string
+resource rg 'Microsoft.Resources/resourceGroups@2021-04-01' = {
+  name: 'rg-${environmentName}'
+  location: location
+}
+`
+	require.NoError(t, os.WriteFile(modulePath, []byte(module), 0600))
+
+	types, err := DeclaredParameterTypes(modulePath)
+	require.NoError(t, err)
+	require.Equal(t, map[string]ParameterType{
+		"environmentName": ParameterTypeString,
+		"location":        ParameterTypeString,
+		"adminPassword":   ParameterTypeString,
+		"instanceCount":   ParameterTypeNumber,
+		"enableLogs":      ParameterTypeBoolean,
+		"tags":            ParameterTypeObject,
+		"zones":           ParameterTypeArray,
+		"custom":          "",
+	}, types)
+
+	_, err = DeclaredParameterTypes(filepath.Join(t.TempDir(), "missing.bicep"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseParameterValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		paramType ParameterType
+		value     string
+		expected  any
+		wantErr   bool
+	}{
+		{"String", ParameterTypeString, "42", "42", false},
+		{"Number", ParameterTypeNumber, "42", 42, false},
+		{"NumberInvalid", ParameterTypeNumber, "4.2", nil, true},
+		{"Boolean", ParameterTypeBoolean, "true", true, false},
+		{"BooleanInvalid", ParameterTypeBoolean, "yes", nil, true},
+		{"Array", ParameterTypeArray, `["1", 2]`, []any{"1", float64(2)}, false},
+		{"ArrayInvalid", ParameterTypeArray, `{"a": 1}`, nil, true},
+		{"Object", ParameterTypeObject, `{"a": "b"}`, map[string]any{"a": "b"}, false},
+		{"ObjectInvalid", ParameterTypeObject, "a=b", nil, true},
+		{"Unknown", "", "value", "value", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ParseParameterValue(tt.paramType, tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, value)
+		})
+	}
+}
@@ -10,11 +10,42 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"golang.org/x/exp/slices"
 
 	. "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 )
 
+// defaultLocationParameter returns the default location from azd config for a location parameter, so the parameter is
+// set without prompting, like the location of the environment.
+func (p *BicepProvider) defaultLocationParameter(
+	ctx context.Context,
+	param azure.ArmTemplateParameterDefinition,
+) (string, bool) {
+	azdMetadata, _ := param.AzdMetadata()
+	if p.mapBicepTypeToInterfaceType(param.Type) != ParameterTypeString ||
+		azdMetadata.Type == nil || *azdMetadata.Type != "location" {
+		return "", false
+	}
+
+	return p.prompters.DefaultLocation(ctx, p.env.GetSubscriptionId(), locationParameterFilter(param))
+}
+
+// locationParameterFilter allows the locations in the allowed values of a location parameter, or any location when the
+// parameter doesn't restrict its values.
+func locationParameterFilter(param azure.ArmTemplateParameterDefinition) prompt.LocationFilterPredicate {
+	return func(loc account.Location) bool {
+		if param.AllowedValues == nil {
+			return true
+		}
+
+		return slices.IndexFunc(*param.AllowedValues, func(v any) bool {
+			s, ok := v.(string)
+			return ok && loc.Name == s
+		}) != -1
+	}
+}
+
 func (p *BicepProvider) promptForParameter(
 	ctx context.Context,
 	key string,
@@ -28,17 +59,7 @@ func (p *BicepProvider) promptForParameter(
 	var value any
 
 	if paramType == ParameterTypeString && azdMetadata.Type != nil && *azdMetadata.Type == "location" {
-		location, err := p.prompters.PromptLocation(ctx, p.env.GetSubscriptionId(), msg, func(loc account.Location) bool {
-			if param.AllowedValues == nil {
-				return true
-			}
-
-			return slices.IndexFunc(*param.AllowedValues, func(v any) bool {
-				s, ok := v.(string)
-				return ok && loc.Name == s
-			}) != -1
-		},
-		)
+		location, err := p.prompters.PromptLocation(ctx, p.env.GetSubscriptionId(), msg, locationParameterFilter(param))
 		if err != nil {
 			return nil, err
 		}
@@ -311,6 +311,63 @@ func TestPromptForParametersLocation(t *testing.T) {
 	require.Equal(t, "westus", value)
 }
 
+func TestEnsureParametersDefaultLocation(t *testing.T) {
+	t.Parallel()
+
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+
+	env := environment.New("test")
+	azCli := mockazcli.NewAzCliFromMockContext(mockContext)
+	accountManager := &mockaccount.MockAccountManager{
+		DefaultLocation: "westus",
+		Locations: []account.Location{
+			{
+				Name:                "eastus2",
+				DisplayName:         "East US 2",
+				RegionalDisplayName: "(US) East US 2",
+			},
+			{
+				Name:                "westus",
+				DisplayName:         "West US",
+				RegionalDisplayName: "(US) West US",
+			},
+		},
+	}
+
+	p := createBicepProvider(t, mockContext)
+	p.prompters = prompt.NewDefaultPrompter(env, mockContext.Console, accountManager, azCli)
+
+	// The default location is used without prompting, unless the parameter doesn't allow it
+	mockContext.Console.WhenSelect(func(options input.ConsoleOptions) bool {
+		return strings.Contains(options.Message, "'filteredLocation'")
+	}).Respond(0)
+	mockContext.Console.WhenConfirm(func(options input.ConsoleOptions) bool {
+		return strings.Contains(options.Message, "Save the value")
+	}).Respond(false)
+
+	locationMetadata := map[string]json.RawMessage{
+		"azd": json.RawMessage(`{"type": "location"}`),
+	}
+	parameters, err := p.ensureParameters(*mockContext.Context, azure.ArmTemplate{
+		Parameters: azure.ArmTemplateParameterDefinitions{
+			"appLocation": {
+				Type:     "string",
+				Metadata: locationMetadata,
+			},
+			"filteredLocation": {
+				Type:          "string",
+				Metadata:      locationMetadata,
+				AllowedValues: &[]any{"eastus2"},
+			},
+		},
+	}, azure.ArmParameters{})
+
+	require.NoError(t, err)
+	require.Equal(t, "westus", parameters["appLocation"].Value)
+	require.Equal(t, "eastus2", parameters["filteredLocation"].Value)
+}
+
 type mockCurrentPrincipal struct{}
 
 func (m *mockCurrentPrincipal) CurrentPrincipalId(_ context.Context) (string, error) {
@@ -58,3 +58,17 @@ const (
 	PropertyChangeTypeModify   PropertyChangeType = "Modify"
 	PropertyChangeTypeNoEffect PropertyChangeType = "NoEffect"
 )
+
+// ChangeCounts returns the number of resources of the preview by the change provisioning would make to them.
+func (p *DeploymentPreview) ChangeCounts() map[ChangeType]int {
+	counts := map[ChangeType]int{}
+	if p.Properties == nil {
+		return counts
+	}
+
+	for _, change := range p.Properties.Changes {
+		counts[change.ChangeType]++
+	}
+
+	return counts
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -82,7 +83,7 @@ func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 	deployResult, err := m.provider.Preview(ctx)
 
 	if err != nil {
-		return nil, fmt.Errorf("error deploying infrastructure: %w", err)
+		return nil, fmt.Errorf("error previewing infrastructure: %w", err)
 	}
 
 	// apply resource mapping
@@ -95,11 +96,15 @@ func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 
 	for index, result := range deployResult.Preview.Properties.Changes {
 		mappingName := infra.GetResourceTypeDisplayName(infra.AzureResourceType(result.ResourceType))
-		if mappingName == "" {
-			// ignore
+		if mappingName == "" && strings.Contains(result.ResourceType, "/") {
+			// ignore Azure resource types without a display name, like nested deployments
 			continue
 		}
-		deployResult.Preview.Properties.Changes[index].ResourceType = mappingName
+
+		// Resource types of other providers, like terraform resource types, are kept as they are
+		if mappingName != "" {
+			deployResult.Preview.Properties.Changes[index].ResourceType = mappingName
+		}
 		filteredResult.Preview.Properties.Changes = append(
 			filteredResult.Preview.Properties.Changes, deployResult.Preview.Properties.Changes[index])
 	}
@@ -21,8 +21,23 @@ type Options struct {
 	Provider ProviderKind `yaml:"provider"`
 	Path     string       `yaml:"path"`
 	Module   string       `yaml:"module"`
+	// Whether the infrastructure is deployed as an Azure deployment stack, which manages the resources it deploys so they
+	// are deleted together with the stack, instead of a standard deployment. Only supported by the bicep provider.
+	DeploymentStack bool `yaml:"deploymentStack,omitempty"`
 	// Not expected to be defined at azure.yaml
 	IgnoreDeploymentState bool `yaml:"-"`
+	// Path to an ARM parameters file whose values are merged over the module parameters.
+	// Not expected to be defined at azure.yaml
+	ParametersFile string `yaml:"-"`
+	// Whether the provider should summarize the changes made by the deployment, which requires additional requests.
+	// Not expected to be defined at azure.yaml
+	ChangeSummary bool `yaml:"-"`
+	// Whether the provider should skip checking that the current account can create deployments at the target scope.
+	// Not expected to be defined at azure.yaml
+	SkipPermissionCheck bool `yaml:"-"`
+	// Name of an existing resource group to deploy a resource group scoped template into.
+	// Not expected to be defined at azure.yaml
+	ResourceGroup string `yaml:"-"`
 }
 
 type SkippedReasonType string
@@ -32,6 +47,16 @@ const DeploymentStateSkipped SkippedReasonType = "deployment State"
 type DeployResult struct {
 	Deployment    *Deployment
 	SkippedReason SkippedReasonType
+	// Changes summarizes the changes made by the deployment. Only set when requested with Options.ChangeSummary and
+	// supported by the provider.
+	Changes *DeploymentChanges
+}
+
+// DeploymentChanges counts the resources of a deployment by the change the deployment made to them.
+type DeploymentChanges struct {
+	Created   int
+	Updated   int
+	Unchanged int
 }
 
 // DeployPreviewResult defines one deployment in preview mode, displaying what changes would it be performed, without
@@ -28,9 +28,11 @@ type ProvisioningProgressDisplay struct {
 	deploymentStarted bool
 	// Keeps track of created resources
 	displayedResources map[string]bool
-	resourceManager    infra.ResourceManager
-	console            input.Console
-	target             infra.Deployment
+	// Keeps track of the last provisioning state of each resource, by resource ID
+	resourceStates  map[string]string
+	resourceManager infra.ResourceManager
+	console         input.Console
+	target          infra.Deployment
 }
 
 func NewProvisioningProgressDisplay(
@@ -40,6 +42,7 @@ func NewProvisioningProgressDisplay(
 ) ProvisioningProgressDisplay {
 	return ProvisioningProgressDisplay{
 		displayedResources: map[string]bool{},
+		resourceStates:     map[string]string{},
 		target:             target,
 		resourceManager:    rm,
 		console:            console,
@@ -50,6 +53,10 @@ func NewProvisioningProgressDisplay(
 // progress.
 func (display *ProvisioningProgressDisplay) ReportProgress(
 	ctx context.Context, queryStart *time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if !display.deploymentStarted {
 		_, err := display.target.Deployment(ctx)
 		if err != nil {
@@ -82,14 +89,21 @@ func (display *ProvisioningProgressDisplay) ReportProgress(
 	newlyDeployedResources := []*armresources.DeploymentOperation{}
 	newlyFailedResources := []*armresources.DeploymentOperation{}
 	runningDeployments := []*armresources.DeploymentOperation{}
+	stateChanges := []*ux.ResourceStateChange{}
 
 	for i := range operations {
 		if operations[i].Properties.TargetResource != nil {
 			resourceId := *operations[i].Properties.TargetResource.ResourceName
+			isTopLevel := infra.IsTopLevelResourceType(
+				infra.AzureResourceType(*operations[i].Properties.TargetResource.ResourceType))
+
+			if isTopLevel {
+				if change := display.trackState(operations[i]); change != nil {
+					stateChanges = append(stateChanges, change)
+				}
+			}
 
-			if !display.displayedResources[resourceId] &&
-				infra.IsTopLevelResourceType(
-					infra.AzureResourceType(*operations[i].Properties.TargetResource.ResourceType)) {
+			if !display.displayedResources[resourceId] && isTopLevel {
 
 				switch *operations[i].Properties.ProvisioningState {
 				case succeededProvisioningState:
@@ -110,15 +124,57 @@ func (display *ProvisioningProgressDisplay) ReportProgress(
 		)
 	})
 
+	// Structured output gets an event for each state change instead of the messages meant for people
+	if formatter := display.console.GetFormatter(); formatter != nil && formatter.Kind() == output.JsonFormat {
+		for _, change := range stateChanges {
+			display.console.MessageUxItem(ctx, change)
+		}
+
+		return nil
+	}
+
 	displayedResources := append(newlyDeployedResources, newlyFailedResources...)
-	display.logNewlyCreatedResources(ctx, displayedResources, runningDeployments)
+	display.logNewlyCreatedResources(ctx, displayedResources, runningDeployments, stateChanges)
 	return nil
 }
 
+// trackState records the provisioning state of the resource targeted by the operation, returning the change when the
+// state differs from the last one seen.
+func (display *ProvisioningProgressDisplay) trackState(
+	operation *armresources.DeploymentOperation,
+) *ux.ResourceStateChange {
+	if operation.Properties.ProvisioningState == nil {
+		return nil
+	}
+
+	target := operation.Properties.TargetResource
+	key := *target.ResourceName
+	if target.ID != nil {
+		key = *target.ID
+	}
+
+	state := *operation.Properties.ProvisioningState
+	previousState := display.resourceStates[key]
+	if state == previousState {
+		return nil
+	}
+
+	display.resourceStates[key] = state
+	return &ux.ResourceStateChange{
+		Type:          infra.GetResourceTypeDisplayName(infra.AzureResourceType(*target.ResourceType)),
+		ResourceType:  *target.ResourceType,
+		Id:            key,
+		Name:          *target.ResourceName,
+		PreviousState: previousState,
+		State:         state,
+	}
+}
+
 func (display *ProvisioningProgressDisplay) logNewlyCreatedResources(
 	ctx context.Context,
 	resources []*armresources.DeploymentOperation,
 	inProgressResources []*armresources.DeploymentOperation,
+	stateChanges []*ux.ResourceStateChange,
 ) {
 	for _, resource := range resources {
 		resourceTypeName := *resource.Properties.TargetResource.ResourceType
@@ -182,7 +238,14 @@ func (display *ProvisioningProgressDisplay) logNewlyCreatedResources(
 
 	if !display.console.IsSpinnerInteractive() {
 		// If non-interactive, we simply do not want to display spinner messages that ends up
-		// being individual lines of messages on the console
+		// being individual lines of messages on the console. Report the resources that started provisioning instead,
+		// so a long deployment doesn't look hung.
+		for _, change := range stateChanges {
+			if change.State == runningProvisioningState && change.Type != "" {
+				display.console.MessageUxItem(ctx, change)
+			}
+		}
+
 		return
 	}
 
@@ -16,9 +16,13 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -123,3 +127,87 @@ func TestReportProgress(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, mockContext.Console.Output(), outputLength)
 }
+
+func TestReportProgress_StateChanges(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	depOpService := mockazcli.NewDeploymentOperationsServiceFromMockContext(mockContext)
+	depService := mockazcli.NewDeploymentsServiceFromMockContext(mockContext)
+
+	scope := infra.NewSubscriptionDeployment(depService, depOpService, "eastus2", "SUBSCRIPTION_ID", "DEPLOYMENT_NAME")
+	mockAzDeploymentShow(t, *mockContext)
+
+	startTime := time.Now()
+
+	t.Run("NonInteractive", func(t *testing.T) {
+		mockResourceManager := mockResourceManager{}
+		console := mockinput.NewMockConsole()
+		progressDisplay := NewProvisioningProgressDisplay(&mockResourceManager, console, scope)
+
+		mockResourceManager.AddInProgressOperation()
+		mockResourceManager.operations[0].Properties.ProvisioningState = to.Ptr(runningProvisioningState)
+		require.NoError(t, progressDisplay.ReportProgress(*mockContext.Context, &startTime))
+		require.Contains(t, console.Output(), "(-) Running: Web App: website-resource-name-0")
+
+		// No new output while the state doesn't change
+		outputLength := len(console.Output())
+		require.NoError(t, progressDisplay.ReportProgress(*mockContext.Context, &startTime))
+		require.Len(t, console.Output(), outputLength)
+
+		mockResourceManager.MarkComplete(0)
+		require.NoError(t, progressDisplay.ReportProgress(*mockContext.Context, &startTime))
+		require.Len(t, console.Output(), outputLength+1)
+		require.Contains(t, console.Output()[outputLength], "Done: Microsoft.Web/sites: website-resource-name-0")
+	})
+
+	t.Run("Json", func(t *testing.T) {
+		mockResourceManager := mockResourceManager{}
+		buf := &bytes.Buffer{}
+		console := input.NewConsole(
+			true, false, buf, input.ConsoleHandles{Stdout: buf, Stderr: buf}, &output.JsonFormatter{})
+		progressDisplay := NewProvisioningProgressDisplay(&mockResourceManager, console, scope)
+
+		mockResourceManager.AddInProgressOperation()
+		mockResourceManager.operations[0].Properties.ProvisioningState = to.Ptr(runningProvisioningState)
+		require.NoError(t, progressDisplay.ReportProgress(*mockContext.Context, &startTime))
+		mockResourceManager.MarkComplete(0)
+		require.NoError(t, progressDisplay.ReportProgress(*mockContext.Context, &startTime))
+
+		events := []contracts.ResourceProvisioning{}
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var envelope struct {
+				Type contracts.EventDataType        `json:"type"`
+				Data contracts.ResourceProvisioning `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &envelope))
+
+			if envelope.Type == contracts.ResourceProvisioningEventDataType {
+				events = append(events, envelope.Data)
+			}
+		}
+
+		require.Equal(t, []contracts.ResourceProvisioning{
+			{
+				ResourceId:   "website-resource-id-0",
+				ResourceName: "website-resource-name-0",
+				ResourceType: string(infra.AzureResourceTypeWebSite),
+				State:        runningProvisioningState,
+			},
+			{
+				ResourceId:    "website-resource-id-0",
+				ResourceName:  "website-resource-name-0",
+				ResourceType:  string(infra.AzureResourceTypeWebSite),
+				PreviousState: runningProvisioningState,
+				State:         succeededProvisioningState,
+			},
+		}, events)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		mockResourceManager := mockResourceManager{}
+		progressDisplay := NewProvisioningProgressDisplay(&mockResourceManager, mockinput.NewMockConsole(), scope)
+
+		ctx, cancel := context.WithCancel(*mockContext.Context)
+		cancel()
+		require.ErrorIs(t, progressDisplay.ReportProgress(ctx, &startTime), context.Canceled)
+	})
+}
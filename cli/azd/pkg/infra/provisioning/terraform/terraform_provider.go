@@ -153,9 +153,9 @@ func (t *TerraformProvider) plan(ctx context.Context) (*Deployment, *terraformDe
 	}
 
 	planArgs := t.createPlanArgs(isRemoteBackendConfig)
-	runResult, err := t.cli.Plan(ctx, modulePath, t.planFilePath(), planArgs...)
+	_, err = t.cli.Plan(ctx, modulePath, t.planFilePath(), planArgs...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("terraform plan failed:%s err %w", runResult, err)
+		return nil, nil, fmt.Errorf("terraform plan failed: %w", err)
 	}
 
 	//create deployment plan
@@ -212,22 +212,88 @@ func (t *TerraformProvider) Deploy(ctx context.Context) (*DeployResult, error) {
 	}, nil
 }
 
+// Preview runs terraform plan and returns the resource changes of the plan. The module is initialized by plan, so a
+// preview works before the first deployment.
 func (t *TerraformProvider) Preview(ctx context.Context) (*DeployPreviewResult, error) {
-	// terraform uses plan() to display the what-if output
-	// no changes are added to the properties
-	_, _, err := t.plan(ctx)
+	_, deploymentDetails, err := t.plan(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	showResult, err := t.cli.Show(ctx, t.modulePath(), deploymentDetails.PlanFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading terraform plan: %w", err)
+	}
+
+	var planOutput terraformPlanOutput
+	if err := json.Unmarshal([]byte(showResult), &planOutput); err != nil {
+		return nil, fmt.Errorf("parsing terraform plan: %w", err)
+	}
+
 	return &DeployPreviewResult{
 		Preview: &DeploymentPreview{
-			Status:     "done",
-			Properties: &DeploymentPreviewProperties{},
+			Status: "done",
+			Properties: &DeploymentPreviewProperties{
+				Changes: planChanges(planOutput),
+			},
 		},
 	}, nil
 }
 
+// planChanges converts the resource changes of a terraform plan to the changes of a deployment preview. Data sources are
+// skipped, since reading them doesn't change any resource.
+func planChanges(planOutput terraformPlanOutput) []*DeploymentPreviewChange {
+	var changes []*DeploymentPreviewChange
+	for _, resourceChange := range planOutput.ResourceChanges {
+		if resourceChange.Mode != terraformModeManaged {
+			continue
+		}
+
+		change := &DeploymentPreviewChange{
+			ChangeType:   planChangeType(resourceChange.Change.Actions),
+			ResourceType: resourceChange.Type,
+			Name:         resourceChange.Address,
+		}
+
+		// Resources to create don't have an id until they are created
+		if before, ok := resourceChange.Change.Before.(map[string]any); ok {
+			if id, ok := before["id"].(string); ok {
+				change.ResourceId = Resource{Id: id}
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// planChangeType returns the change type of the actions terraform plans for a resource. Replacing a resource, which
+// deletes and creates it, is reported as a modification.
+func planChangeType(actions []string) ChangeType {
+	switch {
+	case len(actions) == 2:
+		return ChangeTypeModify
+	case len(actions) != 1:
+		return ChangeTypeUnsupported
+	}
+
+	switch actions[0] {
+	case "create":
+		return ChangeTypeCreate
+	case "update":
+		return ChangeTypeModify
+	case "delete":
+		return ChangeTypeDelete
+	case "no-op":
+		return ChangeTypeNoChange
+	case "read":
+		return ChangeTypeIgnore
+	default:
+		return ChangeTypeUnsupported
+	}
+}
+
 // Destroys the specified deployment through terraform destroy
 func (t *TerraformProvider) Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error) {
 	isRemoteBackendConfig, err := t.isRemoteBackendConfig()
@@ -746,6 +812,31 @@ type terraformResource struct {
 	Values map[string]any `json:"values"`
 }
 
+// terraformPlanOutput is a model type for the output of `terraform show` for a plan file.
+// see https://developer.hashicorp.com/terraform/internals/json-format#plan-representation for more information on the
+// shape of the JSON data
+type terraformPlanOutput struct {
+	FormatVersion   string                    `json:"format_version"`
+	ResourceChanges []terraformResourceChange `json:"resource_changes"`
+}
+
+// terraformResourceChange is the model type for the planned change of a resource in a plan file.
+type terraformResourceChange struct {
+	Address string `json:"address"`
+	// "mode" can be "managed", for resources, or "data", for data resources
+	Mode   string                 `json:"mode"`
+	Type   string                 `json:"type"`
+	Name   string                 `json:"name"`
+	Change terraformChangeActions `json:"change"`
+}
+
+// terraformChangeActions is the model type for the "change" property of a resource change. The "actions" array holds
+// "create", "read", "update", "delete" or "no-op", or both "delete" and "create" when the resource is replaced.
+type terraformChangeActions struct {
+	Actions []string `json:"actions"`
+	Before  any      `json:"before"`
+}
+
 // terraformChildModule is the model type for a child module in the state file. It may contain
 // further child modules which contain additional resources.
 type terraformChildModule struct {
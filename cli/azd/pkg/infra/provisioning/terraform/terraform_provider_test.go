@@ -6,6 +6,7 @@ package terraform
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -58,6 +59,73 @@ func TestTerraformPlan(t *testing.T) {
 	require.NotEmpty(t, deploymentPlan.localStateFilePath)
 }
 
+func TestTerraformPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+	preparePlanShowMocks(mockContext.CommandRunner)
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	previewResult, err := infraProvider.Preview(*mockContext.Context)
+	require.NoError(t, err)
+
+	subscriptionId := "/subscriptions/00000000-0000-0000-0000-000000000000"
+	require.Equal(t, []*DeploymentPreviewChange{
+		{
+			ChangeType:   ChangeTypeNoChange,
+			ResourceType: "azurerm_resource_group",
+			Name:         "azurerm_resource_group.rg",
+			ResourceId:   Resource{Id: subscriptionId + "/resourceGroups/rg-test-env"},
+		},
+		{
+			ChangeType:   ChangeTypeCreate,
+			ResourceType: "azurerm_linux_web_app",
+			Name:         "module.web.azurerm_linux_web_app.web",
+		},
+		{
+			ChangeType:   ChangeTypeModify,
+			ResourceType: "azurerm_key_vault",
+			Name:         "azurerm_key_vault.kv",
+			ResourceId: Resource{
+				Id: subscriptionId + "/resourceGroups/rg-test-env/providers/Microsoft.KeyVault/vaults/kv-test-env",
+			},
+		},
+		{
+			ChangeType:   ChangeTypeModify,
+			ResourceType: "azurerm_storage_account",
+			Name:         "azurerm_storage_account.storage",
+			ResourceId: Resource{
+				Id: subscriptionId + "/resourceGroups/rg-test-env/providers/Microsoft.Storage/storageAccounts/sttestenv",
+			},
+		},
+		{
+			ChangeType:   ChangeTypeDelete,
+			ResourceType: "azurerm_log_analytics_workspace",
+			Name:         "azurerm_log_analytics_workspace.old",
+			ResourceId: Resource{
+				Id: subscriptionId + "/resourceGroups/rg-test-env/providers/Microsoft.OperationalInsights/workspaces/log-old",
+			},
+		},
+	}, previewResult.Preview.Properties.Changes)
+}
+
+func TestTerraformPreviewPlanFails(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, " plan ")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		result := exec.RunResult{ExitCode: 1, Stderr: "Error: Invalid reference"}
+		return result, errors.New("exit code: 1")
+	})
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	_, err := infraProvider.Preview(*mockContext.Context)
+	require.ErrorContains(t, err, "terraform plan failed")
+	require.ErrorContains(t, err, "Error: Invalid reference")
+}
+
 func TestTerraformDestroy(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	prepareGenericMocks(mockContext.CommandRunner)
@@ -175,6 +243,18 @@ func preparePlanningMocks(commandRunner *mockexec.MockCommandRunner) {
 	})
 }
 
+//go:embed testdata/terraform_plan_show_mock.json
+var terraformPlanShowMockOutput string
+
+func preparePlanShowMocks(commandRunner *mockexec.MockCommandRunner) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "show") && strings.Contains(command, ".tfplan")
+	}).Respond(exec.RunResult{
+		Stdout: terraformPlanShowMockOutput,
+		Stderr: "",
+	})
+}
+
 //go:embed testdata/terraform_show_mock.json
 var terraformShowMockOutput string
 
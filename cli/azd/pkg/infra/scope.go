@@ -45,6 +45,8 @@ type Deployment interface {
 	Deployment(ctx context.Context) (*armresources.DeploymentExtended, error)
 	// Operations returns all the operations for this deployment.
 	Operations(ctx context.Context) ([]*armresources.DeploymentOperation, error)
+	// Cancel cancels this deployment while it is running. Resources that were already created are left in place.
+	Cancel(ctx context.Context) error
 }
 
 type ResourceGroupDeployment struct {
@@ -73,6 +75,11 @@ func (s *ResourceGroupDeployment) Deploy(
 		ctx, s.subscriptionId, s.resourceGroupName, s.name, template, parameters, tags)
 }
 
+// Cancels the deployment while it is running
+func (s *ResourceGroupDeployment) Cancel(ctx context.Context) error {
+	return s.deployments.CancelResourceGroupDeployment(ctx, s.subscriptionId, s.resourceGroupName, s.name)
+}
+
 func (s *ResourceGroupDeployment) DeployPreview(
 	ctx context.Context,
 	template azure.RawArmTemplate,
@@ -198,6 +205,11 @@ func (s *SubscriptionDeployment) Deploy(
 	return s.deploymentsService.DeployToSubscription(ctx, s.subscriptionId, s.location, s.name, template, parameters, tags)
 }
 
+// Cancels the deployment while it is running
+func (s *SubscriptionDeployment) Cancel(ctx context.Context) error {
+	return s.deploymentsService.CancelSubscriptionDeployment(ctx, s.subscriptionId, s.name)
+}
+
 // Deploy a given template with a set of parameters.
 func (s *SubscriptionDeployment) DeployPreview(
 	ctx context.Context,
@@ -36,7 +36,16 @@ func askOneNoPrompt(p survey.Prompt, response interface{}) error {
 		*(response.(*string)) = v.Default
 	case *survey.Select:
 		if v.Default == nil {
-			return fmt.Errorf("no default response for prompt '%s'", v.Message)
+			// With a single valid choice there is nothing to decide, so it is selected automatically.
+			if len(v.Options) != 1 {
+				return fmt.Errorf(
+					"no default response for prompt '%s', %d options are available and one must be specified explicitly",
+					v.Message,
+					len(v.Options),
+				)
+			}
+
+			v.Default = v.Options[0]
 		}
 
 		switch ptr := response.(type) {
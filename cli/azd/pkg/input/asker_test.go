@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package input
+
+import (
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_askOneNoPrompt_Select(t *testing.T) {
+	t.Run("SingleOption", func(t *testing.T) {
+		var selected int
+		err := askOneNoPrompt(&survey.Select{
+			Message: "Select a project",
+			Options: []string{"project1"},
+		}, &selected)
+
+		require.NoError(t, err)
+		require.Equal(t, 0, selected)
+	})
+
+	t.Run("SingleOptionString", func(t *testing.T) {
+		var selected string
+		err := askOneNoPrompt(&survey.Select{
+			Message: "Select a project",
+			Options: []string{"project1"},
+		}, &selected)
+
+		require.NoError(t, err)
+		require.Equal(t, "project1", selected)
+	})
+
+	t.Run("MultipleOptionsWithDefault", func(t *testing.T) {
+		var selected int
+		err := askOneNoPrompt(&survey.Select{
+			Message: "Select a project",
+			Options: []string{"project1", "project2"},
+			Default: "project2",
+		}, &selected)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, selected)
+	})
+
+	t.Run("MultipleOptionsWithoutDefault", func(t *testing.T) {
+		var selected int
+		err := askOneNoPrompt(&survey.Select{
+			Message: "Select a project",
+			Options: []string{"project1", "project2"},
+		}, &selected)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "one must be specified explicitly")
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// shellNameRegex matches the names a POSIX shell accepts for variables.
+var shellNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvExportFormatter formats values as `export KEY='value'` lines, which a POSIX shell sets as variables with
+// `eval "$(...)"`.
+type EnvExportFormatter struct {
+}
+
+func (f *EnvExportFormatter) Kind() Format {
+	return EnvExportFormat
+}
+
+func (f *EnvExportFormatter) Format(obj interface{}, writer io.Writer, _ interface{}) error {
+	values, ok := obj.(map[string]string)
+	if !ok {
+		return fmt.Errorf("EnvExportFormatter can only format objects of type map[string]string")
+	}
+
+	keys := maps.Keys(values)
+	slices.Sort(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		if !shellNameRegex.MatchString(key) {
+			return fmt.Errorf("'%s' can't be exported, since it is not a valid shell variable name", key)
+		}
+
+		sb.WriteString(fmt.Sprintf("export %s=%s\n", key, shellQuote(values[key])))
+	}
+
+	_, err := writer.Write([]byte(sb.String()))
+	return err
+}
+
+// shellQuote returns value in single quotes, where a POSIX shell takes every character literally. A single quote in value
+// ends the quoted string, adds an escaped single quote and starts a new quoted string.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+var _ Formatter = (*EnvExportFormatter)(nil)
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvExportFormatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected string
+	}{
+		{"Sorted", map[string]string{"Bravo": "2", "Alpha": "1"}, "export Alpha='1'\nexport Bravo='2'\n"},
+		{"Empty", map[string]string{"EMPTY": ""}, "export EMPTY=''\n"},
+		{"SingleQuote", map[string]string{"NAME": "it's"}, "export NAME='it'\\''s'\n"},
+		{"OnlySingleQuotes", map[string]string{"QUOTES": "''"}, "export QUOTES=''\\'''\\'''\n"},
+		{"ShellSyntax", map[string]string{"CMD": "$HOME `id` $(id) \"x\" \\n"}, "export CMD='$HOME `id` $(id) \"x\" \\n'\n"},
+		{"Newline", map[string]string{"LINES": "a\nb"}, "export LINES='a\nb'\n"},
+		{"NoValues", map[string]string{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			err := (&EnvExportFormatter{}).Format(tt.values, buffer, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, buffer.String())
+		})
+	}
+}
+
+func TestEnvExportFormatterInvalidName(t *testing.T) {
+	for _, key := range []string{"1KEY", "MY-KEY", "MY KEY", "KEY;rm", ""} {
+		err := (&EnvExportFormatter{}).Format(map[string]string{key: "value"}, &bytes.Buffer{}, nil)
+		require.ErrorContains(t, err, "not a valid shell variable name", key)
+	}
+}
+
+func TestEnvExportFormatterEval(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	values := map[string]string{
+		"QUOTE":  "it's a 'test'",
+		"SYNTAX": "$HOME `id` $(id) \"x\" \\ ;|&",
+		"LINES":  "a\nb",
+	}
+
+	buffer := &bytes.Buffer{}
+	err = (&EnvExportFormatter{}).Format(values, buffer, nil)
+	require.NoError(t, err)
+
+	script := buffer.String() + `printf '%s\0' "$QUOTE" "$SYNTAX" "$LINES"`
+	out, err := exec.Command(sh, "-c", script).Output()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{values["QUOTE"], values["SYNTAX"], values["LINES"], ""}, strings.Split(string(out), "\x00"))
+}
@@ -11,10 +11,12 @@ import (
 type Format string
 
 const (
-	EnvVarsFormat Format = "dotenv"
-	JsonFormat    Format = "json"
-	TableFormat   Format = "table"
-	NoneFormat    Format = "none"
+	EnvVarsFormat   Format = "dotenv"
+	EnvExportFormat Format = "env-export"
+	JsonFormat      Format = "json"
+	TableFormat     Format = "table"
+	YamlFormat      Format = "yaml"
+	NoneFormat      Format = "none"
 )
 
 type Formatter interface {
@@ -28,8 +30,12 @@ func NewFormatter(format string) (Formatter, error) {
 		return &JsonFormatter{}, nil
 	case string(EnvVarsFormat):
 		return &EnvVarsFormatter{}, nil
+	case string(EnvExportFormat):
+		return &EnvExportFormatter{}, nil
 	case string(TableFormat):
 		return &TableFormatter{}, nil
+	case string(YamlFormat):
+		return &YamlFormatter{}, nil
 	case string(NoneFormat):
 		return &NoneFormatter{}, nil
 	default:
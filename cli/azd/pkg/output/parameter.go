@@ -5,10 +5,12 @@ package output
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -37,28 +39,57 @@ func AddOutputParam(cmd *cobra.Command, supportedFormats []Format, defaultFormat
 	return cmd
 }
 
+// IsOutputSuppressed returns true when `--output none` was explicitly passed to the command, in which case the console
+// discards everything written on success. `none` is also the default format of most commands, where it selects the
+// regular console output, so only the explicit flag suppresses the output.
+func IsOutputSuppressed(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup(outputFlagName)
+	if f == nil || !f.Changed {
+		return false
+	}
+
+	return Format(strings.ToLower(strings.TrimSpace(f.Value.String()))) == NoneFormat
+}
+
+// GetCommandFormatter returns the formatter for the format selected with the `--output` flag of the command.
 func GetCommandFormatter(cmd *cobra.Command) (Formatter, error) {
+	return GetCommandFormatterWithDefault(cmd, "")
+}
+
+// GetCommandFormatterWithDefault returns the formatter for the format selected with the `--output` flag of the command.
+// When the flag is not set, defaultFormat is used instead of the default of the command, as long as the command supports
+// it. The flag always takes precedence over defaultFormat.
+func GetCommandFormatterWithDefault(cmd *cobra.Command, defaultFormat Format) (Formatter, error) {
 	// If the command does not specify any output params just return nil Formatter pointer
 	outputVal, err := cmd.Flags().GetString(outputFlagName)
 	if err != nil {
 		return &NoneFormatter{}, nil
 	}
 
-	desiredFormatter := strings.ToLower(strings.TrimSpace(outputVal))
 	f := cmd.Flags().Lookup(outputFlagName)
 	supportedFormatters, hasFormatters := f.Annotations[supportedFormatterAnnotation]
+
+	if !f.Changed && defaultFormat != "" {
+		desiredDefault := strings.ToLower(strings.TrimSpace(string(defaultFormat)))
+		if !hasFormatters || slices.Contains(supportedFormatters, desiredDefault) {
+			outputVal = desiredDefault
+		} else {
+			log.Printf("ignoring default output format '%s', which is not supported by the command", defaultFormat)
+		}
+	}
+
+	desiredFormatter := strings.ToLower(strings.TrimSpace(outputVal))
 	if !hasFormatters {
 		return NewFormatter(desiredFormatter)
 	}
 
-	supported := false
-	for _, formatter := range supportedFormatters {
-		if formatter == desiredFormatter {
-			supported = true
-			break
+	if !slices.Contains(supportedFormatters, desiredFormatter) {
+		// Every command accepts `--output none`. Commands that always produce formatted output keep their default format,
+		// and the console discards what they write.
+		if desiredFormatter == string(NoneFormat) && IsOutputSuppressed(cmd) {
+			return NewFormatter(f.DefValue)
 		}
-	}
-	if !supported {
+
 		return nil, fmt.Errorf("unsupported format '%s'", desiredFormatter)
 	}
 
@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCommandFormatterWithDefault(t *testing.T) {
+	newCommand := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		AddOutputParam(cmd, []Format{JsonFormat, NoneFormat}, NoneFormat)
+		require.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	t.Run("DefaultApplies", func(t *testing.T) {
+		formatter, err := GetCommandFormatterWithDefault(newCommand(), JsonFormat)
+		require.NoError(t, err)
+		require.Equal(t, JsonFormat, formatter.Kind())
+	})
+
+	t.Run("FlagOverridesDefault", func(t *testing.T) {
+		formatter, err := GetCommandFormatterWithDefault(newCommand("--output", "none"), JsonFormat)
+		require.NoError(t, err)
+		require.Equal(t, NoneFormat, formatter.Kind())
+	})
+
+	t.Run("UnsupportedDefaultIgnored", func(t *testing.T) {
+		formatter, err := GetCommandFormatterWithDefault(newCommand(), TableFormat)
+		require.NoError(t, err)
+		require.Equal(t, NoneFormat, formatter.Kind())
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		formatter, err := GetCommandFormatter(newCommand())
+		require.NoError(t, err)
+		require.Equal(t, NoneFormat, formatter.Kind())
+	})
+}
+
+func TestIsOutputSuppressed(t *testing.T) {
+	newCommand := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		AddOutputParam(cmd, []Format{JsonFormat, NoneFormat}, NoneFormat)
+		require.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	require.True(t, IsOutputSuppressed(newCommand("--output", "none")))
+	require.True(t, IsOutputSuppressed(newCommand("-o", "NONE")))
+	require.False(t, IsOutputSuppressed(newCommand()))
+	require.False(t, IsOutputSuppressed(newCommand("--output", "json")))
+	require.False(t, IsOutputSuppressed(&cobra.Command{}))
+}
+
+func TestGetCommandFormatterNoneAlwaysAccepted(t *testing.T) {
+	cmd := &cobra.Command{}
+	AddOutputParam(cmd, []Format{JsonFormat, TableFormat}, TableFormat)
+	require.NoError(t, cmd.ParseFlags([]string{"--output", "none"}))
+
+	formatter, err := GetCommandFormatter(cmd)
+	require.NoError(t, err)
+	require.Equal(t, TableFormat, formatter.Kind())
+	require.True(t, IsOutputSuppressed(cmd))
+}
+
+func TestGetCommandFormatterUnsupported(t *testing.T) {
+	cmd := &cobra.Command{}
+	AddOutputParam(cmd, []Format{JsonFormat, NoneFormat}, NoneFormat)
+	require.NoError(t, cmd.ParseFlags([]string{"--output", "env-export"}))
+
+	_, err := GetCommandFormatter(cmd)
+	require.ErrorContains(t, err, "unsupported format 'env-export'")
+}
@@ -33,6 +33,43 @@ type Column struct {
 	Transformer   func(string) string
 }
 
+// Name returns the name used to reference the column when selecting columns, which is the lower-cased heading
+// with spaces replaced by dashes (for example, "Repository Path" is referenced as "repository-path").
+func (c Column) Name() string {
+	return strings.ReplaceAll(strings.ToLower(c.Heading), " ", "-")
+}
+
+// SelectColumns returns the columns matching the specified names, in the order the names are specified.
+// Names are matched case-insensitively against Column.Name. An error is returned for any unknown column name.
+func SelectColumns(columns []Column, names []string) ([]Column, error) {
+	selected := make([]Column, 0, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+
+		for _, c := range columns {
+			if c.Name() == name {
+				selected = append(selected, c)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			validNames := make([]string, 0, len(columns))
+			for _, c := range columns {
+				validNames = append(validNames, c.Name())
+			}
+
+			return nil, fmt.Errorf(
+				"unknown column '%s', valid columns are: %s", name, strings.Join(validNames, ", "))
+		}
+	}
+
+	return selected, nil
+}
+
 type TableFormatter struct {
 }
 
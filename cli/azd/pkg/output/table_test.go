@@ -185,3 +185,24 @@ type convertInput struct {
 	Success  bool
 	Expected []interface{}
 }
+
+func TestSelectColumns(t *testing.T) {
+	columns := []Column{
+		{Heading: "Name", ValueTemplate: "{{.Name}}"},
+		{Heading: "Repository Path", ValueTemplate: "{{.RepositoryPath}}"},
+		{Heading: "Source", ValueTemplate: "{{.Source}}"},
+	}
+
+	t.Run("Selected", func(t *testing.T) {
+		selected, err := SelectColumns(columns, []string{"source", "Repository-Path"})
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		require.Equal(t, "Source", selected[0].Heading)
+		require.Equal(t, "Repository Path", selected[1].Heading)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := SelectColumns(columns, []string{"name", "size"})
+		require.EqualError(t, err, "unknown column 'size', valid columns are: name, repository-path, source")
+	})
+}
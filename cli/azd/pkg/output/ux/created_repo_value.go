@@ -20,14 +20,24 @@ const (
 type CreatedRepoValue struct {
 	Name string
 	Kind GitHubValueKind
+	// Unchanged is true when the repo value already had the expected value and was left as is.
+	Unchanged bool
 }
 
 func (cr *CreatedRepoValue) ToString(currentIndentation string) string {
-	return fmt.Sprintf("%s%s Setting %s repo %s", currentIndentation, donePrefix, cr.Name, cr.Kind)
+	return fmt.Sprintf("%s%s %s", currentIndentation, donePrefix, cr.message())
 }
 
 func (cr *CreatedRepoValue) MarshalJSON() ([]byte, error) {
 	// reusing the same envelope from console messages
 	return json.Marshal(output.EventForMessage(
-		fmt.Sprintf("%s Setting %s repo %s", donePrefix, cr.Name, cr.Kind)))
+		fmt.Sprintf("%s %s", donePrefix, cr.message())))
+}
+
+func (cr *CreatedRepoValue) message() string {
+	if cr.Unchanged {
+		return fmt.Sprintf("%s repo %s already configured", cr.Name, cr.Kind)
+	}
+
+	return fmt.Sprintf("Setting %s repo %s", cr.Name, cr.Kind)
 }
@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+)
+
+// ResourceStateChange reports a change of the provisioning state of a resource while a deployment is in progress.
+type ResourceStateChange struct {
+	// The display name of the resource type, like Web App.
+	Type string
+	// The resource type, like Microsoft.Web/sites.
+	ResourceType  string
+	Id            string
+	Name          string
+	PreviousState string
+	State         string
+}
+
+func (rs *ResourceStateChange) ToString(currentIndentation string) string {
+	var prefix string
+
+	switch DisplayedResourceState(rs.State) {
+	case SucceededState:
+		prefix = donePrefix
+	case FailedState:
+		prefix = failedPrefix
+	default:
+		prefix = fmt.Sprintf("(-) %s:", rs.State)
+	}
+
+	return fmt.Sprintf("%s%s %s: %s", currentIndentation, prefix, rs.Type, rs.Name)
+}
+
+func (rs *ResourceStateChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(contracts.EventEnvelope{
+		Type:      contracts.ResourceProvisioningEventDataType,
+		Timestamp: time.Now(),
+		Data: contracts.ResourceProvisioning{
+			ResourceId:    rs.Id,
+			ResourceName:  rs.Name,
+			ResourceType:  rs.ResourceType,
+			PreviousState: rs.PreviousState,
+			State:         rs.State,
+		},
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type YamlFormatter struct {
+}
+
+func (f *YamlFormatter) Kind() Format {
+	return YamlFormat
+}
+
+// Format writes the object as YAML. The object is first marshalled to JSON so the same field names, ordering and
+// custom marshalling used by the JSON output are honored.
+func (f *YamlFormatter) Format(obj interface{}, writer io.Writer, _ interface{}) error {
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, decoding into a node preserves the field order of the JSON document.
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return fmt.Errorf("converting to yaml: %w", err)
+	}
+	resetNodeStyle(&node)
+
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// resetNodeStyle clears the flow and quoting styles inherited from the JSON document, so the YAML encoder uses block
+// style and only quotes strings that require it.
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetNodeStyle(child)
+	}
+}
+
+var _ Formatter = (*YamlFormatter)(nil)
@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type yamlInput struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Enabled bool              `json:"enabled"`
+	Tags    []string          `json:"tags"`
+	Values  map[string]string `json:"values"`
+}
+
+func TestYamlFormatter(t *testing.T) {
+	obj := yamlInput{
+		Name:    "my-app",
+		Version: "1.0",
+		Enabled: true,
+		Tags:    []string{"web", "api"},
+		Values: map[string]string{
+			"AZURE_LOCATION": "eastus2",
+			"DEBUG":          "true",
+			"MESSAGE":        "hello: world",
+		},
+	}
+
+	formatter := &YamlFormatter{}
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(obj, buffer, nil)
+	require.NoError(t, err)
+
+	expected := `name: my-app
+version: "1.0"
+enabled: true
+tags:
+  - web
+  - api
+values:
+  AZURE_LOCATION: eastus2
+  DEBUG: "true"
+  MESSAGE: 'hello: world'
+`
+	require.Equal(t, expected, buffer.String())
+}
@@ -724,7 +724,7 @@ func (p *AzdoCiProvider) configureConnection(
 	if err != nil {
 		return err
 	}
-	err = azdo.CreateServiceConnection(ctx, connection, details.projectId, *p.Env, *p.credentials, p.console)
+	err = azdo.CreateServiceConnection(ctx, connection, details.projectId, p.Env, *p.credentials, p.console)
 	if err != nil {
 		return err
 	}
@@ -738,6 +738,40 @@ func (p *AzdoCiProvider) configureConnection(
 	return nil
 }
 
+// previewConnection describes the service connection and pipeline configureConnection and configurePipeline would create.
+func (p *AzdoCiProvider) previewConnection(
+	repoDetails *gitRepositoryDetails,
+	provisioningProvider provisioning.Options,
+	authType PipelineAuthType,
+) []string {
+	details := repoDetails.details.(*AzdoRepositoryDetails)
+
+	variables := []string{
+		environment.LocationEnvVarName,
+		environment.EnvNameEnvVarName,
+		"AZURE_SERVICE_CONNECTION",
+		environment.SubscriptionIdEnvVarName,
+		"AZURE_CREDENTIALS",
+	}
+	if provisioningProvider.Provider == provisioning.Bicep {
+		if _, has := p.Env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+			variables = append(variables, environment.ResourceGroupEnvVarName)
+		}
+	}
+	if provisioningProvider.Provider == provisioning.Terraform {
+		variables = append(variables,
+			"ARM_TENANT_ID", "ARM_CLIENT_ID", "ARM_CLIENT_SECRET",
+			"RS_RESOURCE_GROUP", "RS_STORAGE_ACCOUNT", "RS_CONTAINER_NAME")
+	}
+
+	return []string{
+		fmt.Sprintf("Create or update the %s service connection in project %s",
+			azdo.ServiceConnectionName, details.projectName),
+		fmt.Sprintf("Create or update the %s pipeline for repository %s with the %s variables",
+			azdo.AzurePipelineName, details.repoName, strings.Join(variables, ", ")),
+	}
+}
+
 // parses the incoming json object and deserializes it to a struct
 func parseCredentials(ctx context.Context, credentials json.RawMessage) (*azdo.AzureServicePrincipalCredentials, error) {
 	azureCredentials := azdo.AzureServicePrincipalCredentials{}
@@ -401,32 +401,30 @@ func (p *GitHubCiProvider) configureConnection(
 ) error {
 
 	repoSlug := repoDetails.owner + "/" + repoDetails.repoName
+	branches := federatedBranches(repoDetails)
+	authType = githubAuthType(infraOptions, authType)
 
-	// Configure federated auth for both main branch and current branch
-	branches := []string{repoDetails.branch}
-	if !slices.Contains(branches, "main") {
-		branches = append(branches, "main")
-	}
-
-	// Default auth type to client-credentials for terraform
-	if infraOptions.Provider == provisioning.Terraform && authType == "" {
-		authType = AuthTypeClientCredentials
+	// Variables set by a previous run with the same value are left as is
+	existingVariables, err := p.ghCli.ListVariables(ctx, repoSlug)
+	if err != nil {
+		log.Printf("failed listing existing variables of %s, all variables will be set: %v", repoSlug, err)
+		existingVariables = map[string]string{}
 	}
 
 	var authErr error
 
 	switch authType {
 	case AuthTypeClientCredentials:
-		authErr = p.configureClientCredentialsAuth(ctx, infraOptions, repoSlug, credentials)
+		authErr = p.configureClientCredentialsAuth(ctx, infraOptions, repoSlug, existingVariables, credentials)
 	default:
-		authErr = p.configureFederatedAuth(ctx, infraOptions, repoSlug, branches, credentials)
+		authErr = p.configureFederatedAuth(ctx, infraOptions, repoSlug, existingVariables, branches, credentials)
 	}
 
 	if authErr != nil {
 		return fmt.Errorf("failed configuring authentication: %w", authErr)
 	}
 
-	if err := p.setPipelineVariables(ctx, repoSlug, infraOptions); err != nil {
+	if err := p.setPipelineVariables(ctx, repoSlug, existingVariables, infraOptions); err != nil {
 		return fmt.Errorf("failed setting pipeline variables: %w", err)
 	}
 
@@ -441,6 +439,69 @@ func (p *GitHubCiProvider) configureConnection(
 	return nil
 }
 
+// previewConnection describes the secrets, variables and federated credentials configureConnection would set.
+func (p *GitHubCiProvider) previewConnection(
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+) []string {
+	repoSlug := repoDetails.owner + "/" + repoDetails.repoName
+	var changes []string
+
+	switch githubAuthType(infraOptions, authType) {
+	case AuthTypeClientCredentials:
+		changes = append(changes, fmt.Sprintf("Set the AZURE_CREDENTIALS repo secret on %s", repoSlug))
+		if infraOptions.Provider == provisioning.Terraform {
+			changes = append(changes, fmt.Sprintf(
+				"Set the ARM_TENANT_ID and ARM_CLIENT_ID repo variables and the ARM_CLIENT_SECRET repo secret on %s",
+				repoSlug))
+		}
+	default:
+		changes = append(changes, fmt.Sprintf(
+			"Create federated identity credentials for pull requests and the %s branches of %s",
+			strings.Join(federatedBranches(repoDetails), ", "),
+			repoSlug))
+		changes = append(changes, fmt.Sprintf(
+			"Set the %s and AZURE_CLIENT_ID repo variables on %s", environment.TenantIdEnvVarName, repoSlug))
+	}
+
+	variables := []string{
+		environment.EnvNameEnvVarName,
+		environment.LocationEnvVarName,
+		environment.SubscriptionIdEnvVarName,
+	}
+	if infraOptions.Provider == provisioning.Terraform {
+		variables = append(variables, "RS_RESOURCE_GROUP", "RS_STORAGE_ACCOUNT", "RS_CONTAINER_NAME")
+	}
+	if infraOptions.Provider == provisioning.Bicep {
+		if _, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+			variables = append(variables, environment.ResourceGroupEnvVarName)
+		}
+	}
+
+	changes = append(changes, fmt.Sprintf("Set the %s repo variables on %s", strings.Join(variables, ", "), repoSlug))
+	return changes
+}
+
+// federatedBranches returns the branches federated credentials are configured for, the current branch and main.
+func federatedBranches(repoDetails *gitRepositoryDetails) []string {
+	branches := []string{repoDetails.branch}
+	if !slices.Contains(branches, "main") {
+		branches = append(branches, "main")
+	}
+
+	return branches
+}
+
+// githubAuthType returns the auth type used for the pipeline, which defaults to client-credentials for terraform.
+func githubAuthType(infraOptions provisioning.Options, authType PipelineAuthType) PipelineAuthType {
+	if infraOptions.Provider == provisioning.Terraform && authType == "" {
+		return AuthTypeClientCredentials
+	}
+
+	return authType
+}
+
 // setPipelineVariables sets all the pipeline variables required for the pipeline to run.  This includes the environment
 // variables that the core of AZD uses (AZURE_ENV_NAME) as well as the variables that the provisioning system needs to run
 // (AZURE_SUBSCRIPTION_ID, AZURE_LOCATION) as well as scenario specific variables (AZURE_RESOURCE_GROUP for resource group
@@ -448,6 +509,7 @@ func (p *GitHubCiProvider) configureConnection(
 func (p *GitHubCiProvider) setPipelineVariables(
 	ctx context.Context,
 	repoSlug string,
+	existingVariables map[string]string,
 	infraOptions provisioning.Options,
 ) error {
 	for name, value := range map[string]string{
@@ -455,13 +517,9 @@ func (p *GitHubCiProvider) setPipelineVariables(
 		environment.LocationEnvVarName:       p.env.GetLocation(),
 		environment.SubscriptionIdEnvVarName: p.env.GetSubscriptionId(),
 	} {
-		if err := p.ghCli.SetVariable(ctx, repoSlug, name, value); err != nil {
+		if err := p.setVariable(ctx, repoSlug, existingVariables, name, value); err != nil {
 			return fmt.Errorf("failed setting %s variable: %w", name, err)
 		}
-		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
-			Name: name,
-			Kind: ux.GitHubVariable,
-		})
 	}
 
 	if infraOptions.Provider == provisioning.Terraform {
@@ -486,19 +544,16 @@ func (p *GitHubCiProvider) setPipelineVariables(
 			}
 
 			// env var was found
-			if err := p.ghCli.SetVariable(ctx, repoSlug, key, value); err != nil {
+			if err := p.setVariable(ctx, repoSlug, existingVariables, key, value); err != nil {
 				return fmt.Errorf("setting terraform remote state variables: %w", err)
 			}
-			p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
-				Name: key,
-				Kind: ux.GitHubVariable,
-			})
 		}
 	}
 
 	if infraOptions.Provider == provisioning.Bicep {
 		if rgName, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
-			if err := p.ghCli.SetVariable(ctx, repoSlug, environment.ResourceGroupEnvVarName, rgName); err != nil {
+			err := p.setVariable(ctx, repoSlug, existingVariables, environment.ResourceGroupEnvVarName, rgName)
+			if err != nil {
 				return fmt.Errorf("failed setting %s variable: %w", environment.ResourceGroupEnvVarName, err)
 			}
 		}
@@ -507,11 +562,40 @@ func (p *GitHubCiProvider) setPipelineVariables(
 	return nil
 }
 
+// setVariable sets the repo variable, unless it already has the given value.
+func (p *GitHubCiProvider) setVariable(
+	ctx context.Context,
+	repoSlug string,
+	existingVariables map[string]string,
+	name string,
+	value string,
+) error {
+	if existing, has := existingVariables[name]; has && existing == value {
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name:      name,
+			Kind:      ux.GitHubVariable,
+			Unchanged: true,
+		})
+		return nil
+	}
+
+	if err := p.ghCli.SetVariable(ctx, repoSlug, name, value); err != nil {
+		return err
+	}
+
+	p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+		Name: name,
+		Kind: ux.GitHubVariable,
+	})
+	return nil
+}
+
 // Configures Github for standard Service Principal authentication with client id & secret
 func (p *GitHubCiProvider) configureClientCredentialsAuth(
 	ctx context.Context,
 	infraOptions provisioning.Options,
 	repoSlug string,
+	existingVariables map[string]string,
 	credentials json.RawMessage,
 ) error {
 	/* #nosec G101 - Potential hardcoded credentials - false positive */
@@ -545,13 +629,9 @@ func (p *GitHubCiProvider) configureClientCredentialsAuth(
 			"ARM_CLIENT_SECRET": {values.ClientSecret, true},
 		} {
 			if !info.secret {
-				if err := p.ghCli.SetVariable(ctx, repoSlug, key, info.value); err != nil {
+				if err := p.setVariable(ctx, repoSlug, existingVariables, key, info.value); err != nil {
 					return fmt.Errorf("setting github variable %s:: %w", key, err)
 				}
-				p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
-					Name: key,
-					Kind: ux.GitHubVariable,
-				})
 			} else {
 				if err := p.ghCli.SetSecret(ctx, repoSlug, key, info.value); err != nil {
 					return fmt.Errorf("setting github secret %s:: %w", key, err)
@@ -572,6 +652,7 @@ func (p *GitHubCiProvider) configureFederatedAuth(
 	ctx context.Context,
 	infraOptions provisioning.Options,
 	repoSlug string,
+	existingVariables map[string]string,
 	branches []string,
 	credentials json.RawMessage,
 ) error {
@@ -594,13 +675,9 @@ func (p *GitHubCiProvider) configureFederatedAuth(
 		environment.TenantIdEnvVarName: azureCredentials.TenantId,
 		"AZURE_CLIENT_ID":              azureCredentials.ClientId,
 	} {
-		if err := p.ghCli.SetVariable(ctx, repoSlug, key, value); err != nil {
+		if err := p.setVariable(ctx, repoSlug, existingVariables, key, value); err != nil {
 			return fmt.Errorf("failed setting github variable '%s':  %w", key, err)
 		}
-		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
-			Name: key,
-			Kind: ux.GitHubVariable,
-		})
 	}
 
 	return nil
@@ -889,6 +966,10 @@ func ensureFederatedCredential(
 				repoCredential.Subject,
 				*application.Id,
 			)
+			console.MessageUxItem(ctx, &ux.DoneMessage{
+				Message: fmt.Sprintf(
+					"Federated identity credential for GitHub: subject %s already configured", repoCredential.Subject),
+			})
 			return nil
 		}
 	}
@@ -921,6 +1002,7 @@ func createGraphClient(
 	graphOptions := azsdk.
 		NewClientOptionsBuilder().
 		WithTransport(httpClient).
+		WithPerRetryPolicy(azsdk.NewDebugLoggingPolicy()).
 		BuildCoreClientOptions()
 
 	return graphsdk.NewGraphClient(credential, graphOptions)
@@ -108,6 +108,29 @@ func Test_gitHub_provider_preConfigure_check(t *testing.T) {
 	})
 }
 
+func Test_gitHub_provider_previewConnection(t *testing.T) {
+	repoDetails := &gitRepositoryDetails{owner: "Azure", repoName: "azure-dev", branch: "feature"}
+
+	t.Run("federated", func(t *testing.T) {
+		provider := &GitHubCiProvider{env: environment.New("test")}
+		changes := provider.previewConnection(repoDetails, provisioning.Options{Provider: provisioning.Bicep}, "")
+		require.Equal(t, []string{
+			"Create federated identity credentials for pull requests and the feature, main branches of Azure/azure-dev",
+			"Set the AZURE_TENANT_ID and AZURE_CLIENT_ID repo variables on Azure/azure-dev",
+			"Set the AZURE_ENV_NAME, AZURE_LOCATION, AZURE_SUBSCRIPTION_ID repo variables on Azure/azure-dev",
+		}, changes)
+	})
+
+	t.Run("terraform defaults to client credentials", func(t *testing.T) {
+		provider := &GitHubCiProvider{env: environment.New("test")}
+		changes := provider.previewConnection(repoDetails, provisioning.Options{Provider: provisioning.Terraform}, "")
+		require.Len(t, changes, 3)
+		require.Equal(t, "Set the AZURE_CREDENTIALS repo secret on Azure/azure-dev", changes[0])
+		require.Contains(t, changes[1], "ARM_CLIENT_SECRET repo secret")
+		require.Contains(t, changes[2], "RS_STORAGE_ACCOUNT")
+	})
+}
+
 func createGitHubCiProvider(t *testing.T, mockContext *mocks.MockContext) CiProvider {
 	env := environment.New("test")
 	ghCli, err := github.NewGitHubCli(
@@ -140,3 +163,49 @@ func setupGithubCliMocks(mockContext *mocks.MockContext) {
 		return exec.NewRunResult(0, fmt.Sprintf("gh version %s", github.GitHubCliVersion), ""), nil
 	})
 }
+
+// fakeGhCli records the variables set on a repo that already has the given variables.
+type fakeGhCli struct {
+	github.GitHubCli
+	variables    map[string]string
+	setVariables map[string]string
+}
+
+func (f *fakeGhCli) ListVariables(ctx context.Context, repoSlug string) (map[string]string, error) {
+	return f.variables, nil
+}
+
+func (f *fakeGhCli) SetVariable(ctx context.Context, repoSlug string, name string, value string) error {
+	f.setVariables[name] = value
+	return nil
+}
+
+func Test_gitHub_provider_setPipelineVariables_existing(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.NewWithValues("test", map[string]string{
+		environment.LocationEnvVarName:       "eastus2",
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+	})
+	ghCli := &fakeGhCli{
+		// Set by a previous run, before the location of the environment changed
+		variables: map[string]string{
+			environment.EnvNameEnvVarName:        "test",
+			environment.LocationEnvVarName:       "westus",
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		},
+		setVariables: map[string]string{},
+	}
+	provider := &GitHubCiProvider{env: env, ghCli: ghCli, console: mockContext.Console}
+
+	existingVariables, err := ghCli.ListVariables(*mockContext.Context, "Azure/azure-dev")
+	require.NoError(t, err)
+
+	err = provider.setPipelineVariables(
+		*mockContext.Context, "Azure/azure-dev", existingVariables, provisioning.Options{Provider: provisioning.Bicep})
+	require.NoError(t, err)
+
+	// Only the variable with a different value is set again
+	require.Equal(t, map[string]string{environment.LocationEnvVarName: "eastus2"}, ghCli.setVariables)
+	require.Contains(t, mockContext.Console.Output(), "(✓) Done: AZURE_ENV_NAME repo variable already configured")
+	require.Contains(t, mockContext.Console.Output(), "(✓) Done: Setting AZURE_LOCATION repo variable")
+}
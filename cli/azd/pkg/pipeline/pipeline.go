@@ -105,6 +105,13 @@ type CiProvider interface {
 		credential json.RawMessage,
 		authType PipelineAuthType,
 	) error
+	// previewConnection describes the changes configureConnection and configurePipeline would make, without
+	// making them
+	previewConnection(
+		repoDetails *gitRepositoryDetails,
+		provisioningProvider provisioning.Options,
+		authType PipelineAuthType,
+	) []string
 }
 
 func folderExists(folderPath string) bool {
@@ -150,68 +150,12 @@ func (pm *PipelineManager) Configure(ctx context.Context) (result *PipelineConfi
 		return result, fmt.Errorf("ensuring git remote: %w", err)
 	}
 
-	if pm.args.PipelineServicePrincipalName != "" && pm.args.PipelineServicePrincipalId != "" {
-		//nolint:lll
-		return result, fmt.Errorf(
-			"you have specified both --principal-id and --principal-name, but only one of these parameters should be used at a time.",
-		)
-	}
-
-	// Existing Service Principal Lookup strategy
-	// 1. --principal-id
-	// 2. --principal-name
-	// 3. AZURE_PIPELINE_CLIENT_ID environment variable
-	// 4. Create new service principal with default naming convention
-	envClientId := pm.env.Getenv(AzurePipelineClientIdEnvVarName)
-	var appIdOrName string
-	var lookupKind servicePrincipalLookupKind
-	if pm.args.PipelineServicePrincipalId != "" {
-		appIdOrName = pm.args.PipelineServicePrincipalId
-		lookupKind = lookupKindPrincipalId
-	}
-	if appIdOrName == "" && pm.args.PipelineServicePrincipalName != "" {
-		appIdOrName = pm.args.PipelineServicePrincipalName
-		lookupKind = lookupKindPrincipleName
-	}
-	if appIdOrName == "" && envClientId != "" {
-		appIdOrName = envClientId
-		lookupKind = lookupKindEnvironmentVariable
-	}
-
-	var application *graphsdk.Application
-	var displayMsg, applicationName string
-
-	if appIdOrName != "" {
-		application, _ = pm.adService.GetServicePrincipal(ctx, pm.env.GetSubscriptionId(), appIdOrName)
-		if application != nil {
-			appIdOrName = *application.AppId
-			applicationName = application.DisplayName
-		} else {
-			applicationName = pm.args.PipelineServicePrincipalName
-		}
-	} else {
-		// Fall back to convention based naming
-		applicationName = fmt.Sprintf("az-dev-%s", time.Now().UTC().Format("01-02-2006-15-04-05"))
-		appIdOrName = applicationName
-	}
-
-	// If an explicit client id was specified but not found then fail
-	if application == nil && lookupKind == lookupKindPrincipalId {
-		return nil, fmt.Errorf(
-			"service principal with client id '%s' specified in '--principal-id' parameter was not found",
-			pm.args.PipelineServicePrincipalId,
-		)
-	}
-
-	// If an explicit client id was specified but not found then fail
-	if application == nil && lookupKind == lookupKindEnvironmentVariable {
-		return nil, fmt.Errorf(
-			"service principal with client id '%s' specified in environment variable '%s' was not found",
-			envClientId,
-			AzurePipelineClientIdEnvVarName,
-		)
+	application, appIdOrName, applicationName, err := pm.resolveServicePrincipal(ctx)
+	if err != nil {
+		return result, err
 	}
 
+	var displayMsg string
 	if application == nil {
 		displayMsg = fmt.Sprintf("Creating service principal %s", applicationName)
 	} else {
@@ -219,7 +163,7 @@ func (pm *PipelineManager) Configure(ctx context.Context) (result *PipelineConfi
 	}
 
 	pm.console.ShowSpinner(ctx, displayMsg, input.Step)
-	clientId, credentials, err := pm.adService.CreateOrUpdateServicePrincipal(
+	clientId, credentials, existingRoles, err := pm.adService.CreateOrUpdateServicePrincipal(
 		ctx,
 		pm.env.GetSubscriptionId(),
 		appIdOrName,
@@ -234,6 +178,12 @@ func (pm *PipelineManager) Configure(ctx context.Context) (result *PipelineConfi
 		return result, fmt.Errorf("failed to create or update service principal: %w", err)
 	}
 
+	for _, roleName := range existingRoles {
+		pm.console.MessageUxItem(ctx, &ux.DoneMessage{
+			Message: fmt.Sprintf("Role %s already configured", roleName),
+		})
+	}
+
 	// Set in .env to be retrieved for any additional runs
 	if clientId != nil {
 		pm.env.DotenvSet(AzurePipelineClientIdEnvVarName, *clientId)
@@ -319,6 +269,147 @@ func (pm *PipelineManager) Configure(ctx context.Context) (result *PipelineConfi
 	}, nil
 }
 
+// Preview returns a description of each change Configure would make, without creating or updating the service principal,
+// the git remote or anything in the pipeline provider.
+func (pm *PipelineManager) Preview(ctx context.Context) ([]string, error) {
+	requiredTools, err := pm.requiredTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tools.EnsureInstalled(ctx, requiredTools...); err != nil {
+		return nil, err
+	}
+
+	prj, err := project.Load(ctx, pm.azdCtx.ProjectPath())
+	if err != nil {
+		return nil, fmt.Errorf("finding provisioning provider: %w", err)
+	}
+
+	rootPath := pm.azdCtx.ProjectDirectory()
+	updatedConfig, err := pm.preConfigureCheck(ctx, prj.Infra, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	if updatedConfig {
+		pm.console.Message(ctx, "")
+	}
+
+	// Unlike Configure, a missing repository or remote isn't created, since creating a remote can create a repository
+	// in the scm provider
+	gitRepoInfo, err := pm.ensureRemote(ctx, rootPath, pm.args.PipelineRemoteName)
+	if errors.Is(err, git.ErrNotRepository) || errors.Is(err, git.ErrNoSuchRemote) {
+		return nil, fmt.Errorf(
+			"previewing the pipeline configuration requires a git repository with the remote '%s': %w",
+			pm.args.PipelineRemoteName,
+			err,
+		)
+	} else if err != nil {
+		return nil, fmt.Errorf("ensuring git remote: %w", err)
+	}
+
+	application, _, applicationName, err := pm.resolveServicePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	if application == nil {
+		changes = append(changes, fmt.Sprintf("Create service principal %s", applicationName))
+	} else {
+		changes = append(changes,
+			fmt.Sprintf("Update service principal %s (%s)", application.DisplayName, *application.AppId))
+	}
+
+	changes = append(changes, fmt.Sprintf(
+		"Assign the %s roles to the service principal on subscription %s",
+		strings.Join(pm.args.PipelineRoleNames, ", "),
+		pm.env.GetSubscriptionId(),
+	))
+	changes = append(changes,
+		pm.ciProvider.previewConnection(gitRepoInfo, prj.Infra, PipelineAuthType(pm.args.PipelineAuthTypeName))...)
+	changes = append(changes, fmt.Sprintf(
+		"Offer to commit and push local changes to the %s branch of %s/%s",
+		gitRepoInfo.branch,
+		gitRepoInfo.owner,
+		gitRepoInfo.repoName,
+	))
+
+	return changes, nil
+}
+
+// resolveServicePrincipal looks up the existing service principal to use for the pipeline. When no service principal
+// exists, application is nil and appIdOrName and applicationName hold the name of the service principal to create.
+func (pm *PipelineManager) resolveServicePrincipal(ctx context.Context) (
+	application *graphsdk.Application,
+	appIdOrName string,
+	applicationName string,
+	err error,
+) {
+	if pm.args.PipelineServicePrincipalName != "" && pm.args.PipelineServicePrincipalId != "" {
+		//nolint:lll
+		return nil, "", "", fmt.Errorf(
+			"you have specified both --principal-id and --principal-name, but only one of these parameters should be used at a time.",
+		)
+	}
+
+	// Existing Service Principal Lookup strategy
+	// 1. --principal-id
+	// 2. --principal-name
+	// 3. AZURE_PIPELINE_CLIENT_ID environment variable
+	// 4. Create new service principal with default naming convention
+	envClientId := pm.env.Getenv(AzurePipelineClientIdEnvVarName)
+	var lookupKind servicePrincipalLookupKind
+	if pm.args.PipelineServicePrincipalId != "" {
+		appIdOrName = pm.args.PipelineServicePrincipalId
+		lookupKind = lookupKindPrincipalId
+	}
+	if appIdOrName == "" && pm.args.PipelineServicePrincipalName != "" {
+		appIdOrName = pm.args.PipelineServicePrincipalName
+		lookupKind = lookupKindPrincipleName
+	}
+	if appIdOrName == "" && envClientId != "" {
+		appIdOrName = envClientId
+		lookupKind = lookupKindEnvironmentVariable
+	}
+
+	if appIdOrName != "" {
+		application, err = pm.adService.GetServicePrincipal(ctx, pm.env.GetSubscriptionId(), appIdOrName)
+		if err != nil && !errors.Is(err, azcli.ErrApplicationNotFound) {
+			return nil, "", "", fmt.Errorf("looking up service principal '%s': %w", appIdOrName, err)
+		}
+
+		if application != nil {
+			appIdOrName = *application.AppId
+			applicationName = application.DisplayName
+		} else {
+			applicationName = pm.args.PipelineServicePrincipalName
+		}
+	} else {
+		// Fall back to convention based naming
+		applicationName = fmt.Sprintf("az-dev-%s", time.Now().UTC().Format("01-02-2006-15-04-05"))
+		appIdOrName = applicationName
+	}
+
+	// If an explicit client id was specified but not found then fail
+	if application == nil && lookupKind == lookupKindPrincipalId {
+		return nil, "", "", fmt.Errorf(
+			"service principal with client id '%s' specified in '--principal-id' parameter was not found",
+			pm.args.PipelineServicePrincipalId,
+		)
+	}
+
+	// If an explicit client id was specified but not found then fail
+	if application == nil && lookupKind == lookupKindEnvironmentVariable {
+		return nil, "", "", fmt.Errorf(
+			"service principal with client id '%s' specified in environment variable '%s' was not found",
+			envClientId,
+			AzurePipelineClientIdEnvVarName,
+		)
+	}
+
+	return application, appIdOrName, applicationName, nil
+}
+
 // requiredTools get all the provider's required tools.
 func (pm *PipelineManager) requiredTools(ctx context.Context) ([]tools.ExternalTool, error) {
 	scmReqTools, err := pm.scmProvider.requiredTools(ctx)
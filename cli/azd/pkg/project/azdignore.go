@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// azdIgnoreFileName is the name of the files listing paths, in gitignore syntax, that are excluded from service packages.
+const azdIgnoreFileName = ".azdignore"
+
+// azdIgnoreRule is a single pattern of an .azdignore file.
+type azdIgnoreRule struct {
+	pattern string
+	// negate is true for patterns starting with '!', which include paths excluded by an earlier pattern again.
+	negate bool
+	// dirOnly is true for patterns ending with '/', which only match directories.
+	dirOnly bool
+}
+
+// azdIgnore excludes the paths matched by the .azdignore files found under root. Like .gitignore files, each .azdignore
+// file applies to the paths under its directory, and the patterns of nested files take precedence over those of their
+// parents. A directory that is excluded is skipped with everything in it.
+type azdIgnore struct {
+	root string
+	// rules are the rules of the .azdignore file in each directory loaded so far, nil when the directory has none.
+	rules map[string][]azdIgnoreRule
+	// excluded is the number of files and directories excluded so far.
+	excluded int
+}
+
+func newAzdIgnore(root string) *azdIgnore {
+	return &azdIgnore{
+		root:  root,
+		rules: map[string][]azdIgnoreRule{},
+	}
+}
+
+// exclude is an excludeDirEntryCondition that excludes the paths matched by the .azdignore files.
+func (ai *azdIgnore) exclude(path string, file os.FileInfo) bool {
+	rel, err := filepath.Rel(ai.root, path)
+	if err != nil || rel == "." || !isWithin(ai.root, path) {
+		return false
+	}
+
+	excluded := false
+	dir := ai.root
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+
+	// Apply the files from the root down to the directory of the path, so the rules of nested files are applied last
+	for i := range segments {
+		relToDir := strings.Join(segments[i:], "/")
+		for _, rule := range ai.rulesOf(dir) {
+			if rule.dirOnly && !file.IsDir() {
+				continue
+			}
+
+			if matched, _ := doublestar.Match(rule.pattern, relToDir); matched {
+				excluded = !rule.negate
+			}
+		}
+
+		dir = filepath.Join(dir, segments[i])
+	}
+
+	if excluded {
+		ai.excluded++
+	}
+
+	return excluded
+}
+
+// rulesOf returns the rules of the .azdignore file in dir, reading it the first time the directory is seen.
+func (ai *azdIgnore) rulesOf(dir string) []azdIgnoreRule {
+	if rules, has := ai.rules[dir]; has {
+		return rules
+	}
+
+	rules, err := readAzdIgnore(filepath.Join(dir, azdIgnoreFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("ignoring %s: %v", filepath.Join(dir, azdIgnoreFileName), err)
+	}
+
+	ai.rules[dir] = rules
+	return rules
+}
+
+// readAzdIgnore parses the .azdignore file at path. Blank lines and comments are skipped, as are invalid patterns.
+func readAzdIgnore(path string) ([]azdIgnoreRule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rules []azdIgnoreRule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := azdIgnoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		// A pattern with a separator is relative to the directory of the file, otherwise it matches at any depth
+		if strings.Contains(line, "/") {
+			line = strings.TrimPrefix(line, "/")
+		} else {
+			line = "**/" + line
+		}
+
+		if line == "" || !doublestar.ValidatePattern(line) {
+			log.Printf("ignoring invalid pattern '%s' in %s", scanner.Text(), path)
+			continue
+		}
+
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+
+	return rules, scanner.Err()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildForZip_AzdIgnore(t *testing.T) {
+	serviceDir := t.TempDir()
+	files := map[string]string{
+		".azdignore":                "# local artifacts\nnode_modules/\n*.log\n!keep.log\n/tests/fixtures/\n",
+		"app.js":                    "",
+		"debug.log":                 "",
+		"keep.log":                  "",
+		"node_modules/dep/index.js": "",
+		"tests/fixtures/data.json":  "",
+		"tests/app.test.js":         "",
+		"lib/node_modules/util.js":  "",
+		"lib/fixtures/data.json":    "",
+		"lib/.azdignore":            "*.json\n!keep.log\n",
+		"lib/trace.log":             "",
+		"lib/keep.log":              "",
+		"lib/index.js":              "",
+	}
+
+	for path, content := range files {
+		fullPath := filepath.Join(serviceDir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), osutil.PermissionFile))
+	}
+
+	dst := t.TempDir()
+	require.NoError(t, buildForZip(serviceDir, dst, buildForZipOptions{ignoreRoot: serviceDir}))
+
+	var copied []string
+	err := filepath.WalkDir(dst, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dst, path)
+		copied = append(copied, filepath.ToSlash(rel))
+		return err
+	})
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{
+		".azdignore",
+		"app.js",
+		"keep.log",
+		"tests/app.test.js",
+		"lib/.azdignore",
+		"lib/index.js",
+		"lib/keep.log",
+	}, copied)
+}
+
+func Test_buildForZip_AzdIgnoreRoot(t *testing.T) {
+	serviceDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(serviceDir, ".azdignore"), []byte("dist/maps/\n"), osutil.PermissionFile))
+	require.NoError(t, os.MkdirAll(filepath.Join(serviceDir, "dist", "maps"), osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(filepath.Join(serviceDir, "dist", "index.js"), nil, osutil.PermissionFile))
+	require.NoError(t, os.WriteFile(filepath.Join(serviceDir, "dist", "maps", "index.js.map"), nil, osutil.PermissionFile))
+
+	// The .azdignore of the service applies to its output directory, with paths relative to the service
+	dst := t.TempDir()
+	require.NoError(t, buildForZip(filepath.Join(serviceDir, "dist"), dst, buildForZipOptions{ignoreRoot: serviceDir}))
+
+	require.FileExists(t, filepath.Join(dst, "index.js"))
+	require.NoDirExists(t, filepath.Join(dst, "maps"))
+}
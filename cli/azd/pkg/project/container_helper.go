@@ -74,16 +74,30 @@ func (ch *ContainerHelper) LocalImageTag(ctx context.Context, serviceConfig *Ser
 		return "", err
 	}
 
-	if configuredTag != "" {
-		return configuredTag, nil
+	if configuredTag == "" {
+		configuredTag = fmt.Sprintf("%s/%s-%s:azd-deploy-%d",
+			strings.ToLower(serviceConfig.Project.Name),
+			strings.ToLower(serviceConfig.Name),
+			strings.ToLower(ch.env.GetEnvName()),
+			ch.clock.Now().Unix(),
+		)
 	}
 
-	return fmt.Sprintf("%s/%s-%s:azd-deploy-%d",
-		strings.ToLower(serviceConfig.Project.Name),
-		strings.ToLower(serviceConfig.Name),
-		strings.ToLower(ch.env.GetEnvName()),
-		ch.clock.Now().Unix(),
-	), nil
+	if serviceConfig.Docker.ImageTag != "" {
+		return withImageTag(configuredTag, serviceConfig.Docker.ImageTag), nil
+	}
+
+	return configuredTag, nil
+}
+
+// withImageTag replaces the tag of the image reference with the specified tag, adding one when missing.
+func withImageTag(image string, tag string) string {
+	// A ':' before the last '/' belongs to a registry host port, not the tag.
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
+	}
+
+	return fmt.Sprintf("%s:%s", image, tag)
 }
 
 func (ch *ContainerHelper) RequiredExternalTools(context.Context) []tools.ExternalTool {
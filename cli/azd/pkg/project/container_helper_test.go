@@ -45,6 +45,19 @@ func Test_ContainerHelper_LocalImageTag(t *testing.T) {
 				Tag: NewExpandableString("contoso/contoso-image:latest"),
 			},
 			"contoso/contoso-image:latest"},
+		{
+			"TagOverride",
+			DockerProjectOptions{
+				ImageTag: "v1.2.3",
+			},
+			fmt.Sprintf("%s:v1.2.3", defaultImageName)},
+		{
+			"TagOverrideWithImageSpecified",
+			DockerProjectOptions{
+				Tag:      NewExpandableString("localhost:5000/contoso-image:latest"),
+				ImageTag: "v1.2.3",
+			},
+			"localhost:5000/contoso-image:v1.2.3"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,79 @@
+package project
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvironmentOverrides contains the settings under `environments.<name>` in azure.yaml, which are applied over the base
+// configuration when the environment with that name is selected.
+type EnvironmentOverrides struct {
+	// Services contains partial service definitions, keyed by service name, that are merged over the service with the
+	// same name.
+	Services map[string]yaml.Node `yaml:"services,omitempty"`
+}
+
+// validateEnvironmentOverrides ensures every service override refers to a service defined in the project.
+func validateEnvironmentOverrides(projectConfig *ProjectConfig) error {
+	for envName, overrides := range projectConfig.Environments {
+		if overrides == nil {
+			continue
+		}
+
+		for svcName, override := range overrides.Services {
+			if !projectConfig.HasService(svcName) {
+				return fmt.Errorf(
+					"environment '%s' overrides service '%s', which is not defined in the project", envName, svcName)
+			}
+
+			if override.Kind != yaml.MappingNode {
+				return fmt.Errorf("override of service '%s' in environment '%s' must be an object", svcName, envName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyEnvironmentOverrides merges the service overrides defined for the environment over the base service definitions.
+// Values in the override win: nested objects, like `docker`, are merged key by key, while all other values, including
+// lists, replace the base value. Services without an override, and projects without overrides for the environment, are
+// left unchanged. Applying the overrides of the same environment more than once has no further effect.
+func (p *ProjectConfig) ApplyEnvironmentOverrides(envName string) error {
+	overrides, has := p.Environments[envName]
+	if !has || overrides == nil {
+		return nil
+	}
+
+	svcNames := maps.Keys(overrides.Services)
+	slices.Sort(svcNames)
+
+	for _, svcName := range svcNames {
+		override := overrides.Services[svcName]
+		svc, has := p.Services[svcName]
+		if !has || svc == nil {
+			continue
+		}
+
+		// Decoding into the existing service only sets the values present in the override, keeping all others.
+		if err := override.Decode(svc); err != nil {
+			return fmt.Errorf("applying override of service '%s' for environment '%s': %w", svcName, envName, err)
+		}
+
+		var err error
+		svc.Language, err = parseServiceLanguage(svc.Language)
+		if err != nil {
+			return fmt.Errorf("parsing service %s: %w", svcName, err)
+		}
+
+		svc.Host, err = parseServiceHost(svc.Host)
+		if err != nil {
+			return fmt.Errorf("parsing service %s: %w", svcName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const environmentOverridesProject = `
+name: test-proj
+services:
+  web:
+    project: src/web
+    language: js
+    host: containerapp
+    docker:
+      path: ./Dockerfile
+      tag: dev
+      buildArgs:
+        - MODE=debug
+  api:
+    project: src/api
+    language: python
+    host: appservice
+environments:
+  prod:
+    services:
+      web:
+        resourceName: web-prod
+        docker:
+          tag: stable
+          buildArgs:
+            - MODE=release
+`
+
+func Test_ApplyEnvironmentOverrides(t *testing.T) {
+	t.Run("OverriddenEnvironment", func(t *testing.T) {
+		projectConfig, err := Parse(context.Background(), environmentOverridesProject)
+		require.NoError(t, err)
+
+		web := projectConfig.Services["web"]
+		require.NoError(t, projectConfig.ApplyEnvironmentOverrides("prod"))
+
+		// The service is updated in place, so holders of the service config observe the override
+		require.Same(t, web, projectConfig.Services["web"])
+		require.Equal(t, "web", web.Name)
+		require.Same(t, projectConfig, web.Project)
+		require.NotNil(t, web.EventDispatcher)
+
+		// Values in the override win, nested objects are merged and lists are replaced
+		require.Equal(t, NewExpandableString("web-prod"), web.ResourceName)
+		require.Equal(t, NewExpandableString("stable"), web.Docker.Tag)
+		require.Equal(t, "./Dockerfile", web.Docker.Path)
+		require.Equal(t, []string{"MODE=release"}, web.Docker.BuildArgs)
+		require.Equal(t, ContainerAppTarget, web.Host)
+		require.Equal(t, ServiceLanguageJavaScript, web.Language)
+
+		// Services without an override are unchanged
+		api := projectConfig.Services["api"]
+		require.Equal(t, "src/api", api.RelativePath)
+		require.Equal(t, NewExpandableString(""), api.ResourceName)
+
+		// Applying the overrides again has no further effect
+		require.NoError(t, projectConfig.ApplyEnvironmentOverrides("prod"))
+		require.Equal(t, []string{"MODE=release"}, web.Docker.BuildArgs)
+	})
+
+	t.Run("OtherEnvironment", func(t *testing.T) {
+		projectConfig, err := Parse(context.Background(), environmentOverridesProject)
+		require.NoError(t, err)
+
+		require.NoError(t, projectConfig.ApplyEnvironmentOverrides("dev"))
+
+		web := projectConfig.Services["web"]
+		require.Equal(t, NewExpandableString(""), web.ResourceName)
+		require.Equal(t, NewExpandableString("dev"), web.Docker.Tag)
+		require.Equal(t, []string{"MODE=debug"}, web.Docker.BuildArgs)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		_, err := Parse(context.Background(), `
+name: test-proj
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+environments:
+  prod:
+    services:
+      worker:
+        host: containerapp
+`)
+		require.ErrorContains(t, err, "environment 'prod' overrides service 'worker', which is not defined in the project")
+	})
+
+	t.Run("InvalidHost", func(t *testing.T) {
+		projectConfig, err := Parse(context.Background(), `
+name: test-proj
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+environments:
+  prod:
+    services:
+      web:
+        host: mainframe
+`)
+		require.NoError(t, err)
+		require.Error(t, projectConfig.ApplyEnvironmentOverrides("prod"))
+	})
+}
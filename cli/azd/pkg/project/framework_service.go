@@ -50,6 +50,23 @@ func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error)
 	return ServiceLanguageKind(""), fmt.Errorf("unsupported language '%s'", kind)
 }
 
+// SupportsRestoreNoCache returns true when the package manager used to restore dependencies for the language can bypass
+// its cache: npm (js, ts), pip (python) and NuGet (dotnet, csharp, fsharp).
+func SupportsRestoreNoCache(language ServiceLanguageKind) bool {
+	switch language {
+	case ServiceLanguageDotNet,
+		ServiceLanguageCsharp,
+		ServiceLanguageFsharp,
+		ServiceLanguageJavaScript,
+		ServiceLanguageTypeScript,
+		ServiceLanguagePython,
+		"py":
+		return true
+	}
+
+	return false
+}
+
 type FrameworkRequirements struct {
 	Package FrameworkPackageRequirements
 }
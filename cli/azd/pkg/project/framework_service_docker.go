@@ -34,6 +34,9 @@ type DockerProjectOptions struct {
 	Platform  string           `yaml:"platform,omitempty"  json:"platform,omitempty"`
 	Tag       ExpandableString `yaml:"tag,omitempty"       json:"tag,omitempty"`
 	BuildArgs []string         `yaml:"buildArgs,omitempty" json:"buildArgs,omitempty"`
+	// ImageTag overrides the tag of the generated image name (e.g. from `azd deploy --tag`).
+	// It is not read from or written to azure.yaml.
+	ImageTag string `yaml:"-" json:"-"`
 }
 
 type dockerBuildResult struct {
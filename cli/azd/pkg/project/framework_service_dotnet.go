@@ -85,7 +85,7 @@ func (dp *dotnetProject) Restore(
 				task.SetError(err)
 				return
 			}
-			if err := dp.dotnetCli.Restore(ctx, projFile); err != nil {
+			if err := dp.dotnetCli.Restore(ctx, projFile, serviceConfig.RestoreNoCache); err != nil {
 				task.SetError(err)
 				return
 			}
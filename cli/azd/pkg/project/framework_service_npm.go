@@ -56,7 +56,7 @@ func (np *npmProject) Restore(
 	return async.RunTaskWithProgress(
 		func(task *async.TaskContextWithProgress[*ServiceRestoreResult, ServiceProgress]) {
 			task.SetProgress(NewServiceProgress("Installing NPM dependencies"))
-			if err := np.cli.Install(ctx, serviceConfig.Path()); err != nil {
+			if err := np.cli.Install(ctx, serviceConfig.Path(), serviceConfig.RestoreNoCache); err != nil {
 				task.SetError(err)
 				return
 			}
@@ -146,6 +146,7 @@ func (np *npmProject) Package(
 					excludeConditions: []excludeDirEntryCondition{
 						excludeNodeModules,
 					},
+					ignoreRoot: serviceConfig.Path(),
 				}); err != nil {
 				task.SetError(fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err))
 				return
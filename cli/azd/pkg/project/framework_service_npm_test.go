@@ -48,6 +48,37 @@ func Test_NpmProject_Restore(t *testing.T) {
 	)
 }
 
+func Test_NpmProject_Restore_NoCache(t *testing.T) {
+	var runArgs exec.RunArgs
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "npm install")
+		}).
+		RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			runArgs = args
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+	env := environment.New("test")
+	npmCli := npm.NewNpmCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguageTypeScript)
+	serviceConfig.RestoreNoCache = true
+
+	npmProject := NewNpmProject(npmCli, env)
+	restoreTask := npmProject.Restore(*mockContext.Context, serviceConfig)
+	logProgress(restoreTask)
+
+	_, err := restoreTask.Await()
+	require.NoError(t, err)
+	require.Len(t, runArgs.Args, 3)
+	require.Equal(t, []string{"install", "--cache"}, runArgs.Args[:2])
+
+	// The temporary cache is deleted after the install
+	require.NoDirExists(t, runArgs.Args[2])
+}
+
 func Test_NpmProject_Build(t *testing.T) {
 	var runArgs exec.RunArgs
 
@@ -83,7 +83,8 @@ func (pp *pythonProject) Restore(
 			}
 
 			task.SetProgress(NewServiceProgress("Installing Python PIP dependencies"))
-			err = pp.cli.InstallRequirements(ctx, serviceConfig.Path(), vEnvName, "requirements.txt")
+			err = pp.cli.InstallRequirements(
+				ctx, serviceConfig.Path(), vEnvName, "requirements.txt", serviceConfig.RestoreNoCache)
 			if err != nil {
 				task.SetError(
 					fmt.Errorf("requirements for project '%s' could not be installed: %w", serviceConfig.Path(), err),
@@ -150,6 +151,7 @@ func (pp *pythonProject) Package(
 						excludeVirtualEnv,
 						excludePyCache,
 					},
+					ignoreRoot: serviceConfig.Path(),
 				}); err != nil {
 				task.SetError(fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err))
 				return
@@ -40,6 +40,60 @@ func New(ctx context.Context, projectFilePath string, projectName string) (*Proj
 	return Load(ctx, projectFilePath)
 }
 
+// validateServiceNames ensures each service in the yaml content of a project has a distinct name. Besides repeated keys,
+// which would otherwise be reported as a generic yaml error, it rejects names that only differ in case or in '-' and '_',
+// since the values of such services would overwrite each other in the environment, where service names are upper cased
+// and '-' is replaced with '_'. Content that isn't valid yaml is left for the yaml decoder to report.
+func validateServiceNames(yamlContent string) error {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlContent), &document); err != nil ||
+		len(document.Content) == 0 ||
+		document.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var services *yaml.Node
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "services" {
+			services = root.Content[i+1]
+		}
+	}
+
+	if services == nil || services.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	seen := map[string]*yaml.Node{}
+	for i := 0; i < len(services.Content); i += 2 {
+		key := services.Content[i]
+		normalized := strings.ReplaceAll(strings.ToUpper(key.Value), "-", "_")
+
+		previous, has := seen[normalized]
+		if !has {
+			seen[normalized] = key
+			continue
+		}
+
+		if previous.Value == key.Value {
+			return fmt.Errorf(
+				"service '%s' is defined more than once, at lines %d and %d", key.Value, previous.Line, key.Line)
+		}
+
+		return fmt.Errorf(
+			"services '%s' (line %d) and '%s' (line %d) have conflicting names, "+
+				"their environment values would both be stored as SERVICE_%s_*",
+			previous.Value,
+			previous.Line,
+			key.Value,
+			key.Line,
+			normalized,
+		)
+	}
+
+	return nil
+}
+
 // Parse will parse a project from a yaml string and return the project configuration
 func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 	var projectConfig ProjectConfig
@@ -48,6 +102,10 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 		return nil, fmt.Errorf("unable to parse azure.yaml file. File is empty.")
 	}
 
+	if err := validateServiceNames(yamlContent); err != nil {
+		return nil, fmt.Errorf("unable to parse azure.yaml file: %w", err)
+	}
+
 	if err := yaml.Unmarshal([]byte(yamlContent), &projectConfig); err != nil {
 		return nil, fmt.Errorf(
 			"unable to parse azure.yaml file. Check the format of the file, "+
@@ -99,6 +157,23 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 		if err != nil {
 			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
 		}
+
+		svc.Deploy, err = parseServiceDeployMode(svc.Deploy)
+		if err != nil {
+			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+		}
+	}
+
+	if projectConfig.Deploy != nil && projectConfig.Deploy.Parallelism < 0 {
+		return nil, fmt.Errorf(
+			"parsing project %s: invalid deploy parallelism %d, at least one service must be deployed at a time",
+			projectConfig.Name,
+			projectConfig.Deploy.Parallelism,
+		)
+	}
+
+	if err := validateEnvironmentOverrides(&projectConfig); err != nil {
+		return nil, fmt.Errorf("parsing project %s: %w", projectConfig.Name, err)
 	}
 
 	if projectConfig.Infra.Path == "" {
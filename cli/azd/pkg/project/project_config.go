@@ -23,6 +23,12 @@ type ProjectConfig struct {
 	Pipeline          PipelineOptions            `yaml:"pipeline,omitempty"`
 	Hooks             map[string]*ext.HookConfig `yaml:"hooks,omitempty"`
 	State             *state.Config              `yaml:"state,omitempty"`
+	// Defaults contains the defaults of the project, which take precedence over the same `defaults` of the user config.
+	Defaults *DefaultsConfig `yaml:"defaults,omitempty"`
+	// Deploy contains the settings used by `azd deploy`.
+	Deploy *DeployConfig `yaml:"deploy,omitempty"`
+	// Environments contains settings that override the base configuration for the environment with the given name.
+	Environments map[string]*EnvironmentOverrides `yaml:"environments,omitempty"`
 
 	*ext.EventDispatcher[ProjectLifecycleEventArgs] `yaml:",omitempty"`
 }
@@ -34,6 +40,18 @@ type RequiredVersions struct {
 	Azd *string `yaml:"azd,omitempty"`
 }
 
+// DefaultsConfig contains the defaults of the project, set with `azd config set defaults.<name> --project`.
+type DefaultsConfig struct {
+	// Output is the format used by commands when `--output` is not set, like json.
+	Output string `yaml:"output,omitempty"`
+}
+
+// DeployConfig contains the settings used by `azd deploy`.
+type DeployConfig struct {
+	// Parallelism is the number of services deployed at the same time when `--parallel` is not set. Defaults to 1.
+	Parallelism int `yaml:"parallelism,omitempty"`
+}
+
 // options supported in azure.yaml
 type PipelineOptions struct {
 	Provider string `yaml:"provider"`
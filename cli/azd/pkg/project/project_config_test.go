@@ -57,6 +57,89 @@ func TestProjectConfigParse_Invalid(t *testing.T) {
 	}
 }
 
+func TestProjectConfigParse_Deploy(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		projectConfig, err := Parse(context.Background(), `
+name: proj-deploy
+deploy:
+  parallelism: 4
+services:
+  api:
+    language: csharp
+    host: containerapp
+    deploy: serial
+  web:
+    language: js
+    host: appservice
+`)
+		require.NoError(t, err)
+		require.Equal(t, 4, projectConfig.Deploy.Parallelism)
+		require.Equal(t, ServiceDeploySerial, projectConfig.Services["api"].Deploy)
+		require.Equal(t, ServiceDeployParallel, projectConfig.Services["web"].Deploy)
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		_, err := Parse(context.Background(), `
+name: proj-deploy
+services:
+  web:
+    language: js
+    host: appservice
+    deploy: sequential
+`)
+		require.ErrorContains(t, err, "parsing service web: unsupported deploy mode 'sequential'")
+	})
+
+	t.Run("InvalidParallelism", func(t *testing.T) {
+		_, err := Parse(context.Background(), `
+name: proj-deploy
+deploy:
+  parallelism: -1
+`)
+		require.ErrorContains(t, err, "invalid deploy parallelism -1")
+	})
+}
+
+func TestProjectConfigParse_DuplicateServiceNames(t *testing.T) {
+	t.Run("SameName", func(t *testing.T) {
+		_, err := Parse(context.Background(), `
+name: proj-duplicate-service
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+  api:
+    project: src/api
+    language: python
+    host: appservice
+  web:
+    project: src/web2
+    language: js
+    host: containerapp
+`)
+		require.ErrorContains(t, err, "service 'web' is defined more than once, at lines 4 and 12")
+	})
+
+	t.Run("ConflictingNames", func(t *testing.T) {
+		_, err := Parse(context.Background(), `
+name: proj-conflicting-service
+services:
+  my-api:
+    project: src/api
+    language: python
+    host: appservice
+  MY_API:
+    project: src/api2
+    language: python
+    host: appservice
+`)
+		require.ErrorContains(t, err,
+			"services 'my-api' (line 4) and 'MY_API' (line 8) have conflicting names, "+
+				"their environment values would both be stored as SERVICE_MY_API_*")
+	})
+}
+
 func TestProjectConfigDefaults(t *testing.T) {
 	const testProj = `
 name: test-proj
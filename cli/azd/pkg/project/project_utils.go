@@ -5,8 +5,10 @@ package project
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
@@ -46,6 +48,9 @@ type excludeDirEntryCondition func(path string, file os.FileInfo) bool
 // buildForZipOptions provides a set of options for doing build for zip
 type buildForZipOptions struct {
 	excludeConditions []excludeDirEntryCondition
+	// ignoreRoot is the directory whose .azdignore files, including those in nested directories, apply to the copied
+	// paths. Defaults to the source directory.
+	ignoreRoot string
 }
 
 // buildForZip is use by projects which build strategy is to only copy the source code into a folder which is later
@@ -53,8 +58,19 @@ type buildForZipOptions struct {
 // details for each language which should not be ever copied.
 func buildForZip(src, dst string, options buildForZipOptions) error {
 
+	// The .azdignore files of the ignore root only apply when the source is within it
+	ignoreRoot := options.ignoreRoot
+	if ignoreRoot == "" || !isWithin(ignoreRoot, src) {
+		ignoreRoot = src
+	}
+	ignore := newAzdIgnore(ignoreRoot)
+
 	// these exclude conditions applies to all projects
-	options.excludeConditions = append(options.excludeConditions, globalExcludeAzdFolder)
+	options.excludeConditions = append(options.excludeConditions, globalExcludeAzdFolder, ignore.exclude)
+
+	defer func() {
+		log.Printf("excluded %d files and directories of '%s' matching %s", ignore.excluded, src, azdIgnoreFileName)
+	}()
 
 	return copy.Copy(src, dst, copy.Options{
 		Skip: func(srcInfo os.FileInfo, src, dest string) (bool, error) {
@@ -68,6 +84,12 @@ func buildForZip(src, dst string, options buildForZipOptions) error {
 	})
 }
 
+// isWithin returns true when path is dir or a path under it.
+func isWithin(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func globalExcludeAzdFolder(path string, file os.FileInfo) bool {
 	return file.IsDir() && file.Name() == ".azure"
 }
@@ -0,0 +1,96 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"gopkg.in/yaml.v3"
+)
+
+// SetValue sets the value at the dot separated path, like `state.remote.backend`, in the project file, creating the
+// objects along the path as needed. Comments and the order of the existing keys are preserved. The project file is only
+// written when the result is still a valid project.
+func SetValue(ctx context.Context, projectFilePath string, path string, value any) error {
+	contents, err := os.ReadFile(projectFilePath)
+	if err != nil {
+		return fmt.Errorf("reading project file: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return fmt.Errorf("parsing project file: %w", err)
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 ||
+		document.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("project file '%s' is not a YAML object", projectFilePath)
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return fmt.Errorf("encoding value of '%s': %w", path, err)
+	}
+
+	node := document.Content[0]
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("invalid path '%s'", path)
+		}
+
+		child := mappingValue(node, segment)
+		if i == len(segments)-1 {
+			if child == nil {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: segment}, &valueNode)
+			} else {
+				// Keep the comments of the existing entry
+				valueNode.HeadComment, valueNode.LineComment = child.HeadComment, child.LineComment
+				*child = valueNode
+			}
+
+			break
+		}
+
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: segment}, child)
+		} else if child.Kind != yaml.MappingNode {
+			return fmt.Errorf(
+				"cannot set '%s', '%s' is not an object", path, strings.Join(segments[:i+1], "."))
+		}
+
+		node = child
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("marshalling project yaml: %w", err)
+	}
+
+	if _, err := Parse(ctx, buf.String()); err != nil {
+		return fmt.Errorf("setting '%s' results in an invalid project: %w", path, err)
+	}
+
+	if err := os.WriteFile(projectFilePath, buf.Bytes(), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("saving project file: %w", err)
+	}
+
+	return nil
+}
+
+// mappingValue returns the value of the key in the mapping node, or nil when the key is not present.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
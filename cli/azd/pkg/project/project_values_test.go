@@ -0,0 +1,69 @@
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SetValue(t *testing.T) {
+	writeProject := func(t *testing.T, contents string) string {
+		projectPath := filepath.Join(t.TempDir(), "azure.yaml")
+		require.NoError(t, os.WriteFile(projectPath, []byte(contents), osutil.PermissionFile))
+		return projectPath
+	}
+
+	readProject := func(t *testing.T, projectPath string) string {
+		contents, err := os.ReadFile(projectPath)
+		require.NoError(t, err)
+		return string(contents)
+	}
+
+	t.Run("CreatesPath", func(t *testing.T) {
+		projectPath := writeProject(t, "# comment\nname: test-proj\nservices:\n  web:\n    project: src/web\n    language: js\n"+
+			"    host: appservice\n")
+
+		err := SetValue(context.Background(), projectPath, "state.remote.config.accountName", "account")
+		require.NoError(t, err)
+		require.Equal(t, "# comment\nname: test-proj\nservices:\n  web:\n    project: src/web\n    language: js\n"+
+			"    host: appservice\nstate:\n  remote:\n    config:\n      accountName: account\n",
+			readProject(t, projectPath))
+	})
+
+	t.Run("ReplacesValue", func(t *testing.T) {
+		projectPath := writeProject(t, "name: test-proj\nstate:\n  remote:\n    backend: Local # backend\n")
+
+		err := SetValue(context.Background(), projectPath, "state.remote.backend", "AzureBlobStorage")
+		require.NoError(t, err)
+		require.Equal(t, "name: test-proj\nstate:\n  remote:\n    backend: AzureBlobStorage # backend\n",
+			readProject(t, projectPath))
+	})
+
+	t.Run("ObjectValue", func(t *testing.T) {
+		projectPath := writeProject(t, "name: test-proj\n")
+
+		err := SetValue(context.Background(), projectPath, "state.remote", map[string]any{"backend": "AzureBlobStorage"})
+		require.NoError(t, err)
+		require.Equal(t, "name: test-proj\nstate:\n  remote:\n    backend: AzureBlobStorage\n", readProject(t, projectPath))
+	})
+
+	t.Run("NotAnObject", func(t *testing.T) {
+		projectPath := writeProject(t, "name: test-proj\n")
+
+		err := SetValue(context.Background(), projectPath, "name.value", "other")
+		require.ErrorContains(t, err, "'name' is not an object")
+		require.Equal(t, "name: test-proj\n", readProject(t, projectPath))
+	})
+
+	t.Run("InvalidProject", func(t *testing.T) {
+		projectPath := writeProject(t, "name: test-proj\n")
+
+		err := SetValue(context.Background(), projectPath, "services", "web")
+		require.Error(t, err)
+		require.Equal(t, "name: test-proj\n", readProject(t, projectPath))
+	})
+}
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
@@ -28,16 +29,42 @@ type ServiceConfig struct {
 	K8s AksOptions `yaml:"k8s,omitempty"`
 	// The optional Azure Spring Apps options
 	Spring SpringOptions `yaml:"spring,omitempty"`
+	// The optional health check options
+	HealthCheck HealthCheckOptions `yaml:"healthCheck,omitempty"`
+	// The optional deploy mode. Services with the serial mode are deployed on their own, even when `azd deploy`
+	// deploys services in parallel.
+	Deploy ServiceDeployMode `yaml:"deploy,omitempty"`
 	// The infrastructure provisioning configuration
 	Infra provisioning.Options `yaml:"infra,omitempty"`
 	// Hook configuration for service
 	Hooks map[string]*ext.HookConfig `yaml:"hooks,omitempty"`
+	// RestoreNoCache bypasses package manager caches when restoring dependencies (e.g. from `azd restore --no-cache`).
+	RestoreNoCache bool `yaml:"-"`
 
 	*ext.EventDispatcher[ServiceLifecycleEventArgs] `yaml:"-"`
 
 	initialized bool
 }
 
+// ServiceDeployMode controls how `azd deploy` schedules the deployment of a service.
+type ServiceDeployMode string
+
+const (
+	// ServiceDeployParallel deploys the service alongside other services, up to the deploy parallelism. It is the default.
+	ServiceDeployParallel ServiceDeployMode = ""
+	// ServiceDeploySerial deploys the service on its own, before the services deployed in parallel.
+	ServiceDeploySerial ServiceDeployMode = "serial"
+)
+
+func parseServiceDeployMode(mode ServiceDeployMode) (ServiceDeployMode, error) {
+	switch mode {
+	case ServiceDeployParallel, ServiceDeploySerial:
+		return mode, nil
+	}
+
+	return ServiceDeployMode(""), fmt.Errorf("unsupported deploy mode '%s', the supported mode is 'serial'", mode)
+}
+
 // Path returns the fully qualified path to the project
 func (sc *ServiceConfig) Path() string {
 	return filepath.Join(sc.Project.Path, sc.RelativePath)
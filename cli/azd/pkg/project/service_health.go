@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/httputil"
+	"github.com/sethvargo/go-retry"
+)
+
+// HealthCheckOptions configures how a deployed service is checked for health.
+type HealthCheckOptions struct {
+	// The path of the health endpoint of the service, relative to the service endpoint, defaults to "/"
+	Path string `yaml:"path,omitempty"`
+}
+
+const (
+	defaultHealthCheckPath = "/"
+	healthCheckMaxAttempts = 20
+	healthCheckTimeout     = 5 * time.Minute
+)
+
+// healthCheckBackoff creates the backoff used between health check attempts.
+var healthCheckBackoff = func() retry.Backoff {
+	backoff := retry.NewExponential(2 * time.Second)
+	backoff = retry.WithCappedDuration(30*time.Second, backoff)
+	backoff = retry.WithMaxRetries(healthCheckMaxAttempts-1, backoff)
+
+	return retry.WithMaxDuration(healthCheckTimeout, backoff)
+}
+
+// HealthCheckUrl returns the url of the health endpoint for the service at the specified endpoint. Endpoints can describe
+// the endpoint after the url, like "http://10.0.0.1, (Service, Type: LoadBalancer)" for AKS, and the description is
+// ignored. It returns false for endpoints that can't be checked: endpoints that aren't http or https urls, and the cluster
+// internal endpoints of AKS services, which aren't reachable from outside the cluster.
+func (sc *ServiceConfig) HealthCheckUrl(endpoint string) (string, bool, error) {
+	path := sc.HealthCheck.Path
+	if path == "" {
+		path = defaultHealthCheckPath
+	}
+
+	endpoint, description, _ := strings.Cut(endpoint, ",")
+	if strings.Contains(description, "Type: ClusterIP") {
+		return "", false, nil
+	}
+
+	endpointUrl, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil || (endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https") || endpointUrl.Host == "" {
+		return "", false, nil
+	}
+
+	// The health check path is relative to the path of the endpoint, like the path prefix of an AKS ingress, even when it
+	// starts with a slash.
+	if !strings.HasSuffix(endpointUrl.Path, "/") {
+		endpointUrl.Path += "/"
+		endpointUrl.RawPath = ""
+	}
+
+	healthUrl, err := endpointUrl.Parse(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return "", false, fmt.Errorf("parsing health check path '%s': %w", path, err)
+	}
+
+	return healthUrl.String(), true, nil
+}
+
+// WaitForHealthy polls the health endpoint until it returns a 2xx status code, the maximum number of attempts is
+// reached or the timeout elapses. The status code of the last response is returned.
+func WaitForHealthy(ctx context.Context, httpClient httputil.HttpClient, healthUrl string) (int, error) {
+	var statusCode int
+
+	err := retry.Do(ctx, healthCheckBackoff(), func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthUrl, nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := httpClient.Do(req)
+		if err != nil {
+			log.Printf("health check request to '%s' failed: %v", healthUrl, err)
+			return retry.RetryableError(err)
+		}
+		defer res.Body.Close()
+
+		statusCode = res.StatusCode
+		if statusCode < 200 || statusCode > 299 {
+			return retry.RetryableError(fmt.Errorf("health check returned status code %d", statusCode))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return statusCode, fmt.Errorf("service at '%s' is not healthy: %w", healthUrl, err)
+	}
+
+	return statusCode, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/sethvargo/go-retry"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ServiceConfig_HealthCheckUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		endpoint string
+		want     string
+	}{
+		{"Default", "", "https://web.azurewebsites.net/", "https://web.azurewebsites.net/"},
+		{"AbsolutePath", "/health", "https://web.azurewebsites.net/", "https://web.azurewebsites.net/health"},
+		{"RelativePath", "health", "https://web.azurewebsites.net/api/", "https://web.azurewebsites.net/api/health"},
+		{"AksService", "/health", "http://10.0.0.1, (Service, Type: LoadBalancer)", "http://10.0.0.1/health"},
+		{"EndpointPath", "/health", "https://web.azurewebsites.net/api", "https://web.azurewebsites.net/api/health"},
+		{
+			"AksIngress",
+			"/health",
+			"https://aks.example.com/api, (Ingress, Type: LoadBalancer)",
+			"https://aks.example.com/api/health",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceConfig := &ServiceConfig{HealthCheck: HealthCheckOptions{Path: tt.path}}
+			healthUrl, ok, err := serviceConfig.HealthCheckUrl(tt.endpoint)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, tt.want, healthUrl)
+		})
+	}
+
+	t.Run("NotCheckable", func(t *testing.T) {
+		endpoints := []string{
+			"http://10.0.0.1:8080, (Service, Type: ClusterIP)",
+			"web.azurewebsites.net",
+			"Hostname: web.azurewebsites.net",
+			"",
+		}
+
+		for _, endpoint := range endpoints {
+			serviceConfig := &ServiceConfig{}
+			healthUrl, ok, err := serviceConfig.HealthCheckUrl(endpoint)
+			require.NoError(t, err)
+			require.False(t, ok, endpoint)
+			require.Empty(t, healthUrl)
+		}
+	})
+}
+
+func Test_WaitForHealthy(t *testing.T) {
+	originalBackoff := healthCheckBackoff
+	healthCheckBackoff = func() retry.Backoff {
+		return retry.WithMaxRetries(2, retry.NewConstant(time.Millisecond))
+	}
+	t.Cleanup(func() { healthCheckBackoff = originalBackoff })
+
+	const healthUrl = "https://web.azurewebsites.net/health"
+
+	t.Run("HealthyAfterRetry", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		attempts := 0
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.String() == healthUrl
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return mocks.CreateEmptyHttpResponse(request, http.StatusServiceUnavailable)
+			}
+
+			return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+		})
+
+		statusCode, err := WaitForHealthy(*mockContext.Context, mockContext.HttpClient, healthUrl)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, statusCode)
+		require.Equal(t, 2, attempts)
+	})
+
+	t.Run("Unhealthy", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		attempts := 0
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.String() == healthUrl
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			attempts++
+			return mocks.CreateEmptyHttpResponse(request, http.StatusInternalServerError)
+		})
+
+		statusCode, err := WaitForHealthy(*mockContext.Context, mockContext.HttpClient, healthUrl)
+		require.Error(t, err)
+		require.Equal(t, http.StatusInternalServerError, statusCode)
+		require.Equal(t, 3, attempts)
+	})
+}
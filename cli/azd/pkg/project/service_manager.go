@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -84,6 +85,7 @@ type ServiceManager interface {
 		ctx context.Context,
 		serviceConfig *ServiceConfig,
 		packageOutput *ServicePackageResult,
+		options *DeployOptions,
 	) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress]
 
 	// Gets the framework service for the specified service config
@@ -97,10 +99,12 @@ type ServiceManager interface {
 }
 
 type serviceManager struct {
-	env                 *environment.Environment
-	resourceManager     ResourceManager
-	serviceLocator      ioc.ServiceLocator
-	operationCache      map[string]any
+	env             *environment.Environment
+	resourceManager ResourceManager
+	serviceLocator  ioc.ServiceLocator
+	operationCache  map[string]any
+	// operationCacheMu guards operationCache, used by services deployed in parallel
+	operationCacheMu    sync.Mutex
 	alphaFeatureManager *alpha.FeatureManager
 }
 
@@ -307,7 +311,8 @@ func (sm *serviceManager) Package(
 
 		// When a previous restore result was not provided, and we require it
 		// Then we need to restore the dependencies
-		if frameworkRequirements.Package.RequireRestore && (!hasBuildOutput || buildOutput.Restore == nil) {
+		if frameworkRequirements.Package.RequireRestore && !options.SkipRestore &&
+			(!hasBuildOutput || buildOutput.Restore == nil) {
 			restoreTask := sm.Restore(ctx, serviceConfig)
 			syncProgress(task, restoreTask.Progress())
 
@@ -324,7 +329,15 @@ func (sm *serviceManager) Package(
 
 		// When a previous build result was not provided, and we require it
 		// Then we need to build the project
-		if frameworkRequirements.Package.RequireBuild && !hasBuildOutput {
+		if options.SkipBuild && !hasBuildOutput {
+			existingBuild, err := existingBuildOutput(serviceConfig)
+			if err != nil {
+				task.SetError(err)
+				return
+			}
+
+			buildResult = existingBuild
+		} else if frameworkRequirements.Package.RequireBuild && !hasBuildOutput {
 			buildTask := sm.Build(ctx, serviceConfig, restoreResult)
 			syncProgress(task, buildTask.Progress())
 
@@ -415,6 +428,36 @@ func (sm *serviceManager) Package(
 	})
 }
 
+// existingBuildOutput returns the build output left by a previous build of the service, which is packaged instead of
+// building the service again. The output is expected in the output path of the service, which must be set: the service
+// directory itself always has files, so it can't tell whether the service was built.
+func existingBuildOutput(serviceConfig *ServiceConfig) (*ServiceBuildResult, error) {
+	if serviceConfig.Host.RequiresContainer() {
+		return nil, fmt.Errorf(
+			"skipping the build is not supported for service '%s', its container image is built while packaging",
+			serviceConfig.Name,
+		)
+	}
+
+	if serviceConfig.OutputPath == "" {
+		return nil, fmt.Errorf(
+			"skipping the build requires 'dist' to be set for service '%s', to the directory of its build output",
+			serviceConfig.Name,
+		)
+	}
+
+	buildOutputPath := filepath.Join(serviceConfig.Path(), serviceConfig.OutputPath)
+	if entries, err := os.ReadDir(buildOutputPath); err != nil || len(entries) == 0 {
+		return nil, fmt.Errorf(
+			"build output '%s' of service '%s' is empty or does not exist, build the service before skipping the build",
+			buildOutputPath,
+			serviceConfig.Name,
+		)
+	}
+
+	return &ServiceBuildResult{BuildOutputPath: buildOutputPath}, nil
+}
+
 // Deploys the generated artifacts to the Azure resource that will host the service application
 // Common examples would be uploading zip archive using ZipDeploy deployment or
 // pushing container images to a container registry.
@@ -422,6 +465,7 @@ func (sm *serviceManager) Deploy(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
 	packageResult *ServicePackageResult,
+	options *DeployOptions,
 ) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
 	return async.RunTaskWithProgress(func(task *async.TaskContextWithProgress[*ServiceDeployResult, ServiceProgress]) {
 		cachedResult, ok := sm.getOperationResult(ctx, serviceConfig, string(ServiceEventDeploy))
@@ -443,6 +487,23 @@ func (sm *serviceManager) Deploy(
 			return
 		}
 
+		deploy := func(targetResource *environment.TargetResource) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
+			return serviceTarget.Deploy(ctx, serviceConfig, packageResult, targetResource)
+		}
+
+		if options != nil && options.Slot != "" {
+			slotTarget, ok := serviceTarget.(SlotServiceTarget)
+			if !ok {
+				task.SetError(fmt.Errorf(
+					"service '%s' uses host '%s' which does not support deployment slots", serviceConfig.Name, serviceConfig.Host))
+				return
+			}
+
+			deploy = func(targetResource *environment.TargetResource) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
+				return slotTarget.DeploySlot(ctx, serviceConfig, packageResult, targetResource, options.Slot, options.CreateSlot)
+			}
+		}
+
 		targetResource, err := sm.resourceManager.GetTargetResource(ctx, sm.env.GetSubscriptionId(), serviceConfig)
 		if err != nil {
 			task.SetError(fmt.Errorf("getting target resource: %w", err))
@@ -455,7 +516,7 @@ func (sm *serviceManager) Deploy(
 			ServiceEventDeploy,
 			serviceConfig,
 			func() *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
-				return serviceTarget.Deploy(ctx, serviceConfig, packageResult, targetResource)
+				return deploy(targetResource)
 			},
 		)
 
@@ -564,6 +625,9 @@ func (sm *serviceManager) getOperationResult(
 	operationName string,
 ) (any, bool) {
 	key := fmt.Sprintf("%s:%s", serviceConfig.Name, operationName)
+	sm.operationCacheMu.Lock()
+	defer sm.operationCacheMu.Unlock()
+
 	value, ok := sm.operationCache[key]
 
 	return value, ok
@@ -577,6 +641,9 @@ func (sm *serviceManager) setOperationResult(
 	result any,
 ) {
 	key := fmt.Sprintf("%s:%s", serviceConfig.Name, operationName)
+	sm.operationCacheMu.Lock()
+	defer sm.operationCacheMu.Unlock()
+
 	sm.operationCache[key] = result
 }
 
@@ -3,6 +3,8 @@ package project
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockarmresources"
@@ -207,7 +210,7 @@ func Test_ServiceManager_Deploy(t *testing.T) {
 	deployCalled := convert.RefOf(false)
 	ctx := context.WithValue(*mockContext.Context, serviceTargetDeployCalled, deployCalled)
 
-	deployTask := sm.Deploy(ctx, serviceConfig, nil)
+	deployTask := sm.Deploy(ctx, serviceConfig, nil, nil)
 	logProgress(deployTask)
 
 	result, err := deployTask.Await()
@@ -218,6 +221,26 @@ func Test_ServiceManager_Deploy(t *testing.T) {
 	require.True(t, raisedPostDeployEvent)
 }
 
+func Test_ServiceManager_Deploy_SlotNotSupported(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	setupMocksForServiceManager(mockContext)
+	env := environment.NewWithValues("test", map[string]string{
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+	})
+	sm := createServiceManager(mockContext, env)
+	serviceConfig := createTestServiceConfig("./src/api", ServiceTargetFake, ServiceLanguageFake)
+
+	deployCalled := convert.RefOf(false)
+	ctx := context.WithValue(*mockContext.Context, serviceTargetDeployCalled, deployCalled)
+
+	deployTask := sm.Deploy(ctx, serviceConfig, nil, &DeployOptions{Slot: "staging"})
+	logProgress(deployTask)
+
+	_, err := deployTask.Await()
+	require.ErrorContains(t, err, "does not support deployment slots")
+	require.False(t, *deployCalled)
+}
+
 func Test_ServiceManager_GetFrameworkService(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	setupMocksForServiceManager(mockContext)
@@ -304,7 +327,7 @@ func Test_ServiceManager_Events_With_Errors(t *testing.T) {
 		{
 			name: "deploy",
 			run: func(ctx context.Context, serviceManager ServiceManager, serviceConfig *ServiceConfig) (any, error) {
-				deployTask := serviceManager.Deploy(ctx, serviceConfig, nil)
+				deployTask := serviceManager.Deploy(ctx, serviceConfig, nil, nil)
 				logProgress(deployTask)
 				return deployTask.Await()
 			},
@@ -592,3 +615,110 @@ func (t *fakeTool) InstallUrl() string {
 func (t *fakeTool) Name() string {
 	return "fake tool"
 }
+
+const ServiceLanguageFakeCompiled ServiceLanguageKind = "fake-compiled-framework"
+
+// Fake implementation of a framework service that requires restoring and building before packaging
+type fakeCompiledFramework struct {
+	fakeFramework
+}
+
+func newFakeCompiledFramework(commandRunner exec.CommandRunner) FrameworkService {
+	return &fakeCompiledFramework{
+		fakeFramework: fakeFramework{commandRunner: commandRunner},
+	}
+}
+
+func (f *fakeCompiledFramework) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		Package: FrameworkPackageRequirements{
+			RequireRestore: true,
+			RequireBuild:   true,
+		},
+	}
+}
+
+func Test_ServiceManager_Package_SkipRestoreAndBuild(t *testing.T) {
+	newServiceConfig := func(t *testing.T, withBuildOutput bool) *ServiceConfig {
+		serviceConfig := createTestServiceConfig("src/api", ServiceTargetFake, ServiceLanguageFakeCompiled)
+		serviceConfig.Project.Path = t.TempDir()
+		serviceConfig.OutputPath = "dist"
+
+		if withBuildOutput {
+			buildOutputPath := filepath.Join(serviceConfig.Path(), "dist")
+			require.NoError(t, os.MkdirAll(buildOutputPath, osutil.PermissionDirectory))
+			require.NoError(t, os.WriteFile(filepath.Join(buildOutputPath, "app.js"), nil, osutil.PermissionFile))
+		}
+
+		return serviceConfig
+	}
+
+	runPackage := func(
+		t *testing.T,
+		serviceConfig *ServiceConfig,
+		options *PackageOptions,
+	) (*ServicePackageResult, bool, bool, error) {
+		mockContext := mocks.NewMockContext(context.Background())
+		setupMocksForServiceManager(mockContext)
+		_ = mockContext.Container.RegisterNamedSingleton(string(ServiceLanguageFakeCompiled), newFakeCompiledFramework)
+		sm := createServiceManager(mockContext, environment.New("test"))
+
+		restoreCalled := convert.RefOf(false)
+		buildCalled := convert.RefOf(false)
+		ctx := context.WithValue(*mockContext.Context, frameworkRestoreCalled, restoreCalled)
+		ctx = context.WithValue(ctx, frameworkBuildCalled, buildCalled)
+
+		packageTask := sm.Package(ctx, serviceConfig, nil, options)
+		logProgress(packageTask)
+
+		result, err := packageTask.Await()
+		return result, *restoreCalled, *buildCalled, err
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		_, restoreCalled, buildCalled, err := runPackage(t, newServiceConfig(t, false), nil)
+		require.NoError(t, err)
+		require.True(t, restoreCalled)
+		require.True(t, buildCalled)
+	})
+
+	t.Run("SkipRestore", func(t *testing.T) {
+		_, restoreCalled, buildCalled, err := runPackage(t, newServiceConfig(t, false), &PackageOptions{SkipRestore: true})
+		require.NoError(t, err)
+		require.False(t, restoreCalled)
+		require.True(t, buildCalled)
+	})
+
+	t.Run("SkipBuild", func(t *testing.T) {
+		serviceConfig := newServiceConfig(t, true)
+		result, restoreCalled, buildCalled, err := runPackage(
+			t, serviceConfig, &PackageOptions{SkipRestore: true, SkipBuild: true})
+		require.NoError(t, err)
+		require.False(t, restoreCalled)
+		require.False(t, buildCalled)
+		require.Equal(t, filepath.Join(serviceConfig.Path(), "dist"), result.Build.BuildOutputPath)
+	})
+
+	t.Run("SkipBuildWithoutBuildOutput", func(t *testing.T) {
+		_, _, buildCalled, err := runPackage(t, newServiceConfig(t, false), &PackageOptions{SkipBuild: true})
+		require.ErrorContains(t, err, "is empty or does not exist")
+		require.False(t, buildCalled)
+	})
+
+	t.Run("SkipBuildWithoutOutputPath", func(t *testing.T) {
+		serviceConfig := newServiceConfig(t, true)
+		serviceConfig.OutputPath = ""
+
+		_, _, buildCalled, err := runPackage(t, serviceConfig, &PackageOptions{SkipBuild: true})
+		require.ErrorContains(t, err, "requires 'dist' to be set for service 'api'")
+		require.False(t, buildCalled)
+	})
+
+	t.Run("SkipBuildContainerService", func(t *testing.T) {
+		serviceConfig := newServiceConfig(t, true)
+		serviceConfig.Host = ContainerAppTarget
+
+		_, err := existingBuildOutput(serviceConfig)
+		require.ErrorContains(t, err, "not supported for service 'api'")
+	})
+}
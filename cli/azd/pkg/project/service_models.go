@@ -62,6 +62,19 @@ func (sbr *ServiceBuildResult) MarshalJSON() ([]byte, error) {
 
 type PackageOptions struct {
 	OutputPath string
+	// SkipRestore packages the service without restoring its dependencies, which must already be present.
+	SkipRestore bool
+	// SkipBuild packages the existing build output of the service, instead of building it.
+	SkipBuild bool
+}
+
+// DeployOptions are the options of a Deploy operation
+type DeployOptions struct {
+	// Slot deploys the service to the named deployment slot of its target resource, instead of production. Only supported
+	// by service targets that implement SlotServiceTarget.
+	Slot string
+	// CreateSlot creates the deployment slot when it doesn't exist.
+	CreateSlot bool
 }
 
 // ServicePackageResult is the result of a successful Package operation
@@ -92,7 +105,9 @@ type ServiceDeployResult struct {
 	TargetResourceId string            `json:"targetResourceId"`
 	Kind             ServiceTargetKind `json:"kind"`
 	Endpoints        []string          `json:"endpoints"`
-	Details          interface{}       `json:"details"`
+	// Slot is the deployment slot that received the deployment, when not deployed to production
+	Slot    string      `json:"slot,omitempty"`
+	Details interface{} `json:"details"`
 }
 
 // Supports rendering messages for UX items
@@ -104,6 +119,10 @@ func (spr *ServiceDeployResult) ToString(currentIndentation string) string {
 
 	builder := strings.Builder{}
 
+	if spr.Slot != "" {
+		builder.WriteString(fmt.Sprintf("%s- Slot: %s\n", currentIndentation, spr.Slot))
+	}
+
 	if len(spr.Endpoints) == 0 {
 		builder.WriteString(fmt.Sprintf("%s- No endpoints were found\n", currentIndentation))
 	} else {
@@ -13,6 +13,8 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 type ServiceTargetKind string
@@ -72,6 +74,124 @@ type ServiceTarget interface {
 	) ([]string, error)
 }
 
+// RevisionedServiceTarget is implemented by service targets that deploy immutable revisions,
+// allowing traffic to be reverted to a previous revision when a deployment fails.
+type RevisionedServiceTarget interface {
+	// Gets the name of the revision that is currently serving traffic for the target resource
+	CurrentRevision(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+	) (string, error)
+
+	// Reverts the target resource to serve traffic from the specified revision
+	Rollback(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		revision string,
+	) error
+
+	// Deactivates the revisions created by azd for the target resource, except for the `keep` most recent ones.
+	// Returns the names of the deactivated revisions.
+	PruneRevisions(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		keep int,
+	) ([]string, error)
+}
+
+// SettingsServiceTarget is implemented by service targets whose runtime settings, like app settings or environment
+// variables, can be set by azd. Runtime settings are applied to the target resource and are not part of the azd
+// environment.
+type SettingsServiceTarget interface {
+	// Sets the runtime settings of the target resource, keeping any other existing settings.
+	ApplySettings(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		settings map[string]string,
+	) (*SettingsChanges, error)
+
+	// Reverts the runtime settings applied by ApplySettings, removing the added settings and restoring the previous
+	// values of the changed ones.
+	RestoreSettings(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		changes *SettingsChanges,
+	) error
+}
+
+// SlotServiceTarget is implemented by service targets whose target resources have deployment slots, which run a deployment
+// alongside the production slot.
+type SlotServiceTarget interface {
+	// Deploys the given deployment artifact to the named deployment slot of the target resource. When create is true, the
+	// slot is created first if it doesn't exist.
+	DeploySlot(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		servicePackage *ServicePackageResult,
+		targetResource *environment.TargetResource,
+		slot string,
+		create bool,
+	) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress]
+
+	// Swaps the named deployment slot of the target resource into production, and waits for the swap to complete.
+	// Production is left unchanged when the swap fails.
+	SwapSlot(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		slot string,
+	) error
+}
+
+// SettingsChanges are the names of the runtime settings applied by SettingsServiceTarget, grouped by how they compare to
+// the existing settings of the target resource. Names are sorted.
+type SettingsChanges struct {
+	Added     []string
+	Changed   []string
+	Unchanged []string
+
+	// previous are the values of the changed settings before they were applied, used to restore them
+	previous map[string]string
+}
+
+// HasChanges returns true when any setting was added or changed.
+func (c *SettingsChanges) HasChanges() bool {
+	return len(c.Added) > 0 || len(c.Changed) > 0
+}
+
+// compareSettings compares the settings to apply with the existing settings of a target resource.
+func compareSettings(existing map[string]string, settings map[string]string) *SettingsChanges {
+	changes := &SettingsChanges{
+		Added:     []string{},
+		Changed:   []string{},
+		Unchanged: []string{},
+		previous:  map[string]string{},
+	}
+
+	names := maps.Keys(settings)
+	slices.Sort(names)
+
+	for _, name := range names {
+		value, has := existing[name]
+		switch {
+		case !has:
+			changes.Added = append(changes.Added, name)
+		case value != settings[name]:
+			changes.Changed = append(changes.Changed, name)
+			changes.previous[name] = value
+		default:
+			changes.Unchanged = append(changes.Unchanged, name)
+		}
+	}
+
+	return changes
+}
+
 // NewServiceDeployResult is a helper function to create a new ServiceDeployResult
 func NewServiceDeployResult(
 	relatedResourceId string,
@@ -120,6 +240,11 @@ func (st ServiceTargetKind) SupportsDelayedProvisioning() bool {
 	return st == AksTarget
 }
 
+// RequiresContainer returns true if the service target kind deploys container images.
+func (st ServiceTargetKind) RequiresContainer() bool {
+	return st == ContainerAppTarget || st == AksTarget
+}
+
 func checkResourceType(resource *environment.TargetResource, expectedResourceType infra.AzureResourceType) error {
 	if !strings.EqualFold(resource.ResourceType(), string(expectedResourceType)) {
 		return resourceTypeMismatchError(
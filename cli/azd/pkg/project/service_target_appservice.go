@@ -5,6 +5,7 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -77,6 +78,30 @@ func (st *appServiceTarget) Deploy(
 	serviceConfig *ServiceConfig,
 	packageOutput *ServicePackageResult,
 	targetResource *environment.TargetResource,
+) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
+	return st.deploy(ctx, serviceConfig, packageOutput, targetResource, "", false)
+}
+
+// Deploys the prepared zip archive using Zip deploy to a deployment slot of the Azure App Service resource
+func (st *appServiceTarget) DeploySlot(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	slot string,
+	create bool,
+) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
+	return st.deploy(ctx, serviceConfig, packageOutput, targetResource, slot, create)
+}
+
+// deploy deploys the zip archive to the deployment slot of the web app, or to production when slot is empty
+func (st *appServiceTarget) deploy(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	slot string,
+	create bool,
 ) *async.TaskWithProgress[*ServiceDeployResult, ServiceProgress] {
 	return async.RunTaskWithProgress(
 		func(task *async.TaskContextWithProgress[*ServiceDeployResult, ServiceProgress]) {
@@ -85,6 +110,14 @@ func (st *appServiceTarget) Deploy(
 				return
 			}
 
+			if slot != "" {
+				task.SetProgress(NewServiceProgress("Checking deployment slot"))
+				if err := st.ensureSlot(ctx, targetResource, slot, create); err != nil {
+					task.SetError(err)
+					return
+				}
+			}
+
 			zipFile, err := os.Open(packageOutput.PackagePath)
 			if err != nil {
 				task.SetError(fmt.Errorf("failed reading deployment zip file: %w", err))
@@ -95,42 +128,146 @@ func (st *appServiceTarget) Deploy(
 			defer zipFile.Close()
 
 			task.SetProgress(NewServiceProgress("Uploading deployment package"))
-			res, err := st.cli.DeployAppServiceZip(
-				ctx,
-				targetResource.SubscriptionId(),
-				targetResource.ResourceGroupName(),
-				targetResource.ResourceName(),
-				zipFile,
-			)
+			var res *string
+			if slot == "" {
+				res, err = st.cli.DeployAppServiceZip(
+					ctx,
+					targetResource.SubscriptionId(),
+					targetResource.ResourceGroupName(),
+					targetResource.ResourceName(),
+					zipFile,
+				)
+			} else {
+				res, err = st.cli.DeployAppServiceSlotZip(
+					ctx,
+					targetResource.SubscriptionId(),
+					targetResource.ResourceGroupName(),
+					targetResource.ResourceName(),
+					slot,
+					zipFile,
+				)
+			}
 			if err != nil {
 				task.SetError(fmt.Errorf("deploying service %s: %w", serviceConfig.Name, err))
 				return
 			}
 
 			task.SetProgress(NewServiceProgress("Fetching endpoints for app service"))
-			endpoints, err := st.Endpoints(ctx, serviceConfig, targetResource)
+			var endpoints []string
+			if slot == "" {
+				endpoints, err = st.Endpoints(ctx, serviceConfig, targetResource)
+			} else {
+				endpoints, err = st.slotEndpoints(ctx, targetResource, slot)
+			}
 			if err != nil {
 				task.SetError(err)
 				return
 			}
 
-			sdr := NewServiceDeployResult(
-				azure.WebsiteRID(
+			resourceId := azure.WebsiteRID(
+				targetResource.SubscriptionId(),
+				targetResource.ResourceGroupName(),
+				targetResource.ResourceName(),
+			)
+			if slot != "" {
+				resourceId = azure.WebsiteSlotRID(
 					targetResource.SubscriptionId(),
 					targetResource.ResourceGroupName(),
 					targetResource.ResourceName(),
-				),
-				AppServiceTarget,
-				*res,
-				endpoints,
-			)
+					slot,
+				)
+			}
+
+			sdr := NewServiceDeployResult(resourceId, AppServiceTarget, *res, endpoints)
 			sdr.Package = packageOutput
+			sdr.Slot = slot
 
 			task.SetResult(sdr)
 		},
 	)
 }
 
+// Swaps the deployment slot of the Azure App Service resource into production
+func (st *appServiceTarget) SwapSlot(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	slot string,
+) error {
+	if err := st.validateTargetResource(ctx, serviceConfig, targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	return st.cli.SwapAppServiceSlot(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	)
+}
+
+// ensureSlot returns an error wrapping azcli.ErrAzCliSlotNotFound when the deployment slot doesn't exist, unless create
+// is true, in which case the slot is created.
+func (st *appServiceTarget) ensureSlot(
+	ctx context.Context,
+	targetResource *environment.TargetResource,
+	slot string,
+	create bool,
+) error {
+	_, err := st.cli.GetAppServiceSlotProperties(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, azcli.ErrAzCliSlotNotFound) || !create {
+		return fmt.Errorf("slot '%s' of web app '%s': %w", slot, targetResource.ResourceName(), err)
+	}
+
+	if err := st.cli.CreateAppServiceSlot(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	); err != nil {
+		return fmt.Errorf("slot '%s' of web app '%s': %w", slot, targetResource.ResourceName(), err)
+	}
+
+	return nil
+}
+
+// Gets the exposed endpoints for a deployment slot of the App Service
+func (st *appServiceTarget) slotEndpoints(
+	ctx context.Context,
+	targetResource *environment.TargetResource,
+	slot string,
+) ([]string, error) {
+	slotProperties, err := st.cli.GetAppServiceSlotProperties(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetching deployment slot properties: %w", err)
+	}
+
+	endpoints := make([]string, len(slotProperties.HostNames))
+	for idx, hostName := range slotProperties.HostNames {
+		endpoints[idx] = fmt.Sprintf("https://%s/", hostName)
+	}
+
+	return endpoints, nil
+}
+
 // Gets the exposed endpoints for the App Service
 func (st *appServiceTarget) Endpoints(
 	ctx context.Context,
@@ -155,6 +292,80 @@ func (st *appServiceTarget) Endpoints(
 	return endpoints, nil
 }
 
+// Sets app settings of the web app, keeping its other app settings
+func (st *appServiceTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings map[string]string,
+) (*SettingsChanges, error) {
+	existing, err := st.cli.GetAppServiceAppSettings(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := compareSettings(existing, settings)
+	if !changes.HasChanges() {
+		return changes, nil
+	}
+
+	// App settings are replaced as a whole, so the new settings are merged over the existing ones
+	for name, value := range settings {
+		existing[name] = value
+	}
+
+	if err := st.cli.UpdateAppServiceAppSettings(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		existing,
+	); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// Reverts the app settings applied by ApplySettings, keeping the other app settings of the web app
+func (st *appServiceTarget) RestoreSettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	changes *SettingsChanges,
+) error {
+	existing, err := st.cli.GetAppServiceAppSettings(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range changes.Added {
+		delete(existing, name)
+	}
+
+	for _, name := range changes.Changed {
+		existing[name] = changes.previous[name]
+	}
+
+	return st.cli.UpdateAppServiceAppSettings(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		existing,
+	)
+}
+
 func (st *appServiceTarget) validateTargetResource(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
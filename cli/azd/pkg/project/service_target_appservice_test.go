@@ -5,12 +5,22 @@ package project
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/convert"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,3 +67,176 @@ func TestNewAppServiceTargetTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAppServiceTargetApplySettings(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	settingsPath := "/subscriptions/SUB_ID/resourceGroups/RG_ID/providers/Microsoft.Web/sites/res/config/appsettings"
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && request.URL.Path == settingsPath+"/list"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.StringDictionary{
+			Properties: map[string]*string{
+				"KEEP": convert.RefOf("kept"),
+				"MODE": convert.RefOf("debug"),
+				"SAME": convert.RefOf("same"),
+			},
+		})
+	})
+
+	var updated armappservice.StringDictionary
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPut && request.URL.Path == settingsPath
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&updated))
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, updated)
+	})
+
+	serviceTarget := NewAppServiceTarget(environment.New("test"), mockazcli.NewAzCliFromMockContext(mockContext))
+	targetResource := environment.NewTargetResource("SUB_ID", "RG_ID", "res", string(infra.AzureResourceTypeWebSite))
+
+	changes, err := serviceTarget.(SettingsServiceTarget).ApplySettings(
+		*mockContext.Context,
+		&ServiceConfig{},
+		targetResource,
+		map[string]string{"MODE": "release", "NEW": "added", "SAME": "same"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, &SettingsChanges{
+		Added:     []string{"NEW"},
+		Changed:   []string{"MODE"},
+		Unchanged: []string{"SAME"},
+		previous:  map[string]string{"MODE": "debug"},
+	}, changes)
+
+	// Existing settings that are not applied are kept
+	require.Equal(t, map[string]*string{
+		"KEEP": convert.RefOf("kept"),
+		"MODE": convert.RefOf("release"),
+		"NEW":  convert.RefOf("added"),
+		"SAME": convert.RefOf("same"),
+	}, updated.Properties)
+
+	// Restoring removes the added settings and reverts the changed ones
+	updated = armappservice.StringDictionary{}
+	err = serviceTarget.(SettingsServiceTarget).RestoreSettings(*mockContext.Context, &ServiceConfig{}, targetResource, changes)
+	require.NoError(t, err)
+	require.Equal(t, map[string]*string{
+		"KEEP": convert.RefOf("kept"),
+		"MODE": convert.RefOf("debug"),
+		"SAME": convert.RefOf("same"),
+	}, updated.Properties)
+}
+
+func TestAppServiceTargetDeploySlot(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	sitePath := "/subscriptions/SUB_ID/resourceGroups/RG_ID/providers/Microsoft.Web/sites/res"
+
+	slotCreated := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == sitePath+"/slots/staging"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		if !slotCreated {
+			return mocks.CreateEmptyHttpResponse(request, http.StatusNotFound)
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.Site{
+			Properties: &armappservice.SiteProperties{
+				DefaultHostName: convert.RefOf("res-staging.azurewebsites.net"),
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && request.URL.Path == sitePath
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappservice.Site{
+			Location: convert.RefOf("eastus2"),
+			Properties: &armappservice.SiteProperties{
+				ServerFarmID: convert.RefOf("PLAN_ID"),
+			},
+		})
+	})
+
+	var createdSlot armappservice.Site
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPut && request.URL.Path == sitePath+"/slots/staging"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&createdSlot))
+		slotCreated = true
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, createdSlot)
+	})
+
+	var deployHost string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.Contains(request.URL.Path, "/api/zipdeploy")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		deployHost = request.URL.Host
+		response, _ := mocks.CreateEmptyHttpResponse(request, http.StatusAccepted)
+		response.Header.Set("Location", "https://res-staging.scm.azurewebsites.net/deployments/latest")
+		return response, nil
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.Contains(request.URL.Path, "/deployments/latest")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, azsdk.DeployStatusResponse{
+			DeployStatus: azsdk.DeployStatus{
+				Status:     http.StatusOK,
+				StatusText: "OK",
+				Complete:   true,
+			},
+		})
+	})
+
+	packagePath := filepath.Join(t.TempDir(), "package.zip")
+	require.NoError(t, os.WriteFile(packagePath, []byte{}, osutil.PermissionFile))
+
+	serviceTarget := NewAppServiceTarget(environment.New("test"), mockazcli.NewAzCliFromMockContext(mockContext))
+	targetResource := environment.NewTargetResource("SUB_ID", "RG_ID", "res", string(infra.AzureResourceTypeWebSite))
+	serviceConfig := &ServiceConfig{Name: "web"}
+
+	// The slot is not created without create
+	deployTask := serviceTarget.(SlotServiceTarget).DeploySlot(
+		*mockContext.Context, serviceConfig, &ServicePackageResult{PackagePath: packagePath}, targetResource, "staging", false)
+	logProgress(deployTask)
+	_, err := deployTask.Await()
+	require.ErrorIs(t, err, azcli.ErrAzCliSlotNotFound)
+	require.False(t, slotCreated)
+
+	deployTask = serviceTarget.(SlotServiceTarget).DeploySlot(
+		*mockContext.Context, serviceConfig, &ServicePackageResult{PackagePath: packagePath}, targetResource, "staging", true)
+	logProgress(deployTask)
+	result, err := deployTask.Await()
+	require.NoError(t, err)
+
+	require.True(t, slotCreated)
+	require.Equal(t, "eastus2", *createdSlot.Location)
+	require.Equal(t, "PLAN_ID", *createdSlot.Properties.ServerFarmID)
+	require.Equal(t, "res-staging.scm.azurewebsites.net", deployHost)
+
+	require.Equal(t, "staging", result.Slot)
+	require.Equal(t, []string{"https://res-staging.azurewebsites.net/"}, result.Endpoints)
+	require.Equal(t, sitePath+"/slots/staging", result.TargetResourceId)
+}
+
+func TestAppServiceTargetSwapSlot(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	swapPath := "/subscriptions/SUB_ID/resourceGroups/RG_ID/providers/Microsoft.Web/sites/res/slots/staging/slotsswap"
+
+	var swap armappservice.CsmSlotEntity
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && request.URL.Path == swapPath
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&swap))
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	serviceTarget := NewAppServiceTarget(environment.New("test"), mockazcli.NewAzCliFromMockContext(mockContext))
+	targetResource := environment.NewTargetResource("SUB_ID", "RG_ID", "res", string(infra.AzureResourceTypeWebSite))
+
+	err := serviceTarget.(SlotServiceTarget).SwapSlot(*mockContext.Context, &ServiceConfig{}, targetResource, "staging")
+	require.NoError(t, err)
+	require.Equal(t, "production", *swap.TargetSlot)
+	require.True(t, *swap.PreserveVnet)
+}
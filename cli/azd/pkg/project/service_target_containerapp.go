@@ -153,6 +153,105 @@ func (at *containerAppTarget) Endpoints(
 	}
 }
 
+// Gets the name of the latest ready revision of the container app
+func (at *containerAppTarget) CurrentRevision(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (string, error) {
+	return at.containerAppService.GetLatestReadyRevisionName(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+}
+
+// Reverts the container app to serve traffic from the specified revision
+func (at *containerAppTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	revision string,
+) error {
+	return at.containerAppService.RollbackRevision(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		revision,
+	)
+}
+
+// Deactivates the revisions created by azd for the container app, except for the `keep` most recent ones
+func (at *containerAppTarget) PruneRevisions(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	keep int,
+) ([]string, error) {
+	return at.containerAppService.PruneRevisions(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		keep,
+	)
+}
+
+// Sets environment variables of the container app, which adds a new revision when any variable is added or changed
+func (at *containerAppTarget) ApplySettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	settings map[string]string,
+) (*SettingsChanges, error) {
+	existing, err := at.containerAppService.GetEnvironmentVariables(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := compareSettings(existing, settings)
+	if !changes.HasChanges() {
+		return changes, nil
+	}
+
+	if err := at.containerAppService.SetEnvironmentVariables(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		settings,
+		nil,
+	); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// Reverts the environment variables set by ApplySettings, which adds a new revision
+func (at *containerAppTarget) RestoreSettings(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	changes *SettingsChanges,
+) error {
+	return at.containerAppService.SetEnvironmentVariables(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		changes.previous,
+		changes.Added,
+	)
+}
+
 func (at *containerAppTarget) validateTargetResource(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
@@ -24,6 +24,8 @@ type Prompter interface {
 	PromptSubscription(ctx context.Context, msg string) (subscriptionId string, err error)
 	PromptLocation(ctx context.Context, subId string, msg string, filter LocationFilterPredicate) (string, error)
 	PromptResourceGroup(ctx context.Context) (string, error)
+	// DefaultLocation returns the default location from azd config, which PromptLocation selects without prompting.
+	DefaultLocation(ctx context.Context, subId string, filter LocationFilterPredicate) (string, bool)
 }
 
 type DefaultPrompter struct {
@@ -91,6 +93,10 @@ func (p *DefaultPrompter) PromptLocation(
 	msg string,
 	filter LocationFilterPredicate,
 ) (string, error) {
+	if loc, has := p.DefaultLocation(ctx, subId, filter); has {
+		return loc, nil
+	}
+
 	loc, err := azureutil.PromptLocationWithFilter(ctx, subId, msg, "", p.console, p.accountManager, filter)
 	if err != nil {
 		return "", err
@@ -105,6 +111,32 @@ func (p *DefaultPrompter) PromptLocation(
 	return loc, nil
 }
 
+// DefaultLocation returns the location set in azd config (defaults.location) when it is valid for the subscription
+// and allowed by the filter. The AZURE_LOCATION environment variable, when set, takes precedence and only pre-fills the
+// location prompt.
+func (p *DefaultPrompter) DefaultLocation(
+	ctx context.Context,
+	subId string,
+	filter LocationFilterPredicate,
+) (string, bool) {
+	if !p.accountManager.HasDefaultLocation() || os.Getenv(environment.LocationEnvVarName) != "" {
+		return "", false
+	}
+
+	loc, err := p.accountManager.GetDefaultLocation(ctx, subId)
+	if err != nil {
+		log.Printf("ignoring default location. %s\n", err.Error())
+		return "", false
+	}
+
+	if filter != nil && !filter(*loc) {
+		log.Printf("default location '%s' is not allowed, prompting for location\n", loc.Name)
+		return "", false
+	}
+
+	return loc.Name, true
+}
+
 func (p *DefaultPrompter) PromptResourceGroup(ctx context.Context) (string, error) {
 	// Get current resource groups
 	groups, err := p.azCli.ListResourceGroup(ctx, p.env.GetSubscriptionId(), nil)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
@@ -79,3 +80,51 @@ func Test_getSubscriptionOptions(t *testing.T) {
 		require.EqualValues(t, " 1. DISPLAY DEFAULT (SUBSCRIPTION_DEFAULT)", defSub)
 	})
 }
+
+func Test_PromptLocation(t *testing.T) {
+	locations := []account.Location{
+		{Name: "eastus2", DisplayName: "East US 2", RegionalDisplayName: "(US) East US 2"},
+		{Name: "westus", DisplayName: "West US", RegionalDisplayName: "(US) West US"},
+	}
+
+	t.Run("default location skips prompt", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azCli := mockazcli.NewAzCliFromMockContext(mockContext)
+		mockAccount := &mockaccount.MockAccountManager{
+			DefaultLocation: "westus",
+			Locations:       locations,
+		}
+
+		prompter := NewDefaultPrompter(environment.New("test"), mockContext.Console, mockAccount, azCli)
+		location, err := prompter.PromptLocation(*mockContext.Context, "SUBSCRIPTION_ID", "Select a location", nil)
+
+		require.NoError(t, err)
+		require.Equal(t, "westus", location)
+	})
+
+	t.Run("filtered default location prompts", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.Console.WhenSelect(func(options input.ConsoleOptions) bool {
+			return options.Message == "Select a location"
+		}).Respond(0)
+
+		azCli := mockazcli.NewAzCliFromMockContext(mockContext)
+		mockAccount := &mockaccount.MockAccountManager{
+			DefaultLocation: "westus",
+			Locations:       locations,
+		}
+
+		prompter := NewDefaultPrompter(environment.New("test"), mockContext.Console, mockAccount, azCli)
+		location, err := prompter.PromptLocation(
+			*mockContext.Context,
+			"SUBSCRIPTION_ID",
+			"Select a location",
+			func(loc account.Location) bool {
+				return loc.Name != "westus"
+			},
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, "eastus2", location)
+	})
+}
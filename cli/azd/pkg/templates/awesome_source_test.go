@@ -14,11 +14,13 @@ var testAwesomeAzdTemplates []*awesomeAzdTemplate = []*awesomeAzdTemplate{
 		Title:       "template1",
 		Description: "Description of template 1",
 		Source:      "http://github.com/user/template1",
+		Tags:        []string{"bicep", "nodejs"},
 	},
 	{
 		Title:       "template2",
 		Description: "Description of template 2",
 		Source:      "htdtp://github.com/user/template2",
+		Tags:        []string{"bicep", "python"},
 	},
 }
 
@@ -0,0 +1,147 @@
+package templates
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"gopkg.in/yaml.v3"
+)
+
+// remoteTemplateCacheTTL is how long template metadata fetched from a git repository is reused before fetching again.
+const remoteTemplateCacheTTL = 15 * time.Minute
+
+// remoteTemplateMetadata is the subset of azure.yaml used to describe a template that isn't in any configured source.
+type remoteTemplateMetadata struct {
+	Name     string `yaml:"name"`
+	Metadata struct {
+		Template string `yaml:"template"`
+	} `yaml:"metadata"`
+}
+
+// IsRemoteTemplatePath returns true when path is a fully-qualified URI to a git repository, rather than the name of a
+// template in a configured source.
+func IsRemoteTemplatePath(path string) bool {
+	return strings.HasPrefix(path, "https://") ||
+		strings.HasPrefix(path, "http://") ||
+		strings.HasPrefix(path, "git@") ||
+		strings.HasPrefix(path, "git://")
+}
+
+// FetchTemplate returns a template for the git repository at repositoryUrl, built from the azure.yaml at the root of the
+// repository. The repository is shallow cloned into a temporary directory that is removed before returning.
+// Fetched templates are cached in the azd config directory for a short time.
+func FetchTemplate(ctx context.Context, gitCli git.GitCli, repositoryUrl string) (*Template, error) {
+	cachePath, err := remoteTemplateCachePath(repositoryUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	if template, ok := readCachedTemplate(cachePath); ok {
+		return template, nil
+	}
+
+	cloneDir, err := os.MkdirTemp("", "az-dev-template")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp folder: %w", err)
+	}
+	defer os.RemoveAll(cloneDir)
+
+	if err := gitCli.ShallowClone(ctx, repositoryUrl, "", cloneDir); err != nil {
+		return nil, fmt.Errorf("fetching template '%s': %w", repositoryUrl, err)
+	}
+
+	projectBytes, err := os.ReadFile(filepath.Join(cloneDir, "azure.yaml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("template '%s' does not contain an azure.yaml file: %w", repositoryUrl, ErrTemplateNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading template metadata: %w", err)
+	}
+
+	var metadata remoteTemplateMetadata
+	if err := yaml.Unmarshal(projectBytes, &metadata); err != nil {
+		return nil, fmt.Errorf("parsing template metadata: %w", err)
+	}
+
+	template := &Template{
+		Name:           metadata.Name,
+		RepositoryPath: repositoryUrl,
+	}
+
+	// metadata.template is recorded as '<template>@<version>' by template authors
+	if name, version, _ := strings.Cut(metadata.Metadata.Template, "@"); name != "" {
+		template.Name = name
+		if version != "" {
+			template.Description = fmt.Sprintf("Version %s", version)
+		}
+	}
+
+	if template.Name == "" {
+		template.Name = strings.TrimSuffix(filepath.Base(strings.TrimRight(repositoryUrl, "/")), ".git")
+	}
+
+	writeCachedTemplate(cachePath, template)
+
+	return template, nil
+}
+
+// remoteTemplateCachePath returns the path of the cache file for the template at repositoryUrl.
+func remoteTemplateCachePath(repositoryUrl string) (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(repositoryUrl))
+	return filepath.Join(configDir, "templates", "cache", hex.EncodeToString(hash[:])+".json"), nil
+}
+
+func readCachedTemplate(cachePath string) (*Template, bool) {
+	info, err := os.Stat(cachePath)
+	if err != nil || time.Since(info.ModTime()) > remoteTemplateCacheTTL {
+		return nil, false
+	}
+
+	contents, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+
+	var template Template
+	if err := json.Unmarshal(contents, &template); err != nil {
+		log.Printf("ignoring invalid template cache file '%s': %v", cachePath, err)
+		return nil, false
+	}
+
+	return &template, true
+}
+
+// writeCachedTemplate caches the template. Failures are logged and otherwise ignored, the template is fetched again next
+// time.
+func writeCachedTemplate(cachePath string, template *Template) {
+	contents, err := json.Marshal(template)
+	if err != nil {
+		log.Printf("failed to marshal template for cache: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), osutil.PermissionDirectory); err != nil {
+		log.Printf("failed to create template cache directory: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(cachePath, contents, osutil.PermissionFile); err != nil {
+		log.Printf("failed to write template cache file: %v", err)
+	}
+}
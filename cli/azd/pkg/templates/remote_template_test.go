@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsRemoteTemplatePath(t *testing.T) {
+	require.True(t, IsRemoteTemplatePath("https://github.com/Azure-Samples/todo-nodejs-mongo"))
+	require.True(t, IsRemoteTemplatePath("git@github.com:Azure-Samples/todo-nodejs-mongo.git"))
+	require.False(t, IsRemoteTemplatePath("todo-nodejs-mongo"))
+	require.False(t, IsRemoteTemplatePath("Azure-Samples/todo-nodejs-mongo"))
+}
+
+func Test_FetchTemplate(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	const repositoryUrl = "https://github.com/contoso/todo-app"
+
+	mockContext := mocks.NewMockContext(context.Background())
+	clones := 0
+	var cloneDir string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "git clone")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		clones++
+		cloneDir = args.Args[len(args.Args)-1]
+		err := os.WriteFile(
+			filepath.Join(cloneDir, "azure.yaml"),
+			[]byte("name: todo-app\nmetadata:\n  template: todo-app-template@0.0.1-beta\n"),
+			osutil.PermissionFile)
+		return exec.NewRunResult(0, "", ""), err
+	})
+
+	gitCli := git.NewGitCli(mockContext.CommandRunner)
+
+	template, err := FetchTemplate(*mockContext.Context, gitCli, repositoryUrl)
+	require.NoError(t, err)
+	require.Equal(t, &Template{
+		Name:           "todo-app-template",
+		Description:    "Version 0.0.1-beta",
+		RepositoryPath: repositoryUrl,
+	}, template)
+
+	// The temporary clone is removed
+	require.NoDirExists(t, cloneDir)
+
+	// The second fetch is served from the cache
+	cached, err := FetchTemplate(*mockContext.Context, gitCli, repositoryUrl)
+	require.NoError(t, err)
+	require.Equal(t, template, cached)
+	require.Equal(t, 1, clones)
+}
+
+func Test_FetchTemplate_NoProjectFile(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "git clone")
+	}).Respond(exec.NewRunResult(0, "", ""))
+
+	_, err := FetchTemplate(
+		*mockContext.Context, git.NewGitCli(mockContext.CommandRunner), "https://github.com/contoso/empty")
+	require.ErrorIs(t, err, ErrTemplateNotFound)
+}
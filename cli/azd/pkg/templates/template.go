@@ -22,6 +22,12 @@ type Template struct {
 	// "{owner}/{repo}" for GitHub repositories,
 	// or "{repo}" for GitHub repositories under Azure-Samples (default organization).
 	RepositoryPath string `json:"repositoryPath"`
+
+	// RepositoryUrl is the URL of the repository at RepositoryPath, set when the template is listed.
+	RepositoryUrl string `json:"repositoryUrl,omitempty"`
+
+	// Tags are optional labels used to categorize and filter templates.
+	Tags []string `json:"tags,omitempty"`
 }
 
 // Display writes a string representation of the template suitable for display.
@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// templateCacheTTL is how long the cached templates of a remote source are used before they are fetched again.
+const templateCacheTTL = 24 * time.Hour
+
+var (
+	ErrTemplateCacheEmpty = errors.New("no cached templates")
+)
+
+// templateCacheEntry is the content of the cache file of a template source.
+type templateCacheEntry struct {
+	// Location is the location of the source when the templates were fetched. A source that was reconfigured with a
+	// different location does not use the cached templates.
+	Location  string      `json:"location"`
+	FetchedAt time.Time   `json:"fetchedAt"`
+	Templates []*Template `json:"templates"`
+}
+
+// isCachedSource returns true for sources whose templates are fetched over the network, which are cached locally.
+func isCachedSource(config *SourceConfig) bool {
+	return config.Type == SourceKindUrl || config.Type == SourceKindAwesomeAzd
+}
+
+func templateCachePath(key string) (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "templates", fmt.Sprintf("%s.json", filepath.Base(normalizeKey(key)))), nil
+}
+
+// loadCachedTemplates returns the cached templates of the source, and whether they were fetched within the TTL. A nil
+// slice is returned when the source has no cached templates.
+func loadCachedTemplates(config *SourceConfig, now time.Time) ([]*Template, bool, error) {
+	cachePath, err := templateCachePath(config.Key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	contents, err := os.ReadFile(cachePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("reading template cache: %w", err)
+	}
+
+	var entry templateCacheEntry
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		return nil, false, fmt.Errorf("parsing template cache '%s': %w", cachePath, err)
+	}
+
+	if entry.Location != config.Location || entry.Templates == nil {
+		return nil, false, nil
+	}
+
+	return entry.Templates, now.Sub(entry.FetchedAt) < templateCacheTTL, nil
+}
+
+// saveCachedTemplates writes the templates fetched from the source to its cache file.
+func saveCachedTemplates(config *SourceConfig, templates []*Template, now time.Time) error {
+	cachePath, err := templateCachePath(config.Key)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(templateCacheEntry{
+		Location:  config.Location,
+		FetchedAt: now,
+		Templates: templates,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), osutil.PermissionDirectoryOwnerOnly); err != nil {
+		return fmt.Errorf("creating template cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(cachePath, contents, osutil.PermissionFileOwnerOnly); err != nil {
+		return fmt.Errorf("writing template cache: %w", err)
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package templates
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -17,43 +19,71 @@ var (
 
 type TemplateManager struct {
 	sourceManager SourceManager
-	sources       []Source
+	sources       map[string]Source
+	now           func() time.Time
 }
 
 func NewTemplateManager(sourceManager SourceManager) (*TemplateManager, error) {
 	return &TemplateManager{
 		sourceManager: sourceManager,
+		sources:       map[string]Source{},
+		now:           time.Now,
 	}, nil
 }
 
 type ListOptions struct {
 	Source string
+	// Tags filters templates to the ones that contain all of the specified tags.
+	Tags []string
+	// Refresh fetches the templates of remote sources again, even when the cached templates have not expired.
+	Refresh bool
+	// Offline only uses the cached templates of remote sources, even when they have expired.
+	Offline bool
 }
 
 type sourceFilterPredicate func(config *SourceConfig) bool
 
 // ListTemplates retrieves the list of templates in a deterministic order.
+// The templates of remote sources are cached locally and fetched again once the cache expires.
 func (tm *TemplateManager) ListTemplates(ctx context.Context, options *ListOptions) ([]*Template, error) {
+	if options == nil {
+		options = &ListOptions{}
+	}
+
+	if options.Refresh && options.Offline {
+		return nil, errors.New("templates cannot be refreshed in offline mode")
+	}
+
 	allTemplates := []*Template{}
 
 	var filterPredicate sourceFilterPredicate
-	if options != nil && options.Source != "" {
+	if options.Source != "" {
 		filterPredicate = func(config *SourceConfig) bool {
 			return strings.EqualFold(config.Key, options.Source)
 		}
 	}
 
-	sources, err := tm.getSources(ctx, filterPredicate)
+	configs, err := tm.sourceManager.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed listing templates: %w", err)
+		return nil, fmt.Errorf("failed listing templates: failed parsing template sources: %w", err)
 	}
 
-	for _, source := range sources {
-		templates, err := source.ListTemplates(ctx)
+	for _, config := range configs {
+		if filterPredicate != nil && !filterPredicate(config) {
+			continue
+		}
+
+		templates, err := tm.listSourceTemplates(ctx, config, options)
 		if err != nil {
 			return nil, fmt.Errorf("unable to list templates: %w", err)
 		}
 
+		setProvenance(templates, config)
+
+		if len(options.Tags) > 0 {
+			templates = filterTemplatesByTags(templates, options.Tags)
+		}
+
 		// Sort by source, then repository path and finally name
 		slices.SortFunc(templates, func(a *Template, b *Template) bool {
 			if a.Source != b.Source {
@@ -73,76 +103,135 @@ func (tm *TemplateManager) ListTemplates(ctx context.Context, options *ListOptio
 	return allTemplates, nil
 }
 
-func (tm *TemplateManager) GetTemplate(ctx context.Context, path string) (*Template, error) {
-	absTemplatePath, err := Absolute(path)
-	if err != nil {
-		return nil, err
+// setProvenance records the source each template was listed from and the URL of its repository, so the templates of
+// different sources can be told apart. Sources without a name are identified by their key.
+func setProvenance(templates []*Template, config *SourceConfig) {
+	source := config.Name
+	if source == "" {
+		source = config.Key
 	}
 
-	allTemplates, err := tm.ListTemplates(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed listing templates: %w", err)
+	for _, template := range templates {
+		template.Source = source
+
+		if url, err := Absolute(template.RepositoryPath); err == nil {
+			template.RepositoryUrl = url
+		} else {
+			log.Printf("template '%s' of source '%s' has no repository URL: %v", template.Name, source, err)
+		}
 	}
+}
 
-	matchingIndex := slices.IndexFunc(allTemplates, func(template *Template) bool {
-		absPath, err := Absolute(template.RepositoryPath)
+// listSourceTemplates lists the templates of a single source. The templates of remote sources are read from the local
+// cache while it has not expired, and the cache is updated whenever they are fetched. Sources that can't be created are
+// skipped.
+func (tm *TemplateManager) listSourceTemplates(
+	ctx context.Context,
+	config *SourceConfig,
+	options *ListOptions,
+) ([]*Template, error) {
+	cached := isCachedSource(config)
+
+	if cached && !options.Refresh {
+		templates, fresh, err := loadCachedTemplates(config, tm.now())
 		if err != nil {
-			log.Printf("failed to get absolute path for template '%s': %s", template.RepositoryPath, err.Error())
-			return false
+			log.Printf("ignoring template cache of source '%s': %v", config.Key, err)
+		} else if templates != nil && (fresh || options.Offline) {
+			return templates, nil
 		}
+	}
 
-		return absPath == absTemplatePath
-	})
+	if cached && options.Offline {
+		return nil, fmt.Errorf(
+			"%w for template source '%s', run 'azd template list --refresh' while online to populate the cache",
+			ErrTemplateCacheEmpty,
+			config.Key,
+		)
+	}
 
-	if matchingIndex == -1 {
-		return nil, fmt.Errorf("template with name '%s' was not found, %w", path, ErrTemplateNotFound)
+	source, err := tm.source(ctx, config)
+	if err != nil {
+		log.Printf("failed to create source: %s", err.Error())
+		return []*Template{}, nil
 	}
 
-	return allTemplates[matchingIndex], nil
-}
+	templates, err := source.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-func (tm *TemplateManager) getSources(ctx context.Context, filter sourceFilterPredicate) ([]Source, error) {
-	if tm.sources != nil {
-		return tm.sources, nil
+	if cached {
+		if err := saveCachedTemplates(config, templates, tm.now()); err != nil {
+			log.Printf("failed caching templates of source '%s': %v", config.Key, err)
+		}
 	}
 
-	configs, err := tm.sourceManager.List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed parsing template sources: %w", err)
+	return templates, nil
+}
+
+// source returns the source for the configuration, creating it on first use.
+func (tm *TemplateManager) source(ctx context.Context, config *SourceConfig) (Source, error) {
+	if source, has := tm.sources[config.Key]; has {
+		return source, nil
 	}
 
-	sources, err := tm.createSourcesFromConfig(ctx, configs, filter)
+	source, err := tm.sourceManager.CreateSource(ctx, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed initializing template sources: %w", err)
+		return nil, err
 	}
 
-	tm.sources = sources
-
-	return tm.sources, nil
+	tm.sources[config.Key] = source
+	return source, nil
 }
 
-func (tm *TemplateManager) createSourcesFromConfig(
-	ctx context.Context,
-	configs []*SourceConfig,
-	filter sourceFilterPredicate,
-) ([]Source, error) {
-	sources := []Source{}
+// filterTemplatesByTags returns the templates that contain all of the specified tags (case-insensitive).
+func filterTemplatesByTags(templates []*Template, tags []string) []*Template {
+	filtered := []*Template{}
+	for _, template := range templates {
+		hasAllTags := true
+		for _, tag := range tags {
+			if !slices.ContainsFunc(template.Tags, func(templateTag string) bool {
+				return strings.EqualFold(templateTag, tag)
+			}) {
+				hasAllTags = false
+				break
+			}
+		}
 
-	for _, config := range configs {
-		if filter != nil && !filter(config) {
-			continue
+		if hasAllTags {
+			filtered = append(filtered, template)
 		}
+	}
+
+	return filtered
+}
+
+func (tm *TemplateManager) GetTemplate(ctx context.Context, path string) (*Template, error) {
+	absTemplatePath, err := Absolute(path)
+	if err != nil {
+		return nil, err
+	}
+
+	allTemplates, err := tm.ListTemplates(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing templates: %w", err)
+	}
 
-		source, err := tm.sourceManager.CreateSource(ctx, config)
+	matchingIndex := slices.IndexFunc(allTemplates, func(template *Template) bool {
+		absPath, err := Absolute(template.RepositoryPath)
 		if err != nil {
-			log.Printf("failed to create source: %s", err.Error())
-			continue
+			log.Printf("failed to get absolute path for template '%s': %s", template.RepositoryPath, err.Error())
+			return false
 		}
 
-		sources = append(sources, source)
+		return absPath == absTemplatePath
+	})
+
+	if matchingIndex == -1 {
+		return nil, fmt.Errorf("template with name '%s' was not found, %w", path, ErrTemplateNotFound)
 	}
 
-	return sources, nil
+	return allTemplates[matchingIndex], nil
 }
 
 // PromptTemplate asks the user to select a template.
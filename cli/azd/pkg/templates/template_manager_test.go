@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/resources"
@@ -53,7 +54,35 @@ func Test_Templates_ListTemplates(t *testing.T) {
 	require.NotEmpty(t, storedTemplates)
 }
 
+func Test_Templates_ListTemplates_FilterByTags(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	mockContext := mocks.NewMockContext(context.Background())
+	mockAwesomeAzdTemplateSource(mockContext)
+
+	configManager := &mockUserConfigManager{}
+	configManager.On("Load").Return(config.NewConfig(map[string]interface{}{
+		"template": map[string]interface{}{
+			"sources": map[string]interface{}{
+				"awesome-azd": map[string]interface{}{},
+			},
+		},
+	}), nil)
+
+	templateManager, err := NewTemplateManager(NewSourceManager(configManager, mockContext.HttpClient))
+	require.NoError(t, err)
+
+	templates, err := templateManager.ListTemplates(*mockContext.Context, &ListOptions{Tags: []string{"Bicep", "nodejs"}})
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, "template1", templates[0].Name)
+
+	templates, err = templateManager.ListTemplates(*mockContext.Context, &ListOptions{Tags: []string{"terraform"}})
+	require.NoError(t, err)
+	require.Empty(t, templates)
+}
+
 func Test_Templates_ListTemplates_SourceError(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
 	mockContext := mocks.NewMockContext(context.Background())
 
 	invalidUrl := "https://www.example.com/invalid.json"
@@ -86,6 +115,61 @@ func Test_Templates_ListTemplates_SourceError(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func Test_Templates_ListTemplates_Provenance(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	mockContext := mocks.NewMockContext(context.Background())
+	mockAwesomeAzdTemplateSource(mockContext)
+
+	sourceUrl := "https://www.example.com/templates.json"
+	mockContext.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && req.URL.String() == sourceUrl
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, []*Template{
+			{Name: "internal-api", RepositoryPath: "contoso/internal-api"},
+		})
+	})
+
+	configManager := &mockUserConfigManager{}
+	userConfig := config.NewConfig(nil)
+	_ = userConfig.Set(baseConfigKey, map[string]interface{}{
+		"awesome-azd": map[string]interface{}{},
+		// A custom source without a name is identified by its key
+		"contoso": map[string]interface{}{
+			"type":     "url",
+			"location": sourceUrl,
+		},
+	})
+	configManager.On("Load").Return(userConfig, nil)
+
+	templateManager, err := NewTemplateManager(NewSourceManager(configManager, mockContext.HttpClient))
+	require.NoError(t, err)
+
+	templates, err := templateManager.ListTemplates(*mockContext.Context, nil)
+	require.NoError(t, err)
+
+	byName := map[string]*Template{}
+	for _, template := range templates {
+		byName[template.Name] = template
+	}
+
+	require.Contains(t, byName, "template1")
+	require.Equal(t, SourceAwesomeAzd.Name, byName["template1"].Source)
+	require.Equal(t, "http://github.com/user/template1", byName["template1"].RepositoryUrl)
+
+	require.Contains(t, byName, "internal-api")
+	require.Equal(t, "contoso", byName["internal-api"].Source)
+	require.Equal(t, "https://github.com/contoso/internal-api", byName["internal-api"].RepositoryUrl)
+
+	contents, err := json.Marshal(byName["internal-api"])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"name": "internal-api",
+		"source": "contoso",
+		"repositoryPath": "contoso/internal-api",
+		"repositoryUrl": "https://github.com/contoso/internal-api"
+	}`, string(contents))
+}
+
 func Test_Templates_GetTemplate_WithValidPath(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	configManager := &mockUserConfigManager{}
@@ -135,3 +219,109 @@ func Test_Templates_GetTemplate_WithNotFoundPath(t *testing.T) {
 	require.ErrorIs(t, err, ErrTemplateNotFound)
 	require.Nil(t, template)
 }
+
+func Test_Templates_ListTemplates_Cache(t *testing.T) {
+	sourceUrl := "https://www.example.com/templates.json"
+
+	configManager := &mockUserConfigManager{}
+	userConfig := config.NewConfig(nil)
+	_ = userConfig.Set(baseConfigKey, map[string]interface{}{
+		"remote": map[string]interface{}{
+			"type":     "url",
+			"name":     "remote",
+			"location": sourceUrl,
+		},
+	})
+	configManager.On("Load").Return(userConfig, nil)
+
+	setup := func(t *testing.T) (*mocks.MockContext, *int) {
+		requests := 0
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.HttpClient.When(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && req.URL.String() == sourceUrl
+		}).RespondFn(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return mocks.CreateHttpResponseWithBody(req, http.StatusOK, []*Template{
+				{Name: "template1", RepositoryPath: "owner/template1"},
+			})
+		})
+
+		return mockContext, &requests
+	}
+
+	listTemplates := func(
+		mockContext *mocks.MockContext,
+		now time.Time,
+		options *ListOptions,
+	) ([]*Template, error) {
+		templateManager, err := NewTemplateManager(NewSourceManager(configManager, mockContext.HttpClient))
+		require.NoError(t, err)
+		templateManager.now = func() time.Time { return now }
+
+		return templateManager.ListTemplates(*mockContext.Context, options)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Hit", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		mockContext, requests := setup(t)
+
+		templates, err := listTemplates(mockContext, start, nil)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, 1, *requests)
+
+		// A new manager, like the next azd invocation, reads the templates from the cache
+		templates, err = listTemplates(mockContext, start.Add(time.Hour), nil)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, "remote", templates[0].Source)
+		require.Equal(t, 1, *requests)
+	})
+
+	t.Run("Miss", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		mockContext, requests := setup(t)
+
+		_, err := listTemplates(mockContext, start, nil)
+		require.NoError(t, err)
+
+		// Expired templates are fetched again
+		templates, err := listTemplates(mockContext, start.Add(templateCacheTTL), nil)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, 2, *requests)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		mockContext, requests := setup(t)
+
+		_, err := listTemplates(mockContext, start, nil)
+		require.NoError(t, err)
+
+		templates, err := listTemplates(mockContext, start, &ListOptions{Refresh: true})
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, 2, *requests)
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		mockContext, requests := setup(t)
+
+		_, err := listTemplates(mockContext, start, &ListOptions{Offline: true})
+		require.ErrorIs(t, err, ErrTemplateCacheEmpty)
+		require.Equal(t, 0, *requests)
+
+		_, err = listTemplates(mockContext, start, nil)
+		require.NoError(t, err)
+
+		// Offline mode uses the cache even when it expired
+		templates, err := listTemplates(mockContext, start.Add(2*templateCacheTTL), &ListOptions{Offline: true})
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, 1, *requests)
+	})
+}
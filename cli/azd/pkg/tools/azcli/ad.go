@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -29,6 +31,9 @@ type AzureCredentials struct {
 	ResourceManagerEndpointUrl string `json:"resourceManagerEndpointUrl"`
 }
 
+// ErrApplicationNotFound is returned when no application matches the specified ID or name.
+var ErrApplicationNotFound = errors.New("application not found")
+
 type ErrorWithSuggestion struct {
 	Suggestion string
 	Err        error
@@ -50,7 +55,7 @@ type AdService interface {
 		subscriptionId string,
 		applicationIdOrName string,
 		rolesToAssign []string,
-	) (*string, json.RawMessage, error)
+	) (*string, json.RawMessage, []string, error)
 }
 
 type adService struct {
@@ -85,61 +90,88 @@ func (ad *adService) GetServicePrincipal(
 	var application *graphsdk.Application
 
 	// Attempt to find existing application by ID
-	application, _ = ad.getApplicationByAppId(ctx, graphClient, appIdOrName)
+	application, err = ad.getApplicationByAppId(ctx, graphClient, appIdOrName)
+	if isForbiddenError(err) {
+		return nil, applicationAccessError(appIdOrName, err)
+	}
 
 	// Fallback to find by name
 	if application == nil {
-		application, _ = getApplicationByName(ctx, graphClient, appIdOrName)
+		application, err = getApplicationByName(ctx, graphClient, appIdOrName)
+		if isForbiddenError(err) {
+			return nil, applicationAccessError(appIdOrName, err)
+		}
 	}
 
 	if application == nil {
-		return nil, fmt.Errorf("could not find application with ID or name '%s'", appIdOrName)
+		return nil, fmt.Errorf("could not find application with ID or name '%s': %w", appIdOrName, ErrApplicationNotFound)
 	}
 
 	return application, nil
 }
 
+// isForbiddenError returns true when the error is a 403 response from the service.
+func isForbiddenError(err error) bool {
+	var responseError *azcore.ResponseError
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusForbidden
+}
+
+// applicationAccessError wraps a 403 response received while reading an application.
+func applicationAccessError(appIdOrName string, err error) error {
+	return &ErrorWithSuggestion{
+		Suggestion: "\nSuggested Action: Ensure you have permission to read applications and service principals " +
+			"in your Azure AD tenant, or are an owner of the application.\n",
+		Err: fmt.Errorf("reading application '%s': %w", appIdOrName, err),
+	}
+}
+
+// CreateOrUpdateServicePrincipal creates or updates the service principal and assigns it the given roles at the
+// subscription scope. Along with the client id and credentials, it returns the names of the roles that were already
+// assigned to the service principal, which are left as is.
 func (ad *adService) CreateOrUpdateServicePrincipal(
 	ctx context.Context,
 	subscriptionId string,
 	applicationIdOrName string,
 	roleNames []string,
-) (*string, json.RawMessage, error) {
+) (*string, json.RawMessage, []string, error) {
 	graphClient, err := ad.createGraphClient(ctx, subscriptionId)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	var application *graphsdk.Application
 
 	// Attempt to find existing application by ID or name
-	application, _ = ad.GetServicePrincipal(ctx, subscriptionId, applicationIdOrName)
+	application, err = ad.GetServicePrincipal(ctx, subscriptionId, applicationIdOrName)
+	if err != nil && !errors.Is(err, ErrApplicationNotFound) {
+		return nil, nil, nil, err
+	}
 
 	// Create new application if not found
 	if application == nil {
 		// Create application
 		application, err = createApplication(ctx, graphClient, applicationIdOrName)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	// Get or create service principal from application
 	servicePrincipal, err := ensureServicePrincipal(ctx, graphClient, application)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Reset credentials for service principal
 	credential, err := resetCredentials(ctx, graphClient, application)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed resetting application credentials: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed resetting application credentials: %w", err)
 	}
 
 	// Apply specified role assignments
-	err = ad.ensureRoleAssignments(ctx, subscriptionId, roleNames, servicePrincipal)
+	existingRoles, err := ad.ensureRoleAssignments(ctx, subscriptionId, roleNames, servicePrincipal)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed applying role assignment: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed applying role assignment: %w", err)
 	}
 
 	azureCreds := AzureCredentials{
@@ -152,15 +184,15 @@ func (ad *adService) CreateOrUpdateServicePrincipal(
 
 	credentialsJson, err := json.Marshal(azureCreds)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed marshalling Azure credentials to JSON: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed marshalling Azure credentials to JSON: %w", err)
 	}
 
 	var rawMessage json.RawMessage
 	if err := json.Unmarshal(credentialsJson, &rawMessage); err != nil {
-		return nil, nil, fmt.Errorf("failed unmarshalling JSON to raw message: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed unmarshalling JSON to raw message: %w", err)
 	}
 
-	return application.AppId, rawMessage, nil
+	return application.AppId, rawMessage, existingRoles, nil
 }
 
 func (ad *adService) getApplicationByAppId(
@@ -186,7 +218,7 @@ func getApplicationByName(
 ) (*graphsdk.Application, error) {
 	matchingItems, err := graphClient.
 		Applications().
-		Filter(fmt.Sprintf("startswith(displayName, '%s')", applicationName)).
+		Filter(fmt.Sprintf("displayName eq '%s'", azure.EscapeODataString(applicationName))).
 		Get(ctx)
 
 	if err != nil {
@@ -233,7 +265,7 @@ func ensureServicePrincipal(
 ) (*graphsdk.ServicePrincipal, error) {
 	matchingItems, err := client.
 		ServicePrincipals().
-		Filter(fmt.Sprintf("displayName eq '%s'", application.DisplayName)).
+		Filter(fmt.Sprintf("displayName eq '%s'", azure.EscapeODataString(application.DisplayName))).
 		Get(ctx)
 
 	if err != nil {
@@ -295,44 +327,107 @@ func resetCredentials(
 	return credential, nil
 }
 
-// Applies the Azure selected RBAC role assignments to the specified service principal
+// Applies the Azure selected RBAC role assignments to the specified service principal and returns the names of the roles
+// that were already assigned
 func (ad *adService) ensureRoleAssignments(
 	ctx context.Context,
 	subscriptionId string,
 	roleNames []string,
 	servicePrincipal *graphsdk.ServicePrincipal,
-) error {
+) ([]string, error) {
+	existingRoles := []string{}
+
 	for _, roleName := range roleNames {
-		err := ad.ensureRoleAssignment(ctx, subscriptionId, roleName, servicePrincipal)
+		exists, err := ad.ensureRoleAssignment(ctx, subscriptionId, roleName, servicePrincipal)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		if exists {
+			existingRoles = append(existingRoles, roleName)
 		}
 	}
 
-	return nil
+	return existingRoles, nil
 }
 
-// Applies the Azure selected RBAC role assignments to the specified service principal
+// Applies the Azure selected RBAC role assignments to the specified service principal.
+// Returns true when the role was already assigned, in which case no new role assignment is created.
 func (ad *adService) ensureRoleAssignment(
 	ctx context.Context,
 	subscriptionId string,
 	roleName string,
 	servicePrincipal *graphsdk.ServicePrincipal,
-) error {
+) (bool, error) {
 	// Find the specified role in the subscription scope
 	scope := azure.SubscriptionRID(subscriptionId)
 	roleDefinition, err := ad.getRoleDefinition(ctx, subscriptionId, scope, roleName)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	// Skip roles assigned by a previous run, so repeated runs don't create new role assignments
+	exists, err := ad.roleAssignmentExists(ctx, subscriptionId, roleDefinition, servicePrincipal)
+	if err != nil {
+		return false, err
+	}
+
+	if exists {
+		log.Printf(
+			"role '%s' is already assigned to service principal '%s'", roleName, servicePrincipal.DisplayName)
+		return true, nil
 	}
 
 	// Create the new role assignment
 	err = ad.applyRoleAssignmentWithRetry(ctx, subscriptionId, roleDefinition, servicePrincipal)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	return false, nil
+}
+
+// Returns true when the role is assigned to the service principal at the subscription scope
+func (ad *adService) roleAssignmentExists(
+	ctx context.Context,
+	subscriptionId string,
+	roleDefinition *armauthorization.RoleDefinition,
+	servicePrincipal *graphsdk.ServicePrincipal,
+) (bool, error) {
+	if servicePrincipal.Id == nil {
+		return false, nil
+	}
+
+	roleAssignmentsClient, err := ad.createRoleAssignmentsClient(ctx, subscriptionId)
+	if err != nil {
+		return false, err
+	}
+
+	scope := azure.SubscriptionRID(subscriptionId)
+	pager := roleAssignmentsClient.NewListForScopePager(scope, &armauthorization.RoleAssignmentsClientListForScopeOptions{
+		Filter: convert.RefOf(fmt.Sprintf("principalId eq '%s'", *servicePrincipal.Id)),
+	})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed getting next page of role assignments: %w", err)
+		}
+
+		for _, roleAssignment := range page.Value {
+			properties := roleAssignment.Properties
+			if properties == nil || properties.RoleDefinitionID == nil || properties.Scope == nil {
+				continue
+			}
+
+			if strings.EqualFold(*properties.RoleDefinitionID, *roleDefinition.ID) &&
+				strings.EqualFold(*properties.Scope, scope) {
+				return true, nil
+			}
+		}
 	}
 
-	return nil
+	return false, nil
 }
 
 // Applies the role assignment to the specified service principal
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization"
@@ -68,10 +69,11 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		mockgraphsdk.RegisterServicePrincipalCreateItemMock(mockContext, http.StatusCreated, &servicePrincipal)
 		mockgraphsdk.RegisterApplicationAddPasswordMock(mockContext, http.StatusOK, *newApplication.Id, credential)
 		mockgraphsdk.RegisterRoleDefinitionListMock(mockContext, http.StatusOK, roleDefinitions)
+		mockgraphsdk.RegisterRoleAssignmentListMock(mockContext, http.StatusOK, []*armauthorization.RoleAssignment{})
 		mockgraphsdk.RegisterRoleAssignmentPutMock(mockContext, http.StatusCreated)
 
 		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
-		clientId, rawMessage, err := adService.CreateOrUpdateServicePrincipal(
+		clientId, rawMessage, existingRoles, err := adService.CreateOrUpdateServicePrincipal(
 			*mockContext.Context,
 			expectedServicePrincipalCredential.SubscriptionId,
 			"APPLICATION_NAME",
@@ -80,6 +82,7 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, clientId)
 		require.NotNil(t, rawMessage)
+		require.Empty(t, existingRoles)
 
 		assertAzureCredentials(t, rawMessage)
 	})
@@ -112,10 +115,11 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		mockgraphsdk.RegisterApplicationRemovePasswordMock(mockContext, http.StatusNoContent, *newApplication.Id)
 		mockgraphsdk.RegisterApplicationAddPasswordMock(mockContext, http.StatusOK, *newApplication.Id, credential)
 		mockgraphsdk.RegisterRoleDefinitionListMock(mockContext, http.StatusOK, roleDefinitions)
+		mockgraphsdk.RegisterRoleAssignmentListMock(mockContext, http.StatusOK, []*armauthorization.RoleAssignment{})
 		mockgraphsdk.RegisterRoleAssignmentPutMock(mockContext, http.StatusCreated)
 
 		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
-		clientId, rawMessage, err := adService.CreateOrUpdateServicePrincipal(
+		clientId, rawMessage, existingRoles, err := adService.CreateOrUpdateServicePrincipal(
 			*mockContext.Context,
 			expectedServicePrincipalCredential.SubscriptionId,
 			"APPLICATION_NAME",
@@ -124,6 +128,7 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, clientId)
 		require.NotNil(t, rawMessage)
+		require.Empty(t, existingRoles)
 
 		assertAzureCredentials(t, rawMessage)
 	})
@@ -152,11 +157,12 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		mockgraphsdk.RegisterApplicationRemovePasswordMock(mockContext, http.StatusNoContent, *newApplication.Id)
 		mockgraphsdk.RegisterApplicationAddPasswordMock(mockContext, http.StatusOK, *newApplication.Id, credential)
 		mockgraphsdk.RegisterRoleDefinitionListMock(mockContext, http.StatusOK, roleDefinitions)
+		mockgraphsdk.RegisterRoleAssignmentListMock(mockContext, http.StatusOK, []*armauthorization.RoleAssignment{})
 		// Note how role assignment returns a 409 conflict
 		mockgraphsdk.RegisterRoleAssignmentPutMock(mockContext, http.StatusConflict)
 
 		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
-		clientId, rawMessage, err := adService.CreateOrUpdateServicePrincipal(
+		clientId, rawMessage, existingRoles, err := adService.CreateOrUpdateServicePrincipal(
 			*mockContext.Context,
 			expectedServicePrincipalCredential.SubscriptionId,
 			"APPLICATION_NAME",
@@ -165,6 +171,51 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, clientId)
 		require.NotNil(t, rawMessage)
+		require.Empty(t, existingRoles)
+
+		assertAzureCredentials(t, rawMessage)
+	})
+
+	// Tests the use case for re-running against a service principal with the role assignments of a previous run
+	t.Run("RoleAssignmentPreviouslyCreated", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockgraphsdk.RegisterApplicationListMock(mockContext, http.StatusOK, []graphsdk.Application{existingApplication})
+		mockgraphsdk.RegisterApplicationGetItemByAppIdMock(
+			mockContext,
+			http.StatusOK,
+			existingApplication.DisplayName,
+			&existingApplication,
+		)
+		mockgraphsdk.RegisterServicePrincipalListMock(
+			mockContext,
+			http.StatusOK,
+			[]graphsdk.ServicePrincipal{servicePrincipal},
+		)
+		mockgraphsdk.RegisterApplicationRemovePasswordMock(mockContext, http.StatusNoContent, *newApplication.Id)
+		mockgraphsdk.RegisterApplicationAddPasswordMock(mockContext, http.StatusOK, *newApplication.Id, credential)
+		mockgraphsdk.RegisterRoleDefinitionListMock(mockContext, http.StatusOK, roleDefinitions)
+		// Note how no role assignment PUT is registered, creating a role assignment fails the test
+		mockgraphsdk.RegisterRoleAssignmentListMock(mockContext, http.StatusOK, []*armauthorization.RoleAssignment{
+			{
+				ID: convert.RefOf("ASSIGNMENT_ID"),
+				Properties: &armauthorization.RoleAssignmentPropertiesWithScope{
+					PrincipalID:      servicePrincipal.Id,
+					RoleDefinitionID: convert.RefOf("role_id"),
+					Scope:            convert.RefOf("/subscriptions/SUBSCRIPTION_ID"),
+				},
+			},
+		})
+
+		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
+		clientId, rawMessage, existingRoles, err := adService.CreateOrUpdateServicePrincipal(
+			*mockContext.Context,
+			expectedServicePrincipalCredential.SubscriptionId,
+			"APPLICATION_NAME",
+			defaultRoleNames,
+		)
+		require.NoError(t, err)
+		require.NotEmpty(t, clientId)
+		require.Equal(t, defaultRoleNames, existingRoles)
 
 		assertAzureCredentials(t, rawMessage)
 	})
@@ -192,7 +243,7 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		mockgraphsdk.RegisterRoleDefinitionListMock(mockContext, http.StatusOK, []*armauthorization.RoleDefinition{})
 
 		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
-		clientId, rawMessage, err := adService.CreateOrUpdateServicePrincipal(
+		clientId, rawMessage, existingRoles, err := adService.CreateOrUpdateServicePrincipal(
 			*mockContext.Context,
 			expectedServicePrincipalCredential.SubscriptionId,
 			"APPLICATION_NAME",
@@ -201,6 +252,7 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		require.Error(t, err)
 		require.Empty(t, clientId)
 		require.Nil(t, rawMessage)
+		require.Nil(t, existingRoles)
 	})
 
 	t.Run("ErrorCreatingApplication", func(t *testing.T) {
@@ -213,7 +265,7 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		mockgraphsdk.RegisterApplicationCreateItemMock(mockContext, http.StatusUnauthorized, nil)
 
 		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
-		clientId, rawMessage, err := adService.CreateOrUpdateServicePrincipal(
+		clientId, rawMessage, existingRoles, err := adService.CreateOrUpdateServicePrincipal(
 			*mockContext.Context,
 			expectedServicePrincipalCredential.SubscriptionId,
 			"APPLICATION_NAME",
@@ -222,6 +274,73 @@ func Test_CreateOrUpdateServicePrincipal(t *testing.T) {
 		require.Error(t, err)
 		require.Empty(t, clientId)
 		require.Nil(t, rawMessage)
+		require.Nil(t, existingRoles)
+	})
+}
+
+func Test_GetServicePrincipal(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockgraphsdk.RegisterApplicationListMock(mockContext, http.StatusOK, []graphsdk.Application{})
+		mockgraphsdk.RegisterApplicationGetItemByAppIdMock(mockContext, http.StatusNotFound, "APPLICATION_NAME", nil)
+
+		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
+		application, err := adService.GetServicePrincipal(
+			*mockContext.Context,
+			expectedServicePrincipalCredential.SubscriptionId,
+			"APPLICATION_NAME",
+		)
+		require.ErrorIs(t, err, ErrApplicationNotFound)
+		require.Nil(t, application)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockgraphsdk.RegisterApplicationListMock(mockContext, http.StatusOK, []graphsdk.Application{})
+		mockgraphsdk.RegisterApplicationGetItemByAppIdMock(mockContext, http.StatusForbidden, "APPLICATION_NAME", nil)
+
+		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
+		application, err := adService.GetServicePrincipal(
+			*mockContext.Context,
+			expectedServicePrincipalCredential.SubscriptionId,
+			"APPLICATION_NAME",
+		)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrApplicationNotFound)
+		require.Nil(t, application)
+
+		var errWithSuggestion *ErrorWithSuggestion
+		require.ErrorAs(t, err, &errWithSuggestion)
+	})
+
+	t.Run("NameWithQuote", func(t *testing.T) {
+		application := graphsdk.Application{
+			Id:          convert.RefOf("UNIQUE_ID"),
+			AppId:       &expectedServicePrincipalCredential.ClientId,
+			DisplayName: "O'Brien's app",
+		}
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockgraphsdk.RegisterApplicationListMock(mockContext, http.StatusOK, []graphsdk.Application{})
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet &&
+				strings.HasSuffix(request.URL.Path, "/applications") &&
+				request.URL.Query().Get("$filter") == "displayName eq 'O''Brien''s app'"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, graphsdk.ApplicationListResponse{
+				Value: []graphsdk.Application{application},
+			})
+		})
+		mockgraphsdk.RegisterApplicationGetItemByAppIdMock(mockContext, http.StatusNotFound, "O'Brien's app", nil)
+
+		adService := NewAdService(mockContext.SubscriptionCredentialProvider, mockContext.HttpClient)
+		found, err := adService.GetServicePrincipal(
+			*mockContext.Context,
+			expectedServicePrincipalCredential.SubscriptionId,
+			"O'Brien's app",
+		)
+		require.NoError(t, err)
+		require.Equal(t, application.DisplayName, found.DisplayName)
 	})
 }
 
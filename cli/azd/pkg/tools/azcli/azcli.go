@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
 	azdinternal "github.com/azure/azure-dev/cli/azd/internal"
@@ -21,6 +22,7 @@ var (
 	ErrClientAssertionExpired   = errors.New("client assertion expired")
 	ErrNoConfigurationValue     = errors.New("no value configured")
 	ErrAzCliSecretNotFound      = errors.New("secret not found")
+	ErrAzCliSlotNotFound        = errors.New("deployment slot not found")
 )
 
 type AzCli interface {
@@ -92,6 +94,7 @@ type AzCli interface {
 	) (*AzCliFunctionAppProperties, error)
 
 	DeleteResourceGroup(ctx context.Context, subscriptionId string, resourceGroupName string) error
+	ResourceGroupExists(ctx context.Context, subscriptionId string, resourceGroupName string) (bool, error)
 	CreateOrUpdateResourceGroup(
 		ctx context.Context,
 		subscriptionId string,
@@ -120,6 +123,48 @@ type AzCli interface {
 		resourceGroupName string,
 		applicationName string,
 	) (*AzCliAppServiceProperties, error)
+	GetAppServiceAppSettings(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		applicationName string,
+	) (map[string]string, error)
+	UpdateAppServiceAppSettings(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		applicationName string,
+		settings map[string]string,
+	) error
+	GetAppServiceSlotProperties(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		applicationName string,
+		slotName string,
+	) (*AzCliAppServiceProperties, error)
+	CreateAppServiceSlot(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		applicationName string,
+		slotName string,
+	) error
+	DeployAppServiceSlotZip(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		applicationName string,
+		slotName string,
+		deployZipFile io.Reader,
+	) (*string, error)
+	SwapAppServiceSlot(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		applicationName string,
+		slotName string,
+	) error
 	GetStaticWebAppProperties(
 		ctx context.Context,
 		subscriptionID string,
@@ -141,6 +186,8 @@ type AzCliResource struct {
 	Name     string `json:"name"`
 	Type     string `json:"type"`
 	Location string `json:"location"`
+	// ChangedTime is the last time the resource was changed. Only set when listing with the changedTime expansion.
+	ChangedTime *time.Time `json:"changedTime,omitempty"`
 }
 
 type AzCliResourceExtended struct {
@@ -174,6 +221,8 @@ type ListResourceGroupResourcesOptions struct {
 	// An optional filter expression to filter the resource list result
 	// https://learn.microsoft.com/en-us/rest/api/resources/resources/list-by-resource-group#uri-parameters
 	Filter *string
+	// Optional additional properties to include in the result, like changedTime
+	Expand *string
 }
 
 type Filter struct {
@@ -222,18 +271,12 @@ func (cli *azCli) UserAgent() string {
 }
 
 func (cli *azCli) clientOptionsBuilder(ctx context.Context) *azsdk.ClientOptionsBuilder {
-	return azsdk.NewClientOptionsBuilder().
-		WithTransport(cli.httpClient).
-		WithPerCallPolicy(azsdk.NewUserAgentPolicy(cli.UserAgent())).
-		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
+	return azsdk.DefaultClientOptionsBuilder(ctx, cli.httpClient, cli.UserAgent())
 }
 
 func clientOptionsBuilder(
 	ctx context.Context,
 	httpClient httputil.HttpClient,
 	userAgent string) *azsdk.ClientOptionsBuilder {
-	return azsdk.NewClientOptionsBuilder().
-		WithTransport(httpClient).
-		WithPerCallPolicy(azsdk.NewUserAgentPolicy(userAgent)).
-		WithPerCallPolicy(azsdk.NewMsCorrelationPolicy(ctx))
+	return azsdk.DefaultClientOptionsBuilder(ctx, httpClient, userAgent)
 }
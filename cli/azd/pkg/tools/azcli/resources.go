@@ -44,9 +44,12 @@ func (cli *azCli) ListResourceGroupResources(
 	// Filter expression on the underlying REST API are different from --query param in az cli.
 	// https://learn.microsoft.com/en-us/rest/api/resources/resources/list-by-resource-group#uri-parameters
 	options := armresources.ClientListByResourceGroupOptions{}
-	if listOptions != nil && *listOptions.Filter != "" {
+	if listOptions != nil && listOptions.Filter != nil && *listOptions.Filter != "" {
 		options.Filter = listOptions.Filter
 	}
+	if listOptions != nil && listOptions.Expand != nil {
+		options.Expand = listOptions.Expand
+	}
 
 	resources := []AzCliResource{}
 	pager := client.NewListByResourceGroupPager(resourceGroupName, &options)
@@ -58,10 +61,11 @@ func (cli *azCli) ListResourceGroupResources(
 
 		for _, resource := range page.ResourceListResult.Value {
 			resources = append(resources, AzCliResource{
-				Id:       *resource.ID,
-				Name:     *resource.Name,
-				Type:     *resource.Type,
-				Location: *resource.Location,
+				Id:          *resource.ID,
+				Name:        *resource.Name,
+				Type:        *resource.Type,
+				Location:    *resource.Location,
+				ChangedTime: resource.ChangedTime,
 			})
 		}
 	}
@@ -117,6 +121,21 @@ func (cli *azCli) ListResourceGroup(
 	return groups, nil
 }
 
+// ResourceGroupExists returns true when the resource group exists in the subscription.
+func (cli *azCli) ResourceGroupExists(ctx context.Context, subscriptionId string, resourceGroupName string) (bool, error) {
+	client, err := cli.createResourceGroupClient(ctx, subscriptionId)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := client.CheckExistence(ctx, resourceGroupName, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Success, nil
+}
+
 func (cli *azCli) CreateOrUpdateResourceGroup(
 	ctx context.Context,
 	subscriptionId string,
@@ -2,9 +2,12 @@ package azcli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
@@ -35,6 +38,167 @@ func (cli *azCli) GetAppServiceProperties(
 	}, nil
 }
 
+// GetAppServiceAppSettings gets the app settings of the specified web app
+func (cli *azCli) GetAppServiceAppSettings(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+) (map[string]string, error) {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.ListApplicationSettings(ctx, resourceGroup, appName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing app settings: %w", err)
+	}
+
+	settings := map[string]string{}
+	for name, value := range response.Properties {
+		settings[name] = convert.ToValueWithDefault(value, "")
+	}
+
+	return settings, nil
+}
+
+// UpdateAppServiceAppSettings replaces the app settings of the specified web app. Settings that are not included are
+// removed.
+func (cli *azCli) UpdateAppServiceAppSettings(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	settings map[string]string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]*string{}
+	for name, value := range settings {
+		properties[name] = convert.RefOf(value)
+	}
+
+	_, err = client.UpdateApplicationSettings(ctx, resourceGroup, appName, armappservice.StringDictionary{
+		Properties: properties,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("updating app settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetAppServiceSlotProperties gets the properties of the specified deployment slot of a web app. ErrAzCliSlotNotFound is
+// returned when the slot doesn't exist.
+func (cli *azCli) GetAppServiceSlotProperties(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) (*AzCliAppServiceProperties, error) {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := client.GetSlot(ctx, resourceGroup, appName, slotName, nil)
+	if err != nil {
+		var httpErr *azcore.ResponseError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, ErrAzCliSlotNotFound
+		}
+
+		return nil, fmt.Errorf("failed retrieving deployment slot properties: %w", err)
+	}
+
+	return &AzCliAppServiceProperties{
+		HostNames: []string{*slot.Properties.DefaultHostName},
+	}, nil
+}
+
+// CreateAppServiceSlot creates a deployment slot of a web app, in the location and app service plan of the web app, and
+// waits for it to be created.
+func (cli *azCli) CreateAppServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	webApp, err := client.Get(ctx, resourceGroup, appName, nil)
+	if err != nil {
+		return fmt.Errorf("failed retrieving webapp properties: %w", err)
+	}
+
+	poller, err := client.BeginCreateOrUpdateSlot(ctx, resourceGroup, appName, slotName, armappservice.Site{
+		Location: webApp.Location,
+		Properties: &armappservice.SiteProperties{
+			ServerFarmID: webApp.Properties.ServerFarmID,
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("creating deployment slot: %w", err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("creating deployment slot: %w", err)
+	}
+
+	return nil
+}
+
+// DeployAppServiceSlotZip deploys the zip file to the specified deployment slot of a web app
+func (cli *azCli) DeployAppServiceSlotZip(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	deployZipFile io.Reader,
+) (*string, error) {
+	// The deployment site of a slot is named after the web app and the slot
+	return cli.DeployAppServiceZip(ctx, subscriptionId, resourceGroup, fmt.Sprintf("%s-%s", appName, slotName), deployZipFile)
+}
+
+// SwapAppServiceSlot swaps the specified deployment slot of a web app with its production slot, and waits for the swap to
+// complete. When the swap fails, App Service reverts both slots to what they were before the swap.
+func (cli *azCli) SwapAppServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginSwapSlot(ctx, resourceGroup, appName, slotName, armappservice.CsmSlotEntity{
+		TargetSlot:   convert.RefOf("production"),
+		PreserveVnet: convert.RefOf(true),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("swapping deployment slot: %w", err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("swapping deployment slot: %w", err)
+	}
+
+	return nil
+}
+
 func (cli *azCli) DeployAppServiceZip(
 	ctx context.Context,
 	subscriptionId string,
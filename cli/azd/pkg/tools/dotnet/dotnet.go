@@ -17,7 +17,7 @@ import (
 
 type DotNetCli interface {
 	tools.ExternalTool
-	Restore(ctx context.Context, project string) error
+	Restore(ctx context.Context, project string, noCache bool) error
 	Build(ctx context.Context, project string, configuration string, output string) error
 	Publish(ctx context.Context, project string, configuration string, output string) error
 	InitializeSecret(ctx context.Context, project string) error
@@ -67,8 +67,12 @@ func (cli *dotNetCli) CheckInstalled(ctx context.Context) error {
 	return nil
 }
 
-func (cli *dotNetCli) Restore(ctx context.Context, project string) error {
+func (cli *dotNetCli) Restore(ctx context.Context, project string, noCache bool) error {
 	runArgs := exec.NewRunArgs("dotnet", "restore", project)
+	if noCache {
+		runArgs = runArgs.AppendParams("--no-cache")
+	}
+
 	_, err := cli.commandRunner.Run(ctx, runArgs)
 	if err != nil {
 		return fmt.Errorf("dotnet restore on project '%s' failed: %w", project, err)
@@ -100,8 +100,12 @@ func (cli *gitCli) ShallowClone(ctx context.Context, repositoryPath string, bran
 	// default authentication. `git clone` should work for private repos within a codespace with default auth.
 	// See: https://github.com/Azure/azure-dev/issues/2582
 	runArgs := exec.NewRunArgs("git", args...)
-	_, err := cli.commandRunner.Run(ctx, runArgs)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
 	if err != nil {
+		if branch != "" && remoteBranchNotFoundRegex.MatchString(res.Stderr) {
+			return fmt.Errorf("branch '%s' in repository %s: %w", branch, repositoryPath, ErrBranchNotFound)
+		}
+
 		return fmt.Errorf("failed to clone repository %s: %w", repositoryPath, err)
 	}
 
@@ -112,6 +116,8 @@ var noSuchRemoteRegex = regexp.MustCompile("(fatal|error): No such remote")
 var notGitRepositoryRegex = regexp.MustCompile("(fatal|error): not a git repository")
 var ErrNoSuchRemote = errors.New("no such remote")
 var ErrNotRepository = errors.New("not a git repository")
+var remoteBranchNotFoundRegex = regexp.MustCompile("(fatal|error): Remote branch .* not found")
+var ErrBranchNotFound = errors.New("branch not found")
 var gitUntrackedFileRegex = regexp.MustCompile("untracked files present|new file")
 
 func (cli *gitCli) GetRemoteUrl(ctx context.Context, repositoryPath string, remoteName string) (string, error) {
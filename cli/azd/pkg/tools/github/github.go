@@ -37,6 +37,7 @@ type GitHubCli interface {
 	ListSecrets(ctx context.Context, repo string) error
 	SetSecret(ctx context.Context, repo string, name string, value string) error
 	SetVariable(ctx context.Context, repoSlug string, name string, value string) error
+	ListVariables(ctx context.Context, repoSlug string) (map[string]string, error)
 	Login(ctx context.Context, hostname string) error
 	ListRepositories(ctx context.Context) ([]GhCliRepository, error)
 	ViewRepository(ctx context.Context, name string) (GhCliRepository, error)
@@ -241,6 +242,34 @@ func (cli *ghCli) SetVariable(ctx context.Context, repoSlug string, name string,
 	return nil
 }
 
+// ghVariable is a repository variable, as returned by the GitHub actions variables API.
+type ghVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ListVariables returns the values of the actions variables of the repository, keyed by name.
+func (cli *ghCli) ListVariables(ctx context.Context, repoSlug string) (map[string]string, error) {
+	runArgs := cli.newRunArgs("api", "--paginate", "/repos/"+repoSlug+"/actions/variables", "--jq", ".variables[]")
+	res, err := cli.run(ctx, runArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed running gh api: %w", err)
+	}
+
+	variables := map[string]string{}
+	decoder := json.NewDecoder(strings.NewReader(res.Stdout))
+	for decoder.More() {
+		var variable ghVariable
+		if err := decoder.Decode(&variable); err != nil {
+			return nil, fmt.Errorf("could not unmarshal output as a variable: %w, output: %s", err, res.Stdout)
+		}
+
+		variables[variable.Name] = variable.Value
+	}
+
+	return variables, nil
+}
+
 // cGhCliVersionRegexp fetches the version number from the output of gh --version, which looks like this:
 //
 // gh version 2.6.0 (2022-03-15)
@@ -6,6 +6,7 @@ package npm
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -14,7 +15,9 @@ import (
 
 type NpmCli interface {
 	tools.ExternalTool
-	Install(ctx context.Context, project string) error
+	// Install installs the dependencies of the project. When noCache is set, the npm cache isn't used: packages are
+	// downloaded into an empty cache that is deleted after the install.
+	Install(ctx context.Context, project string, noCache bool) error
 
 	// RunScript runs the given npm script (if it exists) in the project.
 	//
@@ -74,11 +77,22 @@ func (cli *npmCli) Name() string {
 	return "npm CLI"
 }
 
-func (cli *npmCli) Install(ctx context.Context, project string) error {
+func (cli *npmCli) Install(ctx context.Context, project string, noCache bool) error {
 	runArgs := exec.
 		NewRunArgs("npm", "install").
 		WithCwd(project)
 
+	// --prefer-online still reuses cached packages that the registry reports as unchanged, so an empty cache is used
+	if noCache {
+		cacheDir, err := os.MkdirTemp("", "azd-npm-cache-*")
+		if err != nil {
+			return fmt.Errorf("creating npm cache directory: %w", err)
+		}
+		defer os.RemoveAll(cacheDir)
+
+		runArgs = runArgs.AppendParams("--cache", cacheDir)
+	}
+
 	_, err := cli.commandRunner.Run(ctx, runArgs)
 
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -67,7 +68,13 @@ func (cli *PythonCli) Name() string {
 	return "Python CLI"
 }
 
-func (cli *PythonCli) InstallRequirements(ctx context.Context, workingDir, environment, requirementFile string) error {
+// InstallRequirements installs the requirements file into the virtual environment. When noCache is set, pip's cache is
+// disabled.
+func (cli *PythonCli) InstallRequirements(
+	ctx context.Context,
+	workingDir, environment, requirementFile string,
+	noCache bool,
+) error {
 	var err error
 
 	pyString, err := checkPath()
@@ -75,6 +82,11 @@ func (cli *PythonCli) InstallRequirements(ctx context.Context, workingDir, envir
 		return err
 	}
 
+	installArgs := []string{"-m", "pip", "install", "-r", requirementFile}
+	if noCache {
+		installArgs = append(installArgs, "--no-cache-dir")
+	}
+
 	if runtime.GOOS == "windows" {
 		// Unfortunately neither cmd.exe, nor PowerShell provide a straightforward way to use a script
 		// to modify environment for command(s) in a command list.
@@ -88,14 +100,14 @@ func (cli *PythonCli) InstallRequirements(ctx context.Context, workingDir, envir
 		vEnvSetting := fmt.Sprintf("VIRTUAL_ENV=%s", path.Join(absWorkingDir, environment))
 
 		runArgs := exec.
-			NewRunArgs(pyString, "-m", "pip", "install", "-r", requirementFile).
+			NewRunArgs(pyString, installArgs...).
 			WithCwd(workingDir).
 			WithEnv([]string{vEnvSetting})
 
 		_, err = cli.commandRunner.Run(ctx, runArgs)
 	} else {
 		envActivation := ". " + path.Join(environment, "bin", "activate")
-		installCmd := fmt.Sprintf("%s -m pip install -r %s", pyString, requirementFile)
+		installCmd := strings.Join(append([]string{pyString}, installArgs...), " ")
 		commands := []string{envActivation, installCmd}
 
 		runArgs := exec.NewRunArgs(pyString).WithCwd(workingDir)
@@ -73,7 +73,7 @@ func createBlobClient(
 	authManager, err := auth.NewManager(
 		fileConfigManager,
 		config.NewUserConfigManager(fileConfigManager),
-		httpClient, mockContext.Console,
+		httpClient, mockContext.Console, mockContext.CommandRunner,
 	)
 	require.NoError(t, err)
 
@@ -2,6 +2,7 @@ package mockaccount
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
@@ -13,6 +14,9 @@ type MockAccountManager struct {
 
 	Subscriptions []account.Subscription
 	Locations     []account.Location
+
+	// SubscriptionAccessError is returned by EnsureSubscriptionAccess
+	SubscriptionAccessError error
 }
 
 func (a *MockAccountManager) Clear(ctx context.Context) error {
@@ -55,6 +59,17 @@ func (a *MockAccountManager) GetSubscriptions(ctx context.Context) ([]account.Su
 	return a.Subscriptions, nil
 }
 
+func (a *MockAccountManager) GetDefaultLocation(
+	ctx context.Context, subscriptionId string) (*account.Location, error) {
+	for _, loc := range a.Locations {
+		if loc.Name == a.DefaultLocation {
+			return &loc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the location '%s' is invalid", a.DefaultLocation)
+}
+
 func (a *MockAccountManager) GetDefaultLocationName(ctx context.Context) string {
 	return a.DefaultLocation
 }
@@ -104,3 +119,11 @@ func (f SubscriptionCredentialProviderFunc) CredentialForSubscription(
 ) (azcore.TokenCredential, error) {
 	return f(ctx, subscriptionId)
 }
+
+func (a *MockAccountManager) EnsureSubscriptionAccess(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+) error {
+	return a.SubscriptionAccessError
+}
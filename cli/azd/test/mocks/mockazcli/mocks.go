@@ -39,3 +39,12 @@ func NewDeploymentsServiceFromMockContext(
 		}),
 		mockContext.HttpClient)
 }
+
+func NewDeploymentStacksServiceFromMockContext(
+	mockContext *mocks.MockContext) azapi.DeploymentStacks {
+	return azapi.NewDeploymentStacks(
+		mockaccount.SubscriptionCredentialProviderFunc(func(_ context.Context, _ string) (azcore.TokenCredential, error) {
+			return mockContext.Credentials, nil
+		}),
+		mockContext.HttpClient)
+}
@@ -41,6 +41,16 @@ func (m *MockEnvManager) Reload(ctx context.Context, env *environment.Environmen
 	return args.Error(0)
 }
 
+func (m *MockEnvManager) Delete(ctx context.Context, name string, options environment.DeleteOptions) error {
+	args := m.Called(ctx, name, options)
+	return args.Error(0)
+}
+
+func (m *MockEnvManager) Rename(ctx context.Context, name string, newName string) error {
+	args := m.Called(ctx, name, newName)
+	return args.Error(0)
+}
+
 func (m *MockEnvManager) EnvPath(env *environment.Environment) string {
 	args := m.Called(env)
 	return args.String(0)
@@ -234,6 +234,29 @@ func RegisterRoleDefinitionListMock(
 	})
 }
 
+func RegisterRoleAssignmentListMock(
+	mockContext *mocks.MockContext,
+	statusCode int,
+	roleAssignments []*armauthorization.RoleAssignment,
+) {
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(request.URL.Path, "/providers/Microsoft.Authorization/roleAssignments")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		if roleAssignments == nil {
+			return mocks.CreateEmptyHttpResponse(request, statusCode)
+		}
+
+		response := armauthorization.RoleAssignmentsClientListForScopeResponse{
+			RoleAssignmentListResult: armauthorization.RoleAssignmentListResult{
+				Value: roleAssignments,
+			},
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, statusCode, response)
+	})
+}
+
 func RegisterRoleAssignmentPutMock(mockContext *mocks.MockContext, statusCode int) {
 	mockContext.HttpClient.When(func(request *http.Request) bool {
 		return request.Method == http.MethodPut &&